@@ -14,7 +14,11 @@ import (
 	"xinde/configs"
 	_ "xinde/docs" // docs is generated by Swag CLI, you have to import it.
 	"xinde/internal/router"
+	"xinde/internal/service/attachment"
+	"xinde/internal/service/outbox"
+	"xinde/internal/service/price"
 	"xinde/internal/store"
+	"xinde/pkg/jwt"
 	"xinde/pkg/logger"
 
 	"github.com/spf13/viper"
@@ -60,6 +64,11 @@ func main() {
 	}
 	logger.Info("数据库连接成功")
 
+	// 加载 JWT 签名密钥，需要在签发或验证 token 之前完成
+	if err := jwt.InitKeySet(store.GetDB()); err != nil {
+		logger.Fatal("Failed to load jwt keys", zap.Error(err))
+	}
+
 	// 5. 初始化路由
 	r, err := router.InitRouter()
 	if err != nil {
@@ -67,6 +76,25 @@ func main() {
 	}
 	logger.Info("路由组创建成功")
 
+	// 启动后台任务：重试产品目录修改后未完成的跨库操作，定期核对附件和产品目录，写入到达生效时间的价格
+	outboxService, err := outbox.NewOutboxService()
+	if err != nil {
+		logger.Fatal("Failed to initialize outbox service", zap.Error(err))
+	}
+	attachmentService, err := attachment.NewAttachmentService()
+	if err != nil {
+		logger.Fatal("Failed to initialize attachment service", zap.Error(err))
+	}
+	priceService, err := price.NewPriceService()
+	if err != nil {
+		logger.Fatal("Failed to initialize price service", zap.Error(err))
+	}
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	outboxService.Start(jobCtx)
+	attachmentService.StartReconcile(jobCtx)
+	priceService.StartPromote(jobCtx)
+
 	// 6. 创建 HTTP 服务器实例
 	port := viper.GetInt("server.port")
 	srv := &http.Server{
@@ -91,6 +119,7 @@ func main() {
 	// 阻塞主 goroutine，直到接收到一个信号
 	<-quit
 	logger.Info("接收到关闭信号，正在关闭服务器...")
+	stopJobs()
 
 	// 创建一个有超时的 context，用于通知服务器在 5 秒内完成现有请求
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
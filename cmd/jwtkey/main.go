@@ -0,0 +1,83 @@
+// jwtkey 在命令行中查看和轮换 JWT 签名密钥，用于部署初期或无法登录管理端时。
+//
+//	jwtkey -f ./configs/config.yaml list
+//	jwtkey -f ./configs/config.yaml rotate -alg RS256
+//
+// 轮换后运行中的实例在 jwt.keyRefreshInterval 内开始使用新密钥
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"xinde/configs"
+	auditModel "xinde/internal/model/audit"
+	"xinde/internal/service/jwt_key"
+	"xinde/internal/store"
+	"xinde/pkg/jwt"
+	"xinde/pkg/logger"
+)
+
+var configFile = flag.String("f", "./configs/config.yaml", "the config file path")
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "用法: %s [-f config] list | rotate [-alg HS256|RS256|EdDSA]\n", os.Args[0])
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	logger.InitLogger()
+	if err := configs.InitConfig(*configFile); err != nil {
+		exit(err)
+	}
+	if err := store.InitDB(); err != nil {
+		exit(err)
+	}
+	if err := jwt.InitKeySet(store.GetDB()); err != nil {
+		exit(err)
+	}
+
+	service, err := jwt_key.NewJWTKeyService()
+	if err != nil {
+		exit(err)
+	}
+
+	switch flag.Arg(0) {
+	case "list":
+		list, err := service.GetKeyList()
+		if err != nil {
+			exit(err)
+		}
+		fmt.Printf("%-20s %-6s %-8s %-10s %-19s %s\n", "KID", "ALG", "STATUS", "VERIFIABLE", "VERIFY_UNTIL", "CREATED_AT")
+		for _, k := range list {
+			fmt.Printf("%-20s %-6s %-8s %-10t %-19s %s\n", k.Kid, k.Alg, k.Status, k.Verifiable, k.VerifyUntil, k.CreatedAt)
+		}
+	case "rotate":
+		cmd := flag.NewFlagSet("rotate", flag.ExitOnError)
+		alg := cmd.String("alg", "", "签名算法，HS256、RS256或EdDSA，为空时使用配置的jwt.algorithm")
+		_ = cmd.Parse(flag.Args()[1:])
+
+		// 命令行操作没有登录用户，审计日志中操作人记为 cli
+		actor := &auditModel.Actor{Username: "cli"}
+		key, err := service.RotateKey(actor, *alg)
+		if err != nil {
+			exit(err)
+		}
+		fmt.Printf("已生成新的签名密钥 %s (%s)\n", key.Kid, key.Alg)
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err.Error())
+	os.Exit(1)
+}
@@ -38,6 +38,70 @@ func InitConfig(filePath string) error {
 
 	// 如果需要，可以在这里设置一些默认值
 	// viper.SetDefault("server.port", 8080)
+	// 反向代理的地址（IP或CIDR）。只有来自这些地址的请求才读取 X-Forwarded-For 作为客户端IP，
+	// 默认为空，直接使用连接的对端地址，防止客户端伪造IP绕过登录限制、伪造登录记录和审计日志中的IP
+	viper.SetDefault("server.trustedProxies", []string{})
+	// 签名密钥、TOTP 密钥和 API Key 签名密钥加密保存在数据库中，加密使用的密钥分别为
+	// jwt.keyEncryptionKey、mfa.encryptionKey、apiKey.encryptionKey，未配置时使用 jwt.secret
+	// access token 应当短期有效，过期后使用 refresh token 换取新的 token
+	viper.SetDefault("jwt.duration", "15m")
+	viper.SetDefault("jwt.refreshDuration", "168h")
+	// 签名密钥保存在数据库中，各实例每隔 jwt.keyRefreshInterval 重新加载一次，轮换后在此时间内全部生效；
+	// 轮换时默认生成的密钥算法
+	viper.SetDefault("jwt.keyRefreshInterval", "1m")
+	viper.SetDefault("jwt.algorithm", "RS256")
+	// 找回密码的验证码
+	viper.SetDefault("account.resetCode.length", 6)
+	viper.SetDefault("account.resetCode.ttl", "10m")
+	viper.SetDefault("account.resetCode.maxAttempts", 5)
+	viper.SetDefault("account.resetCode.resendInterval", "60s")
+	viper.SetDefault("account.resetCode.tokenTTL", "15m")
+	// 每个账号在 window 内最多获取的验证码个数，避免通过反复获取新验证码绕过 maxAttempts 暴力尝试
+	viper.SetDefault("account.resetCode.maxPerWindow", 5)
+	viper.SetDefault("account.resetCode.window", "1h")
+	// 管理员重置密码时生成的一次性密码长度
+	viper.SetDefault("account.oneTimePasswordLength", 12)
+	// 批量导入用户时一个文件最多的行数，每个用户都要生成密码哈希，行数过多会导致请求超时
+	viper.SetDefault("account.importMaxRows", 500)
+	// 登录失败的锁定策略，用户名和IP分别计数。多实例部署时 driver 需配置为 db 共享计数
+	viper.SetDefault("account.loginLimit.user.driver", "memory")
+	viper.SetDefault("account.loginLimit.user.maxFailures", 5)
+	viper.SetDefault("account.loginLimit.user.window", "15m")
+	viper.SetDefault("account.loginLimit.user.baseLockout", "1m")
+	viper.SetDefault("account.loginLimit.user.maxLockout", "1h")
+	viper.SetDefault("account.loginLimit.ip.driver", "memory")
+	viper.SetDefault("account.loginLimit.ip.maxFailures", 20)
+	viper.SetDefault("account.loginLimit.ip.window", "15m")
+	viper.SetDefault("account.loginLimit.ip.baseLockout", "5m")
+	viper.SetDefault("account.loginLimit.ip.maxLockout", "24h")
+	// 用户查看自己最近的登录记录时返回的条数
+	viper.SetDefault("account.recentLoginLimit", 20)
+	// 二次验证：密码校验通过后等待输入验证码的时长、验证器应用中显示的名称、允许的时钟偏差（时间步数）、
+	// 恢复码的个数，以及管理员是否必须开启
+	viper.SetDefault("mfa.tokenDuration", "5m")
+	viper.SetDefault("mfa.issuer", "信德刀具选型")
+	viper.SetDefault("mfa.skew", 1)
+	viper.SetDefault("mfa.recoveryCodes", 10)
+	viper.SetDefault("mfa.enforceAdmin", true)
+	// API Key 签名：请求时间戳与服务器时间相差超过 apiKey.maxSkew 的请求直接拒绝，nonce 在此期间内不能重复使用；
+	// 参与签名的请求体最大字节数
+	viper.SetDefault("apiKey.maxSkew", "5m")
+	viper.SetDefault("apiKey.maxBodySize", 1<<20)
+	// 产品目录在PostgreSQL中修改后需要在MySQL中完成的操作：后台每隔 outbox.interval 检查一次，
+	// 失败后从 outbox.retryDelay 开始按指数退避重试，最长间隔 outbox.maxRetryDelay，重试 outbox.maxAttempts 次后放弃
+	viper.SetDefault("outbox.interval", "30s")
+	viper.SetDefault("outbox.batchSize", 100)
+	viper.SetDefault("outbox.retryDelay", "1m")
+	viper.SetDefault("outbox.maxRetryDelay", "1h")
+	viper.SetDefault("outbox.maxAttempts", 10)
+	// 每隔 outbox.reconcileInterval 检查一次附件和产品目录是否一致，结果写入日志，为0时不检查
+	viper.SetDefault("outbox.reconcileInterval", "6h")
+	// 每隔 price.promoteInterval 把到达生效时间的价格写入当前价格，每批处理 price.promoteBatchSize 个产品
+	viper.SetDefault("price.promoteInterval", "1m")
+	viper.SetDefault("price.promoteBatchSize", 500)
+	// 通知的发送方式，默认只写日志
+	viper.SetDefault("notify.email.driver", "log")
+	viper.SetDefault("notify.sms.driver", "log")
 
 	return nil
 }
@@ -24,9 +24,29 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "以 JWKS（RFC 7517）格式返回仍可用于验证的 RS256 和 EdDSA 公钥，其他服务可据此离线验证 token，按 token 头部的 kid 选择公钥。\nHS256 密钥不会公开。响应不使用统一的返回格式",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "JWTKey"
+                ],
+                "summary": "获取JWT验证公钥",
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_jwt_key.JWKSResp"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/account/login": {
             "post": {
-                "description": "用户登录，返回JWT token和用户基本信息",
+                "description": "用户登录，返回短期有效的access token、用于换取新token的refresh token和用户基本信息。用户名或IP连续登录失败次数过多时会被临时锁定，锁定时长逐次翻倍。开启了二次验证的用户（以及必须开启的管理员）只返回 mfa_required 和 mfa_token，需要再调用 /account/login/mfa 完成登录",
                 "consumes": [
                     "application/json"
                 ],
@@ -67,6 +87,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
+                    "403": {
+                        "description": "注册申请未通过、已被拒绝，或需要先修改管理员重置的一次性密码",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "登录失败次数过多，账号或IP已被临时锁定",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -76,9 +108,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/account/register": {
+        "/api/v1/account/login/mfa": {
             "post": {
-                "description": "用户名，真实姓名，公司名称，公司地址（可选），密码，手机号，邮箱（可选）",
+                "description": "登录返回 mfa_required 时，凭 mfa_token 和验证器中的6位验证码（或一个未使用过的恢复码）完成登录。mfa_token 只能使用一次，验证码错误计入登录失败次数",
                 "consumes": [
                     "application/json"
                 ],
@@ -88,49 +120,55 @@ const docTemplate = `{
                 "tags": [
                     "Account"
                 ],
-                "summary": "注册一个新用户",
+                "summary": "登录二次验证",
                 "parameters": [
                     {
-                        "description": "Register Request",
+                        "description": "MFALogin Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_account.RegisterReq"
+                            "$ref": "#/definitions/xinde_internal_dto_account.MFALoginReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User registered successfully",
+                        "description": "登录成功",
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_account.RegisterResp"
+                            "$ref": "#/definitions/xinde_internal_dto_account.LoginResp"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "参数错误或尚未开启二次验证",
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_account.RegisterResp"
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "401": {
+                        "description": "mfa_token无效或已过期、验证码错误",
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_account.RegisterResp"
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "登录失败次数过多，账号或IP已被临时锁定",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "服务器内部错误",
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_account.RegisterResp"
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/admin/account/approval/list": {
-            "get": {
-                "description": "根据前端传来的字段，返回对应的待审批列表/已同意申请/已拒绝申请的用户列表，根据创建时间降序排列",
+        "/api/v1/account/login/mfa/enroll": {
+            "post": {
+                "description": "登录返回 mfa_enroll_required 时（管理员必须开启二次验证但尚未绑定），凭 mfa_token 获取密钥和二维码内容。重复调用会生成新的密钥",
                 "consumes": [
                     "application/json"
                 ],
@@ -140,26 +178,23 @@ const docTemplate = `{
                 "tags": [
                     "Account"
                 ],
-                "summary": "管理员查看用户审批列表",
+                "summary": "登录时绑定验证器",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "当前页数，可选，默认为1",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "一页的内容数量，可选，默认为设置的默认值",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "MFAToken Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.MFATokenReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查询成功",
+                        "description": "获取成功",
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_account.ApprovalListResp"
+                            "$ref": "#/definitions/xinde_internal_dto_account.MFAEnrollResp"
                         }
                     },
                     "400": {
@@ -168,6 +203,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
+                    "401": {
+                        "description": "mfa_token无效或已过期",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "已经开启了二次验证",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -177,9 +224,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/account/approval/{id}": {
+        "/api/v1/account/login/mfa/enroll/confirm": {
             "post": {
-                "description": "批准用户申请，管理员决定是否同意用户的注册申请",
+                "description": "凭 mfa_token 和验证器中的6位验证码确认绑定，成功后开启二次验证并完成登录，同时返回恢复码。恢复码只返回这一次，请提示用户妥善保存",
                 "consumes": [
                     "application/json"
                 ],
@@ -189,51 +236,45 @@ const docTemplate = `{
                 "tags": [
                     "Account"
                 ],
-                "summary": "批准用户申请",
+                "summary": "登录时确认绑定验证器",
                 "parameters": [
                     {
-                        "description": "Login Request",
+                        "description": "MFAConfirmToken Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_account.ApproveReq"
+                            "$ref": "#/definitions/xinde_internal_dto_account.MFAConfirmTokenReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "审批成功",
+                        "description": "绑定并登录成功",
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_account.ApproveResp"
+                            "$ref": "#/definitions/xinde_internal_dto_account.MFAConfirmLoginResp"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "参数错误或尚未获取密钥",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "401": {
-                        "description": "access_token有错误",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
-                    },
-                    "403": {
-                        "description": "没有管理员权限",
+                        "description": "mfa_token无效或已过期、验证码错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
-                    "404": {
-                        "description": "没有该用户",
+                    "409": {
+                        "description": "已经开启了二次验证",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
-                    "409": {
-                        "description": "用户已经被审批",
+                    "429": {
+                        "description": "登录失败次数过多，账号或IP已被临时锁定",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -247,9 +288,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/account/list": {
-            "get": {
-                "description": "返回已被通过注册申请的用户信息",
+        "/api/v1/account/logout": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "注销当前的access token，并吊销传入的refresh token；all为true时注销该用户在所有设备上的登录",
                 "consumes": [
                     "application/json"
                 ],
@@ -259,26 +305,22 @@ const docTemplate = `{
                 "tags": [
                     "Account"
                 ],
-                "summary": "管理员查看用户列表",
+                "summary": "退出登录",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "当前页数，可选，默认为1",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "一页的内容数量，可选，默认为设置的默认值",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "Logout Request",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.LogoutReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查询成功",
+                        "description": "退出成功",
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_account.ListResp"
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "400": {
@@ -287,6 +329,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
+                    "401": {
+                        "description": "token有错误或refresh token无效",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -296,57 +344,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/account/password/{id}": {
-            "patch": {
-                "description": "管理员根据用户ID修改用户的密码",
-                "consumes": [
-                    "application/json"
+        "/api/v1/account/me": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "查看当前登录用户的个人信息、所在公司的价格等级以及注册申请的审核状态",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Account"
                 ],
-                "summary": "修改密码",
-                "parameters": [
-                    {
-                        "description": "UpdatePassword　Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_account.UpdatePasswordReq"
-                        }
-                    }
-                ],
+                "summary": "查看个人信息",
                 "responses": {
                     "200": {
-                        "description": "修改密码成功",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "参数错误",
+                        "description": "查询成功",
                         "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                            "$ref": "#/definitions/xinde_internal_dto_account.ProfileResp"
                         }
                     },
                     "401": {
-                        "description": "access_token有错误",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
-                    },
-                    "403": {
-                        "description": "没有管理员权限",
+                        "description": "token有错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "404": {
-                        "description": "没有该用户",
+                        "description": "用户不存在",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -358,11 +385,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/admin/account/remark/{id}": {
+            },
             "patch": {
-                "description": "管理员根据用户ID修改用户的备注信息",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "修改当前登录用户的姓名、电话和邮箱，校验规则与注册时相同。不传的字段保持不变，邮箱传空字符串表示清空",
                 "consumes": [
                     "application/json"
                 ],
@@ -372,21 +402,21 @@ const docTemplate = `{
                 "tags": [
                     "Account"
                 ],
-                "summary": "修改备注",
+                "summary": "修改个人信息",
                 "parameters": [
                     {
-                        "description": "ResetRemark　Request",
+                        "description": "UpdateProfile Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_account.ResetRemarkReq"
+                            "$ref": "#/definitions/xinde_internal_dto_account.UpdateProfileReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "修改备注成功",
+                        "description": "修改成功",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -398,19 +428,13 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "access_token有错误",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
-                    },
-                    "403": {
-                        "description": "没有管理员权限",
+                        "description": "token有错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "404": {
-                        "description": "没有该用户",
+                        "description": "用户不存在",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -424,46 +448,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/account/reset/password/{id}": {
-            "post": {
-                "description": "管理员根据用户ID重置用户的密码为123456",
-                "consumes": [
-                    "application/json"
+        "/api/v1/account/me/logins": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "查看当前登录用户最近的登录记录，包括失败的尝试，便于发现异常登录",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Account"
                 ],
-                "summary": "重置用户密码",
+                "summary": "查看自己最近的登录记录",
                 "responses": {
                     "200": {
-                        "description": "重置密码成功",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "参数错误",
+                        "description": "查询成功",
                         "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                            "$ref": "#/definitions/xinde_internal_dto_account.RecentLoginResp"
                         }
                     },
                     "401": {
-                        "description": "access_token有错误",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
-                    },
-                    "403": {
-                        "description": "没有管理员权限",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "没有该用户",
+                        "description": "token有错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -477,46 +485,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/account/{id}": {
-            "delete": {
-                "description": "根据ID删除用户",
-                "consumes": [
-                    "application/json"
+        "/api/v1/account/me/mfa": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "查看当前登录用户是否开启了二次验证、是否必须开启以及剩余可用的恢复码个数",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Account"
                 ],
-                "summary": "删除用户",
+                "summary": "查看二次验证状态",
                 "responses": {
                     "200": {
-                        "description": "删除成功",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "参数错误",
+                        "description": "查询成功",
                         "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                            "$ref": "#/definitions/xinde_internal_dto_account.MFAStatusResp"
                         }
                     },
                     "401": {
-                        "description": "access_token有错误",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
-                    },
-                    "403": {
-                        "description": "没有管理员权限",
+                        "description": "token有错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "404": {
-                        "description": "没有该用户",
+                        "description": "用户不存在",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -528,16 +526,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/admin/attachment/list": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "根据筛选条件分页获取已上传的附件列表",
+                "description": "校验密码和验证码（或恢复码）后关闭二次验证，同时作废全部恢复码。必须开启二次验证的管理员不能关闭",
                 "consumes": [
                     "application/json"
                 ],
@@ -545,57 +541,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Attachment"
+                    "Account"
                 ],
-                "summary": "获取附件列表",
+                "summary": "关闭二次验证",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "pageSize",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "业务类型 (e.g., price_import)",
-                        "name": "business_type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "文件名 (模糊搜索)",
-                        "name": "filename",
-                        "in": "query"
+                        "description": "MFADisable Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.MFADisableReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回附件列表",
+                        "description": "关闭成功",
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_attachment.ListResp"
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "参数错误、密码或验证码错误、尚未开启二次验证",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "401": {
-                        "description": "Token错误",
+                        "description": "token有错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "403": {
-                        "description": "没有管理员权限",
+                        "description": "管理员账号必须开启二次验证",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -609,14 +595,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/attachment/scan/invalid": {
-            "get": {
+        "/api/v1/account/me/mfa/confirm": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "扫描并返回数据库与磁盘文件不一致的记录",
+                "description": "输入验证器中的6位验证码确认绑定，成功后开启二次验证并返回恢复码。恢复码只返回这一次，请提示用户妥善保存",
                 "consumes": [
                     "application/json"
                 ],
@@ -624,24 +610,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Attachment"
+                    "Account"
+                ],
+                "summary": "确认绑定验证器",
+                "parameters": [
+                    {
+                        "description": "MFACode Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.MFACodeReq"
+                        }
+                    }
                 ],
-                "summary": "扫描异常附件",
                 "responses": {
                     "200": {
-                        "description": "成功返回扫描结果",
+                        "description": "绑定成功",
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_attachment.ScanInvalidResp"
+                            "$ref": "#/definitions/xinde_internal_dto_account.MFARecoveryCodesResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误、验证码错误或尚未获取密钥",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "401": {
-                        "description": "Token错误",
+                        "description": "token有错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
-                    "403": {
-                        "description": "没有管理员权限",
+                    "409": {
+                        "description": "已经开启了二次验证",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -655,60 +658,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/attachment/{id}": {
-            "delete": {
+        "/api/v1/account/me/mfa/enroll": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "根据附件ID删除对应的文件和数据库记录",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "为当前登录用户生成密钥和二维码内容，在验证器应用中添加后调用确认接口才会生效。重复调用会生成新的密钥",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Attachment"
-                ],
-                "summary": "删除附件",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "附件 ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Account"
                 ],
+                "summary": "开始绑定验证器",
                 "responses": {
                     "200": {
-                        "description": "删除成功",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
+                        "description": "获取成功",
                         "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                            "$ref": "#/definitions/xinde_internal_dto_account.MFAEnrollResp"
                         }
                     },
                     "401": {
-                        "description": "Token错误",
+                        "description": "token有错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
-                    "403": {
-                        "description": "没有管理员权限",
+                    "404": {
+                        "description": "用户不存在",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
-                    "404": {
-                        "description": "附件不存在",
+                    "409": {
+                        "description": "已经开启了二次验证",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -722,57 +707,113 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/attachments/{id}/download": {
-            "get": {
+        "/api/v1/account/me/mfa/recovery": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "根据附件ID下载对应的文件",
+                "description": "输入验证器中的6位验证码后重新生成恢复码，原有的恢复码全部作废",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "Attachment"
+                    "Account"
                 ],
-                "summary": "下载附件",
+                "summary": "重新生成恢复码",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "附件 ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "MFACode Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.MFACodeReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "文件流",
+                        "description": "生成成功",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/xinde_internal_dto_account.MFARecoveryCodesResp"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "参数错误、验证码错误或尚未开启二次验证",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "401": {
-                        "description": "Token错误",
+                        "description": "token有错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
-                    "403": {
-                        "description": "没有管理员权限",
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/account/me/password": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "校验原密码后修改当前登录用户的密码。修改成功后该用户在所有设备上的登录都会失效，并为当前设备返回一对新的token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "修改自己的密码",
+                "parameters": [
+                    {
+                        "description": "ChangePassword Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.ChangePasswordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功，返回新的token",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.ChangePasswordResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误、原密码错误或新旧密码相同",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token有错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "404": {
-                        "description": "附件或文件不存在",
+                        "description": "用户不存在",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -786,9 +827,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/company/list": {
-            "get": {
-                "description": "返回所有的公司信息",
+        "/api/v1/account/password/forgot": {
+            "post": {
+                "description": "向用户注册时填写的邮箱或手机号发送找回密码的验证码，只有注册申请已通过的用户可以找回密码。重新获取后之前的验证码全部作废。\n为了不暴露账号是否存在及其审核状态，用户不存在、未通过审核、没有绑定对应的邮箱或手机号、发送过于频繁或超过一段时间内的获取次数上限时都返回相同的成功结果。\n每次请求都计入登录失败的IP计数，IP被锁定后返回 429",
                 "consumes": [
                     "application/json"
                 ],
@@ -796,28 +837,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Company"
+                    "Account"
                 ],
-                "summary": "管理员查看公司列表",
+                "summary": "获取找回密码验证码",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "当前页数，可选，默认为1",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "一页的内容数量，可选，默认为设置的默认值",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "ForgotPassword Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.ForgotPasswordReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查询成功",
+                        "description": "请求已受理，账号存在时验证码已发送",
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_company.ListResp"
+                            "$ref": "#/definitions/xinde_internal_dto_account.ForgotPasswordResp"
                         }
                     },
                     "400": {
@@ -826,14 +864,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
-                    "401": {
-                        "description": "token错误",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
-                    },
-                    "403": {
-                        "description": "没有管理员权限",
+                    "429": {
+                        "description": "当前IP请求过多，已被临时限制",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -847,9 +879,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/company/price/level/{id}": {
-            "patch": {
-                "description": "管理员根据公司ID修改公司的价格等级",
+        "/api/v1/account/password/initial": {
+            "post": {
+                "description": "管理员重置密码后，用户凭用户名和一次性密码设置自己的密码，在此之前无法登录。修改成功后直接返回登录信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -857,47 +889,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Company"
+                    "Account"
                 ],
-                "summary": "修改价格等级",
+                "summary": "修改一次性密码",
                 "parameters": [
                     {
-                        "description": "UpdatePriceLevel　Request",
+                        "description": "ChangeInitialPassword Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_company.UpdatePriceLevelReq"
+                            "$ref": "#/definitions/xinde_internal_dto_account.ChangeInitialPasswordReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "修改价格等级成功",
+                        "description": "修改成功",
                         "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                            "$ref": "#/definitions/xinde_internal_dto_account.LoginResp"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "参数错误、两次密码不一致或新旧密码相同",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "401": {
-                        "description": "access_token有错误",
+                        "description": "用户名或密码错误（注册申请未通过或已被拒绝时也返回此错误）",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "403": {
-                        "description": "没有管理员权限",
+                        "description": "密码未被管理员重置，需要登录后修改密码",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
-                    "404": {
-                        "description": "没有该公司",
+                    "429": {
+                        "description": "登录失败次数过多，账号或IP已被临时锁定",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -911,14 +943,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/device/delete/{id}": {
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "彻底删除一个设备类型及其所有方案、主图和导入的Excel文件",
+        "/api/v1/account/password/reset": {
+            "post": {
+                "description": "使用校验验证码后得到的重置令牌设置新密码，令牌只能使用一次。设置成功后该用户在所有设备上的登录都会失效",
                 "consumes": [
                     "application/json"
                 ],
@@ -926,33 +953,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Device"
+                    "Account"
                 ],
-                "summary": "删除设备类型",
+                "summary": "凭重置令牌设置新密码",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "设备类型 ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "ResetPasswordByCode Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.ResetPasswordByCodeReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
+                        "description": "设置成功",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误或无效ID",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "设备类型不存在",
+                        "description": "参数错误、两次密码不一致或重置令牌无效",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -966,131 +989,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/device/import": {
+        "/api/v1/account/password/verify": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "上传Excel文件，批量导入设备（方案）到一个指定分组",
+                "description": "校验找回密码的验证码，通过后返回一个短期有效、只能使用一次的重置令牌。错误次数过多时验证码作废，需要重新获取。\n校验失败计入登录失败的IP计数，IP被锁定后返回 429",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Device"
+                    "Account"
                 ],
-                "summary": "从Excel导入设备",
+                "summary": "校验找回密码验证码",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "目标分组ID",
-                        "name": "group_id",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "设备的名称",
-                        "name": "name",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "包含设备数据的Excel文件",
-                        "name": "device",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "设备的主图",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "导入成功，并返回导入数量",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误或导入失败",
+                        "description": "VerifyCode Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                            "$ref": "#/definitions/xinde_internal_dto_account.VerifyCodeReq"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/admin/device/import/{id}": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "为一个已存在的设备类型上传新的Excel文件，覆盖其下所有方案",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Device"
-                ],
-                "summary": "更新导入设备方案",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "设备类型 ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "包含新设备方案的Excel文件",
-                        "name": "device",
-                        "in": "formData",
-                        "required": true
-                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新导入成功",
+                        "description": "校验成功，返回重置令牌",
                         "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                            "$ref": "#/definitions/xinde_internal_dto_account.VerifyCodeResp"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误或无效ID",
+                        "description": "参数错误、验证码错误或已过期（用户不存在或未通过审核时也返回此错误）",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
-                    "404": {
-                        "description": "设备类型不存在",
+                    "429": {
+                        "description": "验证码错误次数过多，或当前IP请求过多已被临时限制",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "500": {
-                        "description": "服务器内部错误或导入失败",
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -1098,14 +1041,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/device/list": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "分页获取设备类型列表，用于后台管理",
+        "/api/v1/account/refresh": {
+            "post": {
+                "description": "使用refresh token换取新的access token，旧的refresh token随之失效并返回新的refresh token。已失效的refresh token被再次使用时，会注销该用户的全部登录",
                 "consumes": [
                     "application/json"
                 ],
@@ -1113,32 +1051,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Device"
+                    "Account"
                 ],
-                "summary": "获取设备类型列表",
+                "summary": "刷新token",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "页码 (默认: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量 (默认: from config)",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "Refresh Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.RefreshReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回列表",
+                        "description": "刷新成功",
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_device.ListResp"
+                            "$ref": "#/definitions/xinde_internal_dto_account.RefreshResp"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "refresh token无效、已过期或已被使用过",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -1152,14 +1093,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/device/update/name/{id}": {
-            "patch": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "更新一个设备类型的名称",
+        "/api/v1/account/register": {
+            "post": {
+                "description": "用户名，真实姓名，公司名称，公司地址（可选），密码，手机号，邮箱（可选）",
                 "consumes": [
                     "application/json"
                 ],
@@ -1167,63 +1103,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Device"
+                    "Account"
                 ],
-                "summary": "更换设备类型的名称",
+                "summary": "注册一个新用户",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "设备类型 ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "包含新名称的请求体",
-                        "name": "body",
+                        "description": "Register Request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_device.ChangeNameReq"
+                            "$ref": "#/definitions/xinde_internal_dto_account.RegisterReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更换成功",
+                        "description": "User registered successfully",
                         "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                            "$ref": "#/definitions/xinde_internal_dto_account.RegisterResp"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误或无效ID",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                            "$ref": "#/definitions/xinde_internal_dto_account.RegisterResp"
                         }
                     },
-                    "404": {
-                        "description": "设备类型不存在",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                            "$ref": "#/definitions/xinde_internal_dto_account.RegisterResp"
                         }
                     },
                     "500": {
-                        "description": "服务器内部错误",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                            "$ref": "#/definitions/xinde_internal_dto_account.RegisterResp"
                         }
                     }
                 }
             }
         },
-        "/api/v1/admin/filter_image/change/device_type/{id}": {
-            "patch": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "将一条筛选条件图片配置移动到另一个设备类型下",
+        "/api/v1/admin/account/approval/batch": {
+            "post": {
+                "description": "一次批准或拒绝多个注册申请（最多100个），附加选项与单个审批相同，对每个用户生效。\n每个用户单独处理，一个失败不影响其他用户，返回每个用户的处理结果",
                 "consumes": [
                     "application/json"
                 ],
@@ -1231,54 +1155,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "FilterImage"
+                    "Account"
                 ],
-                "summary": "更改所属设备",
+                "summary": "批量审批用户申请",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "筛选条件图片配置 ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "请求体",
-                        "name": "body",
+                        "description": "BatchApprove Request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_device.ChangeDeviceTypeReq"
+                            "$ref": "#/definitions/xinde_internal_dto_account.BatchApproveReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "操作成功",
+                        "description": "处理完成，各用户的结果见results",
                         "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                            "$ref": "#/definitions/xinde_internal_dto_account.BatchApproveResp"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "配置或目标设备不存在",
+                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
-                    "409": {
-                        "description": "目标设备下已存在相同的筛选值配置 (Conflict)",
+                    "401": {
+                        "description": "access_token有错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "403": {
+                        "description": "没有管理员权限",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -1286,147 +1197,108 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/filter_image/create": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "为一个设备类型下的某个筛选值上传一张图片。如果该配置已存在，则会覆盖旧的图片。",
+        "/api/v1/admin/account/approval/list": {
+            "get": {
+                "description": "根据前端传来的字段，返回对应的待审批列表/已同意申请/已拒绝申请的用户列表，支持与用户列表相同的搜索、过滤和排序。\n公司管理员通过 /api/v1/company/account/approval/list 只能看到注册时填写本公司的申请",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "FilterImage"
+                    "Account"
                 ],
-                "summary": "创建或替换筛选条件图片",
+                "summary": "管理员查看用户审批列表",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "关联的设备类型ID",
-                        "name": "device_type_id",
-                        "in": "formData",
-                        "required": true
+                        "description": "当前页数，可选，默认为1",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "匹配的筛选条件的值",
-                        "name": "filter_value",
-                        "in": "formData",
-                        "required": true
+                        "type": "integer",
+                        "description": "一页的内容数量，可选，默认为设置的默认值",
+                        "name": "page_size",
+                        "in": "query"
                     },
                     {
-                        "type": "file",
-                        "description": "要上传的图片文件",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "操作成功",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
+                        "type": "string",
+                        "description": "pending(默认)、approved 或 rejected",
+                        "name": "status",
+                        "in": "query"
                     },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
+                    {
+                        "type": "string",
+                        "description": "按用户名、姓名、电话或公司名称搜索",
+                        "name": "keyword",
+                        "in": "query"
                     },
-                    "401": {
-                        "description": "Token错误",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
+                    {
+                        "type": "integer",
+                        "description": "按公司过滤",
+                        "name": "company_id",
+                        "in": "query"
                     },
-                    "403": {
-                        "description": "没有管理员权限",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
+                    {
+                        "type": "string",
+                        "description": "按所在公司的价格等级过滤",
+                        "name": "price_level",
+                        "in": "query"
                     },
-                    "404": {
-                        "description": "关联的设备类型不存在",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
+                    {
+                        "type": "integer",
+                        "description": "按角色过滤",
+                        "name": "role_id",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/admin/filter_image/list": {
-            "get": {
-                "security": [
                     {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "分页获取已配置的筛选条件图片列表，可按设备类型筛选",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "FilterImage"
-                ],
-                "summary": "获取筛选条件图片列表",
-                "parameters": [
+                        "type": "string",
+                        "description": "注册时间不早于该时间，格式YYYY-MM-DD或YYYY-MM-DD HH:MM:SS",
+                        "name": "created_from",
+                        "in": "query"
+                    },
                     {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
+                        "type": "string",
+                        "description": "注册时间早于该时间，格式同上",
+                        "name": "created_to",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "page_size",
+                        "type": "string",
+                        "description": "上次访问时间不早于该时间，格式同上",
+                        "name": "active_from",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "设备类型ID (用于筛选)",
-                        "name": "device_type_id",
-                        "in": "query",
-                        "required": true
+                        "type": "string",
+                        "description": "上次访问时间早于该时间，格式同上",
+                        "name": "active_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段: id(默认)、username、name、company_name、price_level、created_at、handled_at或recent_search_at",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc(默认) 或 desc",
+                        "name": "sort_order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回列表",
+                        "description": "查询成功",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/xinde_pkg_response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/xinde_internal_dto_device.ListPageData"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/xinde_internal_dto_account.ApprovalListResp"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -1440,14 +1312,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/filter_image/{id}": {
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "删除一条筛选条件图片配置及其关联的图片文件",
+        "/api/v1/admin/account/approval/reopen/{id}": {
+            "post": {
+                "description": "把已批准或已拒绝的注册申请重新置为待审批，之后可以重新审批。已批准的用户会立即掉线，重新批准前不能登录",
                 "consumes": [
                     "application/json"
                 ],
@@ -1455,33 +1322,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "FilterImage"
+                    "Account"
                 ],
-                "summary": "删除筛选条件图片",
+                "summary": "重新打开注册申请",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "筛选条件图片配置 ID",
+                        "description": "用户ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Reopen Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.ReopenReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
+                        "description": "重新打开成功",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误或无效ID",
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "404": {
-                        "description": "配置不存在",
+                        "description": "没有该用户",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "申请尚未处理",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -1495,67 +1389,70 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/group/create": {
+        "/api/v1/admin/account/approval/{id}": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "创建一个新分组，可选择性上传图标",
+                "description": "批准用户申请，管理员决定是否同意用户的注册申请。\n批准时可以把用户关联到已有的公司（company_id），merge_company 为 true 时把注册时按公司名称创建的公司合并过去（该公司的用户全部转移并删除该公司，有专属价格或折扣规则时不能合并），\n也可以同时设置用户所在公司的价格等级（price_level）。已审批的申请需要先重新打开才能再次审批。\n公司管理员通过 /api/v1/company/account/approval/{id} 只能审批本公司的申请，且不能指定公司或价格等级",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Group"
+                    "Account"
                 ],
-                "summary": "创建新分组",
+                "summary": "批准用户申请",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "分组名称",
-                        "name": "name",
-                        "in": "formData",
-                        "required": true
-                    },
                     {
                         "type": "integer",
-                        "description": "父级分组ID",
-                        "name": "parent_id",
-                        "in": "formData",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "分组图标 (可选)",
-                        "name": "icon",
-                        "in": "formData"
+                        "description": "Approve Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.ApproveReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
+                        "description": "审批成功",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "401": {
-                        "description": "Token错误",
+                        "description": "access_token有错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "403": {
-                        "description": "没有管理员权限",
+                        "description": "没有管理员权限，或公司管理员指定了公司或价格等级",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "没有该用户、公司或价格等级",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "用户已经被审批，或被合并的公司有专属价格",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -1569,14 +1466,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/group/delete/{id}": {
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "删除一个分组及其所有子孙分组，并处理关联的设备",
+        "/api/v1/admin/account/company_admin/{id}": {
+            "put": {
+                "description": "平台管理员设置或取消用户的公司管理员身份。公司管理员可以审批注册时填写本公司的申请、重置本公司用户的密码，\n以及查看本公司的价格和搜索记录，只能访问本公司的数据。只有已通过注册申请且有所属公司的用户可以设为公司管理员",
                 "consumes": [
                     "application/json"
                 ],
@@ -1584,39 +1476,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Group"
+                    "Account"
                 ],
-                "summary": "删除分组",
+                "summary": "设置公司管理员",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "分组 ID",
+                        "description": "用户ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "SetCompanyAdmin Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.SetCompanyAdminReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
+                        "description": "设置成功",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误或无效ID",
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "403": {
-                        "description": "禁止删除Root分组",
+                        "description": "没有管理员权限",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "404": {
-                        "description": "分组不存在",
+                        "description": "没有该用户",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "用户尚未通过注册申请或没有所属公司",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -1630,14 +1543,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/group/device/list/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "分页获取设备类型列表，用于前台展示",
+        "/api/v1/admin/account/create": {
+            "post": {
+                "description": "管理员直接创建已通过审批的用户，无需用户自己注册。密码为随机生成的一次性密码，只在本次响应中返回，用户首次登录时必须修改。\ncompany_id 和 company_name 至少填写一个，同时填写时以 company_id 为准；只填写 company_name 时按名称查找公司，没有时新建",
                 "consumes": [
                     "application/json"
                 ],
@@ -1645,88 +1553,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Group",
-                    "Solution"
+                    "Account"
                 ],
-                "summary": "根据分组获取设备类型列表",
+                "summary": "创建用户",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "设备类型 ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "CreateUser Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.CreateUserReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回列表",
+                        "description": "创建成功，返回一次性密码",
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_device.GroupDeviceListResp"
+                            "$ref": "#/definitions/xinde_internal_dto_account.CreateUserResp"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
-                    "404": {
-                        "description": "分组不存在",
+                    "401": {
+                        "description": "access_token有错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "403": {
+                        "description": "没有管理员权限",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/admin/group/list": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "获取一个扁平化、带分页的分组列表，用于后台管理",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Group"
-                ],
-                "summary": "获取后台分组列表",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "页码 (默认: 1)",
-                        "name": "page",
-                        "in": "query"
                     },
-                    {
-                        "type": "integer",
-                        "description": "每页数量 (默认: from config)",
-                        "name": "page_size",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "成功返回分组列表",
+                    "404": {
+                        "description": "没有该公司",
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_group.ListResp"
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误",
+                    "409": {
+                        "description": "用户名已存在",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -1740,110 +1613,110 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/group/tree": {
+        "/api/v1/admin/account/export": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "获取一个完整的、嵌套的树状分组结构",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "按与用户列表相同的查询条件导出全部符合条件的用户（不分页），包含公司、价格等级、角色和上次访问信息",
                 "produces": [
-                    "application/json"
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Group",
-                    "Solution"
+                    "Account"
                 ],
-                "summary": "获取树状分组列表。用于前台展示分组，和后台需要树状分组的地方。",
+                "summary": "导出用户Excel文件",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "是否包含图标URL (必填，true或者false)",
-                        "name": "icon",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "成功返回分组树",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_group.TreeResp"
-                        }
+                        "description": "pending、approved(默认) 或 rejected",
+                        "name": "status",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/admin/group/update/{id}": {
-            "put": {
-                "security": [
                     {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "根据ID更新一个分组的名称、父级或图标",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Group"
-                ],
-                "summary": "更新分组信息",
-                "parameters": [
+                        "type": "string",
+                        "description": "按用户名、姓名、电话或公司名称搜索",
+                        "name": "keyword",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "分组 ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "按公司过滤",
+                        "name": "company_id",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "新的分组名称",
-                        "name": "name",
-                        "in": "formData"
+                        "description": "按所在公司的价格等级过滤",
+                        "name": "price_level",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "新的父级分组ID",
-                        "name": "parent_id",
-                        "in": "formData"
+                        "description": "按角色过滤",
+                        "name": "role_id",
+                        "in": "query"
                     },
                     {
-                        "type": "file",
-                        "description": "新的分组图标 (可选)",
-                        "name": "icon",
-                        "in": "formData"
+                        "type": "string",
+                        "description": "注册时间不早于该时间，格式YYYY-MM-DD或YYYY-MM-DD HH:MM:SS",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "注册时间早于该时间，格式同上",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "上次访问时间不早于该时间，格式同上",
+                        "name": "active_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "上次访问时间早于该时间，格式同上",
+                        "name": "active_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段: id(默认)、username、name、company_name、price_level、created_at、handled_at或recent_search_at",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc(默认) 或 desc",
+                        "name": "sort_order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新成功",
+                        "description": "Excel文件流",
                         "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误或无效ID",
+                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
-                    "404": {
-                        "description": "分组不存在",
+                    "401": {
+                        "description": "Token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -1857,48 +1730,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/price/list": {
-            "get": {
-                "description": "返回所有产品的价格信息",
+        "/api/v1/admin/account/import": {
+            "post": {
+                "description": "上传包含用户信息的Excel文件，按表头名称定位各列（列顺序不限）：用户名、姓名、电话、公司为必需的列，邮箱、公司地址、管理员（是/否，是否为公司管理员）为可选的列。\n逐行校验后创建已通过审批的用户，公司按名称查找，没有时新建。每个用户都生成一次性密码，首次登录时必须修改。\nstrict模式下任意一行有错误则全部不创建；lenient模式下跳过错误行、创建其余行。\n返回逐行的结果文件：原表头之后追加行号、结果、临时密码和错误原因四列，临时密码只在这个文件中出现一次。\n响应头X-Import-Total、X-Import-Succeeded、X-Import-Failed分别为数据行数、创建的用户数和错误行数",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Price"
+                    "Account"
                 ],
-                "summary": "管理员查看价格列表",
+                "summary": "批量导入用户",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "当前页数，可选，默认为1",
-                        "name": "page",
-                        "in": "query"
+                        "type": "file",
+                        "description": "要上传的Excel文件 (格式: .xlsx)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "一页的内容数量，可选，默认为设置的默认值",
-                        "name": "page_size",
-                        "in": "query"
+                        "type": "string",
+                        "description": "导入模式: strict(默认) 或 lenient",
+                        "name": "mode",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查询成功",
+                        "description": "逐行的导入结果Excel文件",
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_price.ListResp"
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "文件上传失败、文件为空、行数过多或缺少必需的列",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "401": {
-                        "description": "token错误",
+                        "description": "access_token有错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -1918,54 +1792,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/prices/import": {
-            "post": {
+        "/api/v1/admin/account/ip/lock": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "上传一个包含价格信息的Excel文件，系统将解析文件内容并批量更新或插入价格数据。如果产品编码已存在，则会用新数据覆盖。",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "管理员解除某个IP因登录失败次数过多而产生的限制，并清除失败计数",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Price"
+                    "Account"
                 ],
-                "summary": "导入价格Excel文件",
+                "summary": "解除IP的登录限制",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "要上传的Excel文件 (格式: .xlsx)",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "客户端IP",
+                        "name": "ip",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "操作成功",
+                        "description": "解锁成功",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "400": {
-                        "description": "文件上传失败或文件内容/格式错误",
+                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "401": {
-                        "description": "Token错误",
+                        "description": "token错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "403": {
-                        "description": "没有管理员权限",
+                        "description": "权限不足",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -1979,14 +1850,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/update/group/{id}": {
-            "patch": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "将一个设备类型移动到另一个指定的分组下",
+        "/api/v1/admin/account/list": {
+            "get": {
+                "description": "返回已被通过注册申请的用户信息，支持按关键字搜索，按公司、价格等级、角色、注册时间和上次访问时间过滤及排序。公司管理员通过 /api/v1/company/account/list 只能看到本公司的用户",
                 "consumes": [
                     "application/json"
                 ],
@@ -1994,42 +1860,92 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Device"
+                    "Account"
                 ],
-                "summary": "更换设备类型的分组",
+                "summary": "管理员查看用户列表",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "设备类型 ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "当前页数，可选，默认为1",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
-                        "description": "包含新分组ID的请求体",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_device.ChangeGroupReq"
-                        }
-                    }
-                ],
+                        "type": "integer",
+                        "description": "一页的内容数量，可选，默认为设置的默认值",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "按用户名、姓名、电话或公司名称搜索",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "按公司过滤",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "按所在公司的价格等级过滤",
+                        "name": "price_level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "按角色过滤",
+                        "name": "role_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "注册时间不早于该时间，格式YYYY-MM-DD或YYYY-MM-DD HH:MM:SS",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "注册时间早于该时间，格式同上",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "上次访问时间不早于该时间，格式同上",
+                        "name": "active_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "上次访问时间早于该时间，格式同上",
+                        "name": "active_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段: id(默认)、username、name、company_name、price_level、created_at、handled_at或recent_search_at",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc(默认) 或 desc",
+                        "name": "sort_order",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "更换成功",
+                        "description": "查询成功",
                         "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                            "$ref": "#/definitions/xinde_internal_dto_account.ListResp"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误或无效ID",
-                        "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "设备类型或目标分组不存在",
+                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -2043,55 +1959,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/update/image/{id}": {
-            "post": {
+        "/api/v1/admin/account/lock/list": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "为一个已存在的设备类型上传新的主图，替换旧图",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "列出当前因登录失败次数过多而被临时锁定的用户名和IP",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Device"
-                ],
-                "summary": "修改设备类型图片",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "设备类型 ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "新的设备主图",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
-                    }
+                    "Account"
                 ],
+                "summary": "查看被锁定的账号和IP",
                 "responses": {
                     "200": {
-                        "description": "修改成功",
+                        "description": "查询成功",
                         "schema": {
-                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                            "$ref": "#/definitions/xinde_internal_dto_account.LockListResp"
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误或无效ID",
+                    "401": {
+                        "description": "token错误",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
-                    "404": {
-                        "description": "设备类型不存在",
+                    "403": {
+                        "description": "权限不足",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -2105,29 +2002,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/groups/device_types/{id}": {
-            "get": {
+        "/api/v1/admin/account/lock/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "分页获取设备类型列表，用于前台展示",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "管理员根据用户ID解除该用户因登录失败次数过多而产生的锁定，并清除失败计数",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Group",
-                    "Solution"
+                    "Account"
                 ],
-                "summary": "根据分组获取设备类型列表",
+                "summary": "解除账号的登录锁定",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "设备类型 ID",
+                        "description": "用户ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2135,19 +2028,31 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回列表",
+                        "description": "解锁成功",
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_device.GroupDeviceListResp"
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "404": {
-                        "description": "分组不存在",
+                        "description": "没有该用户",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -2161,14 +2066,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/groups/tree": {
+        "/api/v1/admin/account/login/history": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "获取一个完整的、嵌套的树状分组结构",
+                "description": "分页查看所有用户的登录记录，包括失败的尝试，最新的在前。支持按用户、用户名、IP、是否成功和时间范围过滤",
                 "consumes": [
                     "application/json"
                 ],
@@ -2176,24 +2081,82 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Group",
-                    "Solution"
+                    "Account"
                 ],
-                "summary": "获取树状分组列表。用于前台展示分组，和后台需要树状分组的地方。",
+                "summary": "查看登录记录",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "当前页数，可选，默认为1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "一页的内容数量，可选，默认为设置的默认值",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "uid",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "是否包含图标URL (必填，true或者false)",
-                        "name": "icon",
-                        "in": "query",
-                        "required": true
+                        "description": "登录时填写的用户名",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户端IP",
+                        "name": "ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否登录成功",
+                        "name": "success",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始时间（含），格式YYYY-MM-DD或YYYY-MM-DD HH:MM:SS",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间（不含），格式同上",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回分组树",
+                        "description": "查询成功",
                         "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_group.TreeResp"
+                            "$ref": "#/definitions/xinde_internal_dto_account.LoginHistoryResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "500": {
@@ -2205,62 +2168,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/solutions/query": {
-            "post": {
+        "/api/v1/admin/account/mfa/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "根据用户提供的筛选条件，动态查询方案列表，并返回下一步可用的筛选选项。传入空的筛选对象可获取初始状态。",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "用户丢失验证器和恢复码时，管理员清除其二次验证，用户下次登录时可以（管理员账号必须）重新绑定",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Solution"
+                    "Account"
                 ],
-                "summary": "查询/筛选选型方案",
+                "summary": "重置用户的二次验证",
                 "parameters": [
                     {
-                        "description": "查询请求体",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/xinde_internal_dto_solution.QueryReq"
-                        }
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查询成功",
+                        "description": "重置成功",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/xinde_pkg_response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/xinde_internal_dto_solution.QueryResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "参数错误或该用户没有开启二次验证",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
                     },
                     "401": {
-                        "description": "Token错误",
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "没有该用户",
                         "schema": {
                             "$ref": "#/definitions/xinde_pkg_response.Response"
                         }
@@ -2274,9 +2232,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ping": {
-            "get": {
-                "description": "do ping",
+        "/api/v1/admin/account/password/{id}": {
+            "patch": {
+                "description": "管理员根据用户ID修改用户的密码",
                 "consumes": [
                     "application/json"
                 ],
@@ -2284,135 +2242,8517 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "Account"
                 ],
-                "summary": "Ping server",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "修改密码",
+                "parameters": [
+                    {
+                        "description": "UpdatePassword　Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/xinde_internal_dto_account.UpdatePasswordReq"
                         }
                     }
-                }
-            }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改密码成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "没有该用户",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/account/remark/{id}": {
+            "patch": {
+                "description": "管理员根据用户ID修改用户的备注信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "修改备注",
+                "parameters": [
+                    {
+                        "description": "ResetRemark　Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.ResetRemarkReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改备注成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "没有该用户",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/account/reset/password/{id}": {
+            "post": {
+                "description": "管理员根据用户ID将用户的密码重置为随机生成的一次性密码，该密码只在本次响应中返回。用户登录前必须先修改这个密码，原有的登录全部失效。\n公司管理员通过 /api/v1/company/account/reset/password/{id} 只能重置本公司用户的密码，不能重置拥有后台权限的用户、其他公司管理员和自己",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "重置用户密码",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "重置密码成功，返回一次性密码",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.ResetPasswordResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限，或公司管理员重置拥有后台权限的用户、其他公司管理员或自己",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "没有该用户",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/account/role/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据用户ID返回该用户拥有的角色及其权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role"
+                ],
+                "summary": "查看用户的角色",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_role.UserRoleResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "整体替换用户的角色，拥有任意角色的用户即为管理员。修改后该用户需要重新登录；不能拿掉最后一名超级管理员的全部权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role"
+                ],
+                "summary": "设置用户的角色",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "SetUserRoles Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_role.SetUserRolesReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "设置成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户或角色不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "为最后一名超级管理员",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/account/{id}": {
+            "delete": {
+                "description": "根据ID删除用户",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "删除用户",
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "没有该用户",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "为最后一名超级管理员",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/api_key/create": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "为公司创建供外部系统服务端调用的 API Key，价格按该公司计算。返回的签名密钥只出现这一次，请提示管理员妥善保存。\n访问范围编码见 /admin/api_key/scopes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APIKey"
+                ],
+                "summary": "创建API Key",
+                "parameters": [
+                    {
+                        "description": "Create Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_api_key.CreateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_api_key.SecretResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误、访问范围无效或过期时间无效",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "公司不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/api_key/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "返回全部 API Key（包括已吊销和已过期的），不包含签名密钥",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APIKey"
+                ],
+                "summary": "查看API Key列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "按公司过滤，可选",
+                        "name": "company_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_api_key.ListResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/api_key/revoke/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据ID吊销 API Key，立即失效且不能恢复，记录保留用于审计",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APIKey"
+                ],
+                "summary": "吊销API Key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "API Key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "吊销成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误或已被吊销",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "API Key不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/api_key/rotate/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据ID重新生成签名密钥，原密钥立即失效，key_id 不变。新的签名密钥只出现这一次",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APIKey"
+                ],
+                "summary": "轮换API Key的签名密钥",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "API Key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "轮换成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_api_key.SecretResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误或已被吊销",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "API Key不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/api_key/scopes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "返回 API Key 可以拥有的全部访问范围编码及说明",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APIKey"
+                ],
+                "summary": "查看可分配的访问范围",
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_api_key.ScopeListResp"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/api_key/update/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据ID修改 API Key 的名称、访问范围或过期时间，立即生效。已吊销的 Key 不能修改",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APIKey"
+                ],
+                "summary": "修改API Key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "API Key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_api_key.UpdateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误、访问范围无效、过期时间无效或已被吊销",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "API Key不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/attachment/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据筛选条件分页获取已上传的附件列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachment"
+                ],
+                "summary": "获取附件列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "业务类型 (e.g., price_import)",
+                        "name": "business_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "文件名 (模糊搜索)",
+                        "name": "filename",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回附件列表",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_attachment.ListResp"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/attachment/scan/dangling": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "找出设备类型、筛选图片或分组已经不存在的附件，以及缺少主图、导入Excel或筛选图片的业务对象。\n同时返回还未执行完和已经失败的跨库操作数，有未执行完的操作时结果可能只是暂时的",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachment"
+                ],
+                "summary": "核对附件和产品目录",
+                "responses": {
+                    "200": {
+                        "description": "成功返回核对结果",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_attachment.ScanDanglingResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/attachment/scan/invalid": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "扫描并返回数据库与磁盘文件不一致的记录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachment"
+                ],
+                "summary": "扫描异常附件",
+                "responses": {
+                    "200": {
+                        "description": "成功返回扫描结果",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_attachment.ScanInvalidResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/attachment/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据附件ID删除对应的文件和数据库记录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachment"
+                ],
+                "summary": "删除附件",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "附件 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "附件不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/attachments/{id}/download": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据附件ID下载对应的文件",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Attachment"
+                ],
+                "summary": "下载附件",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "附件 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "文件流",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "附件或文件不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/audit/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按与审计日志列表相同的查询条件导出全部符合条件的记录（不分页）",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "导出审计日志CSV文件",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "操作人用户ID",
+                        "name": "actor_uid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "操作类型，如user.approve",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "对象类型，如user、price_level",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "对象ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求ID（响应头X-Request-ID）",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始时间（含），格式YYYY-MM-DD或YYYY-MM-DD HH:MM:SS",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间（不含），格式同上",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV文件流",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/audit/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "分页查看管理操作的审计日志，最新的在前。支持按操作人、操作类型、对象、请求ID和时间范围过滤",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "查看审计日志",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "当前页数，可选，默认为1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "一页的内容数量，可选，默认为设置的默认值",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "操作人用户ID",
+                        "name": "actor_uid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "操作类型，如user.approve",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "对象类型，如user、price_level",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "对象ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求ID（响应头X-Request-ID）",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始时间（含），格式YYYY-MM-DD或YYYY-MM-DD HH:MM:SS",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间（不含），格式同上",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_audit.ListResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/company/activity/{id}": {
+            "get": {
+                "description": "按访问时间倒序分页返回公司用户查看设备类型的记录，可以按用户和时间范围过滤。\n公司管理员通过 /api/v1/company/activity 只能查看本公司的记录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "查看公司用户的搜索记录",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "公司ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "当前页数，可选，默认为1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "一页的内容数量，可选，默认为设置的默认值",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "只看某个用户，可选",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始时间，YYYY-MM-DD或YYYY-MM-DD HH:MM:SS，可选",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间（不含），YYYY-MM-DD或YYYY-MM-DD HH:MM:SS，可选",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.ActivityResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "公司不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/company/create": {
+            "post": {
+                "description": "创建公司，名称不能与已有的公司（包括已删除和已合并的）重复，价格等级默认为price_1",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "创建公司",
+                "parameters": [
+                    {
+                        "description": "Create Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.CreateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.CreateResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "价格等级不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "公司名称已存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/company/delete/{id}": {
+            "delete": {
+                "description": "根据ID删除公司。公司下还有用户、专属价格、折扣规则或API Key时不能删除，重复的公司请使用合并",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "删除公司",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "公司ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "公司不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "公司仍在使用中",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/company/detail/{id}": {
+            "get": {
+                "description": "根据ID返回公司信息及用户数。公司管理员通过 /api/v1/company/info 查看本公司，不返回备注",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "查看公司详情",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "公司ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.DetailResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "公司不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/company/duplicates": {
+            "get": {
+                "description": "忽略全角半角、大小写、空白、标点和“有限公司”等后缀后，按编辑距离比较公司名称，相似度不低于threshold的公司归为一组。\n确认重复后可以使用合并接口合并",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "查找疑似重复的公司",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "名称相似度阈值，0-1，可选，默认为0.8",
+                        "name": "threshold",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.DuplicateResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/company/list": {
+            "get": {
+                "description": "返回所有的公司信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "管理员查看公司列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "当前页数，可选，默认为1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "一页的内容数量，可选，默认为设置的默认值",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.ListResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/company/members/{id}": {
+            "get": {
+                "description": "分页返回公司的全部用户，包括尚未审批和已拒绝的",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "查看公司的用户",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "公司ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "当前页数，可选，默认为1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "一页的内容数量，可选，默认为设置的默认值",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.MemberListResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "公司不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/company/merge": {
+            "post": {
+                "description": "把source_ids中的公司合并到target_id：用户、设备访问记录和API Key转到保留的公司，被合并的公司删除，\n以后按被合并公司的名称注册的用户直接归入保留的公司。被合并的公司的公司管理员不再是公司管理员，需要时重新设置。在一个事务中完成。被合并的公司有专属价格或折扣规则时不能合并，请先处理",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "合并公司",
+                "parameters": [
+                    {
+                        "description": "Merge Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.MergeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "合并成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.MergeResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "公司不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "被合并的公司有专属价格或折扣规则",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/company/price/level/{id}": {
+            "patch": {
+                "description": "管理员根据公司ID修改公司的价格等级",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "修改价格等级",
+                "parameters": [
+                    {
+                        "description": "UpdatePriceLevel　Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.UpdatePriceLevelReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改价格等级成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "没有该公司或该价格等级",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/company/price/override/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "管理员根据专属价格ID删除，删除后该产品恢复按折扣规则或价格等级计价",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "删除公司专属价格",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "专属价格ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "专属价格不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/company/price/override/list/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "管理员根据公司ID查看该公司针对单个产品的专属价格，公司管理员只能查看本公司的",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "查看公司专属价格",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "公司ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.OverrideListResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "没有该公司",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/company/price/override/save/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "管理员为公司设置某个产品的专属价格，已存在时覆盖。专属价格的优先级高于折扣规则和价格等级",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "设置公司专属价格",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "公司ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "SaveOverride Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.SaveOverrideReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "设置成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "没有该公司",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/company/price/rule/create/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "管理员为公司创建按产品编码前缀或设备分组（含子孙分组）限定范围的百分比折扣，折扣在公司价格等级的价格上计算",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "创建公司折扣规则",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "公司ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "CreateRule Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.CreateRuleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "没有该公司或该分组",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "已存在相同范围的折扣规则",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/company/price/rule/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "管理员根据折扣规则ID删除规则",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "删除公司折扣规则",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "折扣规则ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "折扣规则不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/company/price/rule/list/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "管理员根据公司ID查看该公司的折扣规则。同时命中多条规则时，产品编码前缀规则优先于设备分组规则，前缀越长、分组越近越优先",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "查看公司折扣规则",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "公司ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.RuleListResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "没有该公司",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/company/update/{id}": {
+            "patch": {
+                "description": "根据ID修改公司的名称、地址、价格等级或备注，不传的字段不修改。改名时同步修改该公司用户的公司名称",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "修改公司",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "公司ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.UpdateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "公司或价格等级不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "公司名称已存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/device/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "彻底删除一个设备类型及其所有方案、主图和导入的Excel文件",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "删除设备类型",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "设备类型 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或无效ID",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "设备类型不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/device/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "上传Excel文件，批量导入设备（方案）到一个指定分组",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "从Excel导入设备",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "目标分组ID",
+                        "name": "group_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "设备的名称",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "设备类型状态: draft 或 published。新建时默认 published，已存在时默认保持不变；指定时清除计划的发布/下架时间",
+                        "name": "status",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "包含设备数据的Excel文件",
+                        "name": "device",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "设备的主图",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "导入成功，并返回导入数量",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误或导入失败",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/device/import/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "为一个已存在的设备类型上传新的Excel文件，覆盖其下所有方案",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "更新导入设备方案",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "设备类型 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "设备类型状态: draft 或 published，不填时保持不变；指定时清除计划的发布/下架时间",
+                        "name": "status",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "包含新设备方案的Excel文件",
+                        "name": "device",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新导入成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或无效ID",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "设备类型不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误或导入失败",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/device/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "分页获取设备类型列表，用于后台管理",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "获取设备类型列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码 (默认: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量 (默认: from config)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回列表",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_device.ListResp"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/device/move": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "把多个设备类型按请求中的顺序移动到目标分组的指定位置，目标分组为设备类型当前的分组时即调整顺序",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "批量移动设备类型或调整顺序",
+                "parameters": [
+                    {
+                        "description": "设备类型ID列表、目标分组和位置",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_device.MoveReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "移动成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "设备类型或分组不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/device/update/name/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "更新一个设备类型的名称",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "更换设备类型的名称",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "设备类型 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "包含新名称的请求体",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_device.ChangeNameReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更换成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或无效ID",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "设备类型不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/device/update/status/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将设备类型设为草稿(draft)、已发布(published)或已下架(archived)，可以同时指定计划发布和下架时间。只有已发布的设备类型对前台用户可见",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "更改设备类型的发布状态",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "设备类型 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "新的状态和计划时间",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_device.UpdateStatusReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更改成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误、无效ID或时间格式错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "设备类型不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/filter_image/change/device_type/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将一条筛选条件图片配置移动到另一个设备类型下",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FilterImage"
+                ],
+                "summary": "更改所属设备",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "筛选条件图片配置 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "请求体",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_device.ChangeDeviceTypeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "操作成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "配置或目标设备不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "目标设备下已存在相同的筛选值配置 (Conflict)",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/filter_image/create": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "为一个设备类型下的某个筛选值上传一张图片。如果该配置已存在，则会覆盖旧的图片。",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FilterImage"
+                ],
+                "summary": "创建或替换筛选条件图片",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "关联的设备类型ID",
+                        "name": "device_type_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "匹配的筛选条件的值",
+                        "name": "filter_value",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "要上传的图片文件",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "操作成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "关联的设备类型不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/filter_image/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "分页获取已配置的筛选条件图片列表，可按设备类型筛选",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FilterImage"
+                ],
+                "summary": "获取筛选条件图片列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "设备类型ID (用于筛选)",
+                        "name": "device_type_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/xinde_pkg_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/xinde_internal_dto_device.ListPageData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/filter_image/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "删除一条筛选条件图片配置及其关联的图片文件",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FilterImage"
+                ],
+                "summary": "删除筛选条件图片",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "筛选条件图片配置 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或无效ID",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "配置不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/group/breadcrumb": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "返回从最上层分组开始的路径（不包含root分组），查询设备类型时最后一个节点是设备类型本身。group_id和device_type_id必须且只能填写一个。前台接口中对当前用户隐藏或未发布的节点返回404",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group",
+                    "Solution"
+                ],
+                "summary": "获取分组或设备类型的完整路径",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "分组 ID",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "设备类型 ID",
+                        "name": "device_type_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回路径",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_group.BreadcrumbResp"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "分组或设备类型不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/group/create": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "创建一个新分组，可选择性上传图标",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "创建新分组",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "分组名称",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "父级分组ID",
+                        "name": "parent_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "分组图标 (可选)",
+                        "name": "icon",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/group/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "删除一个分组及其所有子孙分组。mode=move（默认）时把其下的设备类型移动到 target_group_id 分组（默认 root 分组），\nmode=refuse 时如果其下还有设备类型则拒绝删除。设备类型移动后会失去的可见性规则（被删除的分组，以及不是目标分组祖先的原祖先分组上的规则）\n会转换为设备类型自己的规则，会失去多层规则而无法转换时拒绝删除",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "删除分组",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "分组 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "设备类型的处理方式: move 或 refuse，默认 move",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "接收设备类型的分组ID，默认 root 分组",
+                        "name": "target_group_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误、无效ID或目标分组无效",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "禁止删除Root分组",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "分组不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "mode=refuse 时分组下还有设备类型，或设备类型上的可见性规则无法转换",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/group/device/list/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "分页获取设备类型列表，用于前台展示。前台接口只返回当前用户可见的设备类型，分组对当前用户隐藏时返回404",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group",
+                    "Solution"
+                ],
+                "summary": "根据分组获取设备类型列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "设备类型 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回列表",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_device.GroupDeviceListResp"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "分组不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/group/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取一个扁平化、带分页的分组列表，用于后台管理",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "获取后台分组列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码 (默认: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量 (默认: from config)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回分组列表",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_group.ListResp"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/group/move/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "把分组移动到新的父级下的指定位置，不填 parent_id 时只在原来的兄弟分组中调整顺序。不能移动到自己或子孙分组下",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "移动分组或调整顺序",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "分组 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "新的父级和位置",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_group.MoveReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "移动成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误、无效ID或移动到自己的子孙分组下",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "禁止移动Root分组",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "分组或新的父级分组不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/group/tree": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取一个完整的、嵌套的树状分组结构。前台接口只返回当前用户可见的分组和已发布的设备类型，见产品目录可见性规则；后台接口返回全部分组和全部状态的设备类型。device_types为true时设备类型作为叶子节点一起返回，不需要再逐个分组查询设备类型",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group",
+                    "Solution"
+                ],
+                "summary": "获取树状分组列表。用于前台展示分组，和后台需要树状分组的地方。",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "是否包含图标URL (true或者false)，为true时设备类型也返回图片",
+                        "name": "icon",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否把设备类型作为叶子节点返回，并返回每个分组（含子孙分组）下的设备类型数量",
+                        "name": "device_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否去掉（含子孙分组）没有设备类型的分组，root分组始终保留",
+                        "name": "prune_empty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "返回的分组层数，root分组为第1层，不填表示不限制",
+                        "name": "max_depth",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回分组树",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_group.TreeResp"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/group/update/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据ID更新一个分组的名称、父级或图标",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "更新分组信息",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "分组 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "新的分组名称",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "新的父级分组ID",
+                        "name": "parent_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "新的分组图标 (可选)",
+                        "name": "icon",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或无效ID",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "分组不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/group/visibility/create": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "把分组（含子孙分组及其设备类型）或设备类型限定为只对某个公司或拥有某个角色的用户可见。\n隐藏的分组不会出现在前台分组树中，隐藏的设备类型不会出现在设备类型列表中，选型查询返回404",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "创建产品目录可见性规则",
+                "parameters": [
+                    {
+                        "description": "CreateVisibility Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_group.CreateVisibilityReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "分组、设备类型、公司或角色不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "已存在相同的规则",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/group/visibility/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "管理员根据规则ID删除可见性规则，分组或设备类型的最后一条规则删除后对所有用户可见",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "删除产品目录可见性规则",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "可见性规则ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "可见性规则不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/group/visibility/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "分组或设备类型没有规则时对所有用户可见；有规则时只对规则中的公司和拥有规则中角色的用户可见（满足任意一条即可）。\n分组上的规则同样作用于子孙分组和分组下的设备类型，祖先分组隐藏时子孙一定隐藏。通过 API Key 访问时按 Key 所属的公司判断",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "查看产品目录可见性规则",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "按分组过滤",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "按设备类型过滤",
+                        "name": "device_type_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "company 或 role",
+                        "name": "target_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "公司ID或角色ID，需要同时指定target_type",
+                        "name": "target_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_group.VisibilityListResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/jwt/keys": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "返回全部签名密钥（包括已退役的），不包含密钥材料。从未轮换过时只有配置文件中的 legacy 密钥",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "JWTKey"
+                ],
+                "summary": "查看JWT签名密钥列表",
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_jwt_key.ListResp"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/jwt/rotate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "生成一把新的签名密钥用于签发 token，原密钥退役，在已签发的 token 过期之前仍可验证，用户不会因此掉线。\n其他实例在 jwt.keyRefreshInterval 内开始使用新密钥。RS256 和 EdDSA 密钥的公钥通过 /.well-known/jwks.json 公开",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "JWTKey"
+                ],
+                "summary": "轮换JWT签名密钥",
+                "parameters": [
+                    {
+                        "description": "Rotate Request",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_jwt_key.RotateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "轮换成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_jwt_key.RotateResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误或不支持的签名算法",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/price/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按与价格列表相同的查询条件导出全部符合条件的价格（不分页），列的布局与导入文件一致，修改后可以直接重新导入",
+                "produces": [
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Price"
+                ],
+                "summary": "导出价格Excel文件",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "按产品编码或规格型号搜索",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "prefix(前缀匹配) 或 contains(包含匹配，默认)",
+                        "name": "match_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "missing(缺少价格)、zero(价格为0) 或 missing_or_zero",
+                        "name": "price_filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "price_filter只检查该价格等级，默认检查全部等级",
+                        "name": "level_code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "只导出该时间之后更新过的价格，格式YYYY-MM-DD或YYYY-MM-DD HH:MM:SS",
+                        "name": "updated_since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段: id(默认)、product_code、spec_code、updated_at或价格等级编码",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc(默认) 或 desc",
+                        "name": "sort_order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Excel文件流",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/price/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "返回某个产品编码的全部价格记录（含预先导入、尚未生效的价格），按生效时间倒序排列，并标注每条记录的状态及来源导入文件",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Price"
+                ],
+                "summary": "管理员查看产品价格历史",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "产品编码",
+                        "name": "product_code",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_price.HistoryResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/price/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "上传一个包含价格信息的Excel文件，系统按表头名称定位各列（列顺序不限），逐行校验后批量更新或插入价格数据。如果产品编码已存在，则会用新数据覆盖。\n价格列的表头为价格等级的编码或名称，文件中未出现的价格等级保持原价格不变；可选的effective_from(生效时间)列用于预先导入未来生效的价格或补录过去的价格，留空表示立即生效；未来生效的价格在到达生效时间后自动成为当前价格。\nstrict模式下任意一行有错误则全部不导入；lenient模式下跳过错误行、导入其余行。存在错误行时会生成带错误原因列的Excel报告，可通过附件下载接口下载。",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Price"
+                ],
+                "summary": "导入价格Excel文件",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "要上传的Excel文件 (格式: .xlsx)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "导入模式: strict(默认) 或 lenient",
+                        "name": "mode",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "导入完成，返回逐行的导入结果",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_price.ImportResp"
+                        }
+                    },
+                    "400": {
+                        "description": "文件上传失败、缺少必需的列，或strict模式下存在错误数据",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_price.ImportResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/price/level/create": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "创建一个新的价格等级，编码创建后不可修改。导入价格时，表头为等级编码或名称的列会被识别为该等级的价格",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Price"
+                ],
+                "summary": "创建价格等级",
+                "parameters": [
+                    {
+                        "description": "CreateLevel Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_price.CreateLevelReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "编码或名称已存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/price/level/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据ID删除价格等级及该等级下的当前价格（价格历史保留）。仍有公司使用的等级和新建公司使用的默认等级(price_1)不能删除，且至少保留一个等级",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Price"
+                ],
+                "summary": "删除价格等级",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "价格等级ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误、为最后一个价格等级或为默认价格等级",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "价格等级不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "仍有公司使用该价格等级",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/price/level/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "返回全部价格等级，按排序升序排列，第一个为默认等级",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Price"
+                ],
+                "summary": "管理员查看价格等级列表",
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_price.LevelListResp"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/price/level/update/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据ID修改价格等级的名称或排序，编码不可修改",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Price"
+                ],
+                "summary": "修改价格等级",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "价格等级ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UpdateLevel Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_price.UpdateLevelReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "价格等级不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "名称已存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/price/list": {
+            "get": {
+                "description": "返回产品的价格信息，支持按产品编码或规格型号搜索、按缺少价格/价格为0/更新时间过滤，以及按任意价格等级的价格排序",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Price"
+                ],
+                "summary": "管理员查看价格列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "当前页数，可选，默认为1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "一页的内容数量，可选，默认为设置的默认值",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "按产品编码或规格型号搜索",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "prefix(前缀匹配) 或 contains(包含匹配，默认)",
+                        "name": "match_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "missing(缺少价格)、zero(价格为0) 或 missing_or_zero",
+                        "name": "price_filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "price_filter只检查该价格等级，默认检查全部等级",
+                        "name": "level_code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "只返回该时间之后更新过的价格，格式YYYY-MM-DD或YYYY-MM-DD HH:MM:SS",
+                        "name": "updated_since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段: id(默认)、product_code、spec_code、updated_at或价格等级编码",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc(默认) 或 desc",
+                        "name": "sort_order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_price.ListResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/role/create": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "创建一个自定义角色，编码创建后不可修改。权限编码见 /admin/role/permissions，不能包含*。不传权限时创建标签角色，只用作产品目录可见性规则的对象，拥有它的用户不会成为管理员",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role"
+                ],
+                "summary": "创建角色",
+                "parameters": [
+                    {
+                        "description": "CreateRole Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_role.CreateRoleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误或权限编码无效",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "编码已存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/role/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据ID删除角色，拥有该角色的用户失去相应权限并需要重新登录。内置角色不能删除",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role"
+                ],
+                "summary": "删除角色",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误或为内置角色",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "角色不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/role/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "返回全部角色及其权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role"
+                ],
+                "summary": "查看角色列表",
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_role.RoleListResp"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/role/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "返回全部权限编码及说明。*为全部权限，只属于内置的超级管理员角色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role"
+                ],
+                "summary": "查看可分配的权限",
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_role.PermissionListResp"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/role/update/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据ID修改角色的名称、说明或权限，修改后立即对拥有该角色的用户生效。内置角色不能修改",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role"
+                ],
+                "summary": "修改角色",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UpdateRole Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_role.UpdateRoleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误、权限编码无效或为内置角色",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "角色不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/update/group/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将一个设备类型移动到另一个指定的分组下",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "更换设备类型的分组",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "设备类型 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "包含新分组ID的请求体",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_device.ChangeGroupReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更换成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或无效ID",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "设备类型或目标分组不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/update/image/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "为一个已存在的设备类型上传新的主图，替换旧图",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "修改设备类型图片",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "设备类型 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "新的设备主图",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或无效ID",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "设备类型不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/company/account/approval/list": {
+            "get": {
+                "description": "根据前端传来的字段，返回对应的待审批列表/已同意申请/已拒绝申请的用户列表，支持与用户列表相同的搜索、过滤和排序。\n公司管理员通过 /api/v1/company/account/approval/list 只能看到注册时填写本公司的申请",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "管理员查看用户审批列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "当前页数，可选，默认为1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "一页的内容数量，可选，默认为设置的默认值",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending(默认)、approved 或 rejected",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "按用户名、姓名、电话或公司名称搜索",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "按公司过滤",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "按所在公司的价格等级过滤",
+                        "name": "price_level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "按角色过滤",
+                        "name": "role_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "注册时间不早于该时间，格式YYYY-MM-DD或YYYY-MM-DD HH:MM:SS",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "注册时间早于该时间，格式同上",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "上次访问时间不早于该时间，格式同上",
+                        "name": "active_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "上次访问时间早于该时间，格式同上",
+                        "name": "active_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段: id(默认)、username、name、company_name、price_level、created_at、handled_at或recent_search_at",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc(默认) 或 desc",
+                        "name": "sort_order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.ApprovalListResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/company/account/approval/{id}": {
+            "post": {
+                "description": "批准用户申请，管理员决定是否同意用户的注册申请。\n批准时可以把用户关联到已有的公司（company_id），merge_company 为 true 时把注册时按公司名称创建的公司合并过去（该公司的用户全部转移并删除该公司，有专属价格或折扣规则时不能合并），\n也可以同时设置用户所在公司的价格等级（price_level）。已审批的申请需要先重新打开才能再次审批。\n公司管理员通过 /api/v1/company/account/approval/{id} 只能审批本公司的申请，且不能指定公司或价格等级",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "批准用户申请",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Approve Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.ApproveReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "审批成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限，或公司管理员指定了公司或价格等级",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "没有该用户、公司或价格等级",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "用户已经被审批，或被合并的公司有专属价格",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/company/account/list": {
+            "get": {
+                "description": "返回已被通过注册申请的用户信息，支持按关键字搜索，按公司、价格等级、角色、注册时间和上次访问时间过滤及排序。公司管理员通过 /api/v1/company/account/list 只能看到本公司的用户",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "管理员查看用户列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "当前页数，可选，默认为1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "一页的内容数量，可选，默认为设置的默认值",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "按用户名、姓名、电话或公司名称搜索",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "按公司过滤",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "按所在公司的价格等级过滤",
+                        "name": "price_level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "按角色过滤",
+                        "name": "role_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "注册时间不早于该时间，格式YYYY-MM-DD或YYYY-MM-DD HH:MM:SS",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "注册时间早于该时间，格式同上",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "上次访问时间不早于该时间，格式同上",
+                        "name": "active_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "上次访问时间早于该时间，格式同上",
+                        "name": "active_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段: id(默认)、username、name、company_name、price_level、created_at、handled_at或recent_search_at",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc(默认) 或 desc",
+                        "name": "sort_order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.ListResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/company/account/reset/password/{id}": {
+            "post": {
+                "description": "管理员根据用户ID将用户的密码重置为随机生成的一次性密码，该密码只在本次响应中返回。用户登录前必须先修改这个密码，原有的登录全部失效。\n公司管理员通过 /api/v1/company/account/reset/password/{id} 只能重置本公司用户的密码，不能重置拥有后台权限的用户、其他公司管理员和自己",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "重置用户密码",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "重置密码成功，返回一次性密码",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_account.ResetPasswordResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限，或公司管理员重置拥有后台权限的用户、其他公司管理员或自己",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "没有该用户",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/company/activity": {
+            "get": {
+                "description": "按访问时间倒序分页返回公司用户查看设备类型的记录，可以按用户和时间范围过滤。\n公司管理员通过 /api/v1/company/activity 只能查看本公司的记录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "查看公司用户的搜索记录",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "当前页数，可选，默认为1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "一页的内容数量，可选，默认为设置的默认值",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "只看某个用户，可选",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始时间，YYYY-MM-DD或YYYY-MM-DD HH:MM:SS，可选",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间（不含），YYYY-MM-DD或YYYY-MM-DD HH:MM:SS，可选",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.ActivityResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "公司不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/company/info": {
+            "get": {
+                "description": "根据ID返回公司信息及用户数。公司管理员通过 /api/v1/company/info 查看本公司，不返回备注",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "查看公司详情",
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.DetailResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "token错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "公司不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/company/price/override/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "管理员根据公司ID查看该公司针对单个产品的专属价格，公司管理员只能查看本公司的",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "查看公司专属价格",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "公司ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.OverrideListResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "没有该公司",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/company/price/rule/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "管理员根据公司ID查看该公司的折扣规则。同时命中多条规则时，产品编码前缀规则优先于设备分组规则，前缀越长、分组越近越优先",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Company"
+                ],
+                "summary": "查看公司折扣规则",
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_company.RuleListResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "access_token有错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "没有该公司",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/groups/breadcrumb": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "返回从最上层分组开始的路径（不包含root分组），查询设备类型时最后一个节点是设备类型本身。group_id和device_type_id必须且只能填写一个。前台接口中对当前用户隐藏或未发布的节点返回404",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group",
+                    "Solution"
+                ],
+                "summary": "获取分组或设备类型的完整路径",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "分组 ID",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "设备类型 ID",
+                        "name": "device_type_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回路径",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_group.BreadcrumbResp"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "分组或设备类型不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/groups/device_types/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "分页获取设备类型列表，用于前台展示。前台接口只返回当前用户可见的设备类型，分组对当前用户隐藏时返回404",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group",
+                    "Solution"
+                ],
+                "summary": "根据分组获取设备类型列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "设备类型 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回列表",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_device.GroupDeviceListResp"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "分组不存在",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/groups/tree": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取一个完整的、嵌套的树状分组结构。前台接口只返回当前用户可见的分组和已发布的设备类型，见产品目录可见性规则；后台接口返回全部分组和全部状态的设备类型。device_types为true时设备类型作为叶子节点一起返回，不需要再逐个分组查询设备类型",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group",
+                    "Solution"
+                ],
+                "summary": "获取树状分组列表。用于前台展示分组，和后台需要树状分组的地方。",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "是否包含图标URL (true或者false)，为true时设备类型也返回图片",
+                        "name": "icon",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否把设备类型作为叶子节点返回，并返回每个分组（含子孙分组）下的设备类型数量",
+                        "name": "device_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否去掉（含子孙分组）没有设备类型的分组，root分组始终保留",
+                        "name": "prune_empty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "返回的分组层数，root分组为第1层，不填表示不限制",
+                        "name": "max_depth",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回分组树",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_group.TreeResp"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/prices/query": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "查询一组产品编码的最终价格（已计入公司专属价格和按产品编码前缀的折扣规则），没有价格的产品不会返回。\n产品只出现在未发布或对调用方隐藏的设备类型的方案中时，与没有价格一样不返回\n用户 token 调用时按用户所在公司计算；也可以使用拥有 price:query 范围的 API Key 签名调用，价格按 Key 所属的公司计算",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Price"
+                ],
+                "summary": "查询产品价格",
+                "parameters": [
+                    {
+                        "description": "Query Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_price.QueryReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_price.QueryResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Token错误，或API Key无效、签名错误、请求被重放",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "API Key没有访问该接口的权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/solutions/query": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据用户提供的筛选条件，动态查询方案列表，并返回下一步可用的筛选选项。传入空的筛选对象可获取初始状态。\n管理员（需要 catalog:read 权限）可以传 preview=true 预览草稿或已下架的设备类型，预览不受可见性规则限制。\n也可以使用拥有 solution:query 范围的 API Key 签名调用（X-Api-Key、X-Timestamp、X-Nonce、X-Signature），价格按 Key 所属的公司计算。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Solution"
+                ],
+                "summary": "查询/筛选选型方案",
+                "parameters": [
+                    {
+                        "description": "查询请求体",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xinde_internal_dto_solution.QueryReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/xinde_pkg_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/xinde_internal_dto_solution.QueryResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Token错误，或API Key无效、签名错误、请求被重放",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "API Key没有访问该接口的权限，或没有预览权限",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "设备类型不存在、未发布，或按产品目录可见性规则对当前用户（API Key所属的公司）隐藏",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/xinde_pkg_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ping": {
+            "get": {
+                "description": "do ping",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Ping server",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
         "xinde_internal_dto_account.ApprovalListData": {
             "type": "object",
             "properties": {
-                "company_name": {
+                "company_name": {
+                    "type": "string",
+                    "example": "宁波鲍斯产业链服务有限公司"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2020-09-08 09:08:09"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "13800138000@qq.com"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "张三，真实名称"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "13800138000"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "张三，账号名称"
+                },
+                "why": {
+                    "type": "string",
+                    "example": "同意该用户的申请；拒绝该用户的申请"
+                }
+            }
+        },
+        "xinde_internal_dto_account.ApprovalListPageData": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_account.ApprovalListData"
+                    }
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pageSize": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "pages": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 137
+                }
+            }
+        },
+        "xinde_internal_dto_account.ApprovalListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_account.ApprovalListPageData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.ApproveReq": {
+            "type": "object"
+        },
+        "xinde_internal_dto_account.BatchApproveData": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_account.BatchApproveResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "xinde_internal_dto_account.BatchApproveReq": {
+            "type": "object"
+        },
+        "xinde_internal_dto_account.BatchApproveResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_account.BatchApproveData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.BatchApproveResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "用户已经被管理员批准注册申请"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "xinde_internal_dto_account.ChangeInitialPasswordReq": {
+            "type": "object",
+            "required": [
+                "confirmed_password",
+                "old_password",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "confirmed_password": {
+                    "type": "string",
+                    "example": "这是一个新密码"
+                },
+                "old_password": {
+                    "type": "string",
+                    "example": "一次性密码"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 6,
+                    "example": "这是一个新密码"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "金晖"
+                }
+            }
+        },
+        "xinde_internal_dto_account.ChangePasswordReq": {
+            "type": "object",
+            "required": [
+                "confirmed_password",
+                "old_password",
+                "password"
+            ],
+            "properties": {
+                "confirmed_password": {
+                    "type": "string",
+                    "example": "这是一个新密码"
+                },
+                "old_password": {
+                    "type": "string",
+                    "example": "923845797582"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 6,
+                    "example": "这是一个新密码"
+                }
+            }
+        },
+        "xinde_internal_dto_account.ChangePasswordResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_account.TokenData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.CreateUserData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "password": {
+                    "description": "一次性密码只在这里返回一次，用户首次登录时必须修改",
+                    "type": "string",
+                    "example": "Xk3mP9qR2tWz"
+                }
+            }
+        },
+        "xinde_internal_dto_account.CreateUserReq": {
+            "type": "object"
+        },
+        "xinde_internal_dto_account.CreateUserResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_account.CreateUserData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.ForgotPasswordData": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "验证码的有效期，单位秒",
+                    "type": "integer",
+                    "example": 600
+                },
+                "message": {
+                    "description": "无论账号是否存在都返回相同的提示",
+                    "type": "string",
+                    "example": "如果账号存在，验证码已发送到注册时填写的邮箱或手机号"
+                }
+            }
+        },
+        "xinde_internal_dto_account.ForgotPasswordReq": {
+            "type": "object",
+            "required": [
+                "channel",
+                "username"
+            ],
+            "properties": {
+                "channel": {
+                    "type": "string",
+                    "enum": [
+                        "email",
+                        "phone"
+                    ],
+                    "example": "email或phone，验证码发送到注册时填写的邮箱或手机号"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "金晖"
+                }
+            }
+        },
+        "xinde_internal_dto_account.ForgotPasswordResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_account.ForgotPasswordData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.ListData": {
+            "type": "object",
+            "properties": {
+                "company_name": {
+                    "type": "string",
+                    "example": "宁波鲍斯产业链服务有限公司"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2020-09-08 09:08:09"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "13800138000@qq.com"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "is_company_admin": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "example": "张三，真实名称"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "13800138000"
+                },
+                "price_level": {
+                    "type": "string",
+                    "example": "price_1"
+                },
+                "price_level_name": {
+                    "type": "string",
+                    "example": "价格等级1"
+                },
+                "recent_search_at": {
+                    "type": "string",
+                    "example": "2020-09-08 09:08:09"
+                },
+                "remark": {
+                    "type": "string",
+                    "example": "备注"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "普通用户"
+                },
+                "search_device": {
+                    "type": "string",
+                    "example": "车削刀杆"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "张三，账号名称"
+                }
+            }
+        },
+        "xinde_internal_dto_account.ListPageData": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_account.ListData"
+                    }
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pageSize": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "pages": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 137
+                }
+            }
+        },
+        "xinde_internal_dto_account.ListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_account.ListPageData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.LockData": {
+            "type": "object",
+            "properties": {
+                "ip": {
+                    "type": "string",
+                    "example": "127.0.0.1，type为ip时返回"
+                },
+                "locked_until": {
+                    "type": "string",
+                    "example": "2025-01-01 08:00:00"
+                },
+                "lockouts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "type": {
+                    "type": "string",
+                    "example": "user或ip"
+                },
+                "uid": {
+                    "description": "type为user且用户存在时返回",
+                    "type": "integer",
+                    "example": 2
+                },
+                "username": {
+                    "type": "string",
+                    "example": "金晖，type为user时返回"
+                }
+            }
+        },
+        "xinde_internal_dto_account.LockListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_account.LockData"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.LoginData": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "jwt_token"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "1921771473@qq.com"
+                },
+                "expires_in": {
+                    "description": "access token 的有效期，单位秒",
+                    "type": "integer",
+                    "example": 900
+                },
+                "mfa_enroll_required": {
+                    "description": "为 true 时该账号必须开启二次验证但尚未绑定，需要先凭 mfa_token 绑定验证器",
+                    "type": "boolean",
+                    "example": false
+                },
+                "mfa_expires_in": {
+                    "description": "mfa_token 的有效期，单位秒",
+                    "type": "integer",
+                    "example": 300
+                },
+                "mfa_required": {
+                    "description": "是否需要二次验证",
+                    "type": "boolean",
+                    "example": false
+                },
+                "mfa_token": {
+                    "type": "string",
+                    "example": "mfa_token"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "金晖"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "13065859690"
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "refresh_token"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "金晖"
+                }
+            }
+        },
+        "xinde_internal_dto_account.LoginHistoryData": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-01 08:00:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "127.0.0.1"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "bad_credentials、not_approved、rejected、must_change_password、user_locked、ip_locked、bad_mfa_code或error"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "uid": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "user_agent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "金晖"
+                }
+            }
+        },
+        "xinde_internal_dto_account.LoginHistoryPageData": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_account.LoginHistoryData"
+                    }
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pageSize": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "pages": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 137
+                }
+            }
+        },
+        "xinde_internal_dto_account.LoginHistoryResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_account.LoginHistoryPageData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.LoginReq": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "923845797582"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "金晖"
+                }
+            }
+        },
+        "xinde_internal_dto_account.LoginResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_account.LoginData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.LogoutReq": {
+            "type": "object",
+            "properties": {
+                "all": {
+                    "description": "为 true 时注销该用户在所有设备上的登录",
+                    "type": "boolean",
+                    "example": false
+                },
+                "refresh_token": {
+                    "description": "同时吊销该 refresh token，不传时只注销当前的 access token",
+                    "type": "string",
+                    "example": "refresh_token"
+                }
+            }
+        },
+        "xinde_internal_dto_account.MFACodeReq": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "123456"
+                }
+            }
+        },
+        "xinde_internal_dto_account.MFAConfirmLoginData": {
+            "type": "object",
+            "properties": {
+                "login": {
+                    "$ref": "#/definitions/xinde_internal_dto_account.LoginData"
+                },
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "abcde-fghjk"
+                    ]
+                }
+            }
+        },
+        "xinde_internal_dto_account.MFAConfirmLoginResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_account.MFAConfirmLoginData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.MFAConfirmTokenReq": {
+            "type": "object",
+            "required": [
+                "code",
+                "mfa_token"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "mfa_token": {
+                    "type": "string",
+                    "example": "mfa_token"
+                }
+            }
+        },
+        "xinde_internal_dto_account.MFADisableReq": {
+            "type": "object",
+            "required": [
+                "code",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "123456，验证码或恢复码"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "923845797582"
+                }
+            }
+        },
+        "xinde_internal_dto_account.MFAEnrollData": {
+            "type": "object",
+            "properties": {
+                "provisioning_uri": {
+                    "description": "前端渲染为二维码供验证器应用扫描",
+                    "type": "string",
+                    "example": "otpauth://totp/%E4%BF%A1%E5%BE%B7:admin?secret=...\u0026issuer=..."
+                },
+                "secret": {
+                    "description": "base32 编码的密钥，无法扫描二维码时手动输入",
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+                }
+            }
+        },
+        "xinde_internal_dto_account.MFAEnrollResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_account.MFAEnrollData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.MFALoginReq": {
+            "type": "object",
+            "required": [
+                "code",
+                "mfa_token"
+            ],
+            "properties": {
+                "code": {
+                    "description": "验证器应用中的6位验证码，或者一个未使用过的恢复码",
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "123456"
+                },
+                "mfa_token": {
+                    "type": "string",
+                    "example": "mfa_token"
+                }
+            }
+        },
+        "xinde_internal_dto_account.MFARecoveryCodesData": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "description": "恢复码只在这里返回一次，每个只能使用一次",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "abcde-fghjk"
+                    ]
+                }
+            }
+        },
+        "xinde_internal_dto_account.MFARecoveryCodesResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_account.MFARecoveryCodesData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.MFAStatusData": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "recovery_codes_left": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "required": {
+                    "description": "该账号是否必须开启二次验证",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.MFAStatusResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_account.MFAStatusData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.MFATokenReq": {
+            "type": "object",
+            "required": [
+                "mfa_token"
+            ],
+            "properties": {
+                "mfa_token": {
+                    "type": "string",
+                    "example": "mfa_token"
+                }
+            }
+        },
+        "xinde_internal_dto_account.ProfileData": {
+            "type": "object",
+            "properties": {
+                "company_address": {
+                    "type": "string",
+                    "example": "浙江省宁波市奉化区江口街道聚潮路55号"
+                },
+                "company_name": {
+                    "type": "string",
+                    "example": "宁波鲍斯产业链服务有限公司"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2020-09-08 09:08:09"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "1921771473@qq.com"
+                },
+                "handled_at": {
+                    "type": "string",
+                    "example": "2020-09-08 09:08:09"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "金晖，真实姓名"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "13065859690"
+                },
+                "price_level": {
+                    "type": "string",
+                    "example": "price_1"
+                },
+                "price_level_name": {
+                    "type": "string",
+                    "example": "价格等级1"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending、approved或rejected"
+                },
+                "uid": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "username": {
+                    "type": "string",
+                    "example": "金晖，账号名称"
+                },
+                "why": {
+                    "type": "string",
+                    "example": "审核通过/拒绝的原因"
+                }
+            }
+        },
+        "xinde_internal_dto_account.ProfileResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_account.ProfileData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.RecentLoginResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_account.LoginHistoryData"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.RefreshReq": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "refresh_token"
+                }
+            }
+        },
+        "xinde_internal_dto_account.RefreshResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_account.TokenData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.RegisterReq": {
+            "type": "object",
+            "required": [
+                "company_name",
+                "confirmed_password",
+                "name",
+                "password",
+                "phone",
+                "username"
+            ],
+            "properties": {
+                "company_address": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "浙江省宁波市奉化区江口街道聚潮路55号，可选"
+                },
+                "company_name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "宁波鲍斯产业链服务有限公司"
+                },
+                "confirmed_password": {
+                    "type": "string",
+                    "example": "923845797582"
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "1921771473@qq.com，可选"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "金晖，真实姓名"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 6,
+                    "example": "923845797582"
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 7,
+                    "example": "13065859690"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "金晖，账号名称"
+                }
+            }
+        },
+        "xinde_internal_dto_account.RegisterResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {},
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.ReopenReq": {
+            "type": "object",
+            "required": [
+                "why"
+            ],
+            "properties": {
+                "why": {
+                    "type": "string",
+                    "example": "重新打开注册申请的理由"
+                }
+            }
+        },
+        "xinde_internal_dto_account.ResetPasswordByCodeReq": {
+            "type": "object",
+            "required": [
+                "confirmed_password",
+                "password",
+                "reset_token"
+            ],
+            "properties": {
+                "confirmed_password": {
+                    "type": "string",
+                    "example": "这是一个新密码"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 6,
+                    "example": "这是一个新密码"
+                },
+                "reset_token": {
+                    "type": "string",
+                    "example": "reset_token"
+                }
+            }
+        },
+        "xinde_internal_dto_account.ResetPasswordData": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "description": "一次性密码只在这里返回一次，用户下次登录时必须修改",
+                    "type": "string",
+                    "example": "Xk3mP9qR2tWz"
+                }
+            }
+        },
+        "xinde_internal_dto_account.ResetPasswordResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_account.ResetPasswordData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.ResetRemarkReq": {
+            "type": "object",
+            "properties": {
+                "remark": {
+                    "type": "string",
+                    "example": "这是一条备注"
+                }
+            }
+        },
+        "xinde_internal_dto_account.SetCompanyAdminReq": {
+            "type": "object",
+            "required": [
+                "enabled"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_account.TokenData": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "jwt_token"
+                },
+                "expires_in": {
+                    "description": "access token 的有效期，单位秒",
+                    "type": "integer",
+                    "example": 900
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "refresh_token"
+                }
+            }
+        },
+        "xinde_internal_dto_account.UpdatePasswordReq": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 6,
+                    "example": "这是一个密码"
+                }
+            }
+        },
+        "xinde_internal_dto_account.UpdateProfileReq": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "1921771473@qq.com，可选，传空字符串表示清空邮箱"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 1,
+                    "example": "金晖，可选"
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 7,
+                    "example": "13065859690，可选"
+                }
+            }
+        },
+        "xinde_internal_dto_account.VerifyCodeData": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "重置令牌的有效期，单位秒",
+                    "type": "integer",
+                    "example": 900
+                },
+                "reset_token": {
+                    "description": "凭重置令牌设置新密码",
+                    "type": "string",
+                    "example": "reset_token"
+                }
+            }
+        },
+        "xinde_internal_dto_account.VerifyCodeReq": {
+            "type": "object",
+            "required": [
+                "code",
+                "username"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 10,
+                    "example": "123456"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "金晖"
+                }
+            }
+        },
+        "xinde_internal_dto_account.VerifyCodeResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_account.VerifyCodeData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_api_key.APIKeyData": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "company_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-01 08:00:00"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-01-01 00:00:00，为空表示永不过期"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "key_id": {
+                    "type": "string",
+                    "example": "xd_abcdefghjkmnpqrstuvw"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2025-01-01 08:00:00"
+                },
+                "last_used_ip": {
+                    "type": "string",
+                    "example": "10.0.0.8"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "ERP"
+                },
+                "revoked_at": {
+                    "type": "string",
+                    "example": ""
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "solution:query",
+                        "price:query"
+                    ]
+                }
+            }
+        },
+        "xinde_internal_dto_api_key.CreateReq": {
+            "type": "object",
+            "required": [
+                "company_id",
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "company_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-01-01或2026-01-01 00:00:00，不传表示永不过期"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 63,
+                    "example": "ERP"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "solution:query"
+                    ]
+                }
+            }
+        },
+        "xinde_internal_dto_api_key.ListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_api_key.APIKeyData"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_api_key.ScopeData": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "solution:query"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "查询选型方案"
+                }
+            }
+        },
+        "xinde_internal_dto_api_key.ScopeListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_api_key.ScopeData"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_api_key.SecretData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "key_id": {
+                    "type": "string",
+                    "example": "xd_abcdefghjkmnpqrstuvw"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "Zm9vYmFy..."
+                }
+            }
+        },
+        "xinde_internal_dto_api_key.SecretResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_api_key.SecretData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_api_key.UpdateReq": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "不传表示不修改，传空字符串表示永不过期",
+                    "type": "string",
+                    "example": "2026-01-01或2026-01-01 00:00:00"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 63,
+                    "example": "ERP"
+                },
+                "scopes": {
+                    "description": "不为空时整体替换访问范围",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "solution:query"
+                    ]
+                }
+            }
+        },
+        "xinde_internal_dto_attachment.DanglingData": {
+            "type": "object",
+            "properties": {
+                "dangling_records": {
+                    "description": "附件指向的设备类型、筛选图片或分组不存在",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_attachment.DanglingRecord"
+                    }
+                },
+                "failed_operations": {
+                    "description": "重试次数用完仍然失败的跨库操作数，需要人工处理",
+                    "type": "integer"
+                },
+                "missing_records": {
+                    "description": "设备类型缺少主图或导入的Excel，筛选图片缺少图片",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_attachment.MissingRecord"
+                    }
+                },
+                "pending_operations": {
+                    "description": "还未执行完的跨库操作数，不为0时以上结果可能只是暂时的",
+                    "type": "integer"
+                }
+            }
+        },
+        "xinde_internal_dto_attachment.DanglingRecord": {
+            "type": "object",
+            "properties": {
+                "business_id": {
+                    "type": "integer"
+                },
+                "business_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "storage_path": {
+                    "type": "string"
+                }
+            }
+        },
+        "xinde_internal_dto_attachment.ListData": {
+            "type": "object",
+            "properties": {
+                "business_type": {
+                    "type": "string",
+                    "example": "price_import"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_size": {
+                    "type": "string",
+                    "example": "1.2MB"
+                },
+                "file_type": {
+                    "type": "string",
+                    "example": "xxxxyyyzzz.xlsx"
+                },
+                "filename": {
+                    "type": "string",
+                    "example": "价格表"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "storage_driver": {
+                    "type": "string",
+                    "example": "local或oss或其他"
+                },
+                "uploaded_by": {
+                    "description": "显示上传者用户名，而不是ID",
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "xinde_internal_dto_attachment.ListPageData": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_attachment.ListData"
+                    }
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pageSize": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "pages": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 137
+                }
+            }
+        },
+        "xinde_internal_dto_attachment.ListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_attachment.ListPageData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_attachment.MissingRecord": {
+            "type": "object",
+            "properties": {
+                "business_id": {
+                    "type": "integer"
+                },
+                "business_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "xinde_internal_dto_attachment.OrphanData": {
+            "type": "object",
+            "properties": {
+                "orphan_files": {
+                    "description": "数据库没有，磁盘有",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "orphan_records": {
+                    "description": "数据库有，磁盘没有",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_attachment.OrphanRecord"
+                    }
+                }
+            }
+        },
+        "xinde_internal_dto_attachment.OrphanRecord": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "storage_path": {
+                    "type": "string"
+                },
+                "uploaded_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "xinde_internal_dto_attachment.ScanDanglingResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_attachment.DanglingData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_attachment.ScanInvalidResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_attachment.OrphanData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_audit.ListData": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "user.approve"
+                },
+                "actor_name": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "actor_uid": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-01 08:00:00"
+                },
+                "diff": {
+                    "type": "object"
+                },
+                "entity_id": {
+                    "type": "string",
+                    "example": "12"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "example": "user"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "127.0.0.1"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "0b3f6c1e-6a4c-4a57-9f35-1f7f0c1b2d3e"
+                }
+            }
+        },
+        "xinde_internal_dto_audit.ListPageData": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_audit.ListData"
+                    }
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pageSize": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "pages": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 137
+                }
+            }
+        },
+        "xinde_internal_dto_audit.ListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_audit.ListPageData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_company.ActivityData": {
+            "type": "object",
+            "properties": {
+                "accessed_at": {
+                    "type": "string",
+                    "example": "2025-01-01 08:00:00"
+                },
+                "device_type_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "device_type_name": {
+                    "type": "string",
+                    "example": "车削刀杆"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "张三，真实名称"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "username": {
+                    "type": "string",
+                    "example": "张三，账号名称"
+                }
+            }
+        },
+        "xinde_internal_dto_company.ActivityPageData": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_company.ActivityData"
+                    }
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pageSize": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "pages": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 137
+                }
+            }
+        },
+        "xinde_internal_dto_company.ActivityResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_company.ActivityPageData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_company.CreateData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "xinde_internal_dto_company.CreateReq": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "浙江省宁波市奉化区江口街道聚潮路55号"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "宁波鲍斯产业链有限公司"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "华东区代理商"
+                },
+                "price_level": {
+                    "type": "string",
+                    "maxLength": 31,
+                    "example": "price_1，可选，默认为price_1"
+                }
+            }
+        },
+        "xinde_internal_dto_company.CreateResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_company.CreateData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_company.CreateRuleReq": {
+            "type": "object"
+        },
+        "xinde_internal_dto_company.DetailData": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "浙江省宁波市奉化区江口街道聚潮路55号"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-09-09 09:09:09"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "宁波鲍斯产业链有限公司"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "华东区代理商"
+                },
+                "price_level": {
+                    "description": "价格等级的展示名称",
+                    "type": "string",
+                    "example": "价格等级1"
+                },
+                "price_level_code": {
+                    "type": "string",
+                    "example": "price_1"
+                },
+                "user_count": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "xinde_internal_dto_company.DetailResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_company.DetailData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_company.DuplicateCompany": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "浙江省宁波市奉化区"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-09-09 09:09:09"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "name": {
+                    "type": "string",
+                    "example": "宁波鲍斯产业链服务有限公司"
+                },
+                "price_level_code": {
+                    "type": "string",
+                    "example": "price_1"
+                },
+                "user_count": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "xinde_internal_dto_company.DuplicateGroup": {
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_company.DuplicateCompany"
+                    }
+                },
+                "similarity": {
+                    "description": "组内两两之间的最高相似度",
+                    "type": "number",
+                    "example": 0.92
+                }
+            }
+        },
+        "xinde_internal_dto_company.DuplicateResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_company.DuplicateGroup"
+                    }
+                },
+                "message": {
                     "type": "string",
-                    "example": "宁波鲍斯产业链服务有限公司"
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_company.ListData": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "浙江省宁波市奉化区江口街道聚潮路55号"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-09-09 09:09:09"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "宁波鲍斯产业链有限公司"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "华东区代理商"
+                },
+                "price_level": {
+                    "description": "价格等级的展示名称",
+                    "type": "string",
+                    "example": "价格等级1"
+                },
+                "price_level_code": {
+                    "type": "string",
+                    "example": "price_1"
+                }
+            }
+        },
+        "xinde_internal_dto_company.ListPageData": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_company.ListData"
+                    }
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pageSize": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "pages": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 137
+                }
+            }
+        },
+        "xinde_internal_dto_company.ListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_company.ListPageData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
                 },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_company.MemberData": {
+            "type": "object",
+            "properties": {
                 "created_at": {
                     "type": "string",
                     "example": "2020-09-08 09:08:09"
                 },
-                "email": {
+                "email": {
+                    "type": "string",
+                    "example": "13800138000@qq.com"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "张三，真实名称"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "13800138000"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending或approved或rejected"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "张三，账号名称"
+                }
+            }
+        },
+        "xinde_internal_dto_company.MemberListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_company.MemberPageData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_company.MemberPageData": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_company.MemberData"
+                    }
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pageSize": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "pages": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "xinde_internal_dto_company.MergeData": {
+            "type": "object",
+            "properties": {
+                "demoted_company_admins": {
+                    "description": "被合并的公司原来的公司管理员，合并后不再是公司管理员，需要时重新设置",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        12
+                    ]
+                },
+                "moved_access_logs": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "moved_api_keys": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "moved_users": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "moved_visibility_rules": {
+                    "description": "转到保留公司的产品目录可见性规则，保留公司在同一分组或设备类型上已有规则的不计入",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "xinde_internal_dto_company.MergeReq": {
+            "type": "object"
+        },
+        "xinde_internal_dto_company.MergeResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_company.MergeData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_company.OverrideData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "price": {
+                    "type": "number",
+                    "example": 1099
+                },
+                "product_code": {
+                    "type": "string",
+                    "example": "WGC001547"
+                },
+                "remark": {
+                    "type": "string",
+                    "example": "2025年框架合同"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-01 09:00:00"
+                }
+            }
+        },
+        "xinde_internal_dto_company.OverrideListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_company.OverrideData"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_company.RuleData": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-01 09:00:00"
+                },
+                "discount_percent": {
+                    "type": "number",
+                    "example": 10
+                },
+                "group_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "group_name": {
                     "type": "string",
-                    "example": "13800138000@qq.com"
+                    "example": "车削刀杆"
                 },
                 "id": {
                     "type": "integer",
-                    "example": 2
-                },
-                "name": {
-                    "type": "string",
-                    "example": "张三，真实名称"
+                    "example": 1
                 },
-                "phone": {
+                "product_prefix": {
                     "type": "string",
-                    "example": "13800138000"
+                    "example": ""
                 },
-                "username": {
+                "remark": {
                     "type": "string",
-                    "example": "张三，账号名称"
+                    "example": "备注"
                 },
-                "why": {
+                "scope_type": {
                     "type": "string",
-                    "example": "同意该用户的申请；拒绝该用户的申请"
+                    "example": "group"
                 }
             }
         },
-        "xinde_internal_dto_account.ApprovalListPageData": {
+        "xinde_internal_dto_company.RuleListResp": {
             "type": "object",
             "properties": {
-                "list": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/xinde_internal_dto_account.ApprovalListData"
+                        "$ref": "#/definitions/xinde_internal_dto_company.RuleData"
                     }
                 },
-                "page": {
-                    "type": "integer",
-                    "example": 1
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
                 },
-                "pageSize": {
-                    "type": "integer",
-                    "example": 20
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_company.SaveOverrideReq": {
+            "type": "object",
+            "required": [
+                "price",
+                "product_code"
+            ],
+            "properties": {
+                "price": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 1099
                 },
-                "pages": {
-                    "type": "integer",
-                    "example": 7
+                "product_code": {
+                    "type": "string",
+                    "maxLength": 31,
+                    "example": "WGC001547"
                 },
-                "total": {
-                    "type": "integer",
-                    "example": 137
+                "remark": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "2025年框架合同，可选"
                 }
             }
         },
-        "xinde_internal_dto_account.ApprovalListResp": {
+        "xinde_internal_dto_company.UpdatePriceLevelReq": {
             "type": "object",
+            "required": [
+                "price_level"
+            ],
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 200
+                "price_level": {
+                    "type": "string",
+                    "maxLength": 31,
+                    "example": "price_1，必须是已存在的价格等级编码"
+                }
+            }
+        },
+        "xinde_internal_dto_company.UpdateReq": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "浙江省宁波市奉化区江口街道聚潮路55号"
                 },
-                "data": {
-                    "$ref": "#/definitions/xinde_internal_dto_account.ApprovalListPageData"
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "宁波鲍斯产业链有限公司"
                 },
-                "message": {
+                "notes": {
                     "type": "string",
-                    "example": "操作成功"
+                    "maxLength": 500,
+                    "example": "华东区代理商"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "price_level": {
+                    "type": "string",
+                    "maxLength": 31,
+                    "example": "price_2"
                 }
             }
         },
-        "xinde_internal_dto_account.ApproveReq": {
+        "xinde_internal_dto_device.ChangeDeviceTypeReq": {
             "type": "object",
             "required": [
-                "status",
-                "why"
+                "device_type_id"
             ],
             "properties": {
-                "status": {
+                "device_type_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "xinde_internal_dto_device.ChangeGroupReq": {
+            "type": "object",
+            "required": [
+                "group_id"
+            ],
+            "properties": {
+                "group_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "xinde_internal_dto_device.ChangeNameReq": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
                     "type": "string",
-                    "enum": [
-                        "approve",
-                        "reject"
-                    ],
-                    "example": "approve或者reject"
+                    "example": "车削刀杆"
+                }
+            }
+        },
+        "xinde_internal_dto_device.GroupDeviceListData": {
+            "type": "object",
+            "properties": {
+                "device_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "why": {
+                "group_name": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "刀柄"
+                },
+                "status": {
+                    "description": "实际状态，前台接口只会返回 published",
                     "type": "string",
-                    "example": "批准/拒绝用户申请的理由"
+                    "example": "published"
                 }
             }
         },
-        "xinde_internal_dto_account.ApproveResp": {
+        "xinde_internal_dto_device.GroupDeviceListResp": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
                     "example": 200
                 },
-                "data": {},
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_device.GroupDeviceListData"
+                    }
+                },
                 "message": {
                     "type": "string",
                     "example": "操作成功"
@@ -2423,221 +10763,315 @@ const docTemplate = `{
                 }
             }
         },
-        "xinde_internal_dto_account.ListData": {
+        "xinde_internal_dto_device.ListData": {
             "type": "object",
             "properties": {
-                "company_name": {
-                    "type": "string",
-                    "example": "宁波鲍斯产业链服务有限公司"
-                },
                 "created_at": {
-                    "type": "string",
-                    "example": "2020-09-08 09:08:09"
+                    "type": "string"
                 },
-                "email": {
-                    "type": "string",
-                    "example": "13800138000@qq.com"
+                "effective_status": {
+                    "description": "考虑计划时间后的实际状态，前台只能看到 published",
+                    "type": "string"
+                },
+                "group_name": {
+                    "description": "分组名称",
+                    "type": "string"
                 },
                 "id": {
-                    "type": "integer",
-                    "example": 2
+                    "type": "integer"
+                },
+                "image_url": {
+                    "description": "设备图片",
+                    "type": "string"
                 },
                 "name": {
-                    "type": "string",
-                    "example": "张三，真实名称"
+                    "description": "设备类型名称",
+                    "type": "string"
                 },
-                "phone": {
-                    "type": "string",
-                    "example": "13800138000"
+                "publish_at": {
+                    "description": "计划发布时间，为空表示未设置",
+                    "type": "string"
                 },
-                "price_level": {
-                    "type": "string",
-                    "example": "price_1"
+                "solution_count": {
+                    "description": "条目数 (方案数量)",
+                    "type": "integer"
                 },
-                "recent_search_at": {
-                    "type": "string",
-                    "example": "2020-09-08 09:08:09"
+                "status": {
+                    "description": "设置的状态: draft, published, archived",
+                    "type": "string"
                 },
-                "remark": {
-                    "type": "string",
-                    "example": "备注"
+                "unpublish_at": {
+                    "description": "计划下架时间，为空表示未设置",
+                    "type": "string"
                 },
-                "role": {
-                    "type": "string",
-                    "example": "普通用户"
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "xinde_internal_dto_device.ListPageData": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_device.ListData"
+                    }
                 },
-                "search_device": {
-                    "type": "string",
-                    "example": "车削刀杆"
+                "page": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "username": {
+                "pageSize": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "pages": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 137
+                }
+            }
+        },
+        "xinde_internal_dto_device.ListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_device.ListPageData"
+                },
+                "message": {
                     "type": "string",
-                    "example": "张三，账号名称"
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "xinde_internal_dto_account.ListPageData": {
+        "xinde_internal_dto_device.MoveReq": {
             "type": "object",
+            "required": [
+                "device_type_ids",
+                "group_id"
+            ],
             "properties": {
-                "list": {
+                "device_type_ids": {
                     "type": "array",
+                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/xinde_internal_dto_account.ListData"
-                    }
+                        "type": "integer"
+                    },
+                    "example": [
+                        3,
+                        5
+                    ]
                 },
-                "page": {
+                "group_id": {
                     "type": "integer",
+                    "minimum": 1,
                     "example": 1
                 },
-                "pageSize": {
-                    "type": "integer",
-                    "example": 20
-                },
-                "pages": {
-                    "type": "integer",
-                    "example": 7
-                },
-                "total": {
+                "position": {
+                    "description": "在目标分组中的位置，从0开始，多个设备类型按请求中的顺序连续排列。不填或超出范围时排在最后",
                     "type": "integer",
-                    "example": 137
+                    "minimum": 0,
+                    "example": 0
                 }
             }
         },
-        "xinde_internal_dto_account.ListResp": {
+        "xinde_internal_dto_device.UpdateStatusReq": {
             "type": "object",
+            "required": [
+                "status"
+            ],
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 200
-                },
-                "data": {
-                    "$ref": "#/definitions/xinde_internal_dto_account.ListPageData"
+                "publish_at": {
+                    "description": "计划发布时间，格式 YYYY-MM-DD 或 YYYY-MM-DD HH:MM:SS，状态为 published 时忽略",
+                    "type": "string",
+                    "example": "2025-01-01 08:00:00"
                 },
-                "message": {
+                "status": {
                     "type": "string",
-                    "example": "操作成功"
+                    "enum": [
+                        "draft",
+                        "published",
+                        "archived"
+                    ],
+                    "example": "draft"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "unpublish_at": {
+                    "description": "计划下架时间，格式同上",
+                    "type": "string",
+                    "example": "2025-06-30"
                 }
             }
         },
-        "xinde_internal_dto_account.LoginData": {
+        "xinde_internal_dto_group.BackendListData": {
             "type": "object",
             "properties": {
-                "access_token": {
+                "created_at": {
                     "type": "string",
-                    "example": "jwt_token"
+                    "example": "2020-09-08 09:09:09"
                 },
-                "email": {
+                "icon_url": {
                     "type": "string",
-                    "example": "1921771473@qq.com"
+                    "example": "图片url"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "level": {
+                    "description": "【新增】层级字段",
+                    "type": "integer",
+                    "example": 1
                 },
                 "name": {
                     "type": "string",
-                    "example": "金晖"
+                    "example": "钢件加工"
                 },
-                "phone": {
-                    "type": "string",
-                    "example": "13065859690"
+                "parent_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "username": {
+                "parent_name": {
                     "type": "string",
-                    "example": "金晖"
+                    "example": "root"
                 }
             }
         },
-        "xinde_internal_dto_account.LoginReq": {
+        "xinde_internal_dto_group.BreadcrumbData": {
             "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
             "properties": {
-                "password": {
+                "nodes": {
+                    "description": "从最上层分组开始的完整路径（不包含 root 分组），查询设备类型时最后一个节点是设备类型本身",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_group.BreadcrumbNode"
+                    }
+                },
+                "path": {
+                    "description": "各节点名称用 \"-\" 连接后的路径，与设备类型列表中的分组路径格式一致",
                     "type": "string",
-                    "example": "923845797582"
+                    "example": "一级分组-二级分组"
+                }
+            }
+        },
+        "xinde_internal_dto_group.BreadcrumbNode": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
                 },
-                "username": {
+                "name": {
+                    "type": "string"
+                },
+                "type": {
                     "type": "string",
-                    "example": "金晖"
+                    "example": "group"
                 }
             }
         },
-        "xinde_internal_dto_account.LoginResp": {
+        "xinde_internal_dto_group.BreadcrumbResp": {
             "type": "object",
             "properties": {
                 "code": {
-                    "type": "integer",
-                    "example": 200
+                    "type": "integer"
                 },
                 "data": {
-                    "$ref": "#/definitions/xinde_internal_dto_account.LoginData"
+                    "$ref": "#/definitions/xinde_internal_dto_group.BreadcrumbData"
                 },
                 "message": {
-                    "type": "string",
-                    "example": "操作成功"
+                    "type": "string"
                 },
                 "success": {
-                    "type": "boolean",
-                    "example": true
+                    "type": "boolean"
                 }
             }
         },
-        "xinde_internal_dto_account.RegisterReq": {
+        "xinde_internal_dto_group.CreateVisibilityReq": {
+            "type": "object"
+        },
+        "xinde_internal_dto_group.GroupTreeNode": {
             "type": "object",
-            "required": [
-                "company_name",
-                "confirmed_password",
-                "name",
-                "password",
-                "phone",
-                "username"
-            ],
             "properties": {
-                "company_address": {
-                    "type": "string",
-                    "example": "浙江省宁波市奉化区江口街道聚潮路55号，可选"
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_group.GroupTreeNode"
+                    }
                 },
-                "company_name": {
-                    "type": "string",
-                    "example": "宁波鲍斯产业链服务有限公司"
+                "device_type_count": {
+                    "description": "以下字段只在 device_types 或 prune_empty 为 true 时返回",
+                    "type": "integer"
                 },
-                "confirmed_password": {
-                    "type": "string",
-                    "example": "923845797582"
+                "device_types": {
+                    "description": "直接属于该分组的设备类型",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_group.TreeDeviceTypeNode"
+                    }
                 },
-                "email": {
-                    "type": "string",
-                    "example": "1921771473@qq.com，可选"
+                "icon_url": {
+                    "description": "omitempty 可以在不需要时隐藏",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
                 },
                 "name": {
-                    "type": "string",
-                    "example": "金晖，真实姓名"
+                    "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "example": "923845797582"
+                "parent_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "xinde_internal_dto_group.ListPageData": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_group.BackendListData"
+                    }
                 },
-                "phone": {
-                    "type": "string",
-                    "example": "13065859690"
+                "page": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "username": {
-                    "type": "string",
-                    "example": "金晖，账号名称"
+                "pageSize": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "pages": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 137
                 }
             }
         },
-        "xinde_internal_dto_account.RegisterResp": {
+        "xinde_internal_dto_group.ListResp": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
                     "example": 200
                 },
-                "data": {},
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_group.ListPageData"
+                },
                 "message": {
                     "type": "string",
                     "example": "操作成功"
@@ -2648,92 +11082,107 @@ const docTemplate = `{
                 }
             }
         },
-        "xinde_internal_dto_account.ResetRemarkReq": {
-            "type": "object",
-            "properties": {
-                "remark": {
-                    "type": "string",
-                    "example": "这是一条备注"
-                }
-            }
-        },
-        "xinde_internal_dto_account.UpdatePasswordReq": {
+        "xinde_internal_dto_group.MoveReq": {
             "type": "object",
-            "required": [
-                "password"
-            ],
             "properties": {
-                "password": {
-                    "type": "string",
-                    "example": "这是一个密码"
+                "parent_id": {
+                    "description": "新的父级分组ID，不填表示不改变父级，只调整顺序",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "position": {
+                    "description": "在兄弟分组中的位置，从0开始，不填或超出范围时排在最后",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
                 }
             }
         },
-        "xinde_internal_dto_attachment.ListData": {
+        "xinde_internal_dto_group.TreeDeviceTypeNode": {
             "type": "object",
             "properties": {
-                "business_type": {
-                    "type": "string",
-                    "example": "price_import"
+                "id": {
+                    "type": "integer"
                 },
-                "created_at": {
+                "image_url": {
                     "type": "string"
                 },
-                "file_size": {
-                    "type": "string",
-                    "example": "1.2MB"
+                "name": {
+                    "type": "string"
                 },
-                "file_type": {
-                    "type": "string",
-                    "example": "xxxxyyyzzz.xlsx"
+                "solution_count": {
+                    "type": "integer"
                 },
-                "filename": {
-                    "type": "string",
-                    "example": "价格表"
+                "status": {
+                    "description": "生效中的状态，前台只会看到 published",
+                    "type": "string"
+                }
+            }
+        },
+        "xinde_internal_dto_group.TreeResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
                 },
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                "data": {
+                    "description": "保持为列表，即使只返回 root 节点",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_group.GroupTreeNode"
+                    }
                 },
-                "storage_driver": {
-                    "type": "string",
-                    "example": "local或oss或其他"
+                "message": {
+                    "type": "string"
                 },
-                "uploaded_by": {
-                    "description": "显示上传者用户名，而不是ID",
-                    "type": "string",
-                    "example": "admin"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "xinde_internal_dto_attachment.ListPageData": {
+        "xinde_internal_dto_group.VisibilityData": {
             "type": "object",
             "properties": {
-                "list": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/xinde_internal_dto_attachment.ListData"
-                    }
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-01 09:00:00"
                 },
-                "page": {
+                "device_type_id": {
                     "type": "integer",
-                    "example": 1
+                    "example": 0
                 },
-                "pageSize": {
+                "device_type_name": {
+                    "type": "string",
+                    "example": ""
+                },
+                "group_id": {
                     "type": "integer",
-                    "example": 20
+                    "example": 3
                 },
-                "pages": {
+                "group_name": {
+                    "type": "string",
+                    "example": "车削刀杆"
+                },
+                "id": {
                     "type": "integer",
-                    "example": 7
+                    "example": 1
                 },
-                "total": {
+                "target_id": {
                     "type": "integer",
-                    "example": 137
+                    "example": 5
+                },
+                "target_name": {
+                    "type": "string",
+                    "example": "宁波鲍斯产业链服务有限公司"
+                },
+                "target_type": {
+                    "type": "string",
+                    "example": "company"
                 }
             }
         },
-        "xinde_internal_dto_attachment.ListResp": {
+        "xinde_internal_dto_group.VisibilityListResp": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2741,7 +11190,10 @@ const docTemplate = `{
                     "example": 200
                 },
                 "data": {
-                    "$ref": "#/definitions/xinde_internal_dto_attachment.ListPageData"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_group.VisibilityData"
+                    }
                 },
                 "message": {
                     "type": "string",
@@ -2753,46 +11205,52 @@ const docTemplate = `{
                 }
             }
         },
-        "xinde_internal_dto_attachment.OrphanData": {
+        "xinde_internal_dto_jwt_key.JWKSResp": {
             "type": "object",
             "properties": {
-                "orphan_files": {
-                    "description": "数据库没有，磁盘有",
+                "keys": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    }
-                },
-                "orphan_records": {
-                    "description": "数据库有，磁盘没有",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/xinde_internal_dto_attachment.OrphanRecord"
+                        "$ref": "#/definitions/xinde_pkg_jwt.JWK"
                     }
                 }
             }
         },
-        "xinde_internal_dto_attachment.OrphanRecord": {
+        "xinde_internal_dto_jwt_key.KeyData": {
             "type": "object",
             "properties": {
+                "alg": {
+                    "type": "string",
+                    "example": "RS256"
+                },
                 "created_at": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "2025-01-01 08:00:00"
                 },
-                "filename": {
-                    "type": "string"
+                "kid": {
+                    "type": "string",
+                    "example": "20250101-1a2b3c4d"
                 },
-                "id": {
-                    "type": "integer"
+                "retired_at": {
+                    "type": "string",
+                    "example": ""
                 },
-                "storage_path": {
-                    "type": "string"
+                "status": {
+                    "type": "string",
+                    "example": "active，active：用于签发；retired：已退役"
                 },
-                "uploaded_by": {
-                    "type": "string"
+                "verifiable": {
+                    "description": "是否仍可用于验证token",
+                    "type": "boolean",
+                    "example": true
+                },
+                "verify_until": {
+                    "type": "string",
+                    "example": "2025-01-01 08:16:00，退役密钥可用于验证的截止时间"
                 }
             }
         },
-        "xinde_internal_dto_attachment.ScanInvalidResp": {
+        "xinde_internal_dto_jwt_key.ListResp": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2800,7 +11258,10 @@ const docTemplate = `{
                     "example": 200
                 },
                 "data": {
-                    "$ref": "#/definitions/xinde_internal_dto_attachment.OrphanData"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_jwt_key.KeyData"
+                    }
                 },
                 "message": {
                     "type": "string",
@@ -2812,59 +11273,92 @@ const docTemplate = `{
                 }
             }
         },
-        "xinde_internal_dto_company.ListData": {
+        "xinde_internal_dto_jwt_key.RotateReq": {
             "type": "object",
             "properties": {
-                "address": {
+                "alg": {
                     "type": "string",
-                    "example": "浙江省宁波市奉化区江口街道聚潮路55号"
+                    "example": "RS256，可选HS256、RS256或EdDSA，为空时使用配置的jwt.algorithm"
+                }
+            }
+        },
+        "xinde_internal_dto_jwt_key.RotateResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_jwt_key.KeyData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_price.CreateLevelReq": {
+            "type": "object"
+        },
+        "xinde_internal_dto_price.HistoryData": {
+            "type": "object",
+            "properties": {
+                "attachment_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "attachment_name": {
+                    "type": "string",
+                    "example": "2025Q1价格表.xlsx"
                 },
                 "created_at": {
                     "type": "string",
-                    "example": "2021-09-09 09:09:09"
+                    "example": "2024-12-20 10:30:00"
+                },
+                "effective_from": {
+                    "type": "string",
+                    "example": "2025-01-01 00:00:00"
                 },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
-                "name": {
+                "operator": {
                     "type": "string",
-                    "example": "宁波鲍斯产业链有限公司"
+                    "example": "管理员"
                 },
-                "price_level": {
-                    "type": "string",
-                    "example": "折扣等级1 折扣等级2 折扣等级3 折扣等级4"
-                }
-            }
-        },
-        "xinde_internal_dto_company.ListPageData": {
-            "type": "object",
-            "properties": {
-                "list": {
+                "prices": {
+                    "description": "只包含本次变更涉及的价格等级",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/xinde_internal_dto_company.ListData"
+                        "$ref": "#/definitions/xinde_internal_dto_price.LevelPrice"
                     }
                 },
-                "page": {
-                    "type": "integer",
-                    "example": 1
+                "product_code": {
+                    "type": "string",
+                    "example": "WGC001547"
                 },
-                "pageSize": {
-                    "type": "integer",
-                    "example": 20
+                "spec_code": {
+                    "type": "string",
+                    "example": "SDQCR1212H07"
                 },
-                "pages": {
-                    "type": "integer",
-                    "example": 7
+                "status": {
+                    "description": "scheduled, active, superseded",
+                    "type": "string",
+                    "example": "active"
                 },
-                "total": {
-                    "type": "integer",
-                    "example": 137
+                "unit": {
+                    "type": "string",
+                    "example": "PCS"
                 }
             }
         },
-        "xinde_internal_dto_company.ListResp": {
+        "xinde_internal_dto_price.HistoryResp": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2872,7 +11366,10 @@ const docTemplate = `{
                     "example": 200
                 },
                 "data": {
-                    "$ref": "#/definitions/xinde_internal_dto_company.ListPageData"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_price.HistoryData"
+                    }
                 },
                 "message": {
                     "type": "string",
@@ -2884,81 +11381,100 @@ const docTemplate = `{
                 }
             }
         },
-        "xinde_internal_dto_company.UpdatePriceLevelReq": {
+        "xinde_internal_dto_price.ImportResp": {
             "type": "object",
-            "required": [
-                "price_level"
-            ],
             "properties": {
-                "price_level": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/xinde_internal_dto_price.ImportResult"
+                },
+                "message": {
                     "type": "string",
-                    "enum": [
-                        "price_1",
-                        "price_2",
-                        "price_3",
-                        "price_4"
-                    ],
-                    "example": "price_1或price_2或price_3或price_4"
+                    "example": "操作成功"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "xinde_internal_dto_device.ChangeDeviceTypeReq": {
+        "xinde_internal_dto_price.ImportResult": {
             "type": "object",
-            "required": [
-                "device_type_id"
-            ],
             "properties": {
-                "device_type_id": {
+                "error_report_id": {
+                    "description": "错误报告附件ID，通过 /admin/attachment/download/{id} 下载",
                     "type": "integer",
-                    "minimum": 1
-                }
-            }
-        },
-        "xinde_internal_dto_device.ChangeGroupReq": {
-            "type": "object",
-            "required": [
-                "group_id"
-            ],
-            "properties": {
-                "group_id": {
+                    "example": 12
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_price.ImportRowError"
+                    }
+                },
+                "failed": {
                     "type": "integer",
-                    "minimum": 1,
-                    "example": 1
+                    "example": 2
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "strict"
+                },
+                "succeeded": {
+                    "description": "实际写入的行数",
+                    "type": "integer",
+                    "example": 233
+                },
+                "total": {
+                    "description": "有效数据行数（不含表头和空行）",
+                    "type": "integer",
+                    "example": 235
                 }
             }
         },
-        "xinde_internal_dto_device.ChangeNameReq": {
+        "xinde_internal_dto_price.ImportRowError": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "name": {
+                "product_code": {
                     "type": "string",
-                    "example": "车削刀杆"
+                    "example": "WGC001547"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "price_2不是有效的数字"
+                },
+                "row": {
+                    "description": "Excel中的行号，从1开始，包含表头",
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "xinde_internal_dto_device.GroupDeviceListData": {
+        "xinde_internal_dto_price.LevelData": {
             "type": "object",
             "properties": {
-                "device_id": {
+                "code": {
+                    "type": "string",
+                    "example": "price_1"
+                },
+                "id": {
                     "type": "integer",
                     "example": 1
                 },
-                "group_name": {
-                    "type": "string"
-                },
-                "image_url": {
-                    "type": "string"
-                },
                 "name": {
                     "type": "string",
-                    "example": "刀柄"
+                    "example": "价格等级1"
+                },
+                "sort_order": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "xinde_internal_dto_device.GroupDeviceListResp": {
+        "xinde_internal_dto_price.LevelListResp": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2968,7 +11484,7 @@ const docTemplate = `{
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/xinde_internal_dto_device.GroupDeviceListData"
+                        "$ref": "#/definitions/xinde_internal_dto_price.LevelData"
                     }
                 },
                 "message": {
@@ -2981,43 +11497,62 @@ const docTemplate = `{
                 }
             }
         },
-        "xinde_internal_dto_device.ListData": {
+        "xinde_internal_dto_price.LevelPrice": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "level_code": {
+                    "type": "string",
+                    "example": "price_1"
                 },
-                "group_name": {
-                    "description": "分组名称",
-                    "type": "string"
+                "level_name": {
+                    "type": "string",
+                    "example": "价格等级1"
                 },
+                "price": {
+                    "type": "number",
+                    "example": 1571.3
+                }
+            }
+        },
+        "xinde_internal_dto_price.ListData": {
+            "type": "object",
+            "properties": {
                 "id": {
-                    "type": "integer"
+                    "type": "integer",
+                    "example": 1
                 },
-                "image_url": {
-                    "description": "设备图片",
-                    "type": "string"
+                "prices": {
+                    "description": "按价格等级的排序排列",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_price.LevelPrice"
+                    }
+                },
+                "product_code": {
+                    "type": "string",
+                    "example": "WGC001547"
                 },
-                "name": {
-                    "description": "设备类型名称",
-                    "type": "string"
+                "spec_code": {
+                    "type": "string",
+                    "example": "SDQCR1212H07"
                 },
-                "solution_count": {
-                    "description": "条目数 (方案数量)",
-                    "type": "integer"
+                "unit": {
+                    "type": "string",
+                    "example": "PCS"
                 },
                 "updated_at": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "2025-01-01 08:00:00"
                 }
             }
         },
-        "xinde_internal_dto_device.ListPageData": {
+        "xinde_internal_dto_price.ListPageData": {
             "type": "object",
             "properties": {
                 "list": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/xinde_internal_dto_device.ListData"
+                        "$ref": "#/definitions/xinde_internal_dto_price.ListData"
                     }
                 },
                 "page": {
@@ -3038,7 +11573,7 @@ const docTemplate = `{
                 }
             }
         },
-        "xinde_internal_dto_device.ListResp": {
+        "xinde_internal_dto_price.ListResp": {
             "type": "object",
             "properties": {
                 "code": {
@@ -3046,7 +11581,7 @@ const docTemplate = `{
                     "example": 200
                 },
                 "data": {
-                    "$ref": "#/definitions/xinde_internal_dto_device.ListPageData"
+                    "$ref": "#/definitions/xinde_internal_dto_price.ListPageData"
                 },
                 "message": {
                     "type": "string",
@@ -3058,197 +11593,259 @@ const docTemplate = `{
                 }
             }
         },
-        "xinde_internal_dto_group.BackendListData": {
+        "xinde_internal_dto_price.QueryData": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string",
-                    "example": "2020-09-08 09:09:09"
+                "discount_percent": {
+                    "type": "number",
+                    "example": 5
                 },
-                "icon_url": {
+                "level_code": {
                     "type": "string",
-                    "example": "图片url"
-                },
-                "id": {
-                    "type": "integer",
-                    "example": 2
+                    "example": "price_1"
                 },
-                "level": {
-                    "description": "【新增】层级字段",
-                    "type": "integer",
-                    "example": 1
+                "price": {
+                    "type": "number",
+                    "example": 12.5
                 },
-                "name": {
+                "product_code": {
                     "type": "string",
-                    "example": "钢件加工"
-                },
-                "parent_id": {
-                    "type": "integer",
-                    "example": 1
+                    "example": "P001"
                 },
-                "parent_name": {
+                "source": {
+                    "description": "价格来源：level(价格等级)、override(公司专属价格)或rule(折扣规则)",
                     "type": "string",
-                    "example": "root"
+                    "example": "level"
                 }
             }
         },
-        "xinde_internal_dto_group.GroupTreeNode": {
+        "xinde_internal_dto_price.QueryReq": {
             "type": "object",
+            "required": [
+                "product_codes"
+            ],
             "properties": {
-                "children": {
+                "product_codes": {
                     "type": "array",
+                    "maxItems": 200,
+                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/xinde_internal_dto_group.GroupTreeNode"
-                    }
-                },
-                "icon_url": {
-                    "description": "omitempty 可以在不需要时隐藏",
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "parent_id": {
-                    "type": "integer"
+                        "type": "string"
+                    },
+                    "example": [
+                        "P001",
+                        "P002"
+                    ]
                 }
             }
         },
-        "xinde_internal_dto_group.ListPageData": {
+        "xinde_internal_dto_price.QueryResp": {
             "type": "object",
             "properties": {
-                "list": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/xinde_internal_dto_group.BackendListData"
+                        "$ref": "#/definitions/xinde_internal_dto_price.QueryData"
                     }
                 },
-                "page": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "pageSize": {
-                    "type": "integer",
-                    "example": 20
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
                 },
-                "pages": {
-                    "type": "integer",
-                    "example": 7
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_price.UpdateLevelReq": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 63,
+                    "example": "VIP价格"
                 },
-                "total": {
+                "sort_order": {
                     "type": "integer",
-                    "example": 137
+                    "example": 1
                 }
             }
         },
-        "xinde_internal_dto_group.ListResp": {
+        "xinde_internal_dto_role.CreateRoleReq": {
             "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
             "properties": {
                 "code": {
-                    "type": "integer",
-                    "example": 200
+                    "type": "string",
+                    "maxLength": 31,
+                    "example": "price_viewer，创建后不可修改"
                 },
-                "data": {
-                    "$ref": "#/definitions/xinde_internal_dto_group.ListPageData"
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "只能查看价格"
                 },
-                "message": {
+                "name": {
                     "type": "string",
-                    "example": "操作成功"
+                    "maxLength": 63,
+                    "example": "价格查看"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "permissions": {
+                    "description": "为空时是标签角色，不授予任何管理权限，只用作产品目录可见性规则的对象",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "price:read"
+                    ]
                 }
             }
         },
-        "xinde_internal_dto_group.TreeResp": {
+        "xinde_internal_dto_role.PermissionData": {
             "type": "object",
             "properties": {
                 "code": {
-                    "type": "integer"
+                    "type": "string",
+                    "example": "price:write"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "管理价格"
+                }
+            }
+        },
+        "xinde_internal_dto_role.PermissionListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
                 },
                 "data": {
-                    "description": "保持为列表，即使只返回 root 节点",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/xinde_internal_dto_group.GroupTreeNode"
+                        "$ref": "#/definitions/xinde_internal_dto_role.PermissionData"
                     }
                 },
                 "message": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "操作成功"
                 },
                 "success": {
-                    "type": "boolean"
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "xinde_internal_dto_price.ListData": {
+        "xinde_internal_dto_role.RoleData": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "price_1": {
-                    "type": "number",
-                    "example": 1571.3
-                },
-                "price_2": {
-                    "type": "number",
-                    "example": 1445.6
+                "code": {
+                    "type": "string",
+                    "example": "price_manager"
                 },
-                "price_3": {
-                    "type": "number",
-                    "example": 1230
+                "description": {
+                    "type": "string",
+                    "example": "导入价格，维护价格等级"
                 },
-                "price_4": {
-                    "type": "number",
-                    "example": 1127
+                "id": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "product_code": {
-                    "type": "string",
-                    "example": "WGC001547"
+                "is_system": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "spec_code": {
+                "name": {
                     "type": "string",
-                    "example": "SDQCR1212H07"
+                    "example": "价格管理员"
                 },
-                "unit": {
-                    "type": "string",
-                    "example": "PCS"
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "price:read",
+                        "price:write"
+                    ]
                 }
             }
         },
-        "xinde_internal_dto_price.ListPageData": {
+        "xinde_internal_dto_role.RoleListResp": {
             "type": "object",
             "properties": {
-                "list": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/xinde_internal_dto_price.ListData"
+                        "$ref": "#/definitions/xinde_internal_dto_role.RoleData"
                     }
                 },
-                "page": {
-                    "type": "integer",
-                    "example": 1
+                "message": {
+                    "type": "string",
+                    "example": "操作成功"
                 },
-                "pageSize": {
-                    "type": "integer",
-                    "example": 20
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "xinde_internal_dto_role.SetUserRolesReq": {
+            "type": "object",
+            "properties": {
+                "role_ids": {
+                    "description": "整体替换用户的角色，传空数组表示取消该用户的全部管理权限",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3,
+                        4
+                    ]
+                }
+            }
+        },
+        "xinde_internal_dto_role.UpdateRoleReq": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "只能查看价格"
                 },
-                "pages": {
-                    "type": "integer",
-                    "example": 7
+                "name": {
+                    "type": "string",
+                    "maxLength": 63,
+                    "example": "价格查看"
                 },
-                "total": {
-                    "type": "integer",
-                    "example": 137
+                "permissions": {
+                    "description": "传入时整体替换角色的权限，传空数组表示改为标签角色",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "price:read"
+                    ]
                 }
             }
         },
-        "xinde_internal_dto_price.ListResp": {
+        "xinde_internal_dto_role.UserRoleResp": {
             "type": "object",
             "properties": {
                 "code": {
@@ -3256,7 +11853,10 @@ const docTemplate = `{
                     "example": 200
                 },
                 "data": {
-                    "$ref": "#/definitions/xinde_internal_dto_price.ListPageData"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/xinde_internal_dto_role.RoleData"
+                    }
                 },
                 "message": {
                     "type": "string",
@@ -3308,6 +11908,14 @@ const docTemplate = `{
                     "description": "来自 MySQL 价格表",
                     "type": "number"
                 },
+                "price_rule_id": {
+                    "description": "产生该价格的专属价格或折扣规则ID，来源为 level 时为 0",
+                    "type": "integer"
+                },
+                "price_source": {
+                    "description": "价格来源: override 公司专属价格, rule 公司折扣规则, level 价格等级",
+                    "type": "string"
+                },
                 "product_code": {
                     "type": "string"
                 },
@@ -3378,6 +11986,11 @@ const docTemplate = `{
                 },
                 "pagination": {
                     "$ref": "#/definitions/xinde_internal_dto_solution.PaginationReq"
+                },
+                "preview": {
+                    "description": "预览模式，管理员可以查看草稿或已下架的设备类型，需要 catalog:read 权限，不能通过 API Key 使用",
+                    "type": "boolean",
+                    "example": false
                 }
             }
         },
@@ -3433,6 +12046,37 @@ const docTemplate = `{
                 }
             }
         },
+        "xinde_pkg_jwt.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "description": "Ed25519",
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "description": "RSA",
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                }
+            }
+        },
         "xinde_pkg_response.Response": {
             "type": "object",
             "properties": {
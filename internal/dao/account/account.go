@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"math"
+	"strings"
+	"time"
 	"xinde/internal/model/account"
+	priceModel "xinde/internal/model/price"
 	"xinde/internal/store"
 	"xinde/pkg/stderr"
 	"xinde/pkg/util"
@@ -13,6 +17,8 @@ import (
 
 type Dao struct {
 	db *gorm.DB
+	// 不为 0 时只能访问该公司的用户，见 ForCompany
+	companyID uint
 }
 
 func NewRegisterDao() (*Dao, error) {
@@ -31,6 +37,22 @@ func (d *Dao) DB() *gorm.DB {
 	return d.db
 }
 
+// ForCompany 返回只能访问 companyID 公司用户的 Dao，供公司管理员使用。
+// 用户的查询、更新和删除都会加上 company_id 条件，其他公司的用户等同于不存在
+func (d *Dao) ForCompany(companyID uint) *Dao {
+	scoped := *d
+	scoped.companyID = companyID
+	return &scoped
+}
+
+// companyScope 限定了公司时只查询该公司的用户，用于 t_user 上的查询
+func (d *Dao) companyScope(tx *gorm.DB) *gorm.DB {
+	if d.companyID == 0 {
+		return tx
+	}
+	return tx.Where("t_user.company_id = ?", d.companyID)
+}
+
 // IsExistUser 根据username判断user是否已经存在
 func (d *Dao) IsExistUser(tx *gorm.DB, name string) (bool, error) {
 	if d == nil || d.db == nil || tx == nil {
@@ -42,7 +64,7 @@ func (d *Dao) IsExistUser(tx *gorm.DB, name string) (bool, error) {
 	}
 
 	var user account.User
-	err := tx.Where("username = ?", name).First(&user).Error
+	err := tx.Scopes(d.companyScope).Where("username = ?", name).First(&user).Error
 	if err != nil {
 		return false, err
 	}
@@ -56,7 +78,7 @@ func (d *Dao) IsExistUserByID(tx *gorm.DB, uid uint) (bool, error) {
 	}
 	var count int64
 	// GORM 的查询会自动处理 `deleted_at IS NULL`
-	err := tx.Model(&account.User{}).Where("uid = ?", uid).Count(&count).Error
+	err := tx.Model(&account.User{}).Scopes(d.companyScope).Where("uid = ?", uid).Count(&count).Error
 	if err != nil {
 		return false, err
 	}
@@ -69,7 +91,7 @@ func (d *Dao) GetUserByID(tx *gorm.DB, uid uint) (*account.User, error) {
 	}
 
 	var user *account.User
-	err := tx.Model(&account.User{}).Where("uid = ?", uid).First(&user).Error
+	err := tx.Model(&account.User{}).Scopes(d.companyScope).Where("uid = ?", uid).First(&user).Error
 	if err != nil {
 		return nil, fmt.Errorf("根据id查找user失败: " + err.Error())
 	}
@@ -84,7 +106,7 @@ func (d *Dao) GetUserByIDForUpdate(tx *gorm.DB, uid uint) (*account.User, error)
 
 	var user account.User
 	// 行级锁，防止其他管理员同时审批这个用户
-	err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "NOWAIT"}).Where("uid = ?", uid).First(&user).Error
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "NOWAIT"}).Scopes(d.companyScope).Where("uid = ?", uid).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -92,61 +114,40 @@ func (d *Dao) GetUserByIDForUpdate(tx *gorm.DB, uid uint) (*account.User, error)
 	return &user, nil
 }
 
-// FindUserByUsername 根据username查找用户
-func (d *Dao) FindUserByUsername(tx *gorm.DB, username string) (*account.User, error) {
-	var user account.User
-	err := tx.Where("username = ?", username).First(&user).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf(stderr.ErrorUserUnauthorized)
-		} else {
-			return nil, fmt.Errorf("FindUserByUsername查询用户失败: %w", err)
-		}
-	}
-	return &user, nil
-}
-
-// CountUserWithStatus 查询【未审批/已通过/以拒绝】用户的个数
-func (d *Dao) CountUserWithStatus(tx *gorm.DB, status int) (int64, error) {
-	if tx == nil {
-		return 0, fmt.Errorf(stderr.ErrorDbNil)
-	}
-	var count int64
-	err := tx.Model(&account.User{}).Where("is_user = ?", status).Count(&count).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return 0, nil
-		}
-		return 0, fmt.Errorf("统计用户总数失败: %w", err)
-	}
-	return count, nil
-}
-
-// FindUserListWithPagination 分页查找用户列表
-func (d *Dao) FindUserListWithPagination(tx *gorm.DB, page, pageSize, status int) ([]*account.User, error) {
+// GetUserProfileByID 根据ID查找用户，同时查出所在公司的价格等级及其名称
+func (d *Dao) GetUserProfileByID(tx *gorm.DB, uid uint) (*account.User, error) {
 	if tx == nil {
 		return nil, fmt.Errorf(stderr.ErrorDbNil)
 	}
 
-	var users []*account.User
-
-	// 执行查询
-	offset := (page - 1) * pageSize
+	var user account.User
 	err := tx.
 		Model(&account.User{}).
-		Order("t_user.uid asc").
-		Select("t_user.*, t_company.price_level").
+		Select("t_user.*, t_company.price_level, t_price_level.name as price_level_name").
 		Joins("LEFT JOIN t_company ON t_user.company_id = t_company.id").
-		Where("t_user.is_user = ?", status).
-		Limit(pageSize).
-		Offset(offset).
-		Find(&users).
+		Joins("LEFT JOIN t_price_level ON t_price_level.code = t_company.price_level").
+		Scopes(d.companyScope).
+		Where("t_user.uid = ?", uid).
+		First(&user).
 		Error
 	if err != nil {
-		return nil, fmt.Errorf("统计用户列表失败: %w", err)
+		return nil, err
 	}
+	return &user, nil
+}
 
-	return users, nil
+// FindUserByUsername 根据username查找用户
+func (d *Dao) FindUserByUsername(tx *gorm.DB, username string) (*account.User, error) {
+	var user account.User
+	err := tx.Scopes(d.companyScope).Where("username = ?", username).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf(stderr.ErrorUserUnauthorized)
+		} else {
+			return nil, fmt.Errorf("FindUserByUsername查询用户失败: %w", err)
+		}
+	}
+	return &user, nil
 }
 
 // CreateUser 在`t_user`表中创建用户
@@ -172,46 +173,101 @@ func (d *Dao) CreateUser(tx *gorm.DB, username, email, name, companyName, compan
 	return user.UID, nil
 }
 
+// CreateUsers 批量创建用户，创建后 users 中的 UID 会被回填
+func (d *Dao) CreateUsers(tx *gorm.DB, users []*account.User) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if len(users) == 0 {
+		return nil
+	}
+	if err := tx.Create(users).Error; err != nil {
+		return fmt.Errorf("创建用户失败: %w", err)
+	}
+	return nil
+}
+
 // UpdateUser 更新用户
 func (d *Dao) UpdateUser(tx *gorm.DB, uid uint, updateData map[string]interface{}) error {
 	if tx == nil {
 		return fmt.Errorf(stderr.ErrorDbNil)
 	}
-	err := tx.Model(account.User{}).Where("uid = ?", uid).Updates(updateData).Error
+	err := tx.Model(account.User{}).Scopes(d.companyScope).Where("uid = ?", uid).Updates(updateData).Error
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-// FindOrCreateCompany 尝试根据Name查找公司，如果没有则创建一个新的公司
+// FindOrCreateCompany 尝试根据Name查找公司，如果没有则创建一个新的公司。
+// 名称属于已被合并的公司时返回合并后的公司；属于已删除的公司时恢复该公司（名称有唯一索引，不能再创建同名公司）。
+// 结果可能与 name 不同名，调用方应以返回的公司为准
 func (d *Dao) FindOrCreateCompany(tx *gorm.DB, name, address string) (uint, error) {
 	if d == nil || d.db == nil || tx == nil {
 		return 0, fmt.Errorf(stderr.ErrorDbNil)
 	}
 
 	var company account.Company
-	// GORM 的 FirstOrCreate 方法完美地解决了我们的并发问题
-	// 它会在一个事务中（如果 tx 不是 nil）先尝试 First，如果找不到，再 Create。
-	// 底层通常会使用可串行化的隔离级别或锁来保证原子性。
-	err := tx.Where(account.Company{Name: name}).
-		Attrs(account.Company{Address: util.StringToPointer(address)}).
-		FirstOrCreate(&company).Error
-
+	err := tx.Unscoped().Where("name = ?", name).First(&company).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		company = account.Company{Name: name, Address: util.StringToPointer(address)}
+		if err := tx.Create(&company).Error; err != nil {
+			return 0, fmt.Errorf("查找或创建公司失败: %w", err)
+		}
+		return company.ID, nil
+	}
 	if err != nil {
 		return 0, fmt.Errorf("查找或创建公司失败: %w", err)
 	}
 
+	if !company.DeletedAt.Valid {
+		return company.ID, nil
+	}
+	if company.MergedInto != nil {
+		id, err := d.resolveMergedCompany(tx, *company.MergedInto)
+		if err == nil || err.Error() != stderr.ErrorCompanyNotFound {
+			return id, err
+		}
+		// 合并后保留的公司也被删除了，按已删除的公司处理
+	}
+	err = tx.Unscoped().Model(&account.Company{}).Where("id = ?", company.ID).
+		Updates(map[string]interface{}{"deleted_at": nil, "merged_into": nil}).Error
+	if err != nil {
+		return 0, fmt.Errorf("恢复已删除的公司失败: %w", err)
+	}
 	return company.ID, nil
 }
 
+// resolveMergedCompany 沿合并关系找到最终保留的公司，合并链断开时返回 ErrorCompanyNotFound
+func (d *Dao) resolveMergedCompany(tx *gorm.DB, id uint) (uint, error) {
+	// 合并链不会很长，限制次数防止数据错误时死循环
+	for i := 0; i < 10; i++ {
+		var company account.Company
+		err := tx.Unscoped().Where("id = ?", id).First(&company).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return 0, fmt.Errorf(stderr.ErrorCompanyNotFound)
+			}
+			return 0, fmt.Errorf("查找合并后的公司失败: %w", err)
+		}
+		if !company.DeletedAt.Valid {
+			return company.ID, nil
+		}
+		if company.MergedInto == nil {
+			return 0, fmt.Errorf(stderr.ErrorCompanyNotFound)
+		}
+		id = *company.MergedInto
+	}
+	return 0, fmt.Errorf(stderr.ErrorCompanyNotFound)
+}
+
 // DeleteUserByID 根据ID删除用户
 func (d *Dao) DeleteUserByID(tx *gorm.DB, uid uint) error {
 	if tx == nil {
 		return fmt.Errorf(stderr.ErrorDbNil)
 	}
 	var user account.User
-	err := tx.Where("uid = ?", uid).Delete(&user).Error
+	err := tx.Scopes(d.companyScope).Where("uid = ?", uid).Delete(&user).Error
 	if err != nil {
 		return err
 	}
@@ -244,18 +300,58 @@ func (d *Dao) Transaction(fn func(*gorm.DB) error) error {
 	return nil
 }
 
-// UserPrice is a temporary struct to hold the result of the price query.
+// UserPrice 用户在某个产品上最终看到的价格，以及该价格的来源
 type UserPrice struct {
+	ProductCode string
+	Price       float64
+	// 价格来源，见 priceModel.PriceSource*
+	Source string
+	// 价格所基于的价格等级，来源为专属价格时为空
+	LevelCode string
+	// 专属价格或折扣规则的ID，来源为价格等级时为 0
+	RuleID          uint
+	DiscountPercent float64
+}
+
+// levelPrice is a temporary struct to hold the result of the level price query.
+type levelPrice struct {
 	ProductCode string  `gorm:"column:product_code"`
-	Price1      float64 `gorm:"column:price_1"`
-	Price2      float64 `gorm:"column:price_2"`
-	Price3      float64 `gorm:"column:price_3"`
-	Price4      float64 `gorm:"column:price_4"`
-	PriceLevel  string  `gorm:"column:price_level"`
+	LevelCode   string  `gorm:"column:level_code"`
+	Price       float64 `gorm:"column:price"`
+	SortOrder   int     `gorm:"column:sort_order"`
+}
+
+// FindPricesForUser retrieves the prices a user sees for a list of product codes.
+// 优先级: 公司专属价格 > 公司折扣规则 > 公司价格等级。
+//   - 折扣规则在价格等级的价格上打折；同时命中多条规则时，产品编码前缀规则优先于设备分组规则，
+//     前缀越长越优先，分组越近越优先（groupIDs 为方案所属分组及其祖先分组，由近及远）。
+//   - 价格等级的价格按 at 时刻生效的版本解析，公司的等级不存在或没有该等级的价格时使用默认等级。
+//
+// 没有任何价格的产品不会出现在结果中。
+func (d *Dao) FindPricesForUser(tx *gorm.DB, uid uint, productCodes []string, groupIDs []uint, at time.Time) ([]*UserPrice, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if len(productCodes) == 0 {
+		return []*UserPrice{}, nil
+	}
+
+	// 1. 用户所在的公司及其价格等级
+	var company companyPriceLevel
+	err := tx.Raw(`
+        SELECT u.company_id, IFNULL(c.price_level, '') AS price_level
+        FROM t_user u
+        LEFT JOIN t_company c ON u.company_id = c.id
+        WHERE u.uid = ?`, uid).Scan(&company).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找用户价格等级失败: %w", err)
+	}
+
+	return d.findPrices(tx, &company, productCodes, groupIDs, at)
 }
 
-// FindPricesForUser retrieves prices for a list of product codes based on a user's price level.
-func (d *Dao) FindPricesForUser(tx *gorm.DB, uid uint, productCodes []string) ([]*UserPrice, error) {
+// FindPricesForCompany 与 FindPricesForUser 相同，直接按公司计算价格，用于不属于任何用户的调用方（如 API Key）
+func (d *Dao) FindPricesForCompany(tx *gorm.DB, companyID uint, productCodes []string, groupIDs []uint, at time.Time) ([]*UserPrice, error) {
 	if tx == nil {
 		return nil, fmt.Errorf(stderr.ErrorDbNil)
 	}
@@ -263,32 +359,190 @@ func (d *Dao) FindPricesForUser(tx *gorm.DB, uid uint, productCodes []string) ([
 		return []*UserPrice{}, nil
 	}
 
-	var prices []*UserPrice
+	company := companyPriceLevel{CompanyID: companyID}
+	err := tx.Raw(`SELECT IFNULL(price_level, '') AS price_level FROM t_company WHERE id = ?`, companyID).
+		Scan(&company.PriceLevel).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找公司价格等级失败: %w", err)
+	}
+
+	return d.findPrices(tx, &company, productCodes, groupIDs, at)
+}
+
+// companyPriceLevel 计算价格时使用的公司及其价格等级，公司不存在时 PriceLevel 为空
+type companyPriceLevel struct {
+	CompanyID  uint   `gorm:"column:company_id"`
+	PriceLevel string `gorm:"column:price_level"`
+}
+
+// findPrices 按 FindPricesForUser 中说明的优先级为每个产品确定最终价格
+func (d *Dao) findPrices(tx *gorm.DB, company *companyPriceLevel, productCodes []string, groupIDs []uint, at time.Time) ([]*UserPrice, error) {
+	// 2. 各价格等级的价格
+	levelPrices, err := d.findLevelPrices(tx, productCodes, at)
+	if err != nil {
+		return nil, err
+	}
+	tierPrices := pickTierPrices(levelPrices, company.PriceLevel)
+
+	// 3. 公司专属价格和折扣规则
+	overrides := make(map[string]*priceModel.CompanyPriceOverride)
+	var rules []*priceModel.CompanyPriceRule
+	if company.CompanyID != 0 {
+		var list []*priceModel.CompanyPriceOverride
+		err = tx.Model(&priceModel.CompanyPriceOverride{}).
+			Where("company_id = ? AND product_code IN (?)", company.CompanyID, productCodes).
+			Find(&list).Error
+		if err != nil {
+			return nil, fmt.Errorf("查找公司专属价格失败: %w", err)
+		}
+		for _, o := range list {
+			overrides[o.ProductCode] = o
+		}
+
+		err = tx.Model(&priceModel.CompanyPriceRule{}).Where("company_id = ?", company.CompanyID).Find(&rules).Error
+		if err != nil {
+			return nil, fmt.Errorf("查找公司折扣规则失败: %w", err)
+		}
+	}
+
+	// 4. 按优先级为每个产品确定最终价格
+	groupRank := make(map[uint]int, len(groupIDs))
+	for i, id := range groupIDs {
+		groupRank[id] = i
+	}
+	prices := make([]*UserPrice, 0, len(productCodes))
+	for _, code := range productCodes {
+		if o, ok := overrides[code]; ok {
+			prices = append(prices, &UserPrice{
+				ProductCode: code,
+				Price:       o.Price,
+				Source:      priceModel.PriceSourceOverride,
+				RuleID:      o.ID,
+			})
+			continue
+		}
+
+		tier, ok := tierPrices[code]
+		if !ok {
+			continue
+		}
+		price := &UserPrice{
+			ProductCode: code,
+			Price:       tier.Price,
+			Source:      priceModel.PriceSourceLevel,
+			LevelCode:   tier.LevelCode,
+		}
+		if rule := matchPriceRule(rules, code, groupRank); rule != nil {
+			price.Price = math.Round(tier.Price*(100-rule.DiscountPercent)) / 100
+			price.Source = priceModel.PriceSourceRule
+			price.RuleID = rule.ID
+			price.DiscountPercent = rule.DiscountPercent
+		}
+		prices = append(prices, price)
+	}
+
+	return prices, nil
+}
+
+// findLevelPrices 查询产品在每个价格等级下于 at 时刻生效的价格。
+// 每个等级取 t_price_history 中生效时间不晚于 at 的最新一条；某个等级没有任何已生效的历史记录时回退到 t_price_value。
+// 已被删除的价格等级不会返回。
+func (d *Dao) findLevelPrices(tx *gorm.DB, productCodes []string, at time.Time) ([]*levelPrice, error) {
+	var prices []*levelPrice
 
-	// GORM 无法完美构建 CROSS JOIN，所以我们使用原生 SQL 以确保查询正确
 	sql := `
         SELECT
-            p.product_code,
-            p.price_1,
-            p.price_2,
-            p.price_3,
-            p.price_4,
-            IFNULL(c.price_level, 'price_1') AS price_level 
+            h.product_code,
+            hv.level_code,
+            hv.price,
+            l.sort_order
+        FROM
+            t_price_history h
+        JOIN
+            t_price_history_value hv ON hv.history_id = h.id
+        JOIN
+            t_price_level l ON l.code = hv.level_code
+        WHERE
+            h.product_code IN (?)
+            AND h.effective_from <= ?
+            AND NOT EXISTS (
+                SELECT 1 FROM t_price_history newer
+                JOIN t_price_history_value nv ON nv.history_id = newer.id
+                WHERE newer.product_code = h.product_code
+                    AND nv.level_code = hv.level_code
+                    AND newer.effective_from <= ?
+                    AND (newer.effective_from > h.effective_from
+                        OR (newer.effective_from = h.effective_from AND newer.id > h.id))
+            )
+        UNION ALL
+        SELECT
+            v.product_code,
+            v.level_code,
+            v.price,
+            l.sort_order
         FROM
-            t_user u
-        LEFT JOIN
-            t_company c ON u.company_id = c.id
-		CROSS JOIN
-            t_price p
+            t_price_value v
+        JOIN
+            t_price_level l ON l.code = v.level_code
         WHERE
-	    p.product_code IN (?)
-            AND u.uid = ?;
+            v.product_code IN (?)
+            AND NOT EXISTS (
+                SELECT 1 FROM t_price_history h
+                JOIN t_price_history_value hv ON hv.history_id = h.id
+                WHERE h.product_code = v.product_code
+                    AND hv.level_code = v.level_code
+                    AND h.effective_from <= ?
+            );
     `
 
-	err := tx.Raw(sql, productCodes, uid).Scan(&prices).Error
+	err := tx.Raw(sql, productCodes, at, at, productCodes, at).Scan(&prices).Error
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("查找价格等级价格失败: %w", err)
 	}
-
 	return prices, nil
 }
+
+// pickTierPrices 为每个产品选出 priceLevel 等级的价格，没有时使用排序最靠前的等级（默认等级）的价格
+func pickTierPrices(prices []*levelPrice, priceLevel string) map[string]*levelPrice {
+	chosen := make(map[string]*levelPrice)
+	for _, p := range prices {
+		current, ok := chosen[p.ProductCode]
+		switch {
+		case !ok:
+			chosen[p.ProductCode] = p
+		case current.LevelCode == priceLevel:
+			// 已经找到公司等级的价格
+		case p.LevelCode == priceLevel || p.SortOrder < current.SortOrder:
+			chosen[p.ProductCode] = p
+		}
+	}
+	return chosen
+}
+
+// matchPriceRule 找出对某个产品生效的折扣规则，没有命中时返回 nil
+func matchPriceRule(rules []*priceModel.CompanyPriceRule, productCode string, groupRank map[uint]int) *priceModel.CompanyPriceRule {
+	var prefixRule, groupRule *priceModel.CompanyPriceRule
+	for _, r := range rules {
+		switch r.ScopeType {
+		case priceModel.RuleScopeProductPrefix:
+			if r.ProductPrefix == "" || !strings.HasPrefix(productCode, r.ProductPrefix) {
+				continue
+			}
+			if prefixRule == nil || len(r.ProductPrefix) > len(prefixRule.ProductPrefix) {
+				prefixRule = r
+			}
+		case priceModel.RuleScopeGroup:
+			rank, ok := groupRank[r.GroupID]
+			if !ok {
+				continue
+			}
+			if groupRule == nil || rank < groupRank[groupRule.GroupID] {
+				groupRule = r
+			}
+		}
+	}
+	if prefixRule != nil {
+		return prefixRule
+	}
+	return groupRule
+}
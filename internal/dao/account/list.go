@@ -0,0 +1,157 @@
+package account
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"time"
+	"xinde/internal/model/account"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+// UserListFilter 用户列表、注册申请列表和用户导出共用的查询条件，除 Status 外零值表示不过滤
+type UserListFilter struct {
+	Status int
+	// 同时匹配用户名、姓名、电话和公司名称，按包含匹配
+	Keyword    string
+	CompanyID  uint
+	PriceLevel string
+	RoleID     uint
+	// 注册时间和上次访问时间的范围，不含 To
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	ActiveFrom  *time.Time
+	ActiveTo    *time.Time
+	// 见 userSortColumns，为空时按 id 排序
+	SortBy   string
+	SortDesc bool
+}
+
+// userSortColumns 可以排序的字段及其对应的列
+var userSortColumns = map[string]string{
+	"id":               "t_user.uid",
+	"username":         "t_user.username",
+	"name":             "t_user.name",
+	"company_name":     "t_user.company_name",
+	"price_level":      "t_company.price_level",
+	"created_at":       "t_user.created_at",
+	"handled_at":       "t_user.handled_at",
+	"recent_search_at": "t_user.recent_search_at",
+}
+
+// IsUserSortColumn 判断 sortBy 是否为可排序的字段
+func IsUserSortColumn(sortBy string) bool {
+	_, ok := userSortColumns[sortBy]
+	return ok
+}
+
+// userListSelect 查询用户时同时查出所在公司的价格等级及其名称
+const userListSelect = "t_user.*, t_company.price_level, t_price_level.name as price_level_name"
+
+// applyUserListFilter 在 t_user 上拼接过滤条件，公司和价格等级通过 LEFT JOIN 关联，不会使用户重复
+func (d *Dao) applyUserListFilter(tx *gorm.DB, f *UserListFilter) *gorm.DB {
+	q := tx.Model(&account.User{}).
+		Joins("LEFT JOIN t_company ON t_user.company_id = t_company.id").
+		Joins("LEFT JOIN t_price_level ON t_price_level.code = t_company.price_level").
+		Scopes(d.companyScope).
+		Where("t_user.is_user = ?", f.Status)
+
+	if f.Keyword != "" {
+		pattern := "%" + util.EscapeLike(f.Keyword) + "%"
+		q = q.Where("(t_user.username LIKE ? OR t_user.name LIKE ? OR t_user.phone LIKE ? OR t_user.company_name LIKE ?)",
+			pattern, pattern, pattern, pattern)
+	}
+	if f.CompanyID != 0 {
+		q = q.Where("t_user.company_id = ?", f.CompanyID)
+	}
+	if f.PriceLevel != "" {
+		q = q.Where("t_company.price_level = ?", f.PriceLevel)
+	}
+	if f.RoleID != 0 {
+		q = q.Where("EXISTS (SELECT 1 FROM t_user_role ur WHERE ur.uid = t_user.uid AND ur.role_id = ?)", f.RoleID)
+	}
+	if f.CreatedFrom != nil {
+		q = q.Where("t_user.created_at >= ?", *f.CreatedFrom)
+	}
+	if f.CreatedTo != nil {
+		q = q.Where("t_user.created_at < ?", *f.CreatedTo)
+	}
+	if f.ActiveFrom != nil {
+		q = q.Where("t_user.recent_search_at >= ?", *f.ActiveFrom)
+	}
+	if f.ActiveTo != nil {
+		q = q.Where("t_user.recent_search_at < ?", *f.ActiveTo)
+	}
+	return q
+}
+
+// applyUserListSort 按 f.SortBy 排序，相同时按 id 升序保证分页稳定
+func applyUserListSort(q *gorm.DB, f *UserListFilter) *gorm.DB {
+	direction := "asc"
+	if f.SortDesc {
+		direction = "desc"
+	}
+	column, ok := userSortColumns[f.SortBy]
+	if !ok || column == userSortColumns["id"] {
+		return q.Order("t_user.uid " + direction)
+	}
+	// 列名来自白名单，可以直接拼接
+	return q.Order(column + " " + direction).Order("t_user.uid asc")
+}
+
+// CountUsers 统计符合条件的用户数
+func (d *Dao) CountUsers(tx *gorm.DB, f *UserListFilter) (int64, error) {
+	if tx == nil {
+		return 0, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	var count int64
+	err := d.applyUserListFilter(tx, f).Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("统计用户总数失败: %w", err)
+	}
+	return count, nil
+}
+
+// FindUserListWithPagination 按条件分页查找用户列表
+func (d *Dao) FindUserListWithPagination(tx *gorm.DB, page, pageSize int, f *UserListFilter) ([]*account.User, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var users []*account.User
+	offset := (page - 1) * pageSize
+	err := applyUserListSort(d.applyUserListFilter(tx, f), f).
+		Select(userListSelect).
+		Limit(pageSize).
+		Offset(offset).
+		Find(&users).
+		Error
+	if err != nil {
+		return nil, fmt.Errorf("查找用户列表失败: %w", err)
+	}
+	return users, nil
+}
+
+// StreamUsers 按条件逐行读取用户（游标方式，不会一次性把整张表读入内存），每读到一个用户交给 fn 处理
+func (d *Dao) StreamUsers(tx *gorm.DB, f *UserListFilter, fn func(*account.User) error) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	rows, err := applyUserListSort(d.applyUserListFilter(tx, f), f).Select(userListSelect).Rows()
+	if err != nil {
+		return fmt.Errorf("查询用户失败: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var user account.User
+		if err := tx.ScanRows(rows, &user); err != nil {
+			return fmt.Errorf("读取用户失败: %w", err)
+		}
+		if err := fn(&user); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,98 @@
+package account
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"time"
+	"xinde/internal/model/account"
+	"xinde/pkg/stderr"
+)
+
+// CreateLoginHistory 写入一条登录记录
+func (d *Dao) CreateLoginHistory(tx *gorm.DB, history *account.LoginHistory) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Create(history).Error; err != nil {
+		return fmt.Errorf("写入登录记录失败: %w", err)
+	}
+	return nil
+}
+
+// LoginHistoryFilter 登录记录的查询条件，零值表示不过滤
+type LoginHistoryFilter struct {
+	UID      uint
+	Username string
+	IP       string
+	Success  *bool
+	From     *time.Time
+	To       *time.Time
+}
+
+func applyLoginHistoryFilter(tx *gorm.DB, f *LoginHistoryFilter) *gorm.DB {
+	q := tx.Model(&account.LoginHistory{})
+	if f == nil {
+		return q
+	}
+	if f.UID != 0 {
+		q = q.Where("uid = ?", f.UID)
+	}
+	if f.Username != "" {
+		q = q.Where("username = ?", f.Username)
+	}
+	if f.IP != "" {
+		q = q.Where("ip = ?", f.IP)
+	}
+	if f.Success != nil {
+		q = q.Where("success = ?", *f.Success)
+	}
+	if f.From != nil {
+		q = q.Where("created_at >= ?", *f.From)
+	}
+	if f.To != nil {
+		q = q.Where("created_at < ?", *f.To)
+	}
+	return q
+}
+
+// CountLoginHistory 统计符合条件的登录记录总数
+func (d *Dao) CountLoginHistory(tx *gorm.DB, f *LoginHistoryFilter) (int64, error) {
+	if tx == nil {
+		return 0, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var count int64
+	if err := applyLoginHistoryFilter(tx, f).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("统计登录记录总数失败: %w", err)
+	}
+	return count, nil
+}
+
+// FindLoginHistoryWithPagination 分页查找登录记录，最新的在前
+func (d *Dao) FindLoginHistoryWithPagination(tx *gorm.DB, page, pageSize int, f *LoginHistoryFilter) ([]*account.LoginHistory, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var list []*account.LoginHistory
+	offset := (page - 1) * pageSize
+	err := applyLoginHistoryFilter(tx, f).Order("id desc").Limit(pageSize).Offset(offset).Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("分页查找登录记录失败: %w", err)
+	}
+	return list, nil
+}
+
+// FindRecentLoginHistory 查找用户最近的 limit 条登录记录，最新的在前
+func (d *Dao) FindRecentLoginHistory(tx *gorm.DB, uid uint, limit int) ([]*account.LoginHistory, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var list []*account.LoginHistory
+	err := tx.Where("uid = ?", uid).Order("id desc").Limit(limit).Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找最近的登录记录失败: %w", err)
+	}
+	return list, nil
+}
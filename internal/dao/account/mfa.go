@@ -0,0 +1,125 @@
+package account
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+	"xinde/internal/model/account"
+	"xinde/pkg/stderr"
+)
+
+// GetUserMFA 查找用户的二次验证记录，没有时返回 gorm.ErrRecordNotFound
+func (d *Dao) GetUserMFA(tx *gorm.DB, uid uint) (*account.UserMFA, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var mfa account.UserMFA
+	if err := tx.Where("uid = ?", uid).First(&mfa).Error; err != nil {
+		return nil, err
+	}
+	return &mfa, nil
+}
+
+// GetUserMFAForUpdate 带行级锁查找用户的二次验证记录，防止同一个验证码被并发使用两次。
+// 没有时返回 gorm.ErrRecordNotFound
+func (d *Dao) GetUserMFAForUpdate(tx *gorm.DB, uid uint) (*account.UserMFA, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var mfa account.UserMFA
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uid = ?", uid).First(&mfa).Error; err != nil {
+		return nil, err
+	}
+	return &mfa, nil
+}
+
+// SaveUserMFA 保存用户的二次验证记录，已存在时整条覆盖（重新开始绑定）
+func (d *Dao) SaveUserMFA(tx *gorm.DB, mfa *account.UserMFA) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "uid"}},
+		DoUpdates: clause.AssignmentColumns([]string{"secret", "enabled_at", "last_used_step", "updated_at"}),
+	}).Create(mfa).Error
+	if err != nil {
+		return fmt.Errorf("保存二次验证信息失败: %w", err)
+	}
+	return nil
+}
+
+// UpdateUserMFA 更新用户的二次验证记录
+func (d *Dao) UpdateUserMFA(tx *gorm.DB, uid uint, updateData map[string]interface{}) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	err := tx.Model(&account.UserMFA{}).Where("uid = ?", uid).Updates(updateData).Error
+	if err != nil {
+		return fmt.Errorf("更新二次验证信息失败: %w", err)
+	}
+	return nil
+}
+
+// DeleteUserMFA 删除用户的二次验证记录和全部恢复码
+func (d *Dao) DeleteUserMFA(tx *gorm.DB, uid uint) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Where("uid = ?", uid).Delete(&account.UserMFA{}).Error; err != nil {
+		return fmt.Errorf("删除二次验证信息失败: %w", err)
+	}
+	if err := tx.Where("uid = ?", uid).Delete(&account.MFARecoveryCode{}).Error; err != nil {
+		return fmt.Errorf("删除恢复码失败: %w", err)
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes 作废用户原有的恢复码，保存新生成的恢复码（只保存哈希）
+func (d *Dao) ReplaceRecoveryCodes(tx *gorm.DB, uid uint, hashes []string) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Where("uid = ?", uid).Delete(&account.MFARecoveryCode{}).Error; err != nil {
+		return fmt.Errorf("删除恢复码失败: %w", err)
+	}
+
+	codes := make([]*account.MFARecoveryCode, 0, len(hashes))
+	for _, hash := range hashes {
+		codes = append(codes, &account.MFARecoveryCode{UID: uid, CodeHash: hash})
+	}
+	if err := tx.Create(&codes).Error; err != nil {
+		return fmt.Errorf("保存恢复码失败: %w", err)
+	}
+	return nil
+}
+
+// UseRecoveryCode 将一个未使用的恢复码标记为已使用，返回是否找到了该恢复码
+func (d *Dao) UseRecoveryCode(tx *gorm.DB, uid uint, hash string) (bool, error) {
+	if tx == nil {
+		return false, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	result := tx.Model(&account.MFARecoveryCode{}).
+		Where("uid = ? AND code_hash = ? AND used_at IS NULL", uid, hash).
+		Limit(1).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, fmt.Errorf("使用恢复码失败: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CountUnusedRecoveryCodes 统计用户剩余可用的恢复码个数
+func (d *Dao) CountUnusedRecoveryCodes(tx *gorm.DB, uid uint) (int64, error) {
+	if tx == nil {
+		return 0, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	var count int64
+	err := tx.Model(&account.MFARecoveryCode{}).Where("uid = ? AND used_at IS NULL", uid).Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("统计恢复码个数失败: %w", err)
+	}
+	return count, nil
+}
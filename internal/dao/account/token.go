@@ -0,0 +1,117 @@
+package account
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+	"xinde/internal/model/account"
+	"xinde/pkg/stderr"
+)
+
+// TokenState 校验 access token 时需要的用户状态
+type TokenState struct {
+	TokenVersion uint
+	IsUser       int
+	// access token 的 jti 是否已被注销
+	JTIRevoked bool
+}
+
+// CreateRefreshToken 保存新签发的 refresh token（只保存哈希）
+func (d *Dao) CreateRefreshToken(tx *gorm.DB, token *account.RefreshToken) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Create(token).Error; err != nil {
+		return fmt.Errorf("保存refresh token失败: %w", err)
+	}
+	return nil
+}
+
+// GetRefreshTokenByHashForUpdate 带行级锁，根据哈希查找 refresh token，防止同一个 token 被并发刷新两次。
+// 找不到时返回 gorm.ErrRecordNotFound
+func (d *Dao) GetRefreshTokenByHashForUpdate(tx *gorm.DB, hash string) (*account.RefreshToken, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var token account.RefreshToken
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("token_hash = ?", hash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RevokeRefreshToken 吊销单个 refresh token，轮换时 replacedBy 为新 token 的ID
+func (d *Dao) RevokeRefreshToken(tx *gorm.DB, id uint, replacedBy *uint) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	err := tx.Model(&account.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at":  time.Now(),
+			"replaced_by": replacedBy,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("吊销refresh token失败: %w", err)
+	}
+	return nil
+}
+
+// RevokeUserTokens 吊销用户的全部 token：递增 token 版本号使已签发的 access token 失效，
+// 并吊销全部未吊销的 refresh token。对已软删除的用户同样生效
+func (d *Dao) RevokeUserTokens(tx *gorm.DB, uid uint) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	err := tx.Unscoped().Model(&account.User{}).Scopes(d.companyScope).Where("uid = ?", uid).
+		UpdateColumn("token_version", gorm.Expr("token_version + 1")).Error
+	if err != nil {
+		return fmt.Errorf("更新用户token版本号失败: %w", err)
+	}
+
+	err = tx.Model(&account.RefreshToken{}).
+		Where("uid = ? AND revoked_at IS NULL", uid).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("吊销用户refresh token失败: %w", err)
+	}
+	return nil
+}
+
+// CreateRevokedToken 把 access token 的 jti 加入黑名单，重复注销时忽略
+func (d *Dao) CreateRevokedToken(tx *gorm.DB, jti string, uid uint, expiresAt time.Time) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&account.RevokedToken{
+		JTI:       jti,
+		UID:       uid,
+		ExpiresAt: expiresAt,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("注销access token失败: %w", err)
+	}
+	return nil
+}
+
+// GetTokenState 查询校验 access token 所需的用户状态，一条SQL完成。
+// 用户不存在或已被删除时返回 gorm.ErrRecordNotFound
+func (d *Dao) GetTokenState(tx *gorm.DB, uid uint, jti string) (*TokenState, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var state TokenState
+	err := tx.Model(&account.User{}).
+		Select("token_version, is_user, EXISTS (SELECT 1 FROM t_revoked_token WHERE t_revoked_token.jti = ?) AS jti_revoked", jti).
+		Where("uid = ?", uid).
+		Take(&state).Error
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
@@ -0,0 +1,97 @@
+package account
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+	"xinde/internal/model/account"
+	"xinde/pkg/stderr"
+)
+
+// CreateVerificationCode 保存新生成的验证码（只保存哈希）
+func (d *Dao) CreateVerificationCode(tx *gorm.DB, code *account.VerificationCode) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Create(code).Error; err != nil {
+		return fmt.Errorf("保存验证码失败: %w", err)
+	}
+	return nil
+}
+
+// GetLatestVerificationCodeForUpdate 带行级锁，查找用户某个用途下最新的一条仍可使用的验证码。
+// 没有时返回 gorm.ErrRecordNotFound
+func (d *Dao) GetLatestVerificationCodeForUpdate(tx *gorm.DB, uid uint, purpose string) (*account.VerificationCode, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var code account.VerificationCode
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("uid = ? AND purpose = ? AND consumed_at IS NULL", uid, purpose).
+		Order("id desc").
+		First(&code).Error
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// GetVerificationCodeByResetTokenForUpdate 带行级锁，根据重置令牌的哈希查找验证码记录。
+// 没有时返回 gorm.ErrRecordNotFound
+func (d *Dao) GetVerificationCodeByResetTokenForUpdate(tx *gorm.DB, tokenHash string) (*account.VerificationCode, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var code account.VerificationCode
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("reset_token_hash = ?", tokenHash).
+		First(&code).Error
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// CountVerificationCodesSince 统计用户某个用途下 since 之后生成的验证码个数，包括已作废的
+func (d *Dao) CountVerificationCodesSince(tx *gorm.DB, uid uint, purpose string, since time.Time) (int64, error) {
+	if tx == nil {
+		return 0, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	var count int64
+	err := tx.Model(&account.VerificationCode{}).
+		Where("uid = ? AND purpose = ? AND created_at >= ?", uid, purpose, since).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("统计验证码个数失败: %w", err)
+	}
+	return count, nil
+}
+
+// UpdateVerificationCode 更新验证码记录
+func (d *Dao) UpdateVerificationCode(tx *gorm.DB, id uint, updateData map[string]interface{}) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	err := tx.Model(&account.VerificationCode{}).Where("id = ?", id).Updates(updateData).Error
+	if err != nil {
+		return fmt.Errorf("更新验证码失败: %w", err)
+	}
+	return nil
+}
+
+// ConsumeVerificationCodes 作废用户某个用途下所有仍可使用的验证码
+func (d *Dao) ConsumeVerificationCodes(tx *gorm.DB, uid uint, purpose string) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	err := tx.Model(&account.VerificationCode{}).
+		Where("uid = ? AND purpose = ? AND consumed_at IS NULL", uid, purpose).
+		Update("consumed_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("作废验证码失败: %w", err)
+	}
+	return nil
+}
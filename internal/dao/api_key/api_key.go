@@ -0,0 +1,163 @@
+package api_key
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+	model "xinde/internal/model/api_key"
+	"xinde/internal/store"
+	"xinde/pkg/stderr"
+)
+
+type Dao struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyDao() (*Dao, error) {
+	db := store.GetDB()
+	if db == nil {
+		return nil, fmt.Errorf("数据库连接未初始化，请先调用 store.InitDB()")
+	}
+
+	return &Dao{
+		db: db,
+	}, nil
+}
+
+// DB 返回原始的 gorm.DB 实例，以便 Service 层可以开启事务
+func (d *Dao) DB() *gorm.DB {
+	return d.db
+}
+
+// FindAPIKeys 查找 API Key 及其访问范围，companyID 不为 0 时只查该公司的，按ID倒序排列
+func (d *Dao) FindAPIKeys(tx *gorm.DB, companyID uint) ([]*model.APIKey, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	query := tx.Model(&model.APIKey{}).Preload("Scopes")
+	if companyID != 0 {
+		query = query.Where("company_id = ?", companyID)
+	}
+	var keys []*model.APIKey
+	if err := query.Order("id desc").Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("查找API Key列表失败: %w", err)
+	}
+	return keys, nil
+}
+
+// GetAPIKeyByID 根据ID查找 API Key 及其访问范围，未找到时原样返回 gorm.ErrRecordNotFound
+func (d *Dao) GetAPIKeyByID(tx *gorm.DB, id uint) (*model.APIKey, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var key model.APIKey
+	if err := tx.Model(&model.APIKey{}).Preload("Scopes").Where("id = ?", id).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("根据ID查找API Key失败: %w", err)
+	}
+	return &key, nil
+}
+
+// GetAPIKeyByKeyID 根据公开的标识查找 API Key 及其访问范围，未找到时原样返回 gorm.ErrRecordNotFound
+func (d *Dao) GetAPIKeyByKeyID(tx *gorm.DB, keyID string) (*model.APIKey, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var key model.APIKey
+	if err := tx.Model(&model.APIKey{}).Preload("Scopes").Where("key_id = ?", keyID).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("根据标识查找API Key失败: %w", err)
+	}
+	return &key, nil
+}
+
+// CreateAPIKey 创建 API Key，连同访问范围一起保存
+func (d *Dao) CreateAPIKey(tx *gorm.DB, key *model.APIKey) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Create(key).Error; err != nil {
+		return fmt.Errorf("创建API Key失败: %w", err)
+	}
+	return nil
+}
+
+// UpdateAPIKey 更新 API Key
+func (d *Dao) UpdateAPIKey(tx *gorm.DB, id uint, updateData map[string]interface{}) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Model(&model.APIKey{}).Where("id = ?", id).Updates(updateData).Error; err != nil {
+		return fmt.Errorf("更新API Key失败: %w", err)
+	}
+	return nil
+}
+
+// ReplaceAPIKeyScopes 整体替换 API Key 的访问范围
+func (d *Dao) ReplaceAPIKeyScopes(tx *gorm.DB, id uint, scopes []string) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Where("api_key_id = ?", id).Delete(&model.APIKeyScope{}).Error; err != nil {
+		return fmt.Errorf("删除API Key的访问范围失败: %w", err)
+	}
+
+	list := make([]*model.APIKeyScope, 0, len(scopes))
+	for _, scope := range scopes {
+		list = append(list, &model.APIKeyScope{APIKeyID: id, Scope: scope})
+	}
+	if err := tx.Create(&list).Error; err != nil {
+		return fmt.Errorf("保存API Key的访问范围失败: %w", err)
+	}
+	return nil
+}
+
+// TouchAPIKey 记录 API Key 最近一次使用的时间和IP。距上次记录不足 interval 时不更新，避免每个请求都写库
+func (d *Dao) TouchAPIKey(tx *gorm.DB, id uint, ip string, now time.Time, interval time.Duration) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	err := tx.Model(&model.APIKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, now.Add(-interval)).
+		UpdateColumns(map[string]interface{}{"last_used_at": now, "last_used_ip": ip}).Error
+	if err != nil {
+		return fmt.Errorf("更新API Key的使用时间失败: %w", err)
+	}
+	return nil
+}
+
+// UseNonce 记录一次请求的 nonce，返回 false 表示该 nonce 已经使用过（请求被重放）
+func (d *Dao) UseNonce(tx *gorm.DB, apiKeyID uint, nonce string, expiresAt time.Time) (bool, error) {
+	if tx == nil {
+		return false, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.APIKeyNonce{
+		APIKeyID:  apiKeyID,
+		Nonce:     nonce,
+		ExpiresAt: expiresAt,
+	})
+	if result.Error != nil {
+		return false, fmt.Errorf("保存nonce失败: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteExpiredNonces 删除已经过期的 nonce
+func (d *Dao) DeleteExpiredNonces(tx *gorm.DB, now time.Time) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Where("expires_at < ?", now).Delete(&model.APIKeyNonce{}).Error; err != nil {
+		return fmt.Errorf("清理过期的nonce失败: %w", err)
+	}
+	return nil
+}
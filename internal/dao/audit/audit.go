@@ -0,0 +1,141 @@
+package audit
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"time"
+	model "xinde/internal/model/audit"
+	"xinde/internal/store"
+	"xinde/pkg/stderr"
+)
+
+type Dao struct {
+	db *gorm.DB
+}
+
+func NewAuditDao() (*Dao, error) {
+	db := store.GetDB()
+	if db == nil {
+		return nil, fmt.Errorf("数据库连接未初始化，请先调用 store.InitDB()")
+	}
+
+	return &Dao{
+		db: db,
+	}, nil
+}
+
+// DB 返回原始的 gorm.DB 实例，以便 Service 层可以开启事务
+func (d *Dao) DB() *gorm.DB {
+	return d.db
+}
+
+// Record 写入一条审计记录。应当和被审计的修改使用同一个事务，修改回滚时记录随之回滚
+func (d *Dao) Record(tx *gorm.DB, actor *model.Actor, action, entityType string, entityID interface{}, before, after interface{}) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	log, err := model.NewAuditLog(actor, action, entityType, entityID, before, after)
+	if err != nil {
+		return err
+	}
+	if err := tx.Create(log).Error; err != nil {
+		return fmt.Errorf("写入审计日志失败: " + err.Error())
+	}
+	return nil
+}
+
+// AuditLogFilter 审计日志的查询条件，零值表示不过滤
+type AuditLogFilter struct {
+	ActorUID   uint
+	Action     string
+	EntityType string
+	EntityID   string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+}
+
+func applyAuditLogFilter(tx *gorm.DB, f *AuditLogFilter) *gorm.DB {
+	q := tx.Model(&model.AuditLog{})
+	if f == nil {
+		return q
+	}
+	if f.ActorUID != 0 {
+		q = q.Where("actor_uid = ?", f.ActorUID)
+	}
+	if f.Action != "" {
+		q = q.Where("action = ?", f.Action)
+	}
+	if f.EntityType != "" {
+		q = q.Where("entity_type = ?", f.EntityType)
+	}
+	if f.EntityID != "" {
+		q = q.Where("entity_id = ?", f.EntityID)
+	}
+	if f.RequestID != "" {
+		q = q.Where("request_id = ?", f.RequestID)
+	}
+	if f.From != nil {
+		q = q.Where("created_at >= ?", *f.From)
+	}
+	if f.To != nil {
+		q = q.Where("created_at < ?", *f.To)
+	}
+	return q
+}
+
+// CountAuditLogs 统计符合条件的审计日志总数
+func (d *Dao) CountAuditLogs(tx *gorm.DB, f *AuditLogFilter) (int64, error) {
+	if tx == nil {
+		return 0, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var count int64
+	if err := applyAuditLogFilter(tx, f).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("统计审计日志总数失败: " + err.Error())
+	}
+	return count, nil
+}
+
+// FindAuditLogsWithPagination 分页查找审计日志，最新的在前
+func (d *Dao) FindAuditLogsWithPagination(tx *gorm.DB, page, pageSize int, f *AuditLogFilter) ([]*model.AuditLog, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var list []*model.AuditLog
+	offset := (page - 1) * pageSize
+	err := applyAuditLogFilter(tx, f).Order("id desc").Limit(pageSize).Offset(offset).Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("分页查找审计日志失败: " + err.Error())
+	}
+	return list, nil
+}
+
+// StreamAuditLogs 按条件逐行读取审计日志（游标方式），最新的在前，每一行交给 fn 处理
+func (d *Dao) StreamAuditLogs(tx *gorm.DB, f *AuditLogFilter, fn func(*model.AuditLog) error) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	rows, err := applyAuditLogFilter(tx, f).Order("id desc").Rows()
+	if err != nil {
+		return fmt.Errorf("查询审计日志失败: " + err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var log model.AuditLog
+		if err := tx.ScanRows(rows, &log); err != nil {
+			return fmt.Errorf("读取审计日志失败: " + err.Error())
+		}
+		if err := fn(&log); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("读取审计日志失败: " + err.Error())
+	}
+	return nil
+}
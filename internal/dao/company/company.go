@@ -106,3 +106,138 @@ func (d *Dao) UpdateCompany(tx *gorm.DB, id uint, updateData map[string]interfac
 	}
 	return nil
 }
+
+// CountCompaniesByPriceLevel 统计使用某个价格等级的公司数量
+func (d *Dao) CountCompaniesByPriceLevel(tx *gorm.DB, priceLevel string) (int64, error) {
+	if tx == nil {
+		return 0, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var count int64
+	err := tx.Model(&model.Company{}).Where("price_level = ?", priceLevel).Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("统计价格等级下的公司数量失败: " + err.Error())
+	}
+	return count, nil
+}
+
+// IsExistCompanyByName 判断名称是否已被其他公司使用。名称有唯一索引，已删除和已合并的公司也算在内
+func (d *Dao) IsExistCompanyByName(tx *gorm.DB, name string, excludeID uint) (bool, error) {
+	if tx == nil {
+		return false, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var count int64
+	err := tx.Unscoped().Model(&model.Company{}).Where("name = ? AND id <> ?", name, excludeID).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("查询公司名称失败: %w", err)
+	}
+	return count > 0, nil
+}
+
+// CreateCompany 创建公司
+func (d *Dao) CreateCompany(tx *gorm.DB, company *model.Company) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	if err := tx.Create(company).Error; err != nil {
+		return fmt.Errorf("创建公司失败: %w", err)
+	}
+	return nil
+}
+
+// FindCompaniesByIDs 根据ID查找公司，不存在的ID被忽略
+func (d *Dao) FindCompaniesByIDs(tx *gorm.DB, ids []uint) ([]*model.Company, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var companies []*model.Company
+	err := tx.Where("id IN ?", ids).Order("id asc").Find(&companies).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找公司失败: %w", err)
+	}
+	return companies, nil
+}
+
+// FindAllCompanies 查找全部公司，用于查找疑似重复的公司
+func (d *Dao) FindAllCompanies(tx *gorm.DB) ([]*model.Company, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var companies []*model.Company
+	err := tx.Order("id asc").Find(&companies).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找公司列表失败: %w", err)
+	}
+	return companies, nil
+}
+
+// CountUsersByCompanyIDs 统计各公司的用户数，没有用户的公司不在结果中
+func (d *Dao) CountUsersByCompanyIDs(tx *gorm.DB, ids []uint) (map[uint]int64, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var rows []struct {
+		CompanyID uint
+		Count     int64
+	}
+	err := tx.Model(&model.User{}).Select("company_id, COUNT(*) AS count").
+		Where("company_id IN ?", ids).Group("company_id").Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("统计公司的用户数失败: %w", err)
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, r := range rows {
+		counts[r.CompanyID] = r.Count
+	}
+	return counts, nil
+}
+
+// CountCompanyUsers 统计公司的用户数
+func (d *Dao) CountCompanyUsers(tx *gorm.DB, companyID uint) (int64, error) {
+	if tx == nil {
+		return 0, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var count int64
+	err := tx.Model(&model.User{}).Where("company_id = ?", companyID).Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("统计公司的用户数失败: %w", err)
+	}
+	return count, nil
+}
+
+// FindCompanyUsersWithPagination 分页查找公司的用户
+func (d *Dao) FindCompanyUsersWithPagination(tx *gorm.DB, companyID uint, page, pageSize int) ([]*model.User, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var users []*model.User
+	offset := (page - 1) * pageSize
+	err := tx.Where("company_id = ?", companyID).Order("uid asc").
+		Limit(pageSize).Offset(offset).
+		Find(&users).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找公司的用户失败: %w", err)
+	}
+	return users, nil
+}
+
+// UpdateCompanyUsersName 公司改名后同步用户冗余保存的公司名称
+func (d *Dao) UpdateCompanyUsersName(tx *gorm.DB, companyID uint, name string) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	err := tx.Model(&model.User{}).Where("company_id = ?", companyID).Update("company_name", name).Error
+	if err != nil {
+		return fmt.Errorf("更新用户的公司名称失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package company
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"time"
+	accountModel "xinde/internal/model/account"
+	apiKeyModel "xinde/internal/model/api_key"
+	logModel "xinde/internal/model/device_access_log"
+	groupModel "xinde/internal/model/group"
+	priceModel "xinde/internal/model/price"
+	"xinde/pkg/stderr"
+)
+
+// MergeResult 合并时转移到保留公司的数据条数
+type MergeResult struct {
+	Users           int64
+	AccessLogs      int64
+	APIKeys         int64
+	VisibilityRules int64
+	// 被合并的公司原来的公司管理员，转到保留公司后不再是公司管理员
+	DemotedAdmins []*DemotedAdmin
+}
+
+// DemotedAdmin 合并时被取消公司管理员身份的用户及其原来所在的公司
+type DemotedAdmin struct {
+	UID       uint
+	CompanyID uint
+}
+
+// MergeCompanies 把 sourceIDs 公司的用户、设备访问记录、API Key 和产品目录可见性规则转到 target，然后软删除这些公司并记录被合并到的公司。
+// 被合并的公司的公司管理员转到 target 后取消公司管理员身份，需要重新指定，避免未经授权就能管理 target 的用户。
+// 专属价格和折扣规则不会转移，调用前需要确认被合并的公司没有这些数据（见 HasCompanyPriceData）
+func (d *Dao) MergeCompanies(tx *gorm.DB, target *accountModel.Company, sourceIDs []uint) (*MergeResult, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var err error
+	result := &MergeResult{}
+	err = tx.Model(&accountModel.User{}).Select("uid", "company_id").
+		Where("company_id IN ? AND is_company_admin = ?", sourceIDs, true).
+		Order("uid asc").
+		Scan(&result.DemotedAdmins).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找被合并公司的公司管理员失败: %w", err)
+	}
+
+	users := tx.Model(&accountModel.User{}).Where("company_id IN ?", sourceIDs).Updates(map[string]interface{}{
+		"company_id":       target.ID,
+		"company_name":     target.Name,
+		"is_company_admin": false,
+	})
+	if users.Error != nil {
+		return nil, fmt.Errorf("转移公司用户失败: %w", users.Error)
+	}
+	result.Users = users.RowsAffected
+
+	logs := tx.Model(&logModel.DeviceAccessLog{}).Where("company_id IN ?", sourceIDs).Update("company_id", target.ID)
+	if logs.Error != nil {
+		return nil, fmt.Errorf("转移设备访问记录失败: %w", logs.Error)
+	}
+	result.AccessLogs = logs.RowsAffected
+
+	keys := tx.Model(&apiKeyModel.APIKey{}).Where("company_id IN ?", sourceIDs).Update("company_id", target.ID)
+	if keys.Error != nil {
+		return nil, fmt.Errorf("转移API Key失败: %w", keys.Error)
+	}
+	result.APIKeys = keys.RowsAffected
+
+	if result.VisibilityRules, err = moveVisibilityRules(tx, target.ID, sourceIDs); err != nil {
+		return nil, err
+	}
+
+	err = tx.Model(&accountModel.Company{}).Where("id IN ?", sourceIDs).Updates(map[string]interface{}{
+		"merged_into": target.ID,
+		"deleted_at":  time.Now(),
+	}).Error
+	if err != nil {
+		return nil, fmt.Errorf("删除被合并的公司失败: %w", err)
+	}
+	return result, nil
+}
+
+// moveVisibilityRules 把以 sourceIDs 公司为对象的可见性规则转到 targetID，目标公司在同一节点上已有的规则直接删除
+func moveVisibilityRules(tx *gorm.DB, targetID uint, sourceIDs []uint) (int64, error) {
+	var rules []*groupModel.VisibilityRule
+	err := tx.Where("target_type = ? AND (target_id = ? OR target_id IN ?)", groupModel.VisibilityTargetCompany, targetID, sourceIDs).
+		Order("id asc").
+		Find(&rules).Error
+	if err != nil {
+		return 0, fmt.Errorf("查找公司的可见性规则失败: %w", err)
+	}
+
+	// 先记下目标公司已有规则的节点
+	type node struct{ groupID, deviceTypeID uint }
+	seen := make(map[node]bool, len(rules))
+	for _, r := range rules {
+		if r.TargetID == targetID {
+			seen[node{r.GroupID, r.DeviceTypeID}] = true
+		}
+	}
+
+	var moved int64
+	for _, r := range rules {
+		if r.TargetID == targetID {
+			continue
+		}
+		key := node{r.GroupID, r.DeviceTypeID}
+		if seen[key] {
+			if err := tx.Where("id = ?", r.ID).Delete(&groupModel.VisibilityRule{}).Error; err != nil {
+				return 0, fmt.Errorf("删除重复的可见性规则失败: %w", err)
+			}
+			continue
+		}
+		seen[key] = true
+		if err := tx.Model(&groupModel.VisibilityRule{}).Where("id = ?", r.ID).Update("target_id", targetID).Error; err != nil {
+			return 0, fmt.Errorf("转移可见性规则失败: %w", err)
+		}
+		moved++
+	}
+	return moved, nil
+}
+
+// HasCompanyPriceData 判断公司是否有专属价格或折扣规则
+func (d *Dao) HasCompanyPriceData(tx *gorm.DB, id uint) (bool, error) {
+	if tx == nil {
+		return false, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	return d.hasRows(tx, id, &priceModel.CompanyPriceOverride{}, &priceModel.CompanyPriceRule{})
+}
+
+// HasCompanyReferences 判断公司是否还有用户、专属价格、折扣规则或 API Key
+func (d *Dao) HasCompanyReferences(tx *gorm.DB, id uint) (bool, error) {
+	if tx == nil {
+		return false, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	return d.hasRows(tx, id,
+		&accountModel.User{},
+		&priceModel.CompanyPriceOverride{},
+		&priceModel.CompanyPriceRule{},
+		&apiKeyModel.APIKey{},
+	)
+}
+
+func (d *Dao) hasRows(tx *gorm.DB, companyID uint, models ...interface{}) (bool, error) {
+	for _, m := range models {
+		var count int64
+		if err := tx.Model(m).Where("company_id = ?", companyID).Count(&count).Error; err != nil {
+			return false, fmt.Errorf("统计公司的关联数据失败: %w", err)
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// DeleteCompanyByID 根据ID删除公司（软删除）
+func (d *Dao) DeleteCompanyByID(tx *gorm.DB, id uint) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	err := tx.Where("target_type = ? AND target_id = ?", groupModel.VisibilityTargetCompany, id).Delete(&groupModel.VisibilityRule{}).Error
+	if err != nil {
+		return fmt.Errorf("删除公司的可见性规则失败: %w", err)
+	}
+	err = tx.Where("id = ?", id).Delete(&accountModel.Company{}).Error
+	if err != nil {
+		return fmt.Errorf("删除公司失败: %w", err)
+	}
+	return nil
+}
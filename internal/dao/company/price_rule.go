@@ -0,0 +1,136 @@
+package company
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	model "xinde/internal/model/price"
+	"xinde/pkg/stderr"
+)
+
+// FindPriceOverridesByCompanyID 查找公司的全部专属价格，按产品编码排序
+func (d *Dao) FindPriceOverridesByCompanyID(tx *gorm.DB, companyID uint) ([]*model.CompanyPriceOverride, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var list []*model.CompanyPriceOverride
+	err := tx.Model(&model.CompanyPriceOverride{}).Where("company_id = ?", companyID).Order("product_code asc").Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找公司专属价格失败: " + err.Error())
+	}
+	return list, nil
+}
+
+// UpsertPriceOverride 按 (company_id, product_code) 插入或覆盖专属价格
+func (d *Dao) UpsertPriceOverride(tx *gorm.DB, override *model.CompanyPriceOverride) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "company_id"}, {Name: "product_code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"price", "remark", "created_by_uid"}),
+	}).Create(override).Error
+	if err != nil {
+		return fmt.Errorf("保存公司专属价格失败: " + err.Error())
+	}
+	return nil
+}
+
+// GetPriceOverrideByID 根据ID查找专属价格，未找到时原样返回 gorm.ErrRecordNotFound
+func (d *Dao) GetPriceOverrideByID(tx *gorm.DB, id uint) (*model.CompanyPriceOverride, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var override model.CompanyPriceOverride
+	if err := tx.Model(&model.CompanyPriceOverride{}).Where("id = ?", id).First(&override).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("根据ID查找公司专属价格失败: " + err.Error())
+	}
+	return &override, nil
+}
+
+func (d *Dao) DeletePriceOverrideByID(tx *gorm.DB, id uint) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Delete(&model.CompanyPriceOverride{}, "id = ?", id).Error; err != nil {
+		return fmt.Errorf("删除公司专属价格失败: " + err.Error())
+	}
+	return nil
+}
+
+// FindPriceRulesByCompanyID 查找公司的全部折扣规则，分组规则会一并返回分组名称
+func (d *Dao) FindPriceRulesByCompanyID(tx *gorm.DB, companyID uint) ([]*model.CompanyPriceRule, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var list []*model.CompanyPriceRule
+	err := tx.Model(&model.CompanyPriceRule{}).
+		Select("t_company_price_rule.*, t_group.name as group_name").
+		Joins("LEFT JOIN t_group ON t_group.id = t_company_price_rule.group_id").
+		Where("t_company_price_rule.company_id = ?", companyID).
+		Order("t_company_price_rule.scope_type desc, t_company_price_rule.product_prefix asc, t_company_price_rule.group_id asc").
+		Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找公司折扣规则失败: " + err.Error())
+	}
+	return list, nil
+}
+
+// IsExistPriceRule 判断公司是否已存在相同范围的折扣规则
+func (d *Dao) IsExistPriceRule(tx *gorm.DB, companyID uint, scopeType, productPrefix string, groupID uint) (bool, error) {
+	if tx == nil {
+		return false, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var count int64
+	err := tx.Model(&model.CompanyPriceRule{}).
+		Where("company_id = ? AND scope_type = ? AND product_prefix = ? AND group_id = ?", companyID, scopeType, productPrefix, groupID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("判断公司折扣规则是否存在失败: " + err.Error())
+	}
+	return count > 0, nil
+}
+
+func (d *Dao) CreatePriceRule(tx *gorm.DB, rule *model.CompanyPriceRule) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Create(rule).Error; err != nil {
+		return fmt.Errorf("创建公司折扣规则失败: " + err.Error())
+	}
+	return nil
+}
+
+// GetPriceRuleByID 根据ID查找折扣规则，未找到时原样返回 gorm.ErrRecordNotFound
+func (d *Dao) GetPriceRuleByID(tx *gorm.DB, id uint) (*model.CompanyPriceRule, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var rule model.CompanyPriceRule
+	if err := tx.Model(&model.CompanyPriceRule{}).Where("id = ?", id).First(&rule).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("根据ID查找公司折扣规则失败: " + err.Error())
+	}
+	return &rule, nil
+}
+
+func (d *Dao) DeletePriceRuleByID(tx *gorm.DB, id uint) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Delete(&model.CompanyPriceRule{}, "id = ?", id).Error; err != nil {
+		return fmt.Errorf("删除公司折扣规则失败: " + err.Error())
+	}
+	return nil
+}
@@ -84,16 +84,22 @@ func (d *Dao) CountSolutionsWithDeviceTypeID(tx *gorm.DB, deviceTypeID string) (
 	return count, nil
 }
 
-// FindOrCreateDeviceType 查找或创建一个设备类型
-func (d *Dao) FindOrCreateDeviceType(tx *gorm.DB, name string, groupID uint) (*model.DeviceType, error) {
+// FindOrCreateDeviceType 查找或创建一个设备类型，status 只在新建时使用，新建的设备类型排在分组的最后
+func (d *Dao) FindOrCreateDeviceType(tx *gorm.DB, name string, groupID uint, status string) (*model.DeviceType, error) {
 	if tx == nil {
 		return nil, fmt.Errorf(stderr.ErrorDbNil)
 	}
+	maxSortOrder, err := d.GetMaxDeviceTypeSortOrder(tx, groupID)
+	if err != nil {
+		return nil, err
+	}
 	var dt *model.DeviceType
 	// FirstOrCreate 会查找，如果找不到，就用给定的结构体创建
 	if err := tx.Where("name = ? AND group_id = ?", name, groupID).FirstOrCreate(&dt, model.DeviceType{
-		Name:    name,
-		GroupID: groupID,
+		Name:      name,
+		GroupID:   groupID,
+		Status:    status,
+		SortOrder: maxSortOrder + 1,
 	}).Error; err != nil {
 		return nil, fmt.Errorf("查找或创建设备类型失败: " + err.Error())
 	}
@@ -115,12 +121,27 @@ func (d *Dao) GetDeviceTypeByID(tx *gorm.DB, id uint) (*model.DeviceType, error)
 	return dt, nil
 }
 
+// GetDeviceTypesByIDs 根据ID批量查找设备类型
+func (d *Dao) GetDeviceTypesByIDs(tx *gorm.DB, ids []uint) ([]*model.DeviceType, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var deviceTypes []*model.DeviceType
+	if err := tx.Model(&model.DeviceType{}).Where("id IN ?", ids).Order("id").Find(&deviceTypes).Error; err != nil {
+		return nil, fmt.Errorf("Dao层根据ID列表查找设备类型失败: " + err.Error())
+	}
+	return deviceTypes, nil
+}
+
 func (d *Dao) GetDeviceTypesByGroupID(tx *gorm.DB, groupID uint) ([]*model.DeviceType, error) {
 	if tx == nil {
 		return nil, fmt.Errorf(stderr.ErrorDbNil)
 	}
 	var dts []*model.DeviceType
-	if err := tx.Model(&model.DeviceType{}).Where("group_id = ?", groupID).Find(&dts).Error; err != nil {
+	if err := tx.Model(&model.DeviceType{}).Where("group_id = ?", groupID).Order("sort_order, id").Find(&dts).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
 		} else {
@@ -130,6 +151,57 @@ func (d *Dao) GetDeviceTypesByGroupID(tx *gorm.DB, groupID uint) ([]*model.Devic
 	return dts, nil
 }
 
+// GetMaxDeviceTypeSortOrder 返回分组中设备类型最大的顺序，分组中没有设备类型时返回0
+func (d *Dao) GetMaxDeviceTypeSortOrder(tx *gorm.DB, groupID uint) (int, error) {
+	if tx == nil {
+		return 0, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	var maxSortOrder int
+	if err := tx.Model(&model.DeviceType{}).Where("group_id = ?", groupID).Select("COALESCE(MAX(sort_order), 0)").Scan(&maxSortOrder).Error; err != nil {
+		return 0, fmt.Errorf("Dao层查找分组中设备类型的最大顺序失败: " + err.Error())
+	}
+	return maxSortOrder, nil
+}
+
+// GetAllDeviceTypeIDs 返回所有未删除的设备类型ID
+func (d *Dao) GetAllDeviceTypeIDs(tx *gorm.DB) ([]uint, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	var ids []uint
+	if err := tx.Model(&model.DeviceType{}).Order("id").Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("Dao层查找所有设备类型ID失败: " + err.Error())
+	}
+	return ids, nil
+}
+
+// GetAllFilterImageIDs 返回所有未删除的筛选图片ID
+func (d *Dao) GetAllFilterImageIDs(tx *gorm.DB) ([]uint, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	var ids []uint
+	if err := tx.Model(&model.FilterImage{}).Order("id").Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("Dao层查找所有筛选图片ID失败: " + err.Error())
+	}
+	return ids, nil
+}
+
+// GetDeviceTypesByGroupIDs 查找属于这些分组的所有设备类型，按分组和分组内的顺序排列
+func (d *Dao) GetDeviceTypesByGroupIDs(tx *gorm.DB, groupIDs []uint) ([]*model.DeviceType, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+	var dts []*model.DeviceType
+	if err := tx.Model(&model.DeviceType{}).Where("group_id IN ?", groupIDs).Order("group_id, sort_order, id").Find(&dts).Error; err != nil {
+		return nil, fmt.Errorf("Dao层根据GroupID列表查找设备类型失败: " + err.Error())
+	}
+	return dts, nil
+}
+
 func (d *Dao) DeleteByDeviceTypeID(tx *gorm.DB, deviceTypeID uint) error {
 	if tx == nil {
 		return fmt.Errorf(stderr.ErrorDbNil)
@@ -158,12 +230,13 @@ type RawDeviceType struct {
 	SolutionCount int64 `gorm:"column:solution_count"`
 }
 
+// selectWithSolutionCount 使用子查询来计算每个 device_type 的 solution 数量
+const selectWithSolutionCount = "t_device_type.*, (SELECT count(*) FROM t_device WHERE t_device.device_type_id = t_device_type.id AND t_device.deleted_at IS NULL) as solution_count"
+
 func (d *Dao) GetDeviceTypeListPage(tx *gorm.DB, page, pageSize int) (int64, []*RawDeviceType, error) {
 	var total int64
 	var list []*RawDeviceType
-	// 使用子查询来计算每个 device_type 的 solution 数量
-	query := tx.Model(&model.DeviceType{}).
-		Select("t_device_type.*, (SELECT count(*) FROM t_device WHERE t_device.device_type_id = t_device_type.id AND t_device.deleted_at IS NULL) as solution_count")
+	query := tx.Model(&model.DeviceType{}).Select(selectWithSolutionCount)
 
 	// 1. 先计算总数
 	if err := query.Count(&total).Error; err != nil {
@@ -179,6 +252,18 @@ func (d *Dao) GetDeviceTypeListPage(tx *gorm.DB, page, pageSize int) (int64, []*
 	return total, list, nil
 }
 
+// GetAllDeviceTypesWithSolutionCount 返回所有设备类型及其方案数量，按分组和分组内的顺序排列
+func (d *Dao) GetAllDeviceTypesWithSolutionCount(tx *gorm.DB) ([]*RawDeviceType, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	var list []*RawDeviceType
+	if err := tx.Model(&model.DeviceType{}).Select(selectWithSolutionCount).Order("group_id, sort_order, id").Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("Dao层查找所有设备类型及方案数量失败: " + err.Error())
+	}
+	return list, nil
+}
+
 func (d *Dao) UpdateDeviceType(tx *gorm.DB, id uint, updateData map[string]interface{}) error {
 	if tx == nil {
 		return fmt.Errorf(stderr.ErrorDbNil)
@@ -3,6 +3,7 @@ package device_access_log
 import (
 	"fmt"
 	"gorm.io/gorm"
+	"time"
 	"xinde/internal/dao/common"
 	model "xinde/internal/model/device_access_log"
 	"xinde/internal/store"
@@ -37,3 +38,75 @@ func (d *Dao) Create(tx *gorm.DB, log *model.DeviceAccessLog) error {
 	}
 	return nil
 }
+
+// AccessLogFilter 查询公司访问记录的条件，零值表示不限制
+type AccessLogFilter struct {
+	UserID uint
+	From   *time.Time
+	// 不含该时间
+	To *time.Time
+}
+
+// CompanyAccessLog 公司用户的一条访问记录，带上访问用户和设备类型的名称
+type CompanyAccessLog struct {
+	ID             uint      `gorm:"column:id"`
+	UserID         uint      `gorm:"column:user_id"`
+	Username       string    `gorm:"column:username"`
+	Name           string    `gorm:"column:name"`
+	DeviceTypeID   uint      `gorm:"column:device_type_id"`
+	DeviceTypeName string    `gorm:"column:device_type_name"`
+	AccessedAt     time.Time `gorm:"column:accessed_at"`
+}
+
+// companyAccessLogQuery 按公司和过滤条件构造访问记录的查询
+func (d *Dao) companyAccessLogQuery(tx *gorm.DB, companyID uint, filter *AccessLogFilter) *gorm.DB {
+	query := tx.Model(&model.DeviceAccessLog{}).Where("t_device_access_log.company_id = ?", companyID)
+	if filter.UserID != 0 {
+		query = query.Where("t_device_access_log.user_id = ?", filter.UserID)
+	}
+	if filter.From != nil {
+		query = query.Where("t_device_access_log.accessed_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("t_device_access_log.accessed_at < ?", *filter.To)
+	}
+	return query
+}
+
+// CountCompanyAccessLogs 统计公司用户符合条件的访问记录数
+func (d *Dao) CountCompanyAccessLogs(tx *gorm.DB, companyID uint, filter *AccessLogFilter) (int64, error) {
+	if tx == nil {
+		return 0, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	var count int64
+	err := d.companyAccessLogQuery(tx, companyID, filter).Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("统计公司访问记录失败: %w", err)
+	}
+	return count, nil
+}
+
+// FindCompanyAccessLogsWithPagination 按访问时间倒序分页查询公司用户的访问记录。
+// 用户或设备类型已被删除时仍返回记录，名称保留删除前的值
+func (d *Dao) FindCompanyAccessLogsWithPagination(tx *gorm.DB, companyID uint, filter *AccessLogFilter, page, pageSize int) ([]*CompanyAccessLog, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var logs []*CompanyAccessLog
+	offset := (page - 1) * pageSize
+	err := d.companyAccessLogQuery(tx, companyID, filter).
+		Select("t_device_access_log.id, t_device_access_log.user_id, t_device_access_log.device_type_id, t_device_access_log.accessed_at, " +
+			"IFNULL(t_user.username, '') AS username, IFNULL(t_user.name, '') AS name, IFNULL(t_device_type.name, '') AS device_type_name").
+		Joins("LEFT JOIN t_user ON t_user.uid = t_device_access_log.user_id").
+		Joins("LEFT JOIN t_device_type ON t_device_type.id = t_device_access_log.device_type_id").
+		Order("t_device_access_log.accessed_at desc, t_device_access_log.id desc").
+		Limit(pageSize).
+		Offset(offset).
+		Scan(&logs).
+		Error
+	if err != nil {
+		return nil, fmt.Errorf("查询公司访问记录失败: %w", err)
+	}
+	return logs, nil
+}
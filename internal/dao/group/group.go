@@ -34,14 +34,20 @@ func NewGroupDao() (*Dao, error) {
 	}, nil
 }
 
+// Create 创建分组，排在兄弟分组的最后
 func (d *Dao) Create(tx *gorm.DB, groupName string, parentID uint) (uint, error) {
 	if tx == nil {
 		return 0, fmt.Errorf(stderr.ErrorDbNil)
 	}
 
+	maxSortOrder, err := d.GetMaxSortOrder(tx, parentID)
+	if err != nil {
+		return 0, err
+	}
 	g := &model.Group{
-		Name:     groupName,
-		ParentID: parentID,
+		Name:      groupName,
+		ParentID:  parentID,
+		SortOrder: maxSortOrder + 1,
 	}
 	if err := tx.Model(&model.Group{}).Create(g).Error; err != nil {
 		return 0, fmt.Errorf("Dao层创建分组失败: " + err.Error())
@@ -90,12 +96,36 @@ func (d *Dao) GetAll(tx *gorm.DB) ([]*model.Group, error) {
 		return nil, fmt.Errorf(stderr.ErrorDbNil)
 	}
 	var groups []*model.Group
-	if err := tx.Model(&model.Group{}).Order("id asc").Find(&groups).Error; err != nil {
+	if err := tx.Model(&model.Group{}).Order("sort_order asc, id asc").Find(&groups).Error; err != nil {
 		return nil, fmt.Errorf("Dao层查找所有分组失败: " + err.Error())
 	}
 	return groups, nil
 }
 
+// GetChildren 按顺序返回 parentID 的直接子分组
+func (d *Dao) GetChildren(tx *gorm.DB, parentID uint) ([]*model.Group, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	var groups []*model.Group
+	if err := tx.Model(&model.Group{}).Where("parent_id = ?", parentID).Order("sort_order asc, id asc").Find(&groups).Error; err != nil {
+		return nil, fmt.Errorf("Dao层查找子分组失败: " + err.Error())
+	}
+	return groups, nil
+}
+
+// GetMaxSortOrder 返回 parentID 的子分组中最大的顺序，没有子分组时返回0
+func (d *Dao) GetMaxSortOrder(tx *gorm.DB, parentID uint) (int, error) {
+	if tx == nil {
+		return 0, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	var maxSortOrder int
+	if err := tx.Model(&model.Group{}).Where("parent_id = ?", parentID).Select("COALESCE(MAX(sort_order), 0)").Scan(&maxSortOrder).Error; err != nil {
+		return 0, fmt.Errorf("Dao层查找子分组的最大顺序失败: " + err.Error())
+	}
+	return maxSortOrder, nil
+}
+
 // FindAllDescendantIDs 接收一个 groupID，并返回一个包含其所有子孙ID 的列表。
 func (d *Dao) FindAllDescendantIDs(tx *gorm.DB, groupID uint) ([]uint, error) {
 	if tx == nil {
@@ -124,6 +154,32 @@ func (d *Dao) FindAllDescendantIDs(tx *gorm.DB, groupID uint) ([]uint, error) {
 	return descendantIDs, nil
 }
 
+// FindAncestorIDs 返回 groupID 自身及其所有祖先分组的ID，由近及远排列（自身在第一位，root在最后）
+func (d *Dao) FindAncestorIDs(tx *gorm.DB, groupID uint) ([]uint, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	var ancestorIDs []uint
+
+	// depth 既用于排序，也用于防止脏数据中的环导致无限递归
+	sql := `
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id, 0 AS depth FROM t_group WHERE id = ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT g.id, g.parent_id, a.depth + 1 FROM t_group g JOIN ancestors a ON g.id = a.parent_id
+			WHERE g.deleted_at IS NULL AND a.depth < 64
+		)
+		SELECT id FROM ancestors ORDER BY depth;
+	`
+
+	err := tx.Raw(sql, groupID).Scan(&ancestorIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找祖先分组失败: " + err.Error())
+	}
+
+	return ancestorIDs, nil
+}
+
 func (d *Dao) DeleteGroupsByIDs(tx *gorm.DB, groupIDs []uint) error {
 	if tx == nil {
 		return fmt.Errorf(stderr.ErrorDbNil)
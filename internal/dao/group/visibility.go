@@ -0,0 +1,195 @@
+package group
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	accountModel "xinde/internal/model/account"
+	model "xinde/internal/model/group"
+	roleModel "xinde/internal/model/role"
+	"xinde/pkg/stderr"
+)
+
+// VisibilityRuleFilter 可见性规则列表的查询条件，零值表示不过滤
+type VisibilityRuleFilter struct {
+	GroupID      uint
+	DeviceTypeID uint
+	TargetType   string
+	TargetID     uint
+}
+
+// FindVisibilityRules 按条件查找可见性规则
+func (d *Dao) FindVisibilityRules(tx *gorm.DB, f *VisibilityRuleFilter) ([]*model.VisibilityRule, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	q := tx.Model(&model.VisibilityRule{})
+	if f.GroupID != 0 {
+		q = q.Where("group_id = ?", f.GroupID)
+	}
+	if f.DeviceTypeID != 0 {
+		q = q.Where("device_type_id = ?", f.DeviceTypeID)
+	}
+	if f.TargetType != "" {
+		q = q.Where("target_type = ?", f.TargetType)
+	}
+	if f.TargetID != 0 {
+		q = q.Where("target_id = ?", f.TargetID)
+	}
+
+	var rules []*model.VisibilityRule
+	if err := q.Order("id asc").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("查找可见性规则失败: " + err.Error())
+	}
+	return rules, nil
+}
+
+// FindVisibilityRulesByGroupIDs 查找这些分组上的可见性规则
+func (d *Dao) FindVisibilityRulesByGroupIDs(tx *gorm.DB, groupIDs []uint) ([]*model.VisibilityRule, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+	var rules []*model.VisibilityRule
+	if err := tx.Model(&model.VisibilityRule{}).Where("group_id IN ?", groupIDs).Order("id asc").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("根据分组ID列表查找可见性规则失败: " + err.Error())
+	}
+	return rules, nil
+}
+
+// FindVisibilityRulesByDeviceTypeIDs 查找这些设备类型上的可见性规则
+func (d *Dao) FindVisibilityRulesByDeviceTypeIDs(tx *gorm.DB, deviceTypeIDs []uint) ([]*model.VisibilityRule, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if len(deviceTypeIDs) == 0 {
+		return nil, nil
+	}
+	var rules []*model.VisibilityRule
+	if err := tx.Model(&model.VisibilityRule{}).Where("device_type_id IN ?", deviceTypeIDs).Order("id asc").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("根据设备类型ID列表查找可见性规则失败: " + err.Error())
+	}
+	return rules, nil
+}
+
+// GetVisibilityRuleByID 根据ID查找可见性规则，未找到时原样返回 gorm.ErrRecordNotFound
+func (d *Dao) GetVisibilityRuleByID(tx *gorm.DB, id uint) (*model.VisibilityRule, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var rule model.VisibilityRule
+	if err := tx.Model(&model.VisibilityRule{}).Where("id = ?", id).First(&rule).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("根据ID查找可见性规则失败: " + err.Error())
+	}
+	return &rule, nil
+}
+
+// IsExistVisibilityRule 判断同一个分组或设备类型上是否已有相同对象的规则
+func (d *Dao) IsExistVisibilityRule(tx *gorm.DB, rule *model.VisibilityRule) (bool, error) {
+	if tx == nil {
+		return false, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var count int64
+	err := tx.Model(&model.VisibilityRule{}).
+		Where("group_id = ? AND device_type_id = ? AND target_type = ? AND target_id = ?",
+			rule.GroupID, rule.DeviceTypeID, rule.TargetType, rule.TargetID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("查找可见性规则失败: " + err.Error())
+	}
+	return count > 0, nil
+}
+
+// CreateVisibilityRule 创建可见性规则
+func (d *Dao) CreateVisibilityRule(tx *gorm.DB, rule *model.VisibilityRule) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Create(rule).Error; err != nil {
+		return fmt.Errorf("创建可见性规则失败: " + err.Error())
+	}
+	return nil
+}
+
+// DeleteVisibilityRuleByID 删除可见性规则
+func (d *Dao) DeleteVisibilityRuleByID(tx *gorm.DB, id uint) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Where("id = ?", id).Delete(&model.VisibilityRule{}).Error; err != nil {
+		return fmt.Errorf("删除可见性规则失败: " + err.Error())
+	}
+	return nil
+}
+
+// DeleteVisibilityRulesByGroupIDs 删除这些分组上的可见性规则，删除分组时调用
+func (d *Dao) DeleteVisibilityRulesByGroupIDs(tx *gorm.DB, groupIDs []uint) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if len(groupIDs) == 0 {
+		return nil
+	}
+	if err := tx.Where("group_id IN ?", groupIDs).Delete(&model.VisibilityRule{}).Error; err != nil {
+		return fmt.Errorf("删除分组的可见性规则失败: " + err.Error())
+	}
+	return nil
+}
+
+// DeleteVisibilityRulesByDeviceTypeID 删除设备类型上的可见性规则，删除设备类型时调用
+func (d *Dao) DeleteVisibilityRulesByDeviceTypeID(tx *gorm.DB, deviceTypeID uint) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Where("device_type_id = ?", deviceTypeID).Delete(&model.VisibilityRule{}).Error; err != nil {
+		return fmt.Errorf("删除设备类型的可见性规则失败: " + err.Error())
+	}
+	return nil
+}
+
+// LoadVisibility 查出全部规则和分组，构造 viewer 的 Visibility。viewer.UID 不为 0 时补全其所在公司和角色
+func (d *Dao) LoadVisibility(tx *gorm.DB, viewer *model.Viewer) (*model.Visibility, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if viewer.All {
+		return model.NewVisibility(viewer, nil, nil), nil
+	}
+
+	if viewer.UID != 0 {
+		var companyIDs []uint
+		err := tx.Model(&accountModel.User{}).Where("uid = ?", viewer.UID).Pluck("company_id", &companyIDs).Error
+		if err != nil {
+			return nil, fmt.Errorf("查找用户所在公司失败: " + err.Error())
+		}
+		if len(companyIDs) > 0 {
+			viewer.CompanyID = companyIDs[0]
+		}
+		err = tx.Model(&roleModel.UserRole{}).Where("uid = ?", viewer.UID).Pluck("role_id", &viewer.RoleIDs).Error
+		if err != nil {
+			return nil, fmt.Errorf("查找用户角色失败: " + err.Error())
+		}
+	}
+
+	var rules []*model.VisibilityRule
+	if err := tx.Model(&model.VisibilityRule{}).Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("查找可见性规则失败: " + err.Error())
+	}
+	// 没有任何规则时所有节点都可见，不需要查询分组
+	if len(rules) == 0 {
+		return model.NewVisibility(viewer, nil, nil), nil
+	}
+	groups, err := d.GetAll(tx)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewVisibility(viewer, rules, groups), nil
+}
@@ -0,0 +1,132 @@
+package outbox
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"time"
+	model "xinde/internal/model/outbox"
+	"xinde/internal/store"
+	"xinde/pkg/stderr"
+)
+
+// Dao 待执行操作保存在PostgreSQL中，DB() 返回PostgreSQL连接；
+// 执行记录（t_outbox_applied）保存在MySQL中，相关方法需要传入MySQL的事务
+type Dao struct {
+	db *gorm.DB
+}
+
+func NewOutboxDao() (*Dao, error) {
+	db := store.GetPDB()
+	if db == nil {
+		return nil, fmt.Errorf("数据库连接未初始化，请先调用 store.InitPDB()")
+	}
+	return &Dao{db: db}, nil
+}
+
+func (d *Dao) DB() *gorm.DB {
+	return d.db
+}
+
+func (d *Dao) Create(tx *gorm.DB, op *model.Operation) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Create(op).Error; err != nil {
+		return fmt.Errorf("写入待执行操作失败: " + err.Error())
+	}
+	return nil
+}
+
+// FindDue 按ID顺序查找ID大于 afterID、到了执行时间的待执行操作
+func (d *Dao) FindDue(tx *gorm.DB, now time.Time, afterID uint, limit int) ([]*model.Operation, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	var ops []*model.Operation
+	if err := tx.Where("status = ? AND next_attempt_at <= ? AND id > ?", model.StatusPending, now, afterID).
+		Order("id").Limit(limit).Find(&ops).Error; err != nil {
+		return nil, fmt.Errorf("查找待执行操作失败: " + err.Error())
+	}
+	return ops, nil
+}
+
+// HasEarlierPending 判断同一实体是否还有ID比 id 小、尚未执行的操作
+func (d *Dao) HasEarlierPending(tx *gorm.DB, entityKey string, id uint) (bool, error) {
+	if tx == nil {
+		return false, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	var count int64
+	if err := tx.Model(&model.Operation{}).
+		Where("entity_key = ? AND status = ? AND id < ?", entityKey, model.StatusPending, id).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("查找待执行操作失败: " + err.Error())
+	}
+	return count > 0, nil
+}
+
+func (d *Dao) CountByStatus(tx *gorm.DB, status string) (int64, error) {
+	if tx == nil {
+		return 0, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	var count int64
+	if err := tx.Model(&model.Operation{}).Where("status = ?", status).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("统计待执行操作失败: " + err.Error())
+	}
+	return count, nil
+}
+
+func (d *Dao) MarkDone(tx *gorm.DB, id uint) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Model(&model.Operation{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     model.StatusDone,
+		"last_error": "",
+	}).Error; err != nil {
+		return fmt.Errorf("更新待执行操作状态失败: " + err.Error())
+	}
+	return nil
+}
+
+// MarkRetry 记录一次失败的执行，status 为 pending 时在 nextAttemptAt 之后重试，为 failed 时不再重试
+func (d *Dao) MarkRetry(tx *gorm.DB, id uint, status string, attempts int, lastError string, nextAttemptAt time.Time) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Model(&model.Operation{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":          status,
+		"attempts":        attempts,
+		"last_error":      lastError,
+		"next_attempt_at": nextAttemptAt,
+	}).Error; err != nil {
+		return fmt.Errorf("更新待执行操作状态失败: " + err.Error())
+	}
+	return nil
+}
+
+// IsApplied 判断操作是否已经在MySQL中执行过，tx 为MySQL的事务
+func (d *Dao) IsApplied(tx *gorm.DB, id uint) (bool, error) {
+	if tx == nil {
+		return false, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	var applied *model.Applied
+	if err := tx.Where("operation_id = ?", id).First(&applied).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("查找操作执行记录失败: " + err.Error())
+	}
+	return true, nil
+}
+
+// CreateApplied 记录操作已经在MySQL中执行，tx 为MySQL的事务
+func (d *Dao) CreateApplied(tx *gorm.DB, id uint) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Create(&model.Applied{OperationID: id}).Error; err != nil {
+		return fmt.Errorf("写入操作执行记录失败: " + err.Error())
+	}
+	return nil
+}
@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"time"
 	"xinde/internal/dao/common"
 	model "xinde/internal/model/price"
 	"xinde/internal/store"
 	"xinde/pkg/stderr"
+	"xinde/pkg/util"
 )
 
 type Dao struct {
@@ -37,41 +39,318 @@ func (d *Dao) DB() *gorm.DB {
 	return d.db
 }
 
-// CountPrices 统计价格总数
-func (d *Dao) CountPrices(tx *gorm.DB) (int64, error) {
+// PriceListFilter 价格列表的查询条件，零值表示不过滤
+type PriceListFilter struct {
+	// 同时匹配产品编码和规格型号
+	Keyword string
+	// 为 true 时按前缀匹配，否则按包含匹配
+	PrefixMatch bool
+	// 缺少价格 / 价格为 0 的过滤
+	Missing bool
+	Zero    bool
+	// 只检查该价格等级，为空时检查全部等级
+	LevelCode    string
+	UpdatedSince *time.Time
+	// id、product_code、spec_code、updated_at，或价格等级编码（按该等级的价格排序）
+	SortBy   string
+	SortDesc bool
+}
+
+// priceSortColumns 可以直接排序的 t_price 列
+var priceSortColumns = map[string]bool{
+	"id":           true,
+	"product_code": true,
+	"spec_code":    true,
+	"updated_at":   true,
+}
+
+// IsPriceSortColumn 判断 sortBy 是否为 t_price 上可排序的列，不是时应当是价格等级编码
+func IsPriceSortColumn(sortBy string) bool {
+	return priceSortColumns[sortBy]
+}
+
+// applyPriceListFilter 在 t_price 上拼接过滤条件
+func applyPriceListFilter(tx *gorm.DB, f *PriceListFilter) *gorm.DB {
+	q := tx.Model(&model.Price{})
+	if f == nil {
+		return q
+	}
+
+	if f.Keyword != "" {
+		pattern := util.EscapeLike(f.Keyword) + "%"
+		if !f.PrefixMatch {
+			pattern = "%" + pattern
+		}
+		q = q.Where("(t_price.product_code LIKE ? OR t_price.spec_code LIKE ?)", pattern, pattern)
+	}
+
+	// 缺少价格：存在某个价格等级在 t_price_value 中没有该产品的记录
+	missingSQL := `EXISTS (
+		SELECT 1 FROM t_price_level l
+		WHERE NOT EXISTS (SELECT 1 FROM t_price_value v WHERE v.product_code = t_price.product_code AND v.level_code = l.code)`
+	// 价格为 0：存在某个(未删除的)价格等级的价格为 0
+	zeroSQL := `EXISTS (
+		SELECT 1 FROM t_price_value v JOIN t_price_level l ON l.code = v.level_code
+		WHERE v.product_code = t_price.product_code AND v.price = 0`
+	var args []interface{}
+	if f.LevelCode != "" {
+		missingSQL += " AND l.code = ?"
+		zeroSQL += " AND l.code = ?"
+	}
+	missingSQL += ")"
+	zeroSQL += ")"
+	switch {
+	case f.Missing && f.Zero:
+		if f.LevelCode != "" {
+			args = []interface{}{f.LevelCode, f.LevelCode}
+		}
+		q = q.Where("("+missingSQL+" OR "+zeroSQL+")", args...)
+	case f.Missing:
+		if f.LevelCode != "" {
+			args = []interface{}{f.LevelCode}
+		}
+		q = q.Where(missingSQL, args...)
+	case f.Zero:
+		if f.LevelCode != "" {
+			args = []interface{}{f.LevelCode}
+		}
+		q = q.Where(zeroSQL, args...)
+	}
+
+	// 各等级的价格单独保存，价格变化不一定会更新 t_price.updated_at，所以两边都要检查
+	if f.UpdatedSince != nil {
+		q = q.Where(`(t_price.updated_at >= ? OR EXISTS (
+			SELECT 1 FROM t_price_value v WHERE v.product_code = t_price.product_code AND v.updated_at >= ?))`,
+			*f.UpdatedSince, *f.UpdatedSince)
+	}
+	return q
+}
+
+// applyPriceListSort 拼接排序条件，最后总是按 id 排序以保证分页结果稳定
+func applyPriceListSort(q *gorm.DB, f *PriceListFilter) *gorm.DB {
+	direction := "asc"
+	if f != nil && f.SortDesc {
+		direction = "desc"
+	}
+
+	switch {
+	case f == nil || f.SortBy == "" || f.SortBy == "id":
+		return q.Order("t_price.id " + direction)
+	case priceSortColumns[f.SortBy]:
+		// 列名来自白名单，可以直接拼接
+		return q.Order("t_price." + f.SortBy + " " + direction).Order("t_price.id asc")
+	default:
+		// 按某个价格等级的价格排序，没有该等级价格的产品视为最小
+		return q.Joins("LEFT JOIN t_price_value sort_v ON sort_v.product_code = t_price.product_code AND sort_v.level_code = ?", f.SortBy).
+			Order("sort_v.price " + direction).Order("t_price.id asc")
+	}
+}
+
+// CountPrices 统计符合条件的价格总数
+func (d *Dao) CountPrices(tx *gorm.DB, f *PriceListFilter) (int64, error) {
 	if tx == nil {
 		return 0, fmt.Errorf(stderr.ErrorDbNil)
 	}
 
 	var count int64
-	err := tx.Model(model.Price{}).Count(&count).Error
+	err := applyPriceListFilter(tx, f).Count(&count).Error
 	if err != nil {
 		return 0, fmt.Errorf("统计价格总数失败: " + err.Error())
 	}
 	return count, nil
 }
 
-func (d *Dao) FindPriceListWithPagination(tx *gorm.DB, page, pageSize int) ([]*model.Price, error) {
+func (d *Dao) FindPriceListWithPagination(tx *gorm.DB, page, pageSize int, f *PriceListFilter) ([]*model.Price, error) {
 	if tx == nil {
 		return nil, fmt.Errorf(stderr.ErrorDbNil)
 	}
 
 	var list []*model.Price
 	offset := (page - 1) * pageSize
-	err := tx.Model(&model.Price{}).Order("id asc").Limit(pageSize).Offset(offset).Find(&list).Error
+	q := applyPriceListSort(applyPriceListFilter(tx, f), f)
+	err := q.Select("t_price.*").Preload("Values").Limit(pageSize).Offset(offset).Find(&list).Error
 	if err != nil {
 		return nil, fmt.Errorf("分页查找价格列表失败: " + err.Error())
 	}
 	return list, nil
 }
 
-func (d *Dao) UpsertPrices(tx *gorm.DB, price *model.Price) error {
+// StreamPrices 按条件逐行读取价格（游标方式，不会一次性把整张表读入内存），
+// 每攒够 batchSize 条就加载这些产品的各等级价格并交给 fn 处理
+func (d *Dao) StreamPrices(tx *gorm.DB, f *PriceListFilter, batchSize int, fn func([]*model.Price) error) error {
 	if tx == nil {
 		return fmt.Errorf(stderr.ErrorDbNil)
 	}
-	return tx.Clauses(clause.OnConflict{
+
+	rows, err := applyPriceListSort(applyPriceListFilter(tx, f), f).Select("t_price.*").Rows()
+	if err != nil {
+		return fmt.Errorf("查询价格失败: " + err.Error())
+	}
+	defer rows.Close()
+
+	batch := make([]*model.Price, 0, batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := d.loadPriceValues(tx, batch); err != nil {
+			return err
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+		batch = make([]*model.Price, 0, batchSize)
+		return nil
+	}
+
+	for rows.Next() {
+		var p model.Price
+		if err := tx.ScanRows(rows, &p); err != nil {
+			return fmt.Errorf("读取价格失败: " + err.Error())
+		}
+		batch = append(batch, &p)
+		if len(batch) >= batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("读取价格失败: " + err.Error())
+	}
+	return flush()
+}
+
+// loadPriceValues 为一批产品填充各等级的价格
+func (d *Dao) loadPriceValues(tx *gorm.DB, prices []*model.Price) error {
+	codes := make([]string, 0, len(prices))
+	byCode := make(map[string]*model.Price, len(prices))
+	for _, p := range prices {
+		codes = append(codes, p.ProductCode)
+		byCode[p.ProductCode] = p
+	}
+
+	var values []*model.PriceValue
+	if err := tx.Model(&model.PriceValue{}).Where("product_code IN (?)", codes).Find(&values).Error; err != nil {
+		return fmt.Errorf("查找等级价格失败: " + err.Error())
+	}
+	for _, v := range values {
+		if p, ok := byCode[v.ProductCode]; ok {
+			p.Values = append(p.Values, v)
+		}
+	}
+	return nil
+}
+
+// upsertBatchSize 单条 INSERT ... ON DUPLICATE KEY UPDATE 语句包含的最大行数
+const upsertBatchSize = 500
+
+// UpsertPrices 按 product_code 批量插入或覆盖产品的基本信息，每 upsertBatchSize 行一条语句。
+// 各等级的价格通过 UpsertPriceValues 单独写入
+func (d *Dao) UpsertPrices(tx *gorm.DB, prices []*model.Price) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if len(prices) == 0 {
+		return nil
+	}
+	err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
 		Columns:   []clause.Column{{Name: "product_code"}},
-		DoUpdates: clause.AssignmentColumns([]string{"unit", "spec_code", "price_1", "price_2", "price_3", "price_4"}),
-	}).Create(price).Error
+		DoUpdates: clause.AssignmentColumns([]string{"unit", "spec_code"}),
+	}).CreateInBatches(prices, upsertBatchSize).Error
+	if err != nil {
+		return fmt.Errorf("批量写入价格失败: " + err.Error())
+	}
+	return nil
+}
+
+// CreatePriceHistories 批量追加价格历史记录，各等级的价格 (Values) 会随记录一起写入
+func (d *Dao) CreatePriceHistories(tx *gorm.DB, histories []*model.PriceHistory) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if len(histories) == 0 {
+		return nil
+	}
+	if err := tx.CreateInBatches(histories, upsertBatchSize).Error; err != nil {
+		return fmt.Errorf("批量写入价格历史失败: " + err.Error())
+	}
+	return nil
+}
+
+// FindDuePriceHistoryCodes 查找有已到生效时间、但还没写入当前价格的历史记录的产品编码，最多 limit 个
+func (d *Dao) FindDuePriceHistoryCodes(tx *gorm.DB, at time.Time, limit int) ([]string, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var codes []string
+	err := tx.Model(&model.PriceHistory{}).
+		Where("promoted_at IS NULL AND effective_from <= ?", at).
+		Distinct("product_code").Limit(limit).
+		Pluck("product_code", &codes).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找到达生效时间的价格失败: " + err.Error())
+	}
+	return codes, nil
+}
+
+// FindEffectivePriceHistories 查找这些产品在 at 时刻及之前生效的全部历史记录（含各等级价格），
+// 按生效时间倒序排列，生效时间相同时后导入的在前
+func (d *Dao) FindEffectivePriceHistories(tx *gorm.DB, productCodes []string, at time.Time) ([]*model.PriceHistory, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if len(productCodes) == 0 {
+		return nil, nil
+	}
+
+	var list []*model.PriceHistory
+	err := tx.Model(&model.PriceHistory{}).
+		Preload("Values").
+		Where("product_code IN (?) AND effective_from <= ?", productCodes, at).
+		Order("effective_from desc, id desc").
+		Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找生效的价格历史失败: " + err.Error())
+	}
+	return list, nil
+}
 
+// MarkPriceHistoriesPromoted 把这些产品在 at 时刻及之前生效的历史记录标记为已写入当前价格
+func (d *Dao) MarkPriceHistoriesPromoted(tx *gorm.DB, productCodes []string, at time.Time) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if len(productCodes) == 0 {
+		return nil
+	}
+	err := tx.Model(&model.PriceHistory{}).
+		Where("product_code IN (?) AND promoted_at IS NULL AND effective_from <= ?", productCodes, at).
+		Update("promoted_at", at).Error
+	if err != nil {
+		return fmt.Errorf("标记价格历史失败: " + err.Error())
+	}
+	return nil
+}
+
+// FindPriceHistoryByProductCode 查找某个产品编码的全部价格历史，按生效时间倒序排列
+func (d *Dao) FindPriceHistoryByProductCode(tx *gorm.DB, productCode string) ([]*model.PriceHistory, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var list []*model.PriceHistory
+	err := tx.Model(&model.PriceHistory{}).
+		Select("t_price_history.*, t_attachment.filename as attachment_name, t_user.name as operator_name").
+		Joins("LEFT JOIN t_attachment ON t_attachment.id = t_price_history.attachment_id").
+		Joins("LEFT JOIN t_user ON t_user.uid = t_price_history.created_by_uid").
+		Preload("Values").
+		Where("t_price_history.product_code = ?", productCode).
+		Order("t_price_history.effective_from desc, t_price_history.id desc").
+		Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找价格历史失败: " + err.Error())
+	}
+	return list, nil
 }
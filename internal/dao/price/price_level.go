@@ -0,0 +1,127 @@
+package price
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	model "xinde/internal/model/price"
+	"xinde/pkg/stderr"
+)
+
+// FindAllPriceLevels 查找全部价格等级，按 sort_order 升序排列，第一个即为默认等级
+func (d *Dao) FindAllPriceLevels(tx *gorm.DB) ([]*model.PriceLevel, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var levels []*model.PriceLevel
+	err := tx.Model(&model.PriceLevel{}).Order("sort_order asc, id asc").Find(&levels).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找价格等级列表失败: " + err.Error())
+	}
+	return levels, nil
+}
+
+// GetPriceLevelByID 根据ID查找价格等级，未找到时原样返回 gorm.ErrRecordNotFound
+func (d *Dao) GetPriceLevelByID(tx *gorm.DB, id uint) (*model.PriceLevel, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var level model.PriceLevel
+	if err := tx.Model(&model.PriceLevel{}).Where("id = ?", id).First(&level).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("根据ID查找价格等级失败: " + err.Error())
+	}
+	return &level, nil
+}
+
+// IsExistPriceLevelByCode 根据编码判断价格等级是否存在
+func (d *Dao) IsExistPriceLevelByCode(tx *gorm.DB, code string) (bool, error) {
+	if tx == nil {
+		return false, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var count int64
+	if err := tx.Model(&model.PriceLevel{}).Where("code = ?", code).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("判断价格等级是否存在失败: " + err.Error())
+	}
+	return count > 0, nil
+}
+
+// IsExistPriceLevelByName 判断是否存在同名的价格等级，excludeID 用于修改时排除自身
+func (d *Dao) IsExistPriceLevelByName(tx *gorm.DB, name string, excludeID uint) (bool, error) {
+	if tx == nil {
+		return false, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var count int64
+	err := tx.Model(&model.PriceLevel{}).Where("name = ? AND id <> ?", name, excludeID).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("判断价格等级名称是否存在失败: " + err.Error())
+	}
+	return count > 0, nil
+}
+
+func (d *Dao) CreatePriceLevel(tx *gorm.DB, level *model.PriceLevel) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Create(level).Error; err != nil {
+		return fmt.Errorf("创建价格等级失败: " + err.Error())
+	}
+	return nil
+}
+
+func (d *Dao) UpdatePriceLevel(tx *gorm.DB, id uint, updateData map[string]interface{}) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Model(&model.PriceLevel{}).Where("id = ?", id).Updates(updateData).Error; err != nil {
+		return fmt.Errorf("更新价格等级失败: " + err.Error())
+	}
+	return nil
+}
+
+// DeletePriceLevelByID 删除价格等级（物理删除，以便之后可以重新使用同一个编码）
+func (d *Dao) DeletePriceLevelByID(tx *gorm.DB, id uint) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Delete(&model.PriceLevel{}, "id = ?", id).Error; err != nil {
+		return fmt.Errorf("删除价格等级失败: " + err.Error())
+	}
+	return nil
+}
+
+// UpsertPriceValues 按 (product_code, level_code) 批量插入或覆盖各等级的价格
+func (d *Dao) UpsertPriceValues(tx *gorm.DB, values []*model.PriceValue) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "product_code"}, {Name: "level_code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"price"}),
+	}).CreateInBatches(values, upsertBatchSize).Error
+	if err != nil {
+		return fmt.Errorf("批量写入等级价格失败: " + err.Error())
+	}
+	return nil
+}
+
+// DeletePriceValuesByLevelCode 删除某个等级下的全部当前价格，历史记录保持不变
+func (d *Dao) DeletePriceValuesByLevelCode(tx *gorm.DB, levelCode string) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Delete(&model.PriceValue{}, "level_code = ?", levelCode).Error; err != nil {
+		return fmt.Errorf("删除等级价格失败: " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package role
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	groupModel "xinde/internal/model/group"
+	model "xinde/internal/model/role"
+	"xinde/internal/store"
+	"xinde/pkg/stderr"
+)
+
+type Dao struct {
+	db *gorm.DB
+}
+
+func NewRoleDao() (*Dao, error) {
+	db := store.GetDB()
+	if db == nil {
+		return nil, fmt.Errorf("数据库连接未初始化，请先调用 store.InitDB()")
+	}
+
+	return &Dao{
+		db: db,
+	}, nil
+}
+
+// DB 返回原始的 gorm.DB 实例，以便 Service 层可以开启事务
+func (d *Dao) DB() *gorm.DB {
+	return d.db
+}
+
+// FindAllRoles 查找全部角色及其权限，按ID升序排列
+func (d *Dao) FindAllRoles(tx *gorm.DB) ([]*model.Role, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var roles []*model.Role
+	err := tx.Model(&model.Role{}).Preload("Permissions").Order("id asc").Find(&roles).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找角色列表失败: " + err.Error())
+	}
+	return roles, nil
+}
+
+// GetRoleByID 根据ID查找角色及其权限，未找到时原样返回 gorm.ErrRecordNotFound
+func (d *Dao) GetRoleByID(tx *gorm.DB, id uint) (*model.Role, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var role model.Role
+	if err := tx.Model(&model.Role{}).Preload("Permissions").Where("id = ?", id).First(&role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("根据ID查找角色失败: " + err.Error())
+	}
+	return &role, nil
+}
+
+// FindRolesByIDs 根据ID批量查找角色
+func (d *Dao) FindRolesByIDs(tx *gorm.DB, ids []uint) ([]*model.Role, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var roles []*model.Role
+	err := tx.Model(&model.Role{}).Preload("Permissions").Where("id IN (?)", ids).Order("id asc").Find(&roles).Error
+	if err != nil {
+		return nil, fmt.Errorf("批量查找角色失败: " + err.Error())
+	}
+	return roles, nil
+}
+
+// IsExistRoleByCode 根据编码判断角色是否存在
+func (d *Dao) IsExistRoleByCode(tx *gorm.DB, code string) (bool, error) {
+	if tx == nil {
+		return false, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var count int64
+	if err := tx.Model(&model.Role{}).Where("code = ?", code).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("判断角色是否存在失败: " + err.Error())
+	}
+	return count > 0, nil
+}
+
+// CreateRole 创建角色，角色的权限 (Permissions) 会随角色一起写入
+func (d *Dao) CreateRole(tx *gorm.DB, role *model.Role) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Create(role).Error; err != nil {
+		return fmt.Errorf("创建角色失败: " + err.Error())
+	}
+	return nil
+}
+
+func (d *Dao) UpdateRole(tx *gorm.DB, id uint, updateData map[string]interface{}) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+	if err := tx.Model(&model.Role{}).Where("id = ?", id).Updates(updateData).Error; err != nil {
+		return fmt.Errorf("修改角色失败: " + err.Error())
+	}
+	return nil
+}
+
+// ReplaceRolePermissions 用 permissions 整体替换角色的权限
+func (d *Dao) ReplaceRolePermissions(tx *gorm.DB, roleID uint, permissions []string) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	if err := tx.Where("role_id = ?", roleID).Delete(&model.RolePermission{}).Error; err != nil {
+		return fmt.Errorf("删除角色权限失败: " + err.Error())
+	}
+	if len(permissions) == 0 {
+		return nil
+	}
+	rows := make([]*model.RolePermission, 0, len(permissions))
+	for _, p := range permissions {
+		rows = append(rows, &model.RolePermission{RoleID: roleID, Permission: p})
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		return fmt.Errorf("写入角色权限失败: " + err.Error())
+	}
+	return nil
+}
+
+// DeleteRoleByID 删除角色及其权限、用户的角色分配和以该角色为对象的产品目录可见性规则
+func (d *Dao) DeleteRoleByID(tx *gorm.DB, id uint) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	err := tx.Where("target_type = ? AND target_id = ?", groupModel.VisibilityTargetRole, id).Delete(&groupModel.VisibilityRule{}).Error
+	if err != nil {
+		return fmt.Errorf("删除角色的可见性规则失败: " + err.Error())
+	}
+
+	if err := tx.Where("role_id = ?", id).Delete(&model.UserRole{}).Error; err != nil {
+		return fmt.Errorf("删除用户角色失败: " + err.Error())
+	}
+	if err := tx.Where("role_id = ?", id).Delete(&model.RolePermission{}).Error; err != nil {
+		return fmt.Errorf("删除角色权限失败: " + err.Error())
+	}
+	if err := tx.Where("id = ?", id).Delete(&model.Role{}).Error; err != nil {
+		return fmt.Errorf("删除角色失败: " + err.Error())
+	}
+	return nil
+}
+
+// FindUIDsByRoleID 查找拥有该角色的用户ID
+func (d *Dao) FindUIDsByRoleID(tx *gorm.DB, roleID uint) ([]uint, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var uids []uint
+	err := tx.Model(&model.UserRole{}).Where("role_id = ?", roleID).Pluck("uid", &uids).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找角色下的用户失败: " + err.Error())
+	}
+	return uids, nil
+}
+
+// FindRolesByUID 查找用户拥有的角色及其权限
+func (d *Dao) FindRolesByUID(tx *gorm.DB, uid uint) ([]*model.Role, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var roles []*model.Role
+	err := tx.Model(&model.Role{}).
+		Preload("Permissions").
+		Joins("JOIN t_user_role ON t_user_role.role_id = t_role.id").
+		Where("t_user_role.uid = ?", uid).
+		Order("t_role.id asc").
+		Find(&roles).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找用户角色失败: " + err.Error())
+	}
+	return roles, nil
+}
+
+// FindAllUserRoleNames 查找所有拥有角色的用户及其角色名称，返回 uid -> 角色名称（按角色ID排序）
+func (d *Dao) FindAllUserRoleNames(tx *gorm.DB) (map[uint][]string, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var rows []struct {
+		UID  uint
+		Name string
+	}
+	err := tx.Model(&model.UserRole{}).
+		Select("t_user_role.uid, t_role.name").
+		Joins("JOIN t_role ON t_role.id = t_user_role.role_id").
+		Order("t_user_role.uid asc, t_role.id asc").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找用户角色失败: " + err.Error())
+	}
+
+	names := make(map[uint][]string)
+	for _, r := range rows {
+		names[r.UID] = append(names[r.UID], r.Name)
+	}
+	return names, nil
+}
+
+// ReplaceUserRoles 用 roleIDs 整体替换用户的角色
+func (d *Dao) ReplaceUserRoles(tx *gorm.DB, uid uint, roleIDs []uint) error {
+	if tx == nil {
+		return fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	if err := tx.Where("uid = ?", uid).Delete(&model.UserRole{}).Error; err != nil {
+		return fmt.Errorf("删除用户角色失败: " + err.Error())
+	}
+	if len(roleIDs) == 0 {
+		return nil
+	}
+	rows := make([]*model.UserRole, 0, len(roleIDs))
+	for _, id := range roleIDs {
+		rows = append(rows, &model.UserRole{UID: uid, RoleID: id})
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		return fmt.Errorf("写入用户角色失败: " + err.Error())
+	}
+	return nil
+}
+
+// FindPermissionsByUID 查找用户通过各个角色获得的全部权限（去重）
+func (d *Dao) FindPermissionsByUID(tx *gorm.DB, uid uint) ([]string, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var permissions []string
+	err := tx.Model(&model.RolePermission{}).
+		Distinct("t_role_permission.permission").
+		Joins("JOIN t_user_role ON t_user_role.role_id = t_role_permission.role_id").
+		Where("t_user_role.uid = ?", uid).
+		Pluck("t_role_permission.permission", &permissions).Error
+	if err != nil {
+		return nil, fmt.Errorf("查找用户权限失败: " + err.Error())
+	}
+	return permissions, nil
+}
+
+// CountUsersWithPermission 统计拥有某个权限的未删除用户数，excludeUID 用于排除正在修改的用户
+func (d *Dao) CountUsersWithPermission(tx *gorm.DB, permission string, excludeUID uint) (int64, error) {
+	if tx == nil {
+		return 0, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	var count int64
+	err := tx.Table("t_user_role").
+		Joins("JOIN t_role_permission ON t_role_permission.role_id = t_user_role.role_id").
+		Joins("JOIN t_user ON t_user.uid = t_user_role.uid AND t_user.deleted_at IS NULL").
+		Where("t_role_permission.permission = ? AND t_user_role.uid <> ?", permission, excludeUID).
+		Distinct("t_user_role.uid").
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("统计拥有权限的用户数失败: " + err.Error())
+	}
+	return count, nil
+}
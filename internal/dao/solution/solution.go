@@ -10,6 +10,7 @@ import (
 	dto "xinde/internal/dto/solution"
 	"xinde/internal/model/device"
 	"xinde/internal/store"
+	"xinde/pkg/stderr"
 )
 
 type Dao struct {
@@ -176,3 +177,32 @@ func (d *Dao) AggregateFilters(tx *gorm.DB, req *dto.QueryReq) (map[string][]str
 
 	return finalMap, nil
 }
+
+// FindDeviceTypeIDsByProductCodes 查找组件中使用了这些产品编码的方案所属的设备类型，返回 产品编码 -> 设备类型ID列表。
+// 没有出现在任何方案中的产品编码不会出现在结果中
+func (d *Dao) FindDeviceTypeIDsByProductCodes(tx *gorm.DB, productCodes []string) (map[string][]uint, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+	result := make(map[string][]uint)
+	if len(productCodes) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		ProductCode  string
+		DeviceTypeID uint
+	}
+	err := tx.Table("t_device AS d").
+		Select("DISTINCT c ->> 'product_code' AS product_code, d.device_type_id").
+		Joins("CROSS JOIN LATERAL jsonb_array_elements(CASE WHEN jsonb_typeof(d.details -> 'components') = 'array' THEN d.details -> 'components' ELSE '[]'::jsonb END) AS c").
+		Where("d.deleted_at IS NULL AND c ->> 'product_code' IN ?", productCodes).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("根据产品编码查找设备类型失败: " + err.Error())
+	}
+	for _, r := range rows {
+		result[r.ProductCode] = append(result[r.ProductCode], r.DeviceTypeID)
+	}
+	return result, nil
+}
@@ -3,11 +3,40 @@ package account
 type ApproveReq struct {
 	Why    string `json:"why" form:"why" binding:"required" example:"批准/拒绝用户申请的理由"`
 	Status string `json:"status" form:"status" binding:"required,oneof=approve reject" example:"approve或者reject"`
+	// 以下字段只在批准时有效
+	CompanyID    uint   `json:"company_id" form:"company_id" binding:"omitempty" example:"3，可选，把用户关联到已有的公司"`
+	MergeCompany bool   `json:"merge_company" form:"merge_company" example:"false，可选，为true时把注册时按公司名称创建的公司合并到company_id"`
+	PriceLevel   string `json:"price_level" form:"price_level" binding:"omitempty,max=31" example:"price_1，可选，同时设置用户所在公司的价格等级"`
 }
 
-type ApproveResp struct {
-	Code    int         `json:"code" example:"200"`
-	Message string      `json:"message" example:"操作成功"`
-	Success bool        `json:"success" example:"true"`
-	Data    interface{} `json:"data"`
+type BatchApproveReq struct {
+	IDs          []uint `json:"ids" form:"ids" binding:"required,min=1,max=100,dive,min=1" example:"2,3,5"`
+	Why          string `json:"why" form:"why" binding:"required" example:"批准/拒绝用户申请的理由"`
+	Status       string `json:"status" form:"status" binding:"required,oneof=approve reject" example:"approve或者reject"`
+	CompanyID    uint   `json:"company_id" form:"company_id" binding:"omitempty" example:"3，可选，把这些用户都关联到已有的公司"`
+	MergeCompany bool   `json:"merge_company" form:"merge_company" example:"false，可选，为true时把这些用户注册时创建的公司合并到company_id"`
+	PriceLevel   string `json:"price_level" form:"price_level" binding:"omitempty,max=31" example:"price_1，可选，同时设置用户所在公司的价格等级"`
+}
+
+type BatchApproveResult struct {
+	ID      uint   `json:"id" example:"2"`
+	Success bool   `json:"success" example:"false"`
+	Error   string `json:"error" example:"用户已经被管理员批准注册申请"`
+}
+
+type BatchApproveData struct {
+	Succeeded int                   `json:"succeeded" example:"2"`
+	Failed    int                   `json:"failed" example:"1"`
+	Results   []*BatchApproveResult `json:"results"`
+}
+
+type BatchApproveResp struct {
+	Code    int               `json:"code" example:"200"`
+	Message string            `json:"message" example:"操作成功"`
+	Success bool              `json:"success" example:"true"`
+	Data    *BatchApproveData `json:"data"`
+}
+
+type ReopenReq struct {
+	Why string `json:"why" form:"why" binding:"required" example:"重新打开注册申请的理由"`
 }
@@ -0,0 +1,5 @@
+package account
+
+type SetCompanyAdminReq struct {
+	Enabled *bool `json:"enabled" form:"enabled" binding:"required" example:"true"`
+}
@@ -0,0 +1,36 @@
+package account
+
+const (
+	ImportModeStrict  = "strict"
+	ImportModeLenient = "lenient"
+)
+
+// CreateUserReq 管理员直接创建已通过审批的用户。company_id 和 company_name 至少填写一个，
+// 同时填写时以 company_id 为准；只填写 company_name 时按名称查找公司，没有时新建
+type CreateUserReq struct {
+	Username       string `form:"username" json:"username" binding:"required,max=255" example:"金晖，账号名称"`
+	Name           string `form:"name" json:"name" binding:"required,max=32" example:"金晖，真实姓名"`
+	Phone          string `form:"phone" json:"phone" binding:"required,numeric,min=7,max=20" example:"13065859690"`
+	Email          string `form:"email" json:"email,omitempty" binding:"omitempty,email,max=255" example:"1921771473@qq.com，可选"`
+	CompanyID      uint   `form:"company_id" json:"company_id,omitempty" binding:"omitempty,min=1" example:"3，可选"`
+	CompanyName    string `form:"company_name" json:"company_name,omitempty" binding:"omitempty,max=255" example:"宁波鲍斯产业链服务有限公司，可选"`
+	CompanyAddress string `form:"company_address" json:"company_address,omitempty" binding:"omitempty,max=255" example:"浙江省宁波市奉化区江口街道聚潮路55号，新建公司时使用，可选"`
+	IsCompanyAdmin bool   `form:"is_company_admin" json:"is_company_admin" example:"false"`
+}
+
+type CreateUserData struct {
+	ID uint `json:"id" example:"12"`
+	// 一次性密码只在这里返回一次，用户首次登录时必须修改
+	Password string `json:"password" example:"Xk3mP9qR2tWz"`
+}
+
+type CreateUserResp struct {
+	Code    int             `json:"code" example:"200"`
+	Message string          `json:"message" example:"操作成功"`
+	Success bool            `json:"success" example:"true"`
+	Data    *CreateUserData `json:"data"`
+}
+
+type ImportUserReq struct {
+	Mode string `json:"mode" form:"mode" binding:"omitempty,oneof=strict lenient" example:"strict表示有任意错误行则全部不导入，lenient表示跳过错误行，可选，默认strict"`
+}
@@ -0,0 +1,53 @@
+package account
+
+type ForgotPasswordReq struct {
+	Username string `form:"username" json:"username" binding:"required,max=255" example:"金晖"`
+	Channel  string `form:"channel" json:"channel" binding:"required,oneof=email phone" example:"email或phone，验证码发送到注册时填写的邮箱或手机号"`
+}
+
+type ForgotPasswordData struct {
+	// 无论账号是否存在都返回相同的提示
+	Message string `json:"message" example:"如果账号存在，验证码已发送到注册时填写的邮箱或手机号"`
+	// 验证码的有效期，单位秒
+	ExpiresIn int64 `json:"expires_in" example:"600"`
+}
+
+type ForgotPasswordResp struct {
+	Code    int                 `json:"code" example:"200"`
+	Message string              `json:"message" example:"操作成功"`
+	Success bool                `json:"success" example:"true"`
+	Data    *ForgotPasswordData `json:"data"`
+}
+
+type VerifyCodeReq struct {
+	Username string `form:"username" json:"username" binding:"required,max=255" example:"金晖"`
+	Code     string `form:"code" json:"code" binding:"required,numeric,max=10" example:"123456"`
+}
+
+type VerifyCodeData struct {
+	// 凭重置令牌设置新密码
+	ResetToken string `json:"reset_token" example:"reset_token"`
+	// 重置令牌的有效期，单位秒
+	ExpiresIn int64 `json:"expires_in" example:"900"`
+}
+
+type VerifyCodeResp struct {
+	Code    int             `json:"code" example:"200"`
+	Message string          `json:"message" example:"操作成功"`
+	Success bool            `json:"success" example:"true"`
+	Data    *VerifyCodeData `json:"data"`
+}
+
+type ResetPasswordByCodeReq struct {
+	ResetToken        string `form:"reset_token" json:"reset_token" binding:"required" example:"reset_token"`
+	Password          string `form:"password" json:"password" binding:"required,min=6,max=64" example:"这是一个新密码"`
+	ConfirmedPassword string `form:"confirmed_password" json:"confirmed_password" binding:"required" example:"这是一个新密码"`
+}
+
+// ChangeInitialPasswordReq 管理员重置密码后，用户用一次性密码换成自己的密码
+type ChangeInitialPasswordReq struct {
+	Username          string `form:"username" json:"username" binding:"required" example:"金晖"`
+	OldPassword       string `form:"old_password" json:"old_password" binding:"required" example:"一次性密码"`
+	Password          string `form:"password" json:"password" binding:"required,min=6,max=64" example:"这是一个新密码"`
+	ConfirmedPassword string `form:"confirmed_password" json:"confirmed_password" binding:"required" example:"这是一个新密码"`
+}
@@ -1,8 +1,34 @@
 package account
 
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// UserFilterReq 用户列表、注册申请列表和用户导出共用的查询条件
+type UserFilterReq struct {
+	Keyword     string `json:"keyword" form:"keyword" binding:"omitempty,max=63" example:"张三，按用户名、姓名、电话或公司名称搜索，可选"`
+	CompanyID   uint   `json:"company_id" form:"company_id" binding:"omitempty" example:"1，可选"`
+	PriceLevel  string `json:"price_level" form:"price_level" binding:"omitempty,max=31" example:"price_1，按所在公司的价格等级过滤，可选"`
+	RoleID      uint   `json:"role_id" form:"role_id" binding:"omitempty" example:"1，可选"`
+	CreatedFrom string `json:"created_from" form:"created_from" binding:"omitempty" example:"2025-01-01或2025-01-01 08:00:00，注册时间，可选"`
+	CreatedTo   string `json:"created_to" form:"created_to" binding:"omitempty" example:"2025-02-01或2025-02-01 08:00:00，不含该时间，可选"`
+	ActiveFrom  string `json:"active_from" form:"active_from" binding:"omitempty" example:"2025-01-01或2025-01-01 08:00:00，上次访问时间，可选"`
+	ActiveTo    string `json:"active_to" form:"active_to" binding:"omitempty" example:"2025-02-01或2025-02-01 08:00:00，不含该时间，可选"`
+	SortBy      string `json:"sort_by" form:"sort_by" binding:"omitempty,max=31" example:"id、username、name、company_name、price_level、created_at、handled_at或recent_search_at，可选"`
+	SortOrder   string `json:"sort_order" form:"sort_order" binding:"omitempty,oneof=asc desc" example:"asc或desc，可选"`
+}
+
 type ListReq struct {
 	Page     int `json:"page" form:"page" binding:"omitempty" example:"1"`
 	PageSize int `json:"page_size" form:"page_size" binding:"omitempty,min=1,max=100" example:"1-100，可选"`
+	UserFilterReq
+}
+
+// ExportReq 按与用户列表相同的条件导出用户
+type ExportReq struct {
+	Status string `json:"status" form:"status" binding:"omitempty" example:"pending或approved或rejected，可选，默认approved"`
+	UserFilterReq
 }
 
 type ListData struct {
@@ -13,8 +39,10 @@ type ListData struct {
 	Email          string `json:"email" example:"13800138000@qq.com"`
 	CompanyName    string `json:"company_name" example:"宁波鲍斯产业链服务有限公司"`
 	PriceLevel     string `json:"price_level" example:"price_1"`
+	PriceLevelName string `json:"price_level_name" example:"价格等级1"`
 	Remark         string `json:"remark" example:"备注"`
 	Role           string `json:"role" example:"普通用户"`
+	IsCompanyAdmin bool   `json:"is_company_admin" example:"false"`
 	CreatedAt      string `json:"created_at" example:"2020-09-08 09:08:09"`
 	RecentSearchAt string `json:"recent_search_at" example:"2020-09-08 09:08:09"`
 	SearchDevice   string `json:"search_device" example:"车削刀杆"`
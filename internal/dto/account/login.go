@@ -5,12 +5,21 @@ type LoginReq struct {
 	Password string `form:"password" json:"password" binding:"required" example:"923845797582"`
 }
 
+// LoginData 需要二次验证时不返回 token，而是返回 mfa_token，凭它和验证码完成登录
 type LoginData struct {
-	Username    string `json:"username" example:"金晖"`
-	Name        string `json:"name" example:"金晖"`
-	Phone       string `json:"phone" example:"13065859690"`
-	Email       string `json:"email,omitempty" example:"1921771473@qq.com"`
-	AccessToken string `json:"access_token" example:"jwt_token"`
+	Username string `json:"username" example:"金晖"`
+	Name     string `json:"name" example:"金晖"`
+	Phone    string `json:"phone" example:"13065859690"`
+	Email    string `json:"email,omitempty" example:"1921771473@qq.com"`
+	*TokenData
+
+	// 是否需要二次验证
+	MFARequired bool `json:"mfa_required" example:"false"`
+	// 为 true 时该账号必须开启二次验证但尚未绑定，需要先凭 mfa_token 绑定验证器
+	MFAEnrollRequired bool   `json:"mfa_enroll_required,omitempty" example:"false"`
+	MFAToken          string `json:"mfa_token,omitempty" example:"mfa_token"`
+	// mfa_token 的有效期，单位秒
+	MFAExpiresIn int64 `json:"mfa_expires_in,omitempty" example:"300"`
 }
 
 type LoginResp struct {
@@ -0,0 +1,71 @@
+package account
+
+type LoginHistoryReq struct {
+	Page     int    `json:"page" form:"page" binding:"omitempty" example:"1"`
+	PageSize int    `json:"page_size" form:"page_size" binding:"omitempty,min=1,max=100" example:"1-100，可选"`
+	UID      uint   `json:"uid" form:"uid" binding:"omitempty" example:"2，按用户ID过滤，可选"`
+	Username string `json:"username" form:"username" binding:"omitempty,max=255" example:"金晖，按登录时填写的用户名过滤，可选"`
+	IP       string `json:"ip" form:"ip" binding:"omitempty,max=64" example:"127.0.0.1，可选"`
+	Success  *bool  `json:"success" form:"success" binding:"omitempty" example:"false，只看成功或失败的记录，可选"`
+	From     string `json:"from" form:"from" binding:"omitempty" example:"2025-01-01或2025-01-01 08:00:00，可选"`
+	To       string `json:"to" form:"to" binding:"omitempty" example:"2025-02-01或2025-02-01 08:00:00，不含该时间，可选"`
+}
+
+type LoginHistoryData struct {
+	ID        uint   `json:"id" example:"1"`
+	UID       uint   `json:"uid,omitempty" example:"2"`
+	Username  string `json:"username" example:"金晖"`
+	Success   bool   `json:"success" example:"false"`
+	Reason    string `json:"reason,omitempty" example:"bad_credentials、not_approved、rejected、must_change_password、user_locked、ip_locked、bad_mfa_code或error"`
+	IP        string `json:"ip" example:"127.0.0.1"`
+	UserAgent string `json:"user_agent" example:"Mozilla/5.0"`
+	CreatedAt string `json:"created_at" example:"2025-01-01 08:00:00"`
+}
+
+type LoginHistoryPageData struct {
+	List     []*LoginHistoryData `json:"list"`
+	Total    int                 `json:"total" example:"137"`
+	Page     int                 `json:"page" example:"1"`
+	PageSize int                 `json:"pageSize" example:"20"`
+	Pages    int                 `json:"pages" example:"7"`
+}
+
+type LoginHistoryResp struct {
+	Code    int                   `json:"code" example:"200"`
+	Message string                `json:"message" example:"操作成功"`
+	Success bool                  `json:"success" example:"true"`
+	Data    *LoginHistoryPageData `json:"data"`
+}
+
+type RecentLoginResp struct {
+	Code    int                 `json:"code" example:"200"`
+	Message string              `json:"message" example:"操作成功"`
+	Success bool                `json:"success" example:"true"`
+	Data    []*LoginHistoryData `json:"data"`
+}
+
+// 锁定对象的类型
+const (
+	LockTypeUser = "user"
+	LockTypeIP   = "ip"
+)
+
+type LockData struct {
+	Type        string `json:"type" example:"user或ip"`
+	UID         uint   `json:"uid,omitempty" example:"2"` // type为user且用户存在时返回
+	Username    string `json:"username,omitempty" example:"金晖，type为user时返回"`
+	IP          string `json:"ip,omitempty" example:"127.0.0.1，type为ip时返回"`
+	Lockouts    int    `json:"lockouts" example:"1"`
+	LockedUntil string `json:"locked_until" example:"2025-01-01 08:00:00"`
+}
+
+type LockListResp struct {
+	Code    int         `json:"code" example:"200"`
+	Message string      `json:"message" example:"操作成功"`
+	Success bool        `json:"success" example:"true"`
+	Data    []*LockData `json:"data"`
+}
+
+type UnlockIPReq struct {
+	IP string `json:"ip" form:"ip" binding:"required,ip" example:"127.0.0.1"`
+}
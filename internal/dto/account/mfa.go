@@ -0,0 +1,78 @@
+package account
+
+type MFALoginReq struct {
+	MFAToken string `form:"mfa_token" json:"mfa_token" binding:"required" example:"mfa_token"`
+	// 验证器应用中的6位验证码，或者一个未使用过的恢复码
+	Code string `form:"code" json:"code" binding:"required,max=32" example:"123456"`
+}
+
+type MFATokenReq struct {
+	MFAToken string `form:"mfa_token" json:"mfa_token" binding:"required" example:"mfa_token"`
+}
+
+type MFACodeReq struct {
+	Code string `form:"code" json:"code" binding:"required,max=32" example:"123456"`
+}
+
+type MFAConfirmTokenReq struct {
+	MFAToken string `form:"mfa_token" json:"mfa_token" binding:"required" example:"mfa_token"`
+	Code     string `form:"code" json:"code" binding:"required,numeric,len=6" example:"123456"`
+}
+
+type MFADisableReq struct {
+	Password string `form:"password" json:"password" binding:"required" example:"923845797582"`
+	Code     string `form:"code" json:"code" binding:"required,max=32" example:"123456，验证码或恢复码"`
+}
+
+type MFAEnrollData struct {
+	// base32 编码的密钥，无法扫描二维码时手动输入
+	Secret string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	// 前端渲染为二维码供验证器应用扫描
+	ProvisioningURI string `json:"provisioning_uri" example:"otpauth://totp/%E4%BF%A1%E5%BE%B7:admin?secret=...&issuer=..."`
+}
+
+type MFAEnrollResp struct {
+	Code    int            `json:"code" example:"200"`
+	Message string         `json:"message" example:"操作成功"`
+	Success bool           `json:"success" example:"true"`
+	Data    *MFAEnrollData `json:"data"`
+}
+
+type MFARecoveryCodesData struct {
+	// 恢复码只在这里返回一次，每个只能使用一次
+	RecoveryCodes []string `json:"recovery_codes" example:"abcde-fghjk"`
+}
+
+type MFARecoveryCodesResp struct {
+	Code    int                   `json:"code" example:"200"`
+	Message string                `json:"message" example:"操作成功"`
+	Success bool                  `json:"success" example:"true"`
+	Data    *MFARecoveryCodesData `json:"data"`
+}
+
+// MFAConfirmLoginData 登录时绑定验证器，确认后同时完成登录
+type MFAConfirmLoginData struct {
+	RecoveryCodes []string   `json:"recovery_codes" example:"abcde-fghjk"`
+	Login         *LoginData `json:"login"`
+}
+
+type MFAConfirmLoginResp struct {
+	Code    int                  `json:"code" example:"200"`
+	Message string               `json:"message" example:"操作成功"`
+	Success bool                 `json:"success" example:"true"`
+	Data    *MFAConfirmLoginData `json:"data"`
+}
+
+type MFAStatusData struct {
+	Enabled bool `json:"enabled" example:"true"`
+	// 该账号是否必须开启二次验证
+	Required          bool  `json:"required" example:"true"`
+	RecoveryCodesLeft int64 `json:"recovery_codes_left" example:"8"`
+}
+
+type MFAStatusResp struct {
+	Code    int            `json:"code" example:"200"`
+	Message string         `json:"message" example:"操作成功"`
+	Success bool           `json:"success" example:"true"`
+	Data    *MFAStatusData `json:"data"`
+}
@@ -0,0 +1,51 @@
+package account
+
+// 注册状态
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+type ProfileData struct {
+	UID            uint   `json:"uid" example:"2"`
+	Username       string `json:"username" example:"金晖，账号名称"`
+	Name           string `json:"name" example:"金晖，真实姓名"`
+	Phone          string `json:"phone" example:"13065859690"`
+	Email          string `json:"email" example:"1921771473@qq.com"`
+	CompanyName    string `json:"company_name" example:"宁波鲍斯产业链服务有限公司"`
+	CompanyAddress string `json:"company_address" example:"浙江省宁波市奉化区江口街道聚潮路55号"`
+	PriceLevel     string `json:"price_level" example:"price_1"`
+	PriceLevelName string `json:"price_level_name" example:"价格等级1"`
+	Status         string `json:"status" example:"pending、approved或rejected"`
+	Why            string `json:"why" example:"审核通过/拒绝的原因"`
+	HandledAt      string `json:"handled_at" example:"2020-09-08 09:08:09"`
+	CreatedAt      string `json:"created_at" example:"2020-09-08 09:08:09"`
+}
+
+type ProfileResp struct {
+	Code    int          `json:"code" example:"200"`
+	Message string       `json:"message" example:"操作成功"`
+	Success bool         `json:"success" example:"true"`
+	Data    *ProfileData `json:"data"`
+}
+
+// UpdateProfileReq 字段的校验规则与 RegisterReq 保持一致，不传的字段保持不变
+type UpdateProfileReq struct {
+	Name  *string `form:"name" json:"name" binding:"omitempty,min=1,max=32" example:"金晖，可选"`
+	Phone *string `form:"phone" json:"phone" binding:"omitempty,numeric,min=7,max=20" example:"13065859690，可选"`
+	Email *string `form:"email" json:"email" binding:"omitempty,email,max=255" example:"1921771473@qq.com，可选，传空字符串表示清空邮箱"`
+}
+
+type ChangePasswordReq struct {
+	OldPassword       string `form:"old_password" json:"old_password" binding:"required" example:"923845797582"`
+	Password          string `form:"password" json:"password" binding:"required,min=6,max=64" example:"这是一个新密码"`
+	ConfirmedPassword string `form:"confirmed_password" json:"confirmed_password" binding:"required" example:"这是一个新密码"`
+}
+
+type ChangePasswordResp struct {
+	Code    int        `json:"code" example:"200"`
+	Message string     `json:"message" example:"操作成功"`
+	Success bool       `json:"success" example:"true"`
+	Data    *TokenData `json:"data"`
+}
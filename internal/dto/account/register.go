@@ -1,14 +1,14 @@
 package account
 
 type RegisterReq struct {
-	Name              string `form:"name" json:"name" binding:"required" example:"金晖，真实姓名"`
-	Username          string `form:"username" json:"username" binding:"required" example:"金晖，账号名称"`
-	Password          string `form:"password" json:"password" binding:"required" example:"923845797582"`
+	Name              string `form:"name" json:"name" binding:"required,max=32" example:"金晖，真实姓名"`
+	Username          string `form:"username" json:"username" binding:"required,max=255" example:"金晖，账号名称"`
+	Password          string `form:"password" json:"password" binding:"required,min=6,max=64" example:"923845797582"`
 	ConfirmedPassword string `form:"confirmed_password" json:"confirmed_password" binding:"required" example:"923845797582"`
-	Phone             string `form:"phone" json:"phone" binding:"required" example:"13065859690"`
-	CompanyName       string `form:"company_name" json:"company_name" binding:"required" example:"宁波鲍斯产业链服务有限公司"`
-	CompanyAddress    string `form:"company_address" json:"company_address,omitempty" example:"浙江省宁波市奉化区江口街道聚潮路55号，可选"`
-	Email             string `form:"email" json:"email,omitempty" binding:"omitempty" example:"1921771473@qq.com，可选"`
+	Phone             string `form:"phone" json:"phone" binding:"required,numeric,min=7,max=20" example:"13065859690"`
+	CompanyName       string `form:"company_name" json:"company_name" binding:"required,max=255" example:"宁波鲍斯产业链服务有限公司"`
+	CompanyAddress    string `form:"company_address" json:"company_address,omitempty" binding:"omitempty,max=255" example:"浙江省宁波市奉化区江口街道聚潮路55号，可选"`
+	Email             string `form:"email" json:"email,omitempty" binding:"omitempty,email,max=255" example:"1921771473@qq.com，可选"`
 }
 
 type RegisterResp struct {
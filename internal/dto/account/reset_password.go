@@ -0,0 +1,13 @@
+package account
+
+type ResetPasswordData struct {
+	// 一次性密码只在这里返回一次，用户下次登录时必须修改
+	Password string `json:"password" example:"Xk3mP9qR2tWz"`
+}
+
+type ResetPasswordResp struct {
+	Code    int                `json:"code" example:"200"`
+	Message string             `json:"message" example:"操作成功"`
+	Success bool               `json:"success" example:"true"`
+	Data    *ResetPasswordData `json:"data"`
+}
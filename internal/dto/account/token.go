@@ -0,0 +1,26 @@
+package account
+
+type RefreshReq struct {
+	RefreshToken string `form:"refresh_token" json:"refresh_token" binding:"required" example:"refresh_token"`
+}
+
+type TokenData struct {
+	AccessToken  string `json:"access_token" example:"jwt_token"`
+	RefreshToken string `json:"refresh_token" example:"refresh_token"`
+	// access token 的有效期，单位秒
+	ExpiresIn int64 `json:"expires_in" example:"900"`
+}
+
+type RefreshResp struct {
+	Code    int        `json:"code" example:"200"`
+	Message string     `json:"message" example:"操作成功"`
+	Success bool       `json:"success" example:"true"`
+	Data    *TokenData `json:"data"`
+}
+
+type LogoutReq struct {
+	// 同时吊销该 refresh token，不传时只注销当前的 access token
+	RefreshToken string `form:"refresh_token" json:"refresh_token" example:"refresh_token"`
+	// 为 true 时注销该用户在所有设备上的登录
+	All bool `form:"all" json:"all" example:"false"`
+}
@@ -1,5 +1,5 @@
 package account
 
 type UpdatePasswordReq struct {
-	Password string `json:"password" form:"password" binding:"required" example:"这是一个密码"`
+	Password string `json:"password" form:"password" binding:"required,min=6,max=64" example:"这是一个密码"`
 }
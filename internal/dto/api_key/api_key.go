@@ -0,0 +1,68 @@
+package api_key
+
+type ScopeData struct {
+	Code string `json:"code" example:"solution:query"`
+	Name string `json:"name" example:"查询选型方案"`
+}
+
+type ScopeListResp struct {
+	Code    int          `json:"code" example:"200"`
+	Message string       `json:"message" example:"操作成功"`
+	Success bool         `json:"success" example:"true"`
+	Data    []*ScopeData `json:"data"`
+}
+
+type ListReq struct {
+	CompanyID uint `json:"company_id" form:"company_id" binding:"omitempty" example:"3，按公司过滤，可选"`
+}
+
+type APIKeyData struct {
+	ID         uint     `json:"id" example:"1"`
+	KeyID      string   `json:"key_id" example:"xd_abcdefghjkmnpqrstuvw"`
+	Name       string   `json:"name" example:"ERP"`
+	CompanyID  uint     `json:"company_id" example:"3"`
+	Scopes     []string `json:"scopes" example:"solution:query,price:query"`
+	Active     bool     `json:"active" example:"true"`
+	ExpiresAt  string   `json:"expires_at" example:"2026-01-01 00:00:00，为空表示永不过期"`
+	RevokedAt  string   `json:"revoked_at" example:""`
+	LastUsedAt string   `json:"last_used_at" example:"2025-01-01 08:00:00"`
+	LastUsedIP string   `json:"last_used_ip" example:"10.0.0.8"`
+	CreatedBy  uint     `json:"created_by" example:"1"`
+	CreatedAt  string   `json:"created_at" example:"2025-01-01 08:00:00"`
+}
+
+type ListResp struct {
+	Code    int           `json:"code" example:"200"`
+	Message string        `json:"message" example:"操作成功"`
+	Success bool          `json:"success" example:"true"`
+	Data    []*APIKeyData `json:"data"`
+}
+
+type CreateReq struct {
+	Name      string   `json:"name" form:"name" binding:"required,max=63" example:"ERP"`
+	CompanyID uint     `json:"company_id" form:"company_id" binding:"required" example:"3"`
+	Scopes    []string `json:"scopes" form:"scopes" binding:"required,min=1" example:"solution:query"`
+	ExpiresAt string   `json:"expires_at" form:"expires_at" binding:"omitempty" example:"2026-01-01或2026-01-01 00:00:00，不传表示永不过期"`
+}
+
+type UpdateReq struct {
+	Name string `json:"name" form:"name" binding:"omitempty,max=63" example:"ERP"`
+	// 不为空时整体替换访问范围
+	Scopes []string `json:"scopes" form:"scopes" binding:"omitempty,min=1" example:"solution:query"`
+	// 不传表示不修改，传空字符串表示永不过期
+	ExpiresAt *string `json:"expires_at" form:"expires_at" binding:"omitempty" example:"2026-01-01或2026-01-01 00:00:00"`
+}
+
+// SecretData 签名密钥只在创建和轮换时返回这一次，请提示管理员妥善保存
+type SecretData struct {
+	ID     uint   `json:"id" example:"1"`
+	KeyID  string `json:"key_id" example:"xd_abcdefghjkmnpqrstuvw"`
+	Secret string `json:"secret" example:"Zm9vYmFy..."`
+}
+
+type SecretResp struct {
+	Code    int         `json:"code" example:"200"`
+	Message string      `json:"message" example:"操作成功"`
+	Success bool        `json:"success" example:"true"`
+	Data    *SecretData `json:"data"`
+}
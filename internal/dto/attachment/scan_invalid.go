@@ -13,6 +13,38 @@ type OrphanData struct {
 	OrphanFiles   []string        `json:"orphan_files"`   //数据库没有，磁盘有
 }
 
+// DanglingRecord 业务对象已经不存在的附件
+type DanglingRecord struct {
+	ID           uint   `json:"id"`
+	Filename     string `json:"filename"`
+	StoragePath  string `json:"storage_path"`
+	BusinessType string `json:"business_type"`
+	BusinessID   uint   `json:"business_id"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// MissingRecord 缺少附件的业务对象
+type MissingRecord struct {
+	BusinessType string `json:"business_type"`
+	BusinessID   uint   `json:"business_id"`
+}
+
+type DanglingData struct {
+	DanglingRecords []*DanglingRecord `json:"dangling_records"` // 附件指向的设备类型、筛选图片或分组不存在
+	MissingRecords  []*MissingRecord  `json:"missing_records"`  // 设备类型缺少主图或导入的Excel，筛选图片缺少图片
+	// 还未执行完的跨库操作数，不为0时以上结果可能只是暂时的
+	PendingOperations int64 `json:"pending_operations"`
+	// 重试次数用完仍然失败的跨库操作数，需要人工处理
+	FailedOperations int64 `json:"failed_operations"`
+}
+
+type ScanDanglingResp struct {
+	Code    int           `json:"code" example:"200"`
+	Message string        `json:"message" example:"操作成功"`
+	Success bool          `json:"success" example:"true"`
+	Data    *DanglingData `json:"data"`
+}
+
 type ScanInvalidResp struct {
 	Code    int         `json:"code" example:"200"`
 	Message string      `json:"message" example:"操作成功"`
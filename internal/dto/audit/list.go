@@ -0,0 +1,50 @@
+package audit
+
+import "encoding/json"
+
+// FilterReq 审计日志列表和导出共用的查询条件
+type FilterReq struct {
+	ActorUID   uint   `json:"actor_uid" form:"actor_uid" binding:"omitempty" example:"1，按操作人过滤，可选"`
+	Action     string `json:"action" form:"action" binding:"omitempty,max=63" example:"user.approve，可选"`
+	EntityType string `json:"entity_type" form:"entity_type" binding:"omitempty,max=31" example:"user，可选"`
+	EntityID   string `json:"entity_id" form:"entity_id" binding:"omitempty,max=63" example:"12，需要同时指定entity_type，可选"`
+	RequestID  string `json:"request_id" form:"request_id" binding:"omitempty,max=64" example:"响应头X-Request-ID的值，可选"`
+	From       string `json:"from" form:"from" binding:"omitempty" example:"2025-01-01或2025-01-01 08:00:00，可选"`
+	To         string `json:"to" form:"to" binding:"omitempty" example:"2025-02-01或2025-02-01 08:00:00，不含该时间，可选"`
+}
+
+type ListReq struct {
+	Page     int `json:"page" form:"page" binding:"omitempty" example:"1"`
+	PageSize int `json:"page_size" form:"page_size" binding:"omitempty,min=1,max=100" example:"1-100，可选"`
+	FilterReq
+}
+
+type ListData struct {
+	ID         uint            `json:"id" example:"1"`
+	ActorUID   uint            `json:"actor_uid" example:"1"`
+	ActorName  string          `json:"actor_name" example:"admin"`
+	Action     string          `json:"action" example:"user.approve"`
+	EntityType string          `json:"entity_type" example:"user"`
+	EntityID   string          `json:"entity_id" example:"12"`
+	Before     json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After      json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	Diff       json.RawMessage `json:"diff,omitempty" swaggertype:"object"`
+	IP         string          `json:"ip" example:"127.0.0.1"`
+	RequestID  string          `json:"request_id" example:"0b3f6c1e-6a4c-4a57-9f35-1f7f0c1b2d3e"`
+	CreatedAt  string          `json:"created_at" example:"2025-01-01 08:00:00"`
+}
+
+type ListPageData struct {
+	List     []*ListData `json:"list"`
+	Total    int         `json:"total" example:"137"`
+	Page     int         `json:"page" example:"1"`
+	PageSize int         `json:"pageSize" example:"20"`
+	Pages    int         `json:"pages" example:"7"`
+}
+
+type ListResp struct {
+	Code    int           `json:"code" example:"200"`
+	Message string        `json:"message" example:"操作成功"`
+	Success bool          `json:"success" example:"true"`
+	Data    *ListPageData `json:"data"`
+}
@@ -0,0 +1,34 @@
+package company
+
+type ActivityReq struct {
+	Page     int    `json:"page" form:"page" binding:"omitempty" example:"1"`
+	PageSize int    `json:"page_size" form:"page_size" binding:"omitempty,min=1,max=100" example:"1-100，可选"`
+	UserID   uint   `json:"user_id" form:"user_id" binding:"omitempty" example:"2，只看某个用户，可选"`
+	From     string `json:"from" form:"from" binding:"omitempty" example:"2025-01-01或2025-01-01 08:00:00，可选"`
+	To       string `json:"to" form:"to" binding:"omitempty" example:"2025-02-01或2025-02-01 08:00:00，不含该时间，可选"`
+}
+
+type ActivityData struct {
+	ID             uint   `json:"id" example:"1"`
+	UserID         uint   `json:"user_id" example:"2"`
+	Username       string `json:"username" example:"张三，账号名称"`
+	Name           string `json:"name" example:"张三，真实名称"`
+	DeviceTypeID   uint   `json:"device_type_id" example:"5"`
+	DeviceTypeName string `json:"device_type_name" example:"车削刀杆"`
+	AccessedAt     string `json:"accessed_at" example:"2025-01-01 08:00:00"`
+}
+
+type ActivityPageData struct {
+	List     []*ActivityData `json:"list"`
+	Total    int             `json:"total" example:"137"`
+	Page     int             `json:"page" example:"1"`
+	PageSize int             `json:"pageSize" example:"20"`
+	Pages    int             `json:"pages" example:"7"`
+}
+
+type ActivityResp struct {
+	Code    int               `json:"code" example:"200"`
+	Message string            `json:"message" example:"操作成功"`
+	Success bool              `json:"success" example:"true"`
+	Data    *ActivityPageData `json:"data"`
+}
@@ -6,11 +6,13 @@ type ListReq struct {
 }
 
 type ListData struct {
-	ID         uint   `json:"id" example:"1"`
-	Name       string `json:"name" example:"宁波鲍斯产业链有限公司"`
-	Address    string `json:"address" example:"浙江省宁波市奉化区江口街道聚潮路55号"`
-	PriceLevel string `json:"price_level" example:"折扣等级1 折扣等级2 折扣等级3 折扣等级4"`
-	CreatedAt  string `json:"created_at" example:"2021-09-09 09:09:09"`
+	ID             uint   `json:"id" example:"1"`
+	Name           string `json:"name" example:"宁波鲍斯产业链有限公司"`
+	Address        string `json:"address" example:"浙江省宁波市奉化区江口街道聚潮路55号"`
+	PriceLevel     string `json:"price_level" example:"价格等级1"` // 价格等级的展示名称
+	PriceLevelCode string `json:"price_level_code" example:"price_1"`
+	Notes          string `json:"notes" example:"华东区代理商"`
+	CreatedAt      string `json:"created_at" example:"2021-09-09 09:09:09"`
 }
 
 type ListPageData struct {
@@ -0,0 +1,117 @@
+package company
+
+type CreateReq struct {
+	Name       string `json:"name" form:"name" binding:"required,max=255" example:"宁波鲍斯产业链有限公司"`
+	Address    string `json:"address" form:"address" binding:"omitempty,max=255" example:"浙江省宁波市奉化区江口街道聚潮路55号"`
+	PriceLevel string `json:"price_level" form:"price_level" binding:"omitempty,max=31" example:"price_1，可选，默认为price_1"`
+	Notes      string `json:"notes" form:"notes" binding:"omitempty,max=500" example:"华东区代理商"`
+}
+
+type CreateData struct {
+	ID uint `json:"id" example:"3"`
+}
+
+type CreateResp struct {
+	Code    int         `json:"code" example:"200"`
+	Message string      `json:"message" example:"操作成功"`
+	Success bool        `json:"success" example:"true"`
+	Data    *CreateData `json:"data"`
+}
+
+// UpdateReq 字段为空时不修改；address 和 notes 传空字符串时清空
+type UpdateReq struct {
+	Name       string  `json:"name" form:"name" binding:"omitempty,max=255" example:"宁波鲍斯产业链有限公司"`
+	Address    *string `json:"address" form:"address" binding:"omitempty,max=255" example:"浙江省宁波市奉化区江口街道聚潮路55号"`
+	PriceLevel string  `json:"price_level" form:"price_level" binding:"omitempty,max=31" example:"price_2"`
+	Notes      *string `json:"notes" form:"notes" binding:"omitempty,max=500" example:"华东区代理商"`
+}
+
+type DetailData struct {
+	ListData
+	UserCount int64 `json:"user_count" example:"12"`
+}
+
+type DetailResp struct {
+	Code    int         `json:"code" example:"200"`
+	Message string      `json:"message" example:"操作成功"`
+	Success bool        `json:"success" example:"true"`
+	Data    *DetailData `json:"data"`
+}
+
+type MemberListReq struct {
+	Page     int `json:"page" form:"page" binding:"omitempty" example:"1"`
+	PageSize int `json:"page_size" form:"page_size" binding:"omitempty,min=1,max=100" example:"1-100，可选"`
+}
+
+type MemberData struct {
+	ID        uint   `json:"id" example:"2"`
+	Username  string `json:"username" example:"张三，账号名称"`
+	Name      string `json:"name" example:"张三，真实名称"`
+	Phone     string `json:"phone" example:"13800138000"`
+	Email     string `json:"email" example:"13800138000@qq.com"`
+	Status    string `json:"status" example:"pending或approved或rejected"`
+	CreatedAt string `json:"created_at" example:"2020-09-08 09:08:09"`
+}
+
+type MemberPageData struct {
+	List     []*MemberData `json:"list"`
+	Total    int           `json:"total" example:"12"`
+	Page     int           `json:"page" example:"1"`
+	PageSize int           `json:"pageSize" example:"20"`
+	Pages    int           `json:"pages" example:"1"`
+}
+
+type MemberListResp struct {
+	Code    int             `json:"code" example:"200"`
+	Message string          `json:"message" example:"操作成功"`
+	Success bool            `json:"success" example:"true"`
+	Data    *MemberPageData `json:"data"`
+}
+
+type MergeReq struct {
+	TargetID  uint   `json:"target_id" form:"target_id" binding:"required,min=1" example:"3，保留的公司"`
+	SourceIDs []uint `json:"source_ids" form:"source_ids" binding:"required,min=1,max=50,dive,min=1" example:"7,9，被合并后删除的公司"`
+}
+
+type MergeData struct {
+	MovedUsers      int64 `json:"moved_users" example:"4"`
+	MovedAccessLogs int64 `json:"moved_access_logs" example:"120"`
+	MovedAPIKeys    int64 `json:"moved_api_keys" example:"0"`
+	// 转到保留公司的产品目录可见性规则，保留公司在同一分组或设备类型上已有规则的不计入
+	MovedVisibilityRules int64 `json:"moved_visibility_rules" example:"0"`
+	// 被合并的公司原来的公司管理员，合并后不再是公司管理员，需要时重新设置
+	DemotedCompanyAdmins []uint `json:"demoted_company_admins" example:"12"`
+}
+
+type MergeResp struct {
+	Code    int        `json:"code" example:"200"`
+	Message string     `json:"message" example:"操作成功"`
+	Success bool       `json:"success" example:"true"`
+	Data    *MergeData `json:"data"`
+}
+
+type DuplicateReq struct {
+	Threshold float64 `json:"threshold" form:"threshold" binding:"omitempty,gt=0,lte=1" example:"0.8，可选，名称相似度阈值，默认为0.8"`
+}
+
+type DuplicateCompany struct {
+	ID             uint   `json:"id" example:"7"`
+	Name           string `json:"name" example:"宁波鲍斯产业链服务有限公司"`
+	Address        string `json:"address" example:"浙江省宁波市奉化区"`
+	PriceLevelCode string `json:"price_level_code" example:"price_1"`
+	UserCount      int64  `json:"user_count" example:"1"`
+	CreatedAt      string `json:"created_at" example:"2021-09-09 09:09:09"`
+}
+
+type DuplicateGroup struct {
+	// 组内两两之间的最高相似度
+	Similarity float64             `json:"similarity" example:"0.92"`
+	Companies  []*DuplicateCompany `json:"companies"`
+}
+
+type DuplicateResp struct {
+	Code    int               `json:"code" example:"200"`
+	Message string            `json:"message" example:"操作成功"`
+	Success bool              `json:"success" example:"true"`
+	Data    []*DuplicateGroup `json:"data"`
+}
@@ -0,0 +1,48 @@
+package company
+
+type SaveOverrideReq struct {
+	ProductCode string   `json:"product_code" form:"product_code" binding:"required,max=31" example:"WGC001547"`
+	Price       *float64 `json:"price" form:"price" binding:"required,min=0" example:"1099.00"`
+	Remark      string   `json:"remark" form:"remark" binding:"omitempty,max=255" example:"2025年框架合同，可选"`
+}
+
+type OverrideData struct {
+	ID          uint    `json:"id" example:"1"`
+	ProductCode string  `json:"product_code" example:"WGC001547"`
+	Price       float64 `json:"price" example:"1099.00"`
+	Remark      string  `json:"remark" example:"2025年框架合同"`
+	UpdatedAt   string  `json:"updated_at" example:"2025-01-01 09:00:00"`
+}
+
+type OverrideListResp struct {
+	Code    int             `json:"code" example:"200"`
+	Message string          `json:"message" example:"操作成功"`
+	Success bool            `json:"success" example:"true"`
+	Data    []*OverrideData `json:"data"`
+}
+
+type CreateRuleReq struct {
+	ScopeType       string  `json:"scope_type" form:"scope_type" binding:"required,oneof=product_prefix group" example:"product_prefix或group"`
+	ProductPrefix   string  `json:"product_prefix" form:"product_prefix" binding:"omitempty,max=31" example:"WGC，scope_type为product_prefix时必填"`
+	GroupID         uint    `json:"group_id" form:"group_id" binding:"omitempty,min=1" example:"3，scope_type为group时必填"`
+	DiscountPercent float64 `json:"discount_percent" form:"discount_percent" binding:"required,gt=0,lt=100" example:"10，表示在价格等级的价格上减10%"`
+	Remark          string  `json:"remark" form:"remark" binding:"omitempty,max=255" example:"可选"`
+}
+
+type RuleData struct {
+	ID              uint    `json:"id" example:"1"`
+	ScopeType       string  `json:"scope_type" example:"group"`
+	ProductPrefix   string  `json:"product_prefix" example:""`
+	GroupID         uint    `json:"group_id" example:"3"`
+	GroupName       string  `json:"group_name" example:"车削刀杆"`
+	DiscountPercent float64 `json:"discount_percent" example:"10"`
+	Remark          string  `json:"remark" example:"备注"`
+	CreatedAt       string  `json:"created_at" example:"2025-01-01 09:00:00"`
+}
+
+type RuleListResp struct {
+	Code    int         `json:"code" example:"200"`
+	Message string      `json:"message" example:"操作成功"`
+	Success bool        `json:"success" example:"true"`
+	Data    []*RuleData `json:"data"`
+}
@@ -1,5 +1,5 @@
 package company
 
 type UpdatePriceLevelReq struct {
-	PriceLevel string `json:"price_level" form:"price_level" binding:"required,oneof=price_1 price_2 price_3 price_4" example:"price_1或price_2或price_3或price_4"`
+	PriceLevel string `json:"price_level" form:"price_level" binding:"required,max=31" example:"price_1，必须是已存在的价格等级编码"`
 }
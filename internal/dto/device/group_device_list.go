@@ -5,6 +5,7 @@ type GroupDeviceListData struct {
 	DeviceName string `json:"name" example:"刀柄"`
 	ImageURL   string `json:"image_url" `
 	GroupName  string `json:"group_name"`
+	Status     string `json:"status" example:"published"` // 实际状态，前台接口只会返回 published
 }
 
 type GroupDeviceListResp struct {
@@ -3,4 +3,11 @@ package device
 type ImportReq struct {
 	GroupID        uint   `json:"group_id" form:"group_id" binding:"required,min=1"`
 	DeviceTypeName string `json:"device_type_name" form:"device_type_name" binding:"required"`
+	// 可选，draft 或 published。新建的设备类型不填时为 published，已存在的设备类型不填时保持原状态
+	Status string `json:"status" form:"status" binding:"omitempty,oneof=draft published"`
+}
+
+type UpdateImportReq struct {
+	// 可选，draft 或 published，不填时保持原状态
+	Status string `json:"status" form:"status" binding:"omitempty,oneof=draft published"`
 }
@@ -6,13 +6,17 @@ type ListReq struct {
 }
 
 type ListData struct {
-	ID            uint   `json:"id"`
-	GroupName     string `json:"group_name"`     // 分组名称
-	Name          string `json:"name"`           // 设备类型名称
-	ImageURL      string `json:"image_url"`      // 设备图片
-	SolutionCount int64  `json:"solution_count"` // 条目数 (方案数量)
-	CreatedAt     string `json:"created_at"`
-	UpdatedAt     string `json:"updated_at"`
+	ID              uint   `json:"id"`
+	GroupName       string `json:"group_name"`       // 分组名称
+	Name            string `json:"name"`             // 设备类型名称
+	ImageURL        string `json:"image_url"`        // 设备图片
+	SolutionCount   int64  `json:"solution_count"`   // 条目数 (方案数量)
+	Status          string `json:"status"`           // 设置的状态: draft, published, archived
+	EffectiveStatus string `json:"effective_status"` // 考虑计划时间后的实际状态，前台只能看到 published
+	PublishAt       string `json:"publish_at"`       // 计划发布时间，为空表示未设置
+	UnpublishAt     string `json:"unpublish_at"`     // 计划下架时间，为空表示未设置
+	CreatedAt       string `json:"created_at"`
+	UpdatedAt       string `json:"updated_at"`
 }
 
 type ListPageData struct {
@@ -8,3 +8,11 @@ package device
 type ChangeGroupReq struct {
 	GroupID uint `json:"group_id" form:"group_id" binding:"required,min=1" example:"1"`
 }
+
+// MoveReq 批量移动设备类型或调整顺序的请求体
+type MoveReq struct {
+	DeviceTypeIDs []uint `json:"device_type_ids" form:"device_type_ids" binding:"required,min=1,dive,min=1" example:"3,5"`
+	GroupID       uint   `json:"group_id" form:"group_id" binding:"required,min=1" example:"1"`
+	// 在目标分组中的位置，从0开始，多个设备类型按请求中的顺序连续排列。不填或超出范围时排在最后
+	Position *int `json:"position" form:"position" binding:"omitempty,min=0" example:"0"`
+}
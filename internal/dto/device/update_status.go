@@ -0,0 +1,9 @@
+package device
+
+type UpdateStatusReq struct {
+	Status string `json:"status" form:"status" binding:"required,oneof=draft published archived" example:"draft"`
+	// 计划发布时间，格式 YYYY-MM-DD 或 YYYY-MM-DD HH:MM:SS，状态为 published 时忽略
+	PublishAt string `json:"publish_at" form:"publish_at" example:"2025-01-01 08:00:00"`
+	// 计划下架时间，格式同上
+	UnpublishAt string `json:"unpublish_at" form:"unpublish_at" example:"2025-06-30"`
+}
@@ -0,0 +1,34 @@
+package group
+
+// 路径中节点的类型
+const (
+	BreadcrumbTypeGroup      = "group"
+	BreadcrumbTypeDeviceType = "device_type"
+)
+
+type BreadcrumbReq struct {
+	// group_id 和 device_type_id 必须且只能填写一个
+	GroupID      uint `json:"group_id" form:"group_id" binding:"omitempty,min=1" example:"2"`
+	DeviceTypeID uint `json:"device_type_id" form:"device_type_id" binding:"omitempty,min=1" example:"0"`
+}
+
+// BreadcrumbNode 路径中的一个节点
+type BreadcrumbNode struct {
+	Type string `json:"type" example:"group"`
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+type BreadcrumbData struct {
+	// 从最上层分组开始的完整路径（不包含 root 分组），查询设备类型时最后一个节点是设备类型本身
+	Nodes []*BreadcrumbNode `json:"nodes"`
+	// 各节点名称用 "-" 连接后的路径，与设备类型列表中的分组路径格式一致
+	Path string `json:"path" example:"一级分组-二级分组"`
+}
+
+type BreadcrumbResp struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Success bool            `json:"success"`
+	Data    *BreadcrumbData `json:"data"`
+}
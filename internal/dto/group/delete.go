@@ -0,0 +1,14 @@
+package group
+
+// 删除分组时对其下设备类型的处理方式
+const (
+	DeleteModeMove   = "move"   // 移动到目标分组
+	DeleteModeRefuse = "refuse" // 分组下还有设备类型时拒绝删除
+)
+
+type DeleteReq struct {
+	// 处理方式，默认 move
+	Mode string `json:"mode" form:"mode" binding:"omitempty,oneof=move refuse" example:"move"`
+	// mode 为 move 时接收设备类型的分组，默认 root 分组
+	TargetGroupID uint `json:"target_group_id" form:"target_group_id" binding:"omitempty,min=1" example:"1"`
+}
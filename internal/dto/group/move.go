@@ -0,0 +1,8 @@
+package group
+
+type MoveReq struct {
+	// 新的父级分组ID，不填表示不改变父级，只调整顺序
+	ParentID uint `json:"parent_id" form:"parent_id" binding:"omitempty,min=1" example:"1"`
+	// 在兄弟分组中的位置，从0开始，不填或超出范围时排在最后
+	Position *int `json:"position" form:"position" binding:"omitempty,min=0" example:"0"`
+}
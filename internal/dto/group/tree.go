@@ -1,7 +1,13 @@
 package group
 
 type TreeReq struct {
-	Icon string `json:"icon" form:"icon" binding:"required,oneof=true false" example:"true表示树状列表返回图片，false表示树状列表不返回图片"`
+	Icon string `json:"icon" form:"icon" binding:"omitempty,oneof=true false" example:"true表示树状列表返回图片，false表示树状列表不返回图片"`
+	// 是否把设备类型作为叶子节点挂到所属分组下，同时返回每个分组（含子孙分组）下的设备类型数量
+	DeviceTypes bool `json:"device_types" form:"device_types" example:"false"`
+	// 是否去掉（含子孙分组）没有设备类型的分组，root 分组始终保留
+	PruneEmpty bool `json:"prune_empty" form:"prune_empty" example:"false"`
+	// 返回的分组层数，root 分组为第1层，不填表示不限制。设备类型数量仍按完整的子孙分组统计
+	MaxDepth int `json:"max_depth" form:"max_depth" binding:"omitempty,min=1" example:"3"`
 }
 
 // GroupTreeNode 用于树状结构（前台展示/父级选择）的节点
@@ -11,6 +17,18 @@ type GroupTreeNode struct {
 	ParentID uint             `json:"parent_id"`
 	IconURL  string           `json:"icon_url,omitempty"` // omitempty 可以在不需要时隐藏
 	Children []*GroupTreeNode `json:"children,omitempty"`
+	// 以下字段只在 device_types 或 prune_empty 为 true 时返回
+	DeviceTypeCount *int                  `json:"device_type_count,omitempty"` // 分组及其子孙分组下可见的设备类型数量
+	DeviceTypes     []*TreeDeviceTypeNode `json:"device_types,omitempty"`      // 直接属于该分组的设备类型
+}
+
+// TreeDeviceTypeNode 分组树中作为叶子节点的设备类型
+type TreeDeviceTypeNode struct {
+	ID            uint   `json:"id"`
+	Name          string `json:"name"`
+	ImageURL      string `json:"image_url,omitempty"`
+	SolutionCount int64  `json:"solution_count"`
+	Status        string `json:"status"` // 生效中的状态，前台只会看到 published
 }
 
 // TreeResp 树状结构的完整响应
@@ -0,0 +1,36 @@
+package group
+
+// VisibilityListReq 可见性规则列表的查询条件，都为空时返回全部规则
+type VisibilityListReq struct {
+	GroupID      uint   `json:"group_id" form:"group_id" binding:"omitempty" example:"3，可选"`
+	DeviceTypeID uint   `json:"device_type_id" form:"device_type_id" binding:"omitempty" example:"12，可选"`
+	TargetType   string `json:"target_type" form:"target_type" binding:"omitempty,oneof=company role" example:"company或role，可选"`
+	TargetID     uint   `json:"target_id" form:"target_id" binding:"omitempty" example:"5，需要同时指定target_type，可选"`
+}
+
+// CreateVisibilityReq 创建可见性规则，group_id 和 device_type_id 只能填写一个
+type CreateVisibilityReq struct {
+	GroupID      uint   `json:"group_id" form:"group_id" binding:"omitempty,min=1" example:"3，规则同样作用于子孙分组和分组下的设备类型"`
+	DeviceTypeID uint   `json:"device_type_id" form:"device_type_id" binding:"omitempty,min=1" example:"12"`
+	TargetType   string `json:"target_type" form:"target_type" binding:"required,oneof=company role" example:"company或role"`
+	TargetID     uint   `json:"target_id" form:"target_id" binding:"required,min=1" example:"5，公司ID或角色ID"`
+}
+
+type VisibilityData struct {
+	ID             uint   `json:"id" example:"1"`
+	GroupID        uint   `json:"group_id" example:"3"`
+	GroupName      string `json:"group_name" example:"车削刀杆"`
+	DeviceTypeID   uint   `json:"device_type_id" example:"0"`
+	DeviceTypeName string `json:"device_type_name" example:""`
+	TargetType     string `json:"target_type" example:"company"`
+	TargetID       uint   `json:"target_id" example:"5"`
+	TargetName     string `json:"target_name" example:"宁波鲍斯产业链服务有限公司"`
+	CreatedAt      string `json:"created_at" example:"2025-01-01 09:00:00"`
+}
+
+type VisibilityListResp struct {
+	Code    int               `json:"code" example:"200"`
+	Message string            `json:"message" example:"操作成功"`
+	Success bool              `json:"success" example:"true"`
+	Data    []*VisibilityData `json:"data"`
+}
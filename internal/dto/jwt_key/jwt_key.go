@@ -0,0 +1,36 @@
+package jwt_key
+
+import "xinde/pkg/jwt"
+
+type KeyData struct {
+	Kid         string `json:"kid" example:"20250101-1a2b3c4d"`
+	Alg         string `json:"alg" example:"RS256"`
+	Status      string `json:"status" example:"active，active：用于签发；retired：已退役"`
+	Verifiable  bool   `json:"verifiable" example:"true"` // 是否仍可用于验证token
+	VerifyUntil string `json:"verify_until" example:"2025-01-01 08:16:00，退役密钥可用于验证的截止时间"`
+	RetiredAt   string `json:"retired_at" example:""`
+	CreatedAt   string `json:"created_at" example:"2025-01-01 08:00:00"`
+}
+
+type ListResp struct {
+	Code    int        `json:"code" example:"200"`
+	Message string     `json:"message" example:"操作成功"`
+	Success bool       `json:"success" example:"true"`
+	Data    []*KeyData `json:"data"`
+}
+
+type RotateReq struct {
+	Alg string `json:"alg" form:"alg" binding:"omitempty" example:"RS256，可选HS256、RS256或EdDSA，为空时使用配置的jwt.algorithm"`
+}
+
+type RotateResp struct {
+	Code    int      `json:"code" example:"200"`
+	Message string   `json:"message" example:"操作成功"`
+	Success bool     `json:"success" example:"true"`
+	Data    *KeyData `json:"data"`
+}
+
+// JWKSResp JSON Web Key Set（RFC 7517），不使用统一的响应格式，供标准的 JWT 库直接读取
+type JWKSResp struct {
+	Keys []*jwt.JWK `json:"keys"`
+}
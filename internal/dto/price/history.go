@@ -0,0 +1,32 @@
+package price
+
+const (
+	HistoryStatusScheduled  = "scheduled"  // 尚未到生效时间
+	HistoryStatusActive     = "active"     // 当前生效的价格
+	HistoryStatusSuperseded = "superseded" // 已被更新的价格取代
+)
+
+type HistoryReq struct {
+	ProductCode string `json:"product_code" form:"product_code" binding:"required" example:"WGC001547"`
+}
+
+type HistoryData struct {
+	ID             uint          `json:"id" example:"1"`
+	ProductCode    string        `json:"product_code" example:"WGC001547"`
+	Unit           string        `json:"unit" example:"PCS"`
+	SpecCode       string        `json:"spec_code" example:"SDQCR1212H07"`
+	Prices         []*LevelPrice `json:"prices"` // 只包含本次变更涉及的价格等级
+	EffectiveFrom  string        `json:"effective_from" example:"2025-01-01 00:00:00"`
+	Status         string        `json:"status" example:"active"` // scheduled, active, superseded
+	AttachmentID   uint          `json:"attachment_id" example:"12"`
+	AttachmentName string        `json:"attachment_name" example:"2025Q1价格表.xlsx"`
+	Operator       string        `json:"operator" example:"管理员"`
+	CreatedAt      string        `json:"created_at" example:"2024-12-20 10:30:00"`
+}
+
+type HistoryResp struct {
+	Code    int            `json:"code" example:"200"`
+	Message string         `json:"message" example:"操作成功"`
+	Success bool           `json:"success" example:"true"`
+	Data    []*HistoryData `json:"data"`
+}
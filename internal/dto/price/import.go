@@ -0,0 +1,33 @@
+package price
+
+const (
+	ImportModeStrict  = "strict"
+	ImportModeLenient = "lenient"
+)
+
+type ImportReq struct {
+	Mode string `json:"mode" form:"mode" binding:"omitempty,oneof=strict lenient" example:"strict表示有任意错误行则全部不导入，lenient表示跳过错误行，可选，默认strict"`
+}
+
+// ImportRowError 单行数据的校验错误
+type ImportRowError struct {
+	Row         int    `json:"row" example:"3"` // Excel中的行号，从1开始，包含表头
+	ProductCode string `json:"product_code" example:"WGC001547"`
+	Reason      string `json:"reason" example:"price_2不是有效的数字"`
+}
+
+type ImportResult struct {
+	Mode          string            `json:"mode" example:"strict"`
+	Total         int               `json:"total" example:"235"`     // 有效数据行数（不含表头和空行）
+	Succeeded     int               `json:"succeeded" example:"233"` // 实际写入的行数
+	Failed        int               `json:"failed" example:"2"`
+	Errors        []*ImportRowError `json:"errors"`
+	ErrorReportID uint              `json:"error_report_id,omitempty" example:"12"` // 错误报告附件ID，通过 /admin/attachment/download/{id} 下载
+}
+
+type ImportResp struct {
+	Code    int           `json:"code" example:"200"`
+	Message string        `json:"message" example:"操作成功"`
+	Success bool          `json:"success" example:"true"`
+	Data    *ImportResult `json:"data"`
+}
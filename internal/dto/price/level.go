@@ -0,0 +1,33 @@
+package price
+
+type LevelData struct {
+	ID        uint   `json:"id" example:"1"`
+	Code      string `json:"code" example:"price_1"`
+	Name      string `json:"name" example:"价格等级1"`
+	SortOrder int    `json:"sort_order" example:"1"`
+}
+
+type LevelListResp struct {
+	Code    int          `json:"code" example:"200"`
+	Message string       `json:"message" example:"操作成功"`
+	Success bool         `json:"success" example:"true"`
+	Data    []*LevelData `json:"data"`
+}
+
+type CreateLevelReq struct {
+	Code      string `json:"code" form:"code" binding:"required,max=31" example:"price_5，创建后不可修改"`
+	Name      string `json:"name" form:"name" binding:"required,max=63" example:"价格等级5"`
+	SortOrder int    `json:"sort_order" form:"sort_order" binding:"omitempty" example:"5，数值越小越靠前，可选"`
+}
+
+type UpdateLevelReq struct {
+	Name      string `json:"name" form:"name" binding:"omitempty,max=63" example:"VIP价格"`
+	SortOrder *int   `json:"sort_order" form:"sort_order" binding:"omitempty" example:"1"`
+}
+
+// LevelPrice 某个价格等级下的价格
+type LevelPrice struct {
+	LevelCode string  `json:"level_code" example:"price_1"`
+	LevelName string  `json:"level_name" example:"价格等级1"`
+	Price     float64 `json:"price" example:"1571.30"`
+}
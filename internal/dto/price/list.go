@@ -1,19 +1,41 @@
 package price
 
+const (
+	MatchModePrefix   = "prefix"
+	MatchModeContains = "contains"
+
+	PriceFilterMissing       = "missing"
+	PriceFilterZero          = "zero"
+	PriceFilterMissingOrZero = "missing_or_zero"
+
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// FilterReq 价格列表和价格导出共用的查询条件
+type FilterReq struct {
+	Keyword      string `json:"keyword" form:"keyword" binding:"omitempty,max=31" example:"WGC，按产品编码或规格型号搜索，可选"`
+	MatchMode    string `json:"match_mode" form:"match_mode" binding:"omitempty,oneof=prefix contains" example:"prefix或contains，可选，默认contains"`
+	PriceFilter  string `json:"price_filter" form:"price_filter" binding:"omitempty,oneof=missing zero missing_or_zero" example:"missing表示缺少某个等级的价格，zero表示某个等级的价格为0，可选"`
+	LevelCode    string `json:"level_code" form:"level_code" binding:"omitempty,max=31" example:"price_1，只检查该等级的价格，可选，默认检查全部等级"`
+	UpdatedSince string `json:"updated_since" form:"updated_since" binding:"omitempty" example:"2025-01-01或2025-01-01 08:00:00，可选"`
+	SortBy       string `json:"sort_by" form:"sort_by" binding:"omitempty,max=31" example:"id、product_code、spec_code、updated_at或价格等级编码，可选，默认id"`
+	SortOrder    string `json:"sort_order" form:"sort_order" binding:"omitempty,oneof=asc desc" example:"asc或desc，可选，默认asc"`
+}
+
 type ListReq struct {
 	Page     int `json:"page" form:"page" binding:"omitempty" example:"1"`
 	PageSize int `json:"page_size" form:"page_size" binding:"omitempty,min=1,max=100" example:"1-100，可选"`
+	FilterReq
 }
 
 type ListData struct {
-	ID          uint    `json:"id" example:"1"`
-	ProductCode string  `json:"product_code" example:"WGC001547"`
-	Price1      float64 `json:"price_1" example:"1571.30"`
-	Price2      float64 `json:"price_2" example:"1445.60"`
-	Price3      float64 `json:"price_3" example:"1230.00"`
-	Price4      float64 `json:"price_4" example:"1127.00"`
-	Unit        string  `json:"unit" example:"PCS"`
-	SpecCode    string  `json:"spec_code" example:"SDQCR1212H07"`
+	ID          uint          `json:"id" example:"1"`
+	ProductCode string        `json:"product_code" example:"WGC001547"`
+	Prices      []*LevelPrice `json:"prices"` // 按价格等级的排序排列
+	Unit        string        `json:"unit" example:"PCS"`
+	SpecCode    string        `json:"spec_code" example:"SDQCR1212H07"`
+	UpdatedAt   string        `json:"updated_at" example:"2025-01-01 08:00:00"`
 }
 
 type ListPageData struct {
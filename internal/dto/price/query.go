@@ -0,0 +1,21 @@
+package price
+
+type QueryReq struct {
+	ProductCodes []string `json:"product_codes" form:"product_codes" binding:"required,min=1,max=200,dive,required,max=255" example:"P001,P002"`
+}
+
+type QueryData struct {
+	ProductCode string  `json:"product_code" example:"P001"`
+	Price       float64 `json:"price" example:"12.5"`
+	// 价格来源：level(价格等级)、override(公司专属价格)或rule(折扣规则)
+	Source          string  `json:"source" example:"level"`
+	LevelCode       string  `json:"level_code,omitempty" example:"price_1"`
+	DiscountPercent float64 `json:"discount_percent,omitempty" example:"5"`
+}
+
+type QueryResp struct {
+	Code    int          `json:"code" example:"200"`
+	Message string       `json:"message" example:"操作成功"`
+	Success bool         `json:"success" example:"true"`
+	Data    []*QueryData `json:"data"`
+}
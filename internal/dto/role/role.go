@@ -0,0 +1,56 @@
+package role
+
+type PermissionData struct {
+	Code string `json:"code" example:"price:write"`
+	Name string `json:"name" example:"管理价格"`
+}
+
+type PermissionListResp struct {
+	Code    int               `json:"code" example:"200"`
+	Message string            `json:"message" example:"操作成功"`
+	Success bool              `json:"success" example:"true"`
+	Data    []*PermissionData `json:"data"`
+}
+
+type RoleData struct {
+	ID          uint     `json:"id" example:"3"`
+	Code        string   `json:"code" example:"price_manager"`
+	Name        string   `json:"name" example:"价格管理员"`
+	Description string   `json:"description,omitempty" example:"导入价格，维护价格等级"`
+	IsSystem    bool     `json:"is_system" example:"false"`
+	Permissions []string `json:"permissions" example:"price:read,price:write"`
+}
+
+type RoleListResp struct {
+	Code    int         `json:"code" example:"200"`
+	Message string      `json:"message" example:"操作成功"`
+	Success bool        `json:"success" example:"true"`
+	Data    []*RoleData `json:"data"`
+}
+
+type CreateRoleReq struct {
+	Code        string `json:"code" form:"code" binding:"required,max=31" example:"price_viewer，创建后不可修改"`
+	Name        string `json:"name" form:"name" binding:"required,max=63" example:"价格查看"`
+	Description string `json:"description" form:"description" binding:"omitempty,max=255" example:"只能查看价格"`
+	// 为空时是标签角色，不授予任何管理权限，只用作产品目录可见性规则的对象
+	Permissions []string `json:"permissions" form:"permissions" binding:"omitempty" example:"price:read"`
+}
+
+type UpdateRoleReq struct {
+	Name        string  `json:"name" form:"name" binding:"omitempty,max=63" example:"价格查看"`
+	Description *string `json:"description" form:"description" binding:"omitempty,max=255" example:"只能查看价格"`
+	// 传入时整体替换角色的权限，传空数组表示改为标签角色
+	Permissions []string `json:"permissions" form:"permissions" binding:"omitempty" example:"price:read"`
+}
+
+type UserRoleResp struct {
+	Code    int         `json:"code" example:"200"`
+	Message string      `json:"message" example:"操作成功"`
+	Success bool        `json:"success" example:"true"`
+	Data    []*RoleData `json:"data"`
+}
+
+type SetUserRolesReq struct {
+	// 整体替换用户的角色，传空数组表示取消该用户的全部管理权限
+	RoleIDs []uint `json:"role_ids" form:"role_ids" binding:"omitempty" example:"3,4"`
+}
@@ -11,6 +11,8 @@ type QueryReq struct {
 	DeviceTypeID   uint                   `json:"device_type_id" form:"device_type_id" binding:"required,min=1"`
 	CurrentFilters map[string]interface{} `json:"current_filters" form:"current_filters"`
 	Pagination     PaginationReq          `json:"pagination"`
+	// 预览模式，管理员可以查看草稿或已下架的设备类型，需要 catalog:read 权限，不能通过 API Key 使用
+	Preview bool `json:"preview" form:"preview" example:"false"`
 }
 
 // --- Response DTOs ---
@@ -25,6 +27,8 @@ type ComponentData struct {
 	InventoryXinde   string  `json:"inventory_xinde"`   // 来自 API (onhand)
 	InventoryGongpin string  `json:"inventory_gongpin"` // 来自 API (bsonhand)
 	Price            float64 `json:"price"`             // 来自 MySQL 价格表
+	PriceSource      string  `json:"price_source"`      // 价格来源: override 公司专属价格, rule 公司折扣规则, level 价格等级
+	PriceRuleID      uint    `json:"price_rule_id"`     // 产生该价格的专属价格或折扣规则ID，来源为 level 时为 0
 
 }
 
@@ -14,16 +14,29 @@ import (
 
 // ApprovalList handles approval user list.
 // @Summary 管理员查看用户审批列表
-// @Description 根据前端传来的字段，返回对应的待审批列表/已同意申请/已拒绝申请的用户列表，根据创建时间降序排列
+// @Description 根据前端传来的字段，返回对应的待审批列表/已同意申请/已拒绝申请的用户列表，支持与用户列表相同的搜索、过滤和排序。
+// @Description 公司管理员通过 /api/v1/company/account/approval/list 只能看到注册时填写本公司的申请
 // @Tags Account
 // @Accept json
 // @Produce json
 // @Param page query int false "当前页数，可选，默认为1"
 // @Param page_size query int false "一页的内容数量，可选，默认为设置的默认值"
+// @Param status query string false "pending(默认)、approved 或 rejected"
+// @Param keyword query string false "按用户名、姓名、电话或公司名称搜索"
+// @Param company_id query int false "按公司过滤"
+// @Param price_level query string false "按所在公司的价格等级过滤"
+// @Param role_id query int false "按角色过滤"
+// @Param created_from query string false "注册时间不早于该时间，格式YYYY-MM-DD或YYYY-MM-DD HH:MM:SS"
+// @Param created_to query string false "注册时间早于该时间，格式同上"
+// @Param active_from query string false "上次访问时间不早于该时间，格式同上"
+// @Param active_to query string false "上次访问时间早于该时间，格式同上"
+// @Param sort_by query string false "排序字段: id(默认)、username、name、company_name、price_level、created_at、handled_at或recent_search_at"
+// @Param sort_order query string false "asc(默认) 或 desc"
 // @Success 200 {object} dto.ApprovalListResp "查询成功"
 // @Failure 400 {object} response.Response "参数错误"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /api/v1/admin/account/approval/list [get]
+// @Router /api/v1/company/account/approval/list [get]
 func (ctrl *Controller) ApprovalList(c *gin.Context) {
 	var req dto.ApprovalListReq
 	if err := c.ShouldBind(&req); err != nil {
@@ -38,29 +51,22 @@ func (ctrl *Controller) ApprovalList(c *gin.Context) {
 	}
 
 	// 对前端传过来的status做校验
-	var status int
-	switch req.Status {
-	case "pending":
-		status = model.UserPending
-	case "":
-		status = model.UserPending
-	case "approved":
-		status = model.UserApproved
-	case "rejected":
-		status = model.UserRejected
-	default:
+	status, ok := parseUserStatus(req.Status, model.UserPending)
+	if !ok {
 		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "输入正确的status值，status只包含 pending/approved/rejected 这三种情况")
 		logger.Error("admin/account/approval/list 非法的status值: " + req.Status)
 		return
 	}
 
 	// 参数校验完毕，剩余的工作交由Service层处理
-	list, err := ctrl.accountService.GetApprovalUserList(req.Page, req.PageSize, status)
+	list, err := ctrl.scopedService(c).GetApprovalUserList(req.Page, req.PageSize, status, &req.UserFilterReq)
 	if err != nil {
 		switch err.Error() {
 		case stderr.ErrorDbNil:
 			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, err.Error())
 			logger.Error("admin/account/approval/list " + err.Error())
+		case stderr.ErrorUserFilterTimeInvalid, stderr.ErrorUserSortByInvalid, stderr.ErrorPriceLevelNotFound, stderr.ErrorRoleNotFound:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
 		case stderr.ErrorOverLargePage:
 			response.SuccessWithMessage(c, fmt.Sprintf("%s, 跳转至最后一页", stderr.ErrorOverLargePage), list)
 		case stderr.ErrorOverSmallPage:
@@ -74,3 +80,19 @@ func (ctrl *Controller) ApprovalList(c *gin.Context) {
 
 	response.Success(c, list)
 }
+
+// parseUserStatus 把前端传来的 status 转换为用户状态，为空时返回 defaultStatus
+func parseUserStatus(value string, defaultStatus int) (int, bool) {
+	switch value {
+	case "":
+		return defaultStatus, true
+	case "pending":
+		return model.UserPending, true
+	case "approved":
+		return model.UserApproved, true
+	case "rejected":
+		return model.UserRejected, true
+	default:
+		return 0, false
+	}
+}
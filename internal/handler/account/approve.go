@@ -5,27 +5,41 @@ import (
 	"github.com/gin-gonic/gin"
 	"net/http"
 	dto "xinde/internal/dto/account"
+	"xinde/internal/handler/common"
+	"xinde/internal/middleware/auth"
 	model "xinde/internal/model/account"
+	service "xinde/internal/service/account"
 	"xinde/pkg/logger"
 	"xinde/pkg/response"
 	"xinde/pkg/stderr"
 )
 
+// statusMap 将前端传来的status参数转换成对应的`is_user`字段
+var statusMap = map[string]int{
+	"approve": model.UserApproved,
+	"reject":  model.UserRejected,
+}
+
 // Approve handles admin approve.
 // @Summary 批准用户申请
-// @Description 批准用户申请，管理员决定是否同意用户的注册申请
+// @Description 批准用户申请，管理员决定是否同意用户的注册申请。
+// @Description 批准时可以把用户关联到已有的公司（company_id），merge_company 为 true 时把注册时按公司名称创建的公司合并过去（该公司的用户全部转移并删除该公司，有专属价格或折扣规则时不能合并），
+// @Description 也可以同时设置用户所在公司的价格等级（price_level）。已审批的申请需要先重新打开才能再次审批。
+// @Description 公司管理员通过 /api/v1/company/account/approval/{id} 只能审批本公司的申请，且不能指定公司或价格等级
 // @Tags Account
 // @Accept json
 // @Produce json
-// @Param request body dto.ApproveReq true "Login Request"
-// @Success 200 {object} dto.ApproveResp "审批成功"
+// @Param id path int true "用户ID"
+// @Param request body dto.ApproveReq true "Approve Request"
+// @Success 200 {object} response.Response "审批成功"
 // @Failure 400 {object} response.Response "参数错误"
 // @Failure 401 {object} response.Response "access_token有错误"
-// @Failure 403 {object} response.Response "没有管理员权限"
-// @Failure 404 {object} response.Response "没有该用户"
-// @Failure 409 {object} response.Response "用户已经被审批"
+// @Failure 403 {object} response.Response "没有管理员权限，或公司管理员指定了公司或价格等级"
+// @Failure 404 {object} response.Response "没有该用户、公司或价格等级"
+// @Failure 409 {object} response.Response "用户已经被审批，或被合并的公司有专属价格"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /api/v1/admin/account/approval/{id} [post]
+// @Router /api/v1/company/account/approval/{id} [post]
 func (ctrl *Controller) Approve(c *gin.Context) {
 	id, err := ctrl.getIDFromUrl(c)
 	if err != nil {
@@ -41,20 +55,37 @@ func (ctrl *Controller) Approve(c *gin.Context) {
 		return
 	}
 
-	// 将前端传来的status参数转换成对应的`is_user`字段
-	statusMap := map[string]int{
-		"approve": model.UserApproved,
-		"reject":  model.UserRejected,
-	}
 	status, exists := statusMap[req.Status]
 	if !exists {
 		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "status只能是approve或reject")
 		logger.Error("admin/account/approval 非法status: " + req.Status)
 		return
 	}
+	opts := &service.ApproveOptions{
+		Why:          req.Why,
+		CompanyID:    req.CompanyID,
+		MergeCompany: req.MergeCompany,
+		PriceLevel:   req.PriceLevel,
+	}
+	if msg := checkApproveOptions(status, opts); msg != "" {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, msg)
+		return
+	}
+	// 公司和价格等级由平台管理员决定，公司管理员只能批准或拒绝
+	if _, ok := auth.GetCompanyScope(c); ok && (opts.CompanyID != 0 || opts.MergeCompany || opts.PriceLevel != "") {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, stderr.ErrorCompanyAdminOptionDenied)
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
 
 	// 参数校验完毕，剩余的工作交由service处理
-	err = ctrl.accountService.ApproveUser(uint(id), status, req.Why)
+	err = ctrl.scopedService(c).ApproveUser(actor, uint(id), status, opts)
 
 	// 根据错误，向前端返回不同的响应
 	if err != nil {
@@ -72,9 +103,14 @@ func (ctrl *Controller) handleApproveError(c *gin.Context, err error, userID uin
 		code   int
 		msg    string
 	}{
-		stderr.ErrorUserBanned:   {http.StatusConflict, response.CodeConflict, "用户已被拒绝，请勿重复审批"},
-		stderr.ErrorUserPassed:   {http.StatusConflict, response.CodeConflict, "用户已被通过，请勿重复审批"},
-		stderr.ErrorUserNotFound: {http.StatusNotFound, response.CodeNotFound, "用户不存在"},
+		stderr.ErrorUserBanned:         {http.StatusConflict, response.CodeConflict, "用户已被拒绝，请勿重复审批，如需重新审批请先重新打开申请"},
+		stderr.ErrorUserPassed:         {http.StatusConflict, response.CodeConflict, "用户已被通过，请勿重复审批，如需重新审批请先重新打开申请"},
+		stderr.ErrorUserNotHandled:     {http.StatusConflict, response.CodeConflict, stderr.ErrorUserNotHandled},
+		stderr.ErrorUserNotFound:       {http.StatusNotFound, response.CodeNotFound, "用户不存在"},
+		stderr.ErrorCompanyNotFound:    {http.StatusNotFound, response.CodeNotFound, stderr.ErrorCompanyNotFound},
+		stderr.ErrorPriceLevelNotFound: {http.StatusNotFound, response.CodeNotFound, stderr.ErrorPriceLevelNotFound},
+
+		stderr.ErrorCompanyMergeHasPriceData: {http.StatusConflict, response.CodeConflict, stderr.ErrorCompanyMergeHasPriceData},
 	}
 
 	if errInfo, exists := errorMap[err.Error()]; exists {
@@ -84,3 +120,104 @@ func (ctrl *Controller) handleApproveError(c *gin.Context, err error, userID uin
 		response.Error(c, http.StatusInternalServerError, response.CodeInternalError, "服务器内部错误")
 	}
 }
+
+// checkApproveOptions 校验审批的附加选项，返回错误信息，合法时返回空字符串
+func checkApproveOptions(status int, opts *service.ApproveOptions) string {
+	if status == model.UserRejected && (opts.CompanyID != 0 || opts.MergeCompany || opts.PriceLevel != "") {
+		return "拒绝申请时不能指定公司或价格等级"
+	}
+	if opts.MergeCompany && opts.CompanyID == 0 {
+		return "merge_company为true时必须指定company_id"
+	}
+	return ""
+}
+
+// BatchApprove handles admin batch approve.
+// @Summary 批量审批用户申请
+// @Description 一次批准或拒绝多个注册申请（最多100个），附加选项与单个审批相同，对每个用户生效。
+// @Description 每个用户单独处理，一个失败不影响其他用户，返回每个用户的处理结果
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param request body dto.BatchApproveReq true "BatchApprove Request"
+// @Success 200 {object} dto.BatchApproveResp "处理完成，各用户的结果见results"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "access_token有错误"
+// @Failure 403 {object} response.Response "没有管理员权限"
+// @Router /api/v1/admin/account/approval/batch [post]
+func (ctrl *Controller) BatchApprove(c *gin.Context) {
+	var req dto.BatchApproveReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("admin/account/approval/batch 参数绑定错误: " + err.Error())
+		return
+	}
+
+	status := statusMap[req.Status]
+	opts := &service.ApproveOptions{
+		Why:          req.Why,
+		CompanyID:    req.CompanyID,
+		MergeCompany: req.MergeCompany,
+		PriceLevel:   req.PriceLevel,
+	}
+	if msg := checkApproveOptions(status, opts); msg != "" {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, msg)
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	response.Success(c, ctrl.accountService.BatchApproveUsers(actor, req.IDs, status, opts))
+}
+
+// Reopen handles admin reopen a handled registration.
+// @Summary 重新打开注册申请
+// @Description 把已批准或已拒绝的注册申请重新置为待审批，之后可以重新审批。已批准的用户会立即掉线，重新批准前不能登录
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param id path int true "用户ID"
+// @Param request body dto.ReopenReq true "Reopen Request"
+// @Success 200 {object} response.Response "重新打开成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "access_token有错误"
+// @Failure 403 {object} response.Response "没有管理员权限"
+// @Failure 404 {object} response.Response "没有该用户"
+// @Failure 409 {object} response.Response "申请尚未处理"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/account/approval/reopen/{id} [post]
+func (ctrl *Controller) Reopen(c *gin.Context) {
+	id, err := ctrl.getIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorUserIDInvalid)
+		logger.Error("admin/account/approval/reopen 无效的用户ID格式: " + c.Param("id"))
+		return
+	}
+
+	var req dto.ReopenReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("admin/account/approval/reopen 参数绑定错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.accountService.ReopenUser(actor, id, req.Why)
+	if err != nil {
+		ctrl.handleApproveError(c, err, id)
+		return
+	}
+
+	response.Success(c, nil)
+}
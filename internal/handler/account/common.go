@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"strconv"
+	"xinde/internal/middleware/auth"
+	service "xinde/internal/service/account"
 	"xinde/pkg/stderr"
 )
 
@@ -19,3 +21,19 @@ func (ctrl *Controller) getIDFromUrl(c *gin.Context) (uint, error) {
 	}
 	return uint(id), nil
 }
+
+// scopedService 返回处理当前请求的 Service：公司管理员的请求只能访问本公司的用户，其他请求不受限制
+func (ctrl *Controller) scopedService(c *gin.Context) *service.Service {
+	if companyID, ok := auth.GetCompanyScope(c); ok {
+		return ctrl.accountService.ForCompany(companyID)
+	}
+	return ctrl.accountService
+}
+
+// clientInfo 提取签发 refresh token 时需要记录的客户端信息
+func clientInfo(c *gin.Context) service.ClientInfo {
+	return service.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}
+}
@@ -0,0 +1,68 @@
+package account
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	dto "xinde/internal/dto/account"
+	"xinde/internal/handler/common"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// SetCompanyAdmin handles admin set or unset a user as company admin.
+// @Summary 设置公司管理员
+// @Description 平台管理员设置或取消用户的公司管理员身份。公司管理员可以审批注册时填写本公司的申请、重置本公司用户的密码，
+// @Description 以及查看本公司的价格和搜索记录，只能访问本公司的数据。只有已通过注册申请且有所属公司的用户可以设为公司管理员
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param id path int true "用户ID"
+// @Param request body dto.SetCompanyAdminReq true "SetCompanyAdmin Request"
+// @Success 200 {object} response.Response "设置成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "access_token有错误"
+// @Failure 403 {object} response.Response "没有管理员权限"
+// @Failure 404 {object} response.Response "没有该用户"
+// @Failure 409 {object} response.Response "用户尚未通过注册申请或没有所属公司"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/account/company_admin/{id} [put]
+func (ctrl *Controller) SetCompanyAdmin(c *gin.Context) {
+	id, err := ctrl.getIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorUserIDInvalid)
+		logger.Error("/admin/account/company_admin/ 无效的用户ID格式: " + c.Param("id"))
+		return
+	}
+
+	var req dto.SetCompanyAdminReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/admin/account/company_admin/ 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.accountService.SetCompanyAdmin(actor, id, *req.Enabled)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorUserNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorUserNotFound)
+		case stderr.ErrorCompanyAdminNoCompany:
+			response.Error(c, http.StatusConflict, response.CodeConflict, stderr.ErrorCompanyAdminNoCompany)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/account/company_admin/ 设置公司管理员失败! 用户ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+
+	response.Success(c, nil)
+}
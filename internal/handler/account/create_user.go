@@ -0,0 +1,131 @@
+package account
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
+	dto "xinde/internal/dto/account"
+	"xinde/internal/handler/common"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+// CreateUser handles admin create a user directly.
+// @Summary 创建用户
+// @Description 管理员直接创建已通过审批的用户，无需用户自己注册。密码为随机生成的一次性密码，只在本次响应中返回，用户首次登录时必须修改。
+// @Description company_id 和 company_name 至少填写一个，同时填写时以 company_id 为准；只填写 company_name 时按名称查找公司，没有时新建
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateUserReq true "CreateUser Request"
+// @Success 200 {object} dto.CreateUserResp "创建成功，返回一次性密码"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "access_token有错误"
+// @Failure 403 {object} response.Response "没有管理员权限"
+// @Failure 404 {object} response.Response "没有该公司"
+// @Failure 409 {object} response.Response "用户名已存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/account/create [post]
+func (ctrl *Controller) CreateUser(c *gin.Context) {
+	var req dto.CreateUserReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/admin/account/create 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	data, err := ctrl.accountService.CreateUser(actor, &req)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorUserCompanyMissing:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		case stderr.ErrorCompanyNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		case stderr.ErrorUserAlreadyExist:
+			response.Error(c, http.StatusConflict, response.CodeConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/admin/account/create 创建用户失败: " + err.Error())
+		}
+		return
+	}
+
+	response.Success(c, data)
+}
+
+// ImportUsers handles admin bulk import users from an Excel file.
+// @Summary      批量导入用户
+// @Description  上传包含用户信息的Excel文件，按表头名称定位各列（列顺序不限）：用户名、姓名、电话、公司为必需的列，邮箱、公司地址、管理员（是/否，是否为公司管理员）为可选的列。
+// @Description  逐行校验后创建已通过审批的用户，公司按名称查找，没有时新建。每个用户都生成一次性密码，首次登录时必须修改。
+// @Description  strict模式下任意一行有错误则全部不创建；lenient模式下跳过错误行、创建其余行。
+// @Description  返回逐行的结果文件：原表头之后追加行号、结果、临时密码和错误原因四列，临时密码只在这个文件中出现一次。
+// @Description  响应头X-Import-Total、X-Import-Succeeded、X-Import-Failed分别为数据行数、创建的用户数和错误行数
+// @Tags         Account
+// @Accept       multipart/form-data
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        file formData file true "要上传的Excel文件 (格式: .xlsx)"
+// @Param        mode formData string false "导入模式: strict(默认) 或 lenient"
+// @Success      200 {file} file "逐行的导入结果Excel文件"
+// @Failure      400 {object} response.Response "文件上传失败、文件为空、行数过多或缺少必需的列"
+// @Failure      401 {object} response.Response "access_token有错误"
+// @Failure      403 {object} response.Response "没有管理员权限"
+// @Failure      500 {object} response.Response "服务器内部错误"
+// @Router       /api/v1/admin/account/import [post]
+func (ctrl *Controller) ImportUsers(c *gin.Context) {
+	var req dto.ImportUserReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/admin/account/import 绑定参数错误: " + err.Error())
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "文件上传失败: "+err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	result, err := ctrl.accountService.ImportUsersFromFile(file, actor, req.Mode)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorUserImportEmpty, stderr.ErrorUserImportTooManyRows, stderr.ErrorUserImportMissingColumns:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/admin/account/import 导入用户失败: " + err.Error())
+		}
+		return
+	}
+	defer result.Workbook.Close()
+
+	fileName := fmt.Sprintf("用户导入结果_%s.xlsx", time.Now().Format("20060102150405"))
+	c.Header("Content-Disposition", util.FormatContentDisposition(fileName))
+	c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	c.Header("X-Import-Total", strconv.Itoa(result.Total))
+	c.Header("X-Import-Succeeded", strconv.Itoa(result.Succeeded))
+	c.Header("X-Import-Failed", strconv.Itoa(result.Failed))
+	// 结果文件中有临时密码，不允许缓存
+	c.Header("Cache-Control", "no-store")
+
+	if err := result.Workbook.Write(c.Writer); err != nil {
+		logger.Error("/admin/account/import 向客户端写入文件流时出错: " + err.Error())
+	}
+}
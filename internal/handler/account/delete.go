@@ -5,6 +5,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"net/http"
 	_ "xinde/internal/dto/account"
+	"xinde/internal/handler/common"
 	"xinde/pkg/logger"
 	"xinde/pkg/response"
 	"xinde/pkg/stderr"
@@ -21,6 +22,7 @@ import (
 // @Failure 401 {object} response.Response "access_token有错误"
 // @Failure 403 {object} response.Response "没有管理员权限"
 // @Failure 404 {object} response.Response "没有该用户"
+// @Failure 409 {object} response.Response "为最后一名超级管理员"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /api/v1/admin/account/{id} [delete]
 func (ctrl *Controller) DeleteUser(c *gin.Context) {
@@ -31,12 +33,21 @@ func (ctrl *Controller) DeleteUser(c *gin.Context) {
 		return
 	}
 
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
 	// 无需参数校验，将剩余的工作交由service处理
-	err = ctrl.accountService.DeleteUser(id)
+	err = ctrl.accountService.DeleteUser(actor, id)
 	if err != nil {
 		switch err.Error() {
 		case stderr.ErrorUserNotFound:
 			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorUserNotFound)
+		case stderr.ErrorRoleLastSuperAdmin:
+			response.Error(c, http.StatusConflict, response.CodeConflict, stderr.ErrorRoleLastSuperAdmin)
 		default:
 			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
 			logger.Error(fmt.Sprintf("/admin/account/delete/ 删除用户失败! 用户ID: %d 错误: %s", id, err.Error()))
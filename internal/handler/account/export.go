@@ -0,0 +1,75 @@
+package account
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+	dto "xinde/internal/dto/account"
+	model "xinde/internal/model/account"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+// Export handles exporting users to an Excel file.
+// @Summary      导出用户Excel文件
+// @Description  按与用户列表相同的查询条件导出全部符合条件的用户（不分页），包含公司、价格等级、角色和上次访问信息
+// @Tags         Account
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        status query string false "pending、approved(默认) 或 rejected"
+// @Param        keyword query string false "按用户名、姓名、电话或公司名称搜索"
+// @Param        company_id query int false "按公司过滤"
+// @Param        price_level query string false "按所在公司的价格等级过滤"
+// @Param        role_id query int false "按角色过滤"
+// @Param        created_from query string false "注册时间不早于该时间，格式YYYY-MM-DD或YYYY-MM-DD HH:MM:SS"
+// @Param        created_to query string false "注册时间早于该时间，格式同上"
+// @Param        active_from query string false "上次访问时间不早于该时间，格式同上"
+// @Param        active_to query string false "上次访问时间早于该时间，格式同上"
+// @Param        sort_by query string false "排序字段: id(默认)、username、name、company_name、price_level、created_at、handled_at或recent_search_at"
+// @Param        sort_order query string false "asc(默认) 或 desc"
+// @Security     ApiKeyAuth
+// @Success      200 {file} file "Excel文件流"
+// @Failure      400 {object} response.Response "参数错误"
+// @Failure      401 {object} response.Response "Token错误"
+// @Failure      403 {object} response.Response "没有管理员权限"
+// @Failure      500 {object} response.Response "服务器内部错误"
+// @Router       /api/v1/admin/account/export [get]
+func (ctrl *Controller) Export(c *gin.Context) {
+	var req dto.ExportReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/admin/account/export 绑定参数错误: " + err.Error())
+		return
+	}
+
+	status, ok := parseUserStatus(req.Status, model.UserApproved)
+	if !ok {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "输入正确的status值，status只包含 pending/approved/rejected 这三种情况")
+		logger.Error("/admin/account/export 非法的status值: " + req.Status)
+		return
+	}
+
+	file, err := ctrl.accountService.ExportUsers(status, &req.UserFilterReq)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorUserFilterTimeInvalid, stderr.ErrorUserSortByInvalid, stderr.ErrorPriceLevelNotFound, stderr.ErrorRoleNotFound:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/admin/account/export 导出用户失败: " + err.Error())
+		}
+		return
+	}
+	defer file.Close()
+
+	fileName := fmt.Sprintf("用户导出_%s.xlsx", time.Now().Format("20060102150405"))
+	c.Header("Content-Disposition", util.FormatContentDisposition(fileName))
+	c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+
+	// 直接将文件写入响应体
+	if err := file.Write(c.Writer); err != nil {
+		logger.Error("/admin/account/export 向客户端写入文件流时出错: " + err.Error())
+	}
+}
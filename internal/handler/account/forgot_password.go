@@ -0,0 +1,172 @@
+package account
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	dto "xinde/internal/dto/account"
+	"xinde/internal/handler/common"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// ForgotPassword handles requesting a password reset verification code.
+// @Summary 获取找回密码验证码
+// @Description 向用户注册时填写的邮箱或手机号发送找回密码的验证码，只有注册申请已通过的用户可以找回密码。重新获取后之前的验证码全部作废。
+// @Description 为了不暴露账号是否存在及其审核状态，用户不存在、未通过审核、没有绑定对应的邮箱或手机号、发送过于频繁或超过一段时间内的获取次数上限时都返回相同的成功结果。
+// @Description 每次请求都计入登录失败的IP计数，IP被锁定后返回 429
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param request body dto.ForgotPasswordReq true "ForgotPassword Request"
+// @Success 200 {object} dto.ForgotPasswordResp "请求已受理，账号存在时验证码已发送"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 429 {object} response.Response "当前IP请求过多，已被临时限制"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/account/password/forgot [post]
+func (ctrl *Controller) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/account/password/forgot 参数绑定错误: " + err.Error())
+		return
+	}
+
+	data, err := ctrl.accountService.ForgotPassword(req.Username, req.Channel, c.ClientIP())
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorResetIPLocked:
+			response.Error(c, http.StatusTooManyRequests, response.CodeTooManyRequests, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/account/password/forgot 获取验证码失败! 用户: %s 错误: %s", req.Username, err.Error()))
+		}
+		return
+	}
+	response.Success(c, data)
+}
+
+// VerifyResetCode handles verifying a password reset verification code.
+// @Summary 校验找回密码验证码
+// @Description 校验找回密码的验证码，通过后返回一个短期有效、只能使用一次的重置令牌。错误次数过多时验证码作废，需要重新获取。
+// @Description 校验失败计入登录失败的IP计数，IP被锁定后返回 429
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param request body dto.VerifyCodeReq true "VerifyCode Request"
+// @Success 200 {object} dto.VerifyCodeResp "校验成功，返回重置令牌"
+// @Failure 400 {object} response.Response "参数错误、验证码错误或已过期（用户不存在或未通过审核时也返回此错误）"
+// @Failure 429 {object} response.Response "验证码错误次数过多，或当前IP请求过多已被临时限制"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/account/password/verify [post]
+func (ctrl *Controller) VerifyResetCode(c *gin.Context) {
+	var req dto.VerifyCodeReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/account/password/verify 参数绑定错误: " + err.Error())
+		return
+	}
+
+	data, err := ctrl.accountService.VerifyResetCode(req.Username, req.Code, c.ClientIP())
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorVerificationCodeInvalid:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		case stderr.ErrorVerificationTooManyTries, stderr.ErrorResetIPLocked:
+			response.Error(c, http.StatusTooManyRequests, response.CodeTooManyRequests, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/account/password/verify 校验验证码失败! 用户: %s 错误: %s", req.Username, err.Error()))
+		}
+		return
+	}
+	response.Success(c, data)
+}
+
+// ResetPasswordByCode handles setting a new password with a reset token.
+// @Summary 凭重置令牌设置新密码
+// @Description 使用校验验证码后得到的重置令牌设置新密码，令牌只能使用一次。设置成功后该用户在所有设备上的登录都会失效
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param request body dto.ResetPasswordByCodeReq true "ResetPasswordByCode Request"
+// @Success 200 {object} response.Response "设置成功"
+// @Failure 400 {object} response.Response "参数错误、两次密码不一致或重置令牌无效"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/account/password/reset [post]
+func (ctrl *Controller) ResetPasswordByCode(c *gin.Context) {
+	var req dto.ResetPasswordByCodeReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/account/password/reset 参数绑定错误: " + err.Error())
+		return
+	}
+
+	// 重复输入密码需一致
+	if req.Password != req.ConfirmedPassword {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorUserPasswordNotConfirm)
+		return
+	}
+
+	err := ctrl.accountService.ResetPasswordByCode(common.GetRequestActor(c), req.ResetToken, req.Password)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorResetTokenInvalid:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/account/password/reset 设置新密码失败! 错误: " + err.Error())
+		}
+		return
+	}
+	response.Success(c, nil)
+}
+
+// ChangeInitialPassword handles replacing an admin-issued one-time password.
+// @Summary 修改一次性密码
+// @Description 管理员重置密码后，用户凭用户名和一次性密码设置自己的密码，在此之前无法登录。修改成功后直接返回登录信息
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param request body dto.ChangeInitialPasswordReq true "ChangeInitialPassword Request"
+// @Success 200 {object} dto.LoginResp "修改成功"
+// @Failure 400 {object} response.Response "参数错误、两次密码不一致或新旧密码相同"
+// @Failure 401 {object} response.Response "用户名或密码错误（注册申请未通过或已被拒绝时也返回此错误）"
+// @Failure 403 {object} response.Response "密码未被管理员重置，需要登录后修改密码"
+// @Failure 429 {object} response.Response "登录失败次数过多，账号或IP已被临时锁定"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/account/password/initial [post]
+func (ctrl *Controller) ChangeInitialPassword(c *gin.Context) {
+	var req dto.ChangeInitialPasswordReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/account/password/initial 参数绑定错误: " + err.Error())
+		return
+	}
+
+	// 重复输入密码需一致
+	if req.Password != req.ConfirmedPassword {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorUserPasswordNotConfirm)
+		return
+	}
+
+	data, err := ctrl.accountService.ChangeInitialPassword(common.GetRequestActor(c), req.Username, req.OldPassword, req.Password, clientInfo(c))
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorUserPasswordUnchanged:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		case stderr.ErrorUserUnauthorized:
+			response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+		case stderr.ErrorUserNoInitialPassword:
+			response.Error(c, http.StatusForbidden, response.CodeForbidden, err.Error())
+		case stderr.ErrorLoginUserLocked, stderr.ErrorLoginIPLocked:
+			response.Error(c, http.StatusTooManyRequests, response.CodeTooManyRequests, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/account/password/initial 修改一次性密码失败! 用户: %s 错误: %s", req.Username, err.Error()))
+		}
+		return
+	}
+	response.Success(c, data)
+}
@@ -13,16 +13,27 @@ import (
 
 // List handles user list.
 // @Summary 管理员查看用户列表
-// @Description 返回已被通过注册申请的用户信息
+// @Description 返回已被通过注册申请的用户信息，支持按关键字搜索，按公司、价格等级、角色、注册时间和上次访问时间过滤及排序。公司管理员通过 /api/v1/company/account/list 只能看到本公司的用户
 // @Tags Account
 // @Accept json
 // @Produce json
 // @Param page query int false "当前页数，可选，默认为1"
 // @Param page_size query int false "一页的内容数量，可选，默认为设置的默认值"
+// @Param keyword query string false "按用户名、姓名、电话或公司名称搜索"
+// @Param company_id query int false "按公司过滤"
+// @Param price_level query string false "按所在公司的价格等级过滤"
+// @Param role_id query int false "按角色过滤"
+// @Param created_from query string false "注册时间不早于该时间，格式YYYY-MM-DD或YYYY-MM-DD HH:MM:SS"
+// @Param created_to query string false "注册时间早于该时间，格式同上"
+// @Param active_from query string false "上次访问时间不早于该时间，格式同上"
+// @Param active_to query string false "上次访问时间早于该时间，格式同上"
+// @Param sort_by query string false "排序字段: id(默认)、username、name、company_name、price_level、created_at、handled_at或recent_search_at"
+// @Param sort_order query string false "asc(默认) 或 desc"
 // @Success 200 {object} dto.ListResp "查询成功"
 // @Failure 400 {object} response.Response "参数错误"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /api/v1/admin/account/list [get]
+// @Router /api/v1/company/account/list [get]
 func (ctrl *Controller) List(c *gin.Context) {
 	var req dto.ListReq
 	if err := c.ShouldBind(&req); err != nil {
@@ -36,12 +47,14 @@ func (ctrl *Controller) List(c *gin.Context) {
 	}
 
 	// 参数校验完毕，剩余的工作交由Service层处理
-	list, err := ctrl.accountService.GetUserList(req.Page, req.PageSize)
+	list, err := ctrl.scopedService(c).GetUserList(req.Page, req.PageSize, &req.UserFilterReq)
 	if err != nil {
 		switch err.Error() {
 		case stderr.ErrorDbNil:
 			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, err.Error())
 			logger.Error("admin/account/list " + err.Error())
+		case stderr.ErrorUserFilterTimeInvalid, stderr.ErrorUserSortByInvalid, stderr.ErrorPriceLevelNotFound, stderr.ErrorRoleNotFound:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
 		case stderr.ErrorOverLargePage:
 			response.SuccessWithMessage(c, fmt.Sprintf("%s, 跳转至最后一页", stderr.ErrorOverLargePage), list)
 		case stderr.ErrorOverSmallPage:
@@ -11,7 +11,7 @@ import (
 
 // Login handles user login.
 // @Summary 用户登录
-// @Description 用户登录，返回JWT token和用户基本信息
+// @Description 用户登录，返回短期有效的access token、用于换取新token的refresh token和用户基本信息。用户名或IP连续登录失败次数过多时会被临时锁定，锁定时长逐次翻倍。开启了二次验证的用户（以及必须开启的管理员）只返回 mfa_required 和 mfa_token，需要再调用 /account/login/mfa 完成登录
 // @Tags Account
 // @Accept json
 // @Produce json
@@ -19,6 +19,8 @@ import (
 // @Success 200 {object} dto.LoginResp "登录成功"
 // @Failure 400 {object} response.Response "参数错误"
 // @Failure 401 {object} response.Response "用户名或密码错误"
+// @Failure 403 {object} response.Response "注册申请未通过、已被拒绝，或需要先修改管理员重置的一次性密码"
+// @Failure 429 {object} response.Response "登录失败次数过多，账号或IP已被临时锁定"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /api/v1/account/login [post]
 func (ctrl *Controller) Login(c *gin.Context) {
@@ -30,7 +32,7 @@ func (ctrl *Controller) Login(c *gin.Context) {
 	}
 
 	// 校验参数由ShouldBind完成，剩下的交由Service层处理
-	loginRespData, err := ctrl.accountService.Login(req.Username, req.Password)
+	loginRespData, err := ctrl.accountService.Login(req.Username, req.Password, clientInfo(c))
 	if err != nil {
 		switch err.Error() {
 		case stderr.ErrorUserNotPass:
@@ -39,6 +41,10 @@ func (ctrl *Controller) Login(c *gin.Context) {
 			response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
 		case stderr.ErrorUserBanned:
 			response.Error(c, http.StatusForbidden, response.CodeForbidden, err.Error())
+		case stderr.ErrorUserMustChangePassword:
+			response.Error(c, http.StatusForbidden, response.CodeForbidden, err.Error())
+		case stderr.ErrorLoginUserLocked, stderr.ErrorLoginIPLocked:
+			response.Error(c, http.StatusTooManyRequests, response.CodeTooManyRequests, err.Error())
 		default:
 			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, err.Error())
 		}
@@ -0,0 +1,191 @@
+package account
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"net/http"
+	dto "xinde/internal/dto/account"
+	"xinde/internal/handler/common"
+	"xinde/internal/middleware/auth"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// LoginHistory handles admin viewing login history.
+// @Summary 查看登录记录
+// @Description 分页查看所有用户的登录记录，包括失败的尝试，最新的在前。支持按用户、用户名、IP、是否成功和时间范围过滤
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param page query int false "当前页数，可选，默认为1"
+// @Param page_size query int false "一页的内容数量，可选，默认为设置的默认值"
+// @Param uid query int false "用户ID"
+// @Param username query string false "登录时填写的用户名"
+// @Param ip query string false "客户端IP"
+// @Param success query bool false "是否登录成功"
+// @Param from query string false "开始时间（含），格式YYYY-MM-DD或YYYY-MM-DD HH:MM:SS"
+// @Param to query string false "结束时间（不含），格式同上"
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.LoginHistoryResp "查询成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/account/login/history [get]
+func (ctrl *Controller) LoginHistory(c *gin.Context) {
+	var req dto.LoginHistoryReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/admin/account/login/history 绑定参数错误: " + err.Error())
+		return
+	}
+
+	if req.PageSize == 0 {
+		req.PageSize = viper.GetInt("page.defaultPageSize")
+	}
+
+	list, err := ctrl.accountService.GetLoginHistoryList(&req)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorLoginHistoryTimeInvalid:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		case stderr.ErrorOverLargePage:
+			response.SuccessWithMessage(c, fmt.Sprintf("%s, 跳转至最后一页", stderr.ErrorOverLargePage), list)
+		case stderr.ErrorOverSmallPage:
+			response.SuccessWithMessage(c, fmt.Sprintf("%s, 跳转至第一页", stderr.ErrorOverSmallPage), list)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/admin/account/login/history " + err.Error())
+		}
+		return
+	}
+	response.Success(c, list)
+}
+
+// LockList handles admin viewing locked usernames and IPs.
+// @Summary 查看被锁定的账号和IP
+// @Description 列出当前因登录失败次数过多而被临时锁定的用户名和IP
+// @Tags Account
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.LockListResp "查询成功"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/account/lock/list [get]
+func (ctrl *Controller) LockList(c *gin.Context) {
+	list, err := ctrl.accountService.ListLoginLocks()
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+		logger.Error("/admin/account/lock/list " + err.Error())
+		return
+	}
+	response.Success(c, list)
+}
+
+// UnlockUser handles admin unlocking a user.
+// @Summary 解除账号的登录锁定
+// @Description 管理员根据用户ID解除该用户因登录失败次数过多而产生的锁定，并清除失败计数
+// @Tags Account
+// @Produce json
+// @Param id path int true "用户ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response "解锁成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "没有该用户"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/account/lock/{id} [delete]
+func (ctrl *Controller) UnlockUser(c *gin.Context) {
+	id, err := ctrl.getIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorUserIDInvalid)
+		logger.Error("/admin/account/lock/ 无效的用户ID格式: " + c.Param("id"))
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.accountService.UnlockUser(actor, id)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorUserNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorUserNotFound)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/account/lock/ 解除登录锁定失败! 用户ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, nil)
+}
+
+// UnlockIP handles admin unlocking an IP.
+// @Summary 解除IP的登录限制
+// @Description 管理员解除某个IP因登录失败次数过多而产生的限制，并清除失败计数
+// @Tags Account
+// @Produce json
+// @Param ip query string true "客户端IP"
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response "解锁成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/account/ip/lock [delete]
+func (ctrl *Controller) UnlockIP(c *gin.Context) {
+	var req dto.UnlockIPReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/admin/account/ip/lock 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	if err := ctrl.accountService.UnlockIP(actor, req.IP); err != nil {
+		response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+		logger.Error(fmt.Sprintf("/admin/account/ip/lock 解除IP登录限制失败! IP: %s 错误: %s", req.IP, err.Error()))
+		return
+	}
+	response.Success(c, nil)
+}
+
+// RecentLogins handles the current user viewing their recent logins.
+// @Summary 查看自己最近的登录记录
+// @Description 查看当前登录用户最近的登录记录，包括失败的尝试，便于发现异常登录
+// @Tags Account
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.RecentLoginResp "查询成功"
+// @Failure 401 {object} response.Response "token有错误"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/account/me/logins [get]
+func (ctrl *Controller) RecentLogins(c *gin.Context) {
+	uid, err := auth.GetCurrentUserID(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+		return
+	}
+
+	list, err := ctrl.accountService.GetRecentLogins(uid)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+		logger.Error(fmt.Sprintf("/account/me/logins 查询登录记录失败! 用户ID: %d 错误: %s", uid, err.Error()))
+		return
+	}
+	response.Success(c, list)
+}
@@ -0,0 +1,379 @@
+package account
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	dto "xinde/internal/dto/account"
+	"xinde/internal/handler/common"
+	"xinde/internal/middleware/auth"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// LoginMFA handles the second step of a login with two-factor authentication.
+// @Summary 登录二次验证
+// @Description 登录返回 mfa_required 时，凭 mfa_token 和验证器中的6位验证码（或一个未使用过的恢复码）完成登录。mfa_token 只能使用一次，验证码错误计入登录失败次数
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param request body dto.MFALoginReq true "MFALogin Request"
+// @Success 200 {object} dto.LoginResp "登录成功"
+// @Failure 400 {object} response.Response "参数错误或尚未开启二次验证"
+// @Failure 401 {object} response.Response "mfa_token无效或已过期、验证码错误"
+// @Failure 429 {object} response.Response "登录失败次数过多，账号或IP已被临时锁定"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/account/login/mfa [post]
+func (ctrl *Controller) LoginMFA(c *gin.Context) {
+	var req dto.MFALoginReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/account/login/mfa 参数绑定错误: " + err.Error())
+		return
+	}
+
+	data, err := ctrl.accountService.VerifyMFALogin(req.MFAToken, req.Code, clientInfo(c))
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorMFANotEnabled:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		case stderr.ErrorMFATokenInvalid, stderr.ErrorMFACodeInvalid:
+			response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+		case stderr.ErrorLoginUserLocked, stderr.ErrorLoginIPLocked:
+			response.Error(c, http.StatusTooManyRequests, response.CodeTooManyRequests, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/account/login/mfa 二次验证失败! 错误: " + err.Error())
+		}
+		return
+	}
+
+	logger.Info(fmt.Sprintf("用户 %s 通过二次验证登录成功", data.Username))
+	response.Success(c, data)
+}
+
+// LoginMFAEnroll handles fetching a TOTP secret during login.
+// @Summary 登录时绑定验证器
+// @Description 登录返回 mfa_enroll_required 时（管理员必须开启二次验证但尚未绑定），凭 mfa_token 获取密钥和二维码内容。重复调用会生成新的密钥
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param request body dto.MFATokenReq true "MFAToken Request"
+// @Success 200 {object} dto.MFAEnrollResp "获取成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "mfa_token无效或已过期"
+// @Failure 409 {object} response.Response "已经开启了二次验证"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/account/login/mfa/enroll [post]
+func (ctrl *Controller) LoginMFAEnroll(c *gin.Context) {
+	var req dto.MFATokenReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/account/login/mfa/enroll 参数绑定错误: " + err.Error())
+		return
+	}
+
+	data, err := ctrl.accountService.BeginMFAEnrollWithToken(req.MFAToken)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorMFATokenInvalid:
+			response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+		case stderr.ErrorMFAAlreadyEnabled:
+			response.Error(c, http.StatusConflict, response.CodeConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/account/login/mfa/enroll 获取二次验证密钥失败! 错误: " + err.Error())
+		}
+		return
+	}
+	response.Success(c, data)
+}
+
+// LoginMFAConfirm handles confirming a TOTP enrolment during login.
+// @Summary 登录时确认绑定验证器
+// @Description 凭 mfa_token 和验证器中的6位验证码确认绑定，成功后开启二次验证并完成登录，同时返回恢复码。恢复码只返回这一次，请提示用户妥善保存
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param request body dto.MFAConfirmTokenReq true "MFAConfirmToken Request"
+// @Success 200 {object} dto.MFAConfirmLoginResp "绑定并登录成功"
+// @Failure 400 {object} response.Response "参数错误或尚未获取密钥"
+// @Failure 401 {object} response.Response "mfa_token无效或已过期、验证码错误"
+// @Failure 409 {object} response.Response "已经开启了二次验证"
+// @Failure 429 {object} response.Response "登录失败次数过多，账号或IP已被临时锁定"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/account/login/mfa/enroll/confirm [post]
+func (ctrl *Controller) LoginMFAConfirm(c *gin.Context) {
+	var req dto.MFAConfirmTokenReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/account/login/mfa/enroll/confirm 参数绑定错误: " + err.Error())
+		return
+	}
+
+	data, err := ctrl.accountService.ConfirmMFAEnrollWithToken(common.GetRequestActor(c), req.MFAToken, req.Code, clientInfo(c))
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorMFANotEnrolling:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		case stderr.ErrorMFATokenInvalid, stderr.ErrorMFACodeInvalid:
+			response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+		case stderr.ErrorMFAAlreadyEnabled:
+			response.Error(c, http.StatusConflict, response.CodeConflict, err.Error())
+		case stderr.ErrorLoginUserLocked, stderr.ErrorLoginIPLocked:
+			response.Error(c, http.StatusTooManyRequests, response.CodeTooManyRequests, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/account/login/mfa/enroll/confirm 确认绑定验证器失败! 错误: " + err.Error())
+		}
+		return
+	}
+
+	logger.Info(fmt.Sprintf("用户 %s 绑定验证器并登录成功", data.Login.Username))
+	response.Success(c, data)
+}
+
+// MFAStatus handles viewing the current user's two-factor authentication status.
+// @Summary 查看二次验证状态
+// @Description 查看当前登录用户是否开启了二次验证、是否必须开启以及剩余可用的恢复码个数
+// @Tags Account
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.MFAStatusResp "查询成功"
+// @Failure 401 {object} response.Response "token有错误"
+// @Failure 404 {object} response.Response "用户不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/account/me/mfa [get]
+func (ctrl *Controller) MFAStatus(c *gin.Context) {
+	uid, err := auth.GetCurrentUserID(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+		return
+	}
+
+	data, err := ctrl.accountService.GetMFAStatus(uid)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorUserNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorUserNotFound)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/account/me/mfa 查询二次验证状态失败! 用户ID: %d 错误: %s", uid, err.Error()))
+		}
+		return
+	}
+	response.Success(c, data)
+}
+
+// MFAEnroll handles starting a TOTP enrolment for the current user.
+// @Summary 开始绑定验证器
+// @Description 为当前登录用户生成密钥和二维码内容，在验证器应用中添加后调用确认接口才会生效。重复调用会生成新的密钥
+// @Tags Account
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.MFAEnrollResp "获取成功"
+// @Failure 401 {object} response.Response "token有错误"
+// @Failure 404 {object} response.Response "用户不存在"
+// @Failure 409 {object} response.Response "已经开启了二次验证"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/account/me/mfa/enroll [post]
+func (ctrl *Controller) MFAEnroll(c *gin.Context) {
+	uid, err := auth.GetCurrentUserID(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+		return
+	}
+
+	data, err := ctrl.accountService.BeginMFAEnroll(uid)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorUserNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorUserNotFound)
+		case stderr.ErrorMFAAlreadyEnabled:
+			response.Error(c, http.StatusConflict, response.CodeConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/account/me/mfa/enroll 获取二次验证密钥失败! 用户ID: %d 错误: %s", uid, err.Error()))
+		}
+		return
+	}
+	response.Success(c, data)
+}
+
+// MFAConfirm handles confirming a TOTP enrolment for the current user.
+// @Summary 确认绑定验证器
+// @Description 输入验证器中的6位验证码确认绑定，成功后开启二次验证并返回恢复码。恢复码只返回这一次，请提示用户妥善保存
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.MFACodeReq true "MFACode Request"
+// @Success 200 {object} dto.MFARecoveryCodesResp "绑定成功"
+// @Failure 400 {object} response.Response "参数错误、验证码错误或尚未获取密钥"
+// @Failure 401 {object} response.Response "token有错误"
+// @Failure 409 {object} response.Response "已经开启了二次验证"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/account/me/mfa/confirm [post]
+func (ctrl *Controller) MFAConfirm(c *gin.Context) {
+	var req dto.MFACodeReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/account/me/mfa/confirm 参数绑定错误: " + err.Error())
+		return
+	}
+
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+		return
+	}
+
+	data, err := ctrl.accountService.ConfirmMFAEnroll(actor, req.Code)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorMFACodeInvalid, stderr.ErrorMFANotEnrolling:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		case stderr.ErrorMFAAlreadyEnabled:
+			response.Error(c, http.StatusConflict, response.CodeConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/account/me/mfa/confirm 确认绑定验证器失败! 用户ID: %d 错误: %s", actor.UID, err.Error()))
+		}
+		return
+	}
+	response.Success(c, data)
+}
+
+// DisableMFA handles turning off two-factor authentication for the current user.
+// @Summary 关闭二次验证
+// @Description 校验密码和验证码（或恢复码）后关闭二次验证，同时作废全部恢复码。必须开启二次验证的管理员不能关闭
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.MFADisableReq true "MFADisable Request"
+// @Success 200 {object} response.Response "关闭成功"
+// @Failure 400 {object} response.Response "参数错误、密码或验证码错误、尚未开启二次验证"
+// @Failure 401 {object} response.Response "token有错误"
+// @Failure 403 {object} response.Response "管理员账号必须开启二次验证"
+// @Failure 404 {object} response.Response "用户不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/account/me/mfa [delete]
+func (ctrl *Controller) DisableMFA(c *gin.Context) {
+	var req dto.MFADisableReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/account/me/mfa 参数绑定错误: " + err.Error())
+		return
+	}
+
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+		return
+	}
+
+	err = ctrl.accountService.DisableMFA(actor, req.Password, req.Code)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorMFAPasswordWrong, stderr.ErrorMFACodeInvalid, stderr.ErrorMFANotEnabled:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		case stderr.ErrorMFARequiredForAdmin:
+			response.Error(c, http.StatusForbidden, response.CodeForbidden, err.Error())
+		case stderr.ErrorUserNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorUserNotFound)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/account/me/mfa 关闭二次验证失败! 用户ID: %d 错误: %s", actor.UID, err.Error()))
+		}
+		return
+	}
+	response.Success(c, nil)
+}
+
+// RegenerateRecoveryCodes handles regenerating the current user's recovery codes.
+// @Summary 重新生成恢复码
+// @Description 输入验证器中的6位验证码后重新生成恢复码，原有的恢复码全部作废
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.MFACodeReq true "MFACode Request"
+// @Success 200 {object} dto.MFARecoveryCodesResp "生成成功"
+// @Failure 400 {object} response.Response "参数错误、验证码错误或尚未开启二次验证"
+// @Failure 401 {object} response.Response "token有错误"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/account/me/mfa/recovery [post]
+func (ctrl *Controller) RegenerateRecoveryCodes(c *gin.Context) {
+	var req dto.MFACodeReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/account/me/mfa/recovery 参数绑定错误: " + err.Error())
+		return
+	}
+
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+		return
+	}
+
+	data, err := ctrl.accountService.RegenerateRecoveryCodes(actor, req.Code)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorMFACodeInvalid, stderr.ErrorMFANotEnabled:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/account/me/mfa/recovery 重新生成恢复码失败! 用户ID: %d 错误: %s", actor.UID, err.Error()))
+		}
+		return
+	}
+	response.Success(c, data)
+}
+
+// ResetMFA handles admin clearing a user's two-factor authentication.
+// @Summary 重置用户的二次验证
+// @Description 用户丢失验证器和恢复码时，管理员清除其二次验证，用户下次登录时可以（管理员账号必须）重新绑定
+// @Tags Account
+// @Produce json
+// @Param id path int true "用户ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response "重置成功"
+// @Failure 400 {object} response.Response "参数错误或该用户没有开启二次验证"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "没有该用户"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/account/mfa/{id} [delete]
+func (ctrl *Controller) ResetMFA(c *gin.Context) {
+	id, err := ctrl.getIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorUserIDInvalid)
+		logger.Error("/admin/account/mfa/ 无效的用户ID格式: " + c.Param("id"))
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.accountService.ResetUserMFA(actor, id)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorMFANotEnabled:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		case stderr.ErrorUserNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorUserNotFound)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/account/mfa/ 重置二次验证失败! 用户ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, nil)
+}
@@ -0,0 +1,137 @@
+package account
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	dto "xinde/internal/dto/account"
+	"xinde/internal/handler/common"
+	"xinde/internal/middleware/auth"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// Profile handles viewing the current user's profile.
+// @Summary 查看个人信息
+// @Description 查看当前登录用户的个人信息、所在公司的价格等级以及注册申请的审核状态
+// @Tags Account
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.ProfileResp "查询成功"
+// @Failure 401 {object} response.Response "token有错误"
+// @Failure 404 {object} response.Response "用户不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/account/me [get]
+func (ctrl *Controller) Profile(c *gin.Context) {
+	uid, err := auth.GetCurrentUserID(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+		return
+	}
+
+	data, err := ctrl.accountService.GetProfile(uid)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorUserNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorUserNotFound)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/account/me 查询个人信息失败! 用户ID: %d 错误: %s", uid, err.Error()))
+		}
+		return
+	}
+	response.Success(c, data)
+}
+
+// UpdateProfile handles updating the current user's profile.
+// @Summary 修改个人信息
+// @Description 修改当前登录用户的姓名、电话和邮箱，校验规则与注册时相同。不传的字段保持不变，邮箱传空字符串表示清空
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.UpdateProfileReq true "UpdateProfile Request"
+// @Success 200 {object} response.Response "修改成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token有错误"
+// @Failure 404 {object} response.Response "用户不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/account/me [patch]
+func (ctrl *Controller) UpdateProfile(c *gin.Context) {
+	var req dto.UpdateProfileReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/account/me 参数绑定错误: " + err.Error())
+		return
+	}
+
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+		return
+	}
+
+	err = ctrl.accountService.UpdateProfile(actor, &req)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorUserNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorUserNotFound)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/account/me 修改个人信息失败! 用户ID: %d 错误: %s", actor.UID, err.Error()))
+		}
+		return
+	}
+	response.Success(c, nil)
+}
+
+// ChangePassword handles the current user changing their own password.
+// @Summary 修改自己的密码
+// @Description 校验原密码后修改当前登录用户的密码。修改成功后该用户在所有设备上的登录都会失效，并为当前设备返回一对新的token
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.ChangePasswordReq true "ChangePassword Request"
+// @Success 200 {object} dto.ChangePasswordResp "修改成功，返回新的token"
+// @Failure 400 {object} response.Response "参数错误、原密码错误或新旧密码相同"
+// @Failure 401 {object} response.Response "token有错误"
+// @Failure 404 {object} response.Response "用户不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/account/me/password [put]
+func (ctrl *Controller) ChangePassword(c *gin.Context) {
+	var req dto.ChangePasswordReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/account/me/password 参数绑定错误: " + err.Error())
+		return
+	}
+
+	// 重复输入密码需一致
+	if req.Password != req.ConfirmedPassword {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorUserPasswordNotConfirm)
+		return
+	}
+
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+		return
+	}
+
+	data, err := ctrl.accountService.ChangePassword(actor, req.OldPassword, req.Password, clientInfo(c))
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorUserOldPasswordWrong, stderr.ErrorUserPasswordUnchanged:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		case stderr.ErrorUserNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorUserNotFound)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/account/me/password 修改密码失败! 用户ID: %d 错误: %s", actor.UID, err.Error()))
+		}
+		return
+	}
+	response.Success(c, data)
+}
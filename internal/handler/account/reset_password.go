@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	dto "xinde/internal/dto/account"
+	"xinde/internal/handler/common"
 	"xinde/pkg/logger"
 	"xinde/pkg/response"
 	"xinde/pkg/stderr"
@@ -11,17 +13,20 @@ import (
 
 // ResetPassword handles admin reset user's password.
 // @Summary 重置用户密码
-// @Description 管理员根据用户ID重置用户的密码为123456
+// @Description 管理员根据用户ID将用户的密码重置为随机生成的一次性密码，该密码只在本次响应中返回。用户登录前必须先修改这个密码，原有的登录全部失效。
+// @Description 公司管理员通过 /api/v1/company/account/reset/password/{id} 只能重置本公司用户的密码，不能重置拥有后台权限的用户、其他公司管理员和自己
 // @Tags Account
 // @Accept json
 // @Produce json
-// @Success 200 {object} response.Response "重置密码成功"
+// @Param id path int true "用户ID"
+// @Success 200 {object} dto.ResetPasswordResp "重置密码成功，返回一次性密码"
 // @Failure 400 {object} response.Response "参数错误"
 // @Failure 401 {object} response.Response "access_token有错误"
-// @Failure 403 {object} response.Response "没有管理员权限"
+// @Failure 403 {object} response.Response "没有管理员权限，或公司管理员重置拥有后台权限的用户、其他公司管理员或自己"
 // @Failure 404 {object} response.Response "没有该用户"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /api/v1/admin/account/reset/password/{id} [post]
+// @Router /api/v1/company/account/reset/password/{id} [post]
 func (ctrl *Controller) ResetPassword(c *gin.Context) {
 	id, err := ctrl.getIDFromUrl(c)
 	if err != nil {
@@ -30,12 +35,22 @@ func (ctrl *Controller) ResetPassword(c *gin.Context) {
 		return
 	}
 
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
 	// 无需参数校验，将剩余的工作交给Service处理
-	err = ctrl.accountService.ResetPassword(id)
+	var data *dto.ResetPasswordData
+	data, err = ctrl.scopedService(c).ResetPassword(actor, id)
 	if err != nil {
 		switch err.Error() {
 		case stderr.ErrorUserNotFound:
 			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorUserNotFound)
+		case stderr.ErrorPermissionDenied:
+			response.Error(c, http.StatusForbidden, response.CodeForbidden, stderr.ErrorPermissionDenied)
 		default:
 			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
 			logger.Error(fmt.Sprintf("/admin/account/reset/password/ 重置用户密码失败! 用户ID: %d 错误: %s", id, err.Error()))
@@ -43,5 +58,5 @@ func (ctrl *Controller) ResetPassword(c *gin.Context) {
 		return
 	}
 
-	response.Success(c, nil)
+	response.Success(c, data)
 }
@@ -0,0 +1,94 @@
+package account
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	dto "xinde/internal/dto/account"
+	"xinde/internal/middleware/auth"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// Refresh handles access token refresh.
+// @Summary 刷新token
+// @Description 使用refresh token换取新的access token，旧的refresh token随之失效并返回新的refresh token。已失效的refresh token被再次使用时，会注销该用户的全部登录
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshReq true "Refresh Request"
+// @Success 200 {object} dto.RefreshResp "刷新成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "refresh token无效、已过期或已被使用过"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/account/refresh [post]
+func (ctrl *Controller) Refresh(c *gin.Context) {
+	var req dto.RefreshReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/account/refresh 绑定DTO错误: " + err.Error())
+		return
+	}
+
+	data, err := ctrl.accountService.Refresh(req.RefreshToken, clientInfo(c))
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorRefreshTokenInvalid:
+			response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+		case stderr.ErrorRefreshTokenReused:
+			response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+			logger.Warn("/account/refresh 检测到refresh token被重复使用，已注销该用户的全部登录 IP: " + c.ClientIP())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/account/refresh 刷新token失败: " + err.Error())
+		}
+		return
+	}
+
+	response.Success(c, data)
+}
+
+// Logout handles user logout.
+// @Summary 退出登录
+// @Description 注销当前的access token，并吊销传入的refresh token；all为true时注销该用户在所有设备上的登录
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.LogoutReq false "Logout Request"
+// @Success 200 {object} response.Response "退出成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token有错误或refresh token无效"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/account/logout [post]
+func (ctrl *Controller) Logout(c *gin.Context) {
+	var req dto.LogoutReq
+	// 请求体可以为空
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBind(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+			logger.Error("/account/logout 绑定DTO错误: " + err.Error())
+			return
+		}
+	}
+
+	claims, err := auth.GetCurrentUser(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+		return
+	}
+
+	err = ctrl.accountService.Logout(claims, req.RefreshToken, req.All)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorRefreshTokenInvalid:
+			response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/account/logout 退出登录失败: " + err.Error())
+		}
+		return
+	}
+
+	response.Success(c, nil)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"net/http"
 	dto "xinde/internal/dto/account"
+	"xinde/internal/handler/common"
 	"xinde/pkg/logger"
 	"xinde/pkg/response"
 	"xinde/pkg/stderr"
@@ -39,8 +40,15 @@ func (ctrl *Controller) UpdatePassword(c *gin.Context) {
 		return
 	}
 
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
 	// 完成参数校验，将剩余的工作交由service处理
-	err = ctrl.accountService.UpdatePassword(id, req.Password)
+	err = ctrl.accountService.UpdatePassword(actor, id, req.Password)
 	if err != nil {
 		switch err.Error() {
 		case stderr.ErrorUserNotFound:
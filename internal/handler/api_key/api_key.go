@@ -0,0 +1,267 @@
+package api_key
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	dto "xinde/internal/dto/api_key"
+	"xinde/internal/handler/common"
+	"xinde/internal/service/api_key"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+type Controller struct {
+	apiKeyService *api_key.Service
+}
+
+func NewAPIKeyController() (*Controller, error) {
+	apiKeyService, err := api_key.NewAPIKeyService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Controller{
+		apiKeyService: apiKeyService,
+	}, nil
+}
+
+// ScopeList handles api key scope list.
+// @Summary 查看可分配的访问范围
+// @Description 返回 API Key 可以拥有的全部访问范围编码及说明
+// @Tags APIKey
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.ScopeListResp "查询成功"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Router /api/v1/admin/api_key/scopes [get]
+func (ctrl *Controller) ScopeList(c *gin.Context) {
+	response.Success(c, ctrl.apiKeyService.GetScopeList())
+}
+
+// List handles api key list.
+// @Summary 查看API Key列表
+// @Description 返回全部 API Key（包括已吊销和已过期的），不包含签名密钥
+// @Tags APIKey
+// @Accept json
+// @Produce json
+// @Param company_id query int false "按公司过滤，可选"
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.ListResp "查询成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/api_key/list [get]
+func (ctrl *Controller) List(c *gin.Context) {
+	var req dto.ListReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/admin/api_key/list 绑定参数错误: " + err.Error())
+		return
+	}
+
+	list, err := ctrl.apiKeyService.GetAPIKeyList(&req)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+		logger.Error("/admin/api_key/list " + err.Error())
+		return
+	}
+	response.Success(c, list)
+}
+
+// Create handles the creation of a new api key.
+// @Summary 创建API Key
+// @Description 为公司创建供外部系统服务端调用的 API Key，价格按该公司计算。返回的签名密钥只出现这一次，请提示管理员妥善保存。
+// @Description 访问范围编码见 /admin/api_key/scopes
+// @Tags APIKey
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateReq true "Create Request"
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.SecretResp "创建成功"
+// @Failure 400 {object} response.Response "参数错误、访问范围无效或过期时间无效"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "公司不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/api_key/create [post]
+func (ctrl *Controller) Create(c *gin.Context) {
+	var req dto.CreateReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/admin/api_key/create 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	data, err := ctrl.apiKeyService.CreateAPIKey(actor, &req)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorAPIKeyScopeInvalid, stderr.ErrorAPIKeyExpiresAtInvalid:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		case stderr.ErrorCompanyNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/admin/api_key/create 创建API Key失败: " + err.Error())
+		}
+		return
+	}
+	response.Success(c, data)
+}
+
+// Update handles the update of an api key.
+// @Summary 修改API Key
+// @Description 根据ID修改 API Key 的名称、访问范围或过期时间，立即生效。已吊销的 Key 不能修改
+// @Tags APIKey
+// @Accept json
+// @Produce json
+// @Param id path int true "API Key ID"
+// @Param request body dto.UpdateReq true "Update Request"
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response "修改成功"
+// @Failure 400 {object} response.Response "参数错误、访问范围无效、过期时间无效或已被吊销"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "API Key不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/api_key/update/{id} [patch]
+func (ctrl *Controller) Update(c *gin.Context) {
+	id, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorAPIKeyIDInvalid)
+		logger.Error("/admin/api_key/update 无效的API Key ID格式: " + err.Error())
+		return
+	}
+
+	var req dto.UpdateReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/admin/api_key/update 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.apiKeyService.UpdateAPIKey(actor, id, &req)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorAPIKeyNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		case stderr.ErrorAPIKeyScopeInvalid, stderr.ErrorAPIKeyExpiresAtInvalid, stderr.ErrorAPIKeyRevoked:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/api_key/update 修改API Key失败! ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, nil)
+}
+
+// Rotate handles regenerating the signing secret of an api key.
+// @Summary 轮换API Key的签名密钥
+// @Description 根据ID重新生成签名密钥，原密钥立即失效，key_id 不变。新的签名密钥只出现这一次
+// @Tags APIKey
+// @Accept json
+// @Produce json
+// @Param id path int true "API Key ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.SecretResp "轮换成功"
+// @Failure 400 {object} response.Response "参数错误或已被吊销"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "API Key不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/api_key/rotate/{id} [post]
+func (ctrl *Controller) Rotate(c *gin.Context) {
+	id, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorAPIKeyIDInvalid)
+		logger.Error("/admin/api_key/rotate 无效的API Key ID格式: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	data, err := ctrl.apiKeyService.RotateAPIKeySecret(actor, id)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorAPIKeyNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		case stderr.ErrorAPIKeyRevoked:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/api_key/rotate 轮换签名密钥失败! ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, data)
+}
+
+// Revoke handles revoking an api key.
+// @Summary 吊销API Key
+// @Description 根据ID吊销 API Key，立即失效且不能恢复，记录保留用于审计
+// @Tags APIKey
+// @Accept json
+// @Produce json
+// @Param id path int true "API Key ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response "吊销成功"
+// @Failure 400 {object} response.Response "参数错误或已被吊销"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "API Key不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/api_key/revoke/{id} [delete]
+func (ctrl *Controller) Revoke(c *gin.Context) {
+	id, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorAPIKeyIDInvalid)
+		logger.Error("/admin/api_key/revoke 无效的API Key ID格式: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.apiKeyService.RevokeAPIKey(actor, id)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorAPIKeyNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		case stderr.ErrorAPIKeyRevoked:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/api_key/revoke 吊销API Key失败! ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, nil)
+}
@@ -33,8 +33,15 @@ func (ctrl *Controller) Delete(c *gin.Context) {
 		logger.Error("DELETE admin/attachment/{id} 无效的ID格式: " + err.Error())
 		return
 	}
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
 	// 将删除任务交由service层处理
-	err = ctrl.attachmentService.Delete(id)
+	err = ctrl.attachmentService.Delete(actor, id)
 	if err != nil {
 		switch err.Error() {
 		case stderr.ErrorAttachmentNotFound:
@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"net/http"
 	dto "xinde/internal/dto/attachment"
+	"xinde/internal/handler/common"
 	"xinde/internal/middleware/auth"
 	"xinde/pkg/logger"
 	"xinde/pkg/response"
@@ -18,11 +19,10 @@ func (ctrl *Controller) FixOrphan(c *gin.Context) {
 		return
 	}
 
-	// 从上下文中获取当前操作管理员的ID
-	userID, err := auth.GetCurrentUserID(c)
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
 	if err != nil {
-		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员信息: "+err.Error())
-		logger.Error("/admin/attachment/fix/orphan 无法获取当前管理员信息: " + err.Error())
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
 		return
 	}
 	if !auth.IsAdmin(c) {
@@ -32,7 +32,7 @@ func (ctrl *Controller) FixOrphan(c *gin.Context) {
 	}
 
 	// 将修复任务交由service层处理
-	err = ctrl.attachmentService.FixOrphan(userID, req.FilePath, req.Action)
+	err = ctrl.attachmentService.FixOrphan(actor, req.FilePath, req.Action)
 	if err != nil {
 		switch err.Error() {
 		case stderr.ErrorAttachmentNotFoundOnDesk:
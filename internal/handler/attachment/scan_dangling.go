@@ -0,0 +1,33 @@
+package attachment
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	_ "xinde/internal/dto/attachment"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// ScanDangling handles checking attachments against the device catalog.
+// @Summary      核对附件和产品目录
+// @Description  找出设备类型、筛选图片或分组已经不存在的附件，以及缺少主图、导入Excel或筛选图片的业务对象。
+// @Description  同时返回还未执行完和已经失败的跨库操作数，有未执行完的操作时结果可能只是暂时的
+// @Tags         Attachment
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200 {object} _.ScanDanglingResp "成功返回核对结果"
+// @Failure      401 {object} response.Response "Token错误"
+// @Failure      403 {object} response.Response "没有管理员权限"
+// @Failure      500 {object} response.Response "服务器内部错误"
+// @Router       /api/v1/admin/attachment/scan/dangling [get]
+func (ctrl *Controller) ScanDangling(c *gin.Context) {
+	data, err := ctrl.attachmentService.ScanDangling()
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+		logger.Error("/admin/attachment/scan/dangling 核对附件和产品目录失败: " + err.Error())
+		return
+	}
+	response.Success(c, data)
+}
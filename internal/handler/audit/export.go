@@ -0,0 +1,55 @@
+package audit
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+	dto "xinde/internal/dto/audit"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/util"
+)
+
+// Export handles exporting audit logs to a CSV file.
+// @Summary      导出审计日志CSV文件
+// @Description  按与审计日志列表相同的查询条件导出全部符合条件的记录（不分页）
+// @Tags         Audit
+// @Produce      text/csv
+// @Param        actor_uid query int false "操作人用户ID"
+// @Param        action query string false "操作类型，如user.approve"
+// @Param        entity_type query string false "对象类型，如user、price_level"
+// @Param        entity_id query string false "对象ID"
+// @Param        request_id query string false "请求ID（响应头X-Request-ID）"
+// @Param        from query string false "开始时间（含），格式YYYY-MM-DD或YYYY-MM-DD HH:MM:SS"
+// @Param        to query string false "结束时间（不含），格式同上"
+// @Security     ApiKeyAuth
+// @Success      200 {file} file "CSV文件流"
+// @Failure      400 {object} response.Response "参数错误"
+// @Failure      401 {object} response.Response "Token错误"
+// @Failure      403 {object} response.Response "权限不足"
+// @Failure      500 {object} response.Response "服务器内部错误"
+// @Router       /api/v1/admin/audit/export [get]
+func (ctrl *Controller) Export(c *gin.Context) {
+	var req dto.FilterReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/admin/audit/export 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 开始写文件流之后就不能再返回错误响应了，所以先校验参数
+	if err := ctrl.auditService.ValidateFilter(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		return
+	}
+
+	fileName := fmt.Sprintf("审计日志_%s.csv", time.Now().Format("20060102150405"))
+	c.Header("Content-Disposition", util.FormatContentDisposition(fileName))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	if err := ctrl.auditService.ExportAuditLogs(&req, c.Writer); err != nil {
+		logger.Error("/admin/audit/export 导出审计日志失败: " + err.Error())
+	}
+}
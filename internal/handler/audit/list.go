@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"net/http"
+	dto "xinde/internal/dto/audit"
+	"xinde/internal/service/audit"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+type Controller struct {
+	auditService *audit.Service
+}
+
+func NewAuditController() (*Controller, error) {
+	auditService, err := audit.NewAuditService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Controller{
+		auditService: auditService,
+	}, nil
+}
+
+// List handles audit log list.
+// @Summary 查看审计日志
+// @Description 分页查看管理操作的审计日志，最新的在前。支持按操作人、操作类型、对象、请求ID和时间范围过滤
+// @Tags Audit
+// @Accept json
+// @Produce json
+// @Param page query int false "当前页数，可选，默认为1"
+// @Param page_size query int false "一页的内容数量，可选，默认为设置的默认值"
+// @Param actor_uid query int false "操作人用户ID"
+// @Param action query string false "操作类型，如user.approve"
+// @Param entity_type query string false "对象类型，如user、price_level"
+// @Param entity_id query string false "对象ID"
+// @Param request_id query string false "请求ID（响应头X-Request-ID）"
+// @Param from query string false "开始时间（含），格式YYYY-MM-DD或YYYY-MM-DD HH:MM:SS"
+// @Param to query string false "结束时间（不含），格式同上"
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.ListResp "查询成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/audit/list [get]
+func (ctrl *Controller) List(c *gin.Context) {
+	var req dto.ListReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/admin/audit/list 绑定参数错误: " + err.Error())
+		return
+	}
+
+	if req.PageSize == 0 {
+		req.PageSize = viper.GetInt("page.defaultPageSize")
+	}
+
+	list, err := ctrl.auditService.GetAuditLogList(&req)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorAuditTimeInvalid:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		case stderr.ErrorOverLargePage:
+			response.SuccessWithMessage(c, fmt.Sprintf("%s, 跳转至最后一页", stderr.ErrorOverLargePage), list)
+		case stderr.ErrorOverSmallPage:
+			response.SuccessWithMessage(c, fmt.Sprintf("%s, 跳转至第一页", stderr.ErrorOverSmallPage), list)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/admin/audit/list " + err.Error())
+		}
+		return
+	}
+	response.Success(c, list)
+}
@@ -4,6 +4,11 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"strconv"
+	"xinde/internal/middleware/auth"
+	"xinde/internal/middleware/requestid"
+	"xinde/internal/model/audit"
+	groupModel "xinde/internal/model/group"
+	roleModel "xinde/internal/model/role"
 	"xinde/pkg/stderr"
 )
 
@@ -18,3 +23,41 @@ func GetIDFromUrl(c *gin.Context) (uint, error) {
 	}
 	return uint(id), nil
 }
+
+// GetActor 从请求中提取审计日志需要的操作人信息，需要先经过 JWTAuth
+func GetActor(c *gin.Context) (*audit.Actor, error) {
+	uid, err := auth.GetCurrentUserID(c)
+	if err != nil {
+		return nil, err
+	}
+	return &audit.Actor{
+		UID:       uid,
+		Username:  c.GetString("username"),
+		IP:        c.ClientIP(),
+		RequestID: requestid.Get(c),
+	}, nil
+}
+
+// GetRequestActor 用于不需要登录的接口，只带上请求的IP和请求ID，操作人的用户信息由 Service 层补全
+func GetRequestActor(c *gin.Context) *audit.Actor {
+	return &audit.Actor{
+		IP:        c.ClientIP(),
+		RequestID: requestid.Get(c),
+	}
+}
+
+// GetCatalogViewer 返回查看产品目录的人，用于按可见性规则过滤分组和设备类型。
+// 通过后台接口（已校验 catalog:read 权限）访问时不受限制；通过 API Key 访问时按 Key 所属的公司；其他情况按当前用户
+func GetCatalogViewer(c *gin.Context) (*groupModel.Viewer, error) {
+	if auth.HasPermission(c, roleModel.PermCatalogRead) {
+		return &groupModel.Viewer{All: true}, nil
+	}
+	if key, ok := auth.GetAPIKey(c); ok {
+		return &groupModel.Viewer{CompanyID: key.CompanyID}, nil
+	}
+	uid, err := auth.GetCurrentUserID(c)
+	if err != nil {
+		return nil, err
+	}
+	return &groupModel.Viewer{UID: uid}, nil
+}
@@ -0,0 +1,81 @@
+package company
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"net/http"
+	dto "xinde/internal/dto/company"
+	"xinde/internal/handler/common"
+	"xinde/internal/middleware/auth"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// getCompanyID 公司管理员的请求只能访问其所在的公司，其他请求从URL中提取公司ID
+func getCompanyID(c *gin.Context) (uint, error) {
+	if companyID, ok := auth.GetCompanyScope(c); ok {
+		return companyID, nil
+	}
+	return common.GetIDFromUrl(c)
+}
+
+// Activity handles the search activity of a company's users.
+// @Summary 查看公司用户的搜索记录
+// @Description 按访问时间倒序分页返回公司用户查看设备类型的记录，可以按用户和时间范围过滤。
+// @Description 公司管理员通过 /api/v1/company/activity 只能查看本公司的记录
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path int true "公司ID"
+// @Param page query int false "当前页数，可选，默认为1"
+// @Param page_size query int false "一页的内容数量，可选，默认为设置的默认值"
+// @Param user_id query int false "只看某个用户，可选"
+// @Param from query string false "开始时间，YYYY-MM-DD或YYYY-MM-DD HH:MM:SS，可选"
+// @Param to query string false "结束时间（不含），YYYY-MM-DD或YYYY-MM-DD HH:MM:SS，可选"
+// @Success 200 {object} dto.ActivityResp "查询成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "公司不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/company/activity/{id} [get]
+// @Router /api/v1/company/activity [get]
+func (ctrl *Controller) Activity(c *gin.Context) {
+	id, err := getCompanyID(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorCompanyIDInvalid)
+		logger.Error("/company/activity 无效的公司ID格式: " + err.Error())
+		return
+	}
+
+	var req dto.ActivityReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/company/activity 绑定参数错误: " + err.Error())
+		return
+	}
+	if req.PageSize == 0 {
+		req.PageSize = viper.GetInt("page.defaultPageSize")
+	}
+
+	list, err := ctrl.companyService.GetCompanyActivity(id, &req)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorCompanyActivityTimeInvalid:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		case stderr.ErrorCompanyNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		case stderr.ErrorOverLargePage:
+			response.SuccessWithMessage(c, fmt.Sprintf("%s, 跳转至最后一页", stderr.ErrorOverLargePage), list)
+		case stderr.ErrorOverSmallPage:
+			response.SuccessWithMessage(c, fmt.Sprintf("%s, 跳转至第一页", stderr.ErrorOverSmallPage), list)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/company/activity 查询公司用户的搜索记录失败! 公司ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, list)
+}
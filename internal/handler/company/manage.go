@@ -0,0 +1,333 @@
+package company
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"net/http"
+	dto "xinde/internal/dto/company"
+	"xinde/internal/handler/common"
+	"xinde/internal/middleware/auth"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// Detail handles company detail.
+// @Summary 查看公司详情
+// @Description 根据ID返回公司信息及用户数。公司管理员通过 /api/v1/company/info 查看本公司，不返回备注
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path int true "公司ID"
+// @Success 200 {object} dto.DetailResp "查询成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "公司不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/company/detail/{id} [get]
+// @Router /api/v1/company/info [get]
+func (ctrl *Controller) Detail(c *gin.Context) {
+	id, err := getCompanyID(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorCompanyIDInvalid)
+		logger.Error("/admin/company/detail 无效的公司ID格式: " + err.Error())
+		return
+	}
+
+	data, err := ctrl.companyService.GetCompanyDetail(id)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorCompanyNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/company/detail 查询公司失败! 公司ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	// 备注是平台内部使用的，不给公司管理员看
+	if _, ok := auth.GetCompanyScope(c); ok {
+		data.Notes = ""
+	}
+	response.Success(c, data)
+}
+
+// Create handles the creation of a new company.
+// @Summary 创建公司
+// @Description 创建公司，名称不能与已有的公司（包括已删除和已合并的）重复，价格等级默认为price_1
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateReq true "Create Request"
+// @Success 200 {object} dto.CreateResp "创建成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "价格等级不存在"
+// @Failure 409 {object} response.Response "公司名称已存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/company/create [post]
+func (ctrl *Controller) Create(c *gin.Context) {
+	var req dto.CreateReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/admin/company/create 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	id, err := ctrl.companyService.CreateCompany(actor, &req)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorCompanyNameExists:
+			response.Error(c, http.StatusConflict, response.CodeConflict, err.Error())
+		case stderr.ErrorPriceLevelNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/admin/company/create 创建公司失败: " + err.Error())
+		}
+		return
+	}
+	response.Success(c, &dto.CreateData{ID: id})
+}
+
+// Update handles the update of a company.
+// @Summary 修改公司
+// @Description 根据ID修改公司的名称、地址、价格等级或备注，不传的字段不修改。改名时同步修改该公司用户的公司名称
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path int true "公司ID"
+// @Param request body dto.UpdateReq true "Update Request"
+// @Success 200 {object} response.Response "修改成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "公司或价格等级不存在"
+// @Failure 409 {object} response.Response "公司名称已存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/company/update/{id} [patch]
+func (ctrl *Controller) Update(c *gin.Context) {
+	id, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorCompanyIDInvalid)
+		logger.Error("/admin/company/update 无效的公司ID格式: " + err.Error())
+		return
+	}
+
+	var req dto.UpdateReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/admin/company/update 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.companyService.UpdateCompany(actor, id, &req)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorCompanyNotFound, stderr.ErrorPriceLevelNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		case stderr.ErrorCompanyNameExists:
+			response.Error(c, http.StatusConflict, response.CodeConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/company/update 修改公司失败! 公司ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, nil)
+}
+
+// Delete handles the deletion of a company.
+// @Summary 删除公司
+// @Description 根据ID删除公司。公司下还有用户、专属价格、折扣规则或API Key时不能删除，重复的公司请使用合并
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path int true "公司ID"
+// @Success 200 {object} response.Response "删除成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "公司不存在"
+// @Failure 409 {object} response.Response "公司仍在使用中"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/company/delete/{id} [delete]
+func (ctrl *Controller) Delete(c *gin.Context) {
+	id, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorCompanyIDInvalid)
+		logger.Error("/admin/company/delete 无效的公司ID格式: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.companyService.DeleteCompany(actor, id)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorCompanyNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		case stderr.ErrorCompanyInUse:
+			response.Error(c, http.StatusConflict, response.CodeConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/company/delete 删除公司失败! 公司ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, nil)
+}
+
+// Members handles company member list.
+// @Summary 查看公司的用户
+// @Description 分页返回公司的全部用户，包括尚未审批和已拒绝的
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path int true "公司ID"
+// @Param page query int false "当前页数，可选，默认为1"
+// @Param page_size query int false "一页的内容数量，可选，默认为设置的默认值"
+// @Success 200 {object} dto.MemberListResp "查询成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "公司不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/company/members/{id} [get]
+func (ctrl *Controller) Members(c *gin.Context) {
+	id, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorCompanyIDInvalid)
+		logger.Error("/admin/company/members 无效的公司ID格式: " + err.Error())
+		return
+	}
+
+	var req dto.MemberListReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/admin/company/members 绑定参数错误: " + err.Error())
+		return
+	}
+	if req.PageSize == 0 {
+		req.PageSize = viper.GetInt("page.defaultPageSize")
+	}
+
+	list, err := ctrl.companyService.GetCompanyMemberList(id, req.Page, req.PageSize)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorCompanyNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		case stderr.ErrorOverLargePage:
+			response.SuccessWithMessage(c, fmt.Sprintf("%s, 跳转至最后一页", stderr.ErrorOverLargePage), list)
+		case stderr.ErrorOverSmallPage:
+			response.SuccessWithMessage(c, fmt.Sprintf("%s, 跳转至第一页", stderr.ErrorOverSmallPage), list)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/company/members 查询公司的用户失败! 公司ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, list)
+}
+
+// Duplicates handles finding companies with similar names.
+// @Summary 查找疑似重复的公司
+// @Description 忽略全角半角、大小写、空白、标点和“有限公司”等后缀后，按编辑距离比较公司名称，相似度不低于threshold的公司归为一组。
+// @Description 确认重复后可以使用合并接口合并
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param threshold query number false "名称相似度阈值，0-1，可选，默认为0.8"
+// @Success 200 {object} dto.DuplicateResp "查询成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/company/duplicates [get]
+func (ctrl *Controller) Duplicates(c *gin.Context) {
+	var req dto.DuplicateReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/admin/company/duplicates 绑定参数错误: " + err.Error())
+		return
+	}
+
+	groups, err := ctrl.companyService.FindDuplicateCompanies(req.Threshold)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+		logger.Error("/admin/company/duplicates " + err.Error())
+		return
+	}
+	response.Success(c, groups)
+}
+
+// Merge handles merging companies.
+// @Summary 合并公司
+// @Description 把source_ids中的公司合并到target_id：用户、设备访问记录和API Key转到保留的公司，被合并的公司删除，
+// @Description 以后按被合并公司的名称注册的用户直接归入保留的公司。被合并的公司的公司管理员不再是公司管理员，需要时重新设置。在一个事务中完成。被合并的公司有专属价格或折扣规则时不能合并，请先处理
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param request body dto.MergeReq true "Merge Request"
+// @Success 200 {object} dto.MergeResp "合并成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "公司不存在"
+// @Failure 409 {object} response.Response "被合并的公司有专属价格或折扣规则"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/company/merge [post]
+func (ctrl *Controller) Merge(c *gin.Context) {
+	var req dto.MergeReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/admin/company/merge 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	data, err := ctrl.companyService.MergeCompanies(actor, req.TargetID, req.SourceIDs)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorCompanyMergeTargetSource:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		case stderr.ErrorCompanyNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		case stderr.ErrorCompanyMergeHasPriceData:
+			response.Error(c, http.StatusConflict, response.CodeConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/company/merge 合并公司失败! 保留公司ID: %d 错误: %s", req.TargetID, err.Error()))
+		}
+		return
+	}
+	response.Success(c, data)
+}
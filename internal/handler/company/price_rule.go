@@ -0,0 +1,285 @@
+package company
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	dto "xinde/internal/dto/company"
+	"xinde/internal/handler/common"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// OverrideList handles the list of a company's price overrides.
+// @Summary 查看公司专属价格
+// @Description 管理员根据公司ID查看该公司针对单个产品的专属价格，公司管理员只能查看本公司的
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path int true "公司ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.OverrideListResp "查询成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "access_token有错误"
+// @Failure 403 {object} response.Response "没有管理员权限"
+// @Failure 404 {object} response.Response "没有该公司"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/company/price/override/list/{id} [get]
+// @Router /api/v1/company/price/override/list [get]
+func (ctrl *Controller) OverrideList(c *gin.Context) {
+	id, err := getCompanyID(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorCompanyIDInvalid)
+		logger.Error("/admin/company/price/override/list 无效的公司ID格式: " + err.Error())
+		return
+	}
+
+	list, err := ctrl.companyService.GetPriceOverrideList(id)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorCompanyNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorCompanyNotFound)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/company/price/override/list 查询公司专属价格失败! 公司ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, list)
+}
+
+// SaveOverride handles setting a company's price override.
+// @Summary 设置公司专属价格
+// @Description 管理员为公司设置某个产品的专属价格，已存在时覆盖。专属价格的优先级高于折扣规则和价格等级
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path int true "公司ID"
+// @Param request body dto.SaveOverrideReq true "SaveOverride Request"
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response "设置成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "access_token有错误"
+// @Failure 403 {object} response.Response "没有管理员权限"
+// @Failure 404 {object} response.Response "没有该公司"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/company/price/override/save/{id} [post]
+func (ctrl *Controller) SaveOverride(c *gin.Context) {
+	id, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorCompanyIDInvalid)
+		logger.Error("/admin/company/price/override/save 无效的公司ID格式: " + err.Error())
+		return
+	}
+
+	var req dto.SaveOverrideReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/admin/company/price/override/save 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.companyService.SavePriceOverride(actor, id, req.ProductCode, *req.Price, req.Remark)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorCompanyNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorCompanyNotFound)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/company/price/override/save 设置公司专属价格失败! 公司ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, nil)
+}
+
+// DeleteOverride handles the deletion of a price override.
+// @Summary 删除公司专属价格
+// @Description 管理员根据专属价格ID删除，删除后该产品恢复按折扣规则或价格等级计价
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path int true "专属价格ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response "删除成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "access_token有错误"
+// @Failure 403 {object} response.Response "没有管理员权限"
+// @Failure 404 {object} response.Response "专属价格不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/company/price/override/delete/{id} [delete]
+func (ctrl *Controller) DeleteOverride(c *gin.Context) {
+	id, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorPriceOverrideIDInvalid)
+		logger.Error("/admin/company/price/override/delete 无效的专属价格ID格式: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.companyService.DeletePriceOverride(actor, id)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorPriceOverrideNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorPriceOverrideNotFound)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/company/price/override/delete 删除公司专属价格失败! ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, nil)
+}
+
+// RuleList handles the list of a company's discount rules.
+// @Summary 查看公司折扣规则
+// @Description 管理员根据公司ID查看该公司的折扣规则。同时命中多条规则时，产品编码前缀规则优先于设备分组规则，前缀越长、分组越近越优先
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path int true "公司ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.RuleListResp "查询成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "access_token有错误"
+// @Failure 403 {object} response.Response "没有管理员权限"
+// @Failure 404 {object} response.Response "没有该公司"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/company/price/rule/list/{id} [get]
+// @Router /api/v1/company/price/rule/list [get]
+func (ctrl *Controller) RuleList(c *gin.Context) {
+	id, err := getCompanyID(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorCompanyIDInvalid)
+		logger.Error("/admin/company/price/rule/list 无效的公司ID格式: " + err.Error())
+		return
+	}
+
+	list, err := ctrl.companyService.GetPriceRuleList(id)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorCompanyNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorCompanyNotFound)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/company/price/rule/list 查询公司折扣规则失败! 公司ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, list)
+}
+
+// CreateRule handles the creation of a company's discount rule.
+// @Summary 创建公司折扣规则
+// @Description 管理员为公司创建按产品编码前缀或设备分组（含子孙分组）限定范围的百分比折扣，折扣在公司价格等级的价格上计算
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path int true "公司ID"
+// @Param request body dto.CreateRuleReq true "CreateRule Request"
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response "创建成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "access_token有错误"
+// @Failure 403 {object} response.Response "没有管理员权限"
+// @Failure 404 {object} response.Response "没有该公司或该分组"
+// @Failure 409 {object} response.Response "已存在相同范围的折扣规则"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/company/price/rule/create/{id} [post]
+func (ctrl *Controller) CreateRule(c *gin.Context) {
+	id, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorCompanyIDInvalid)
+		logger.Error("/admin/company/price/rule/create 无效的公司ID格式: " + err.Error())
+		return
+	}
+
+	var req dto.CreateRuleReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/admin/company/price/rule/create 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.companyService.CreatePriceRule(actor, id, &req)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorPriceRuleScopeInvalid:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorPriceRuleScopeInvalid)
+		case stderr.ErrorCompanyNotFound, stderr.ErrorGroupNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		case stderr.ErrorPriceRuleConflict:
+			response.Error(c, http.StatusConflict, response.CodeConflict, stderr.ErrorPriceRuleConflict)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/company/price/rule/create 创建公司折扣规则失败! 公司ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, nil)
+}
+
+// DeleteRule handles the deletion of a discount rule.
+// @Summary 删除公司折扣规则
+// @Description 管理员根据折扣规则ID删除规则
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param id path int true "折扣规则ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response "删除成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "access_token有错误"
+// @Failure 403 {object} response.Response "没有管理员权限"
+// @Failure 404 {object} response.Response "折扣规则不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/company/price/rule/delete/{id} [delete]
+func (ctrl *Controller) DeleteRule(c *gin.Context) {
+	id, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorPriceRuleIDInvalid)
+		logger.Error("/admin/company/price/rule/delete 无效的折扣规则ID格式: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.companyService.DeletePriceRule(actor, id)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorPriceRuleNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorPriceRuleNotFound)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/company/price/rule/delete 删除公司折扣规则失败! ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, nil)
+}
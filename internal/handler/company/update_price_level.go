@@ -22,7 +22,7 @@ import (
 // @Failure 400 {object} response.Response "参数错误"
 // @Failure 401 {object} response.Response "access_token有错误"
 // @Failure 403 {object} response.Response "没有管理员权限"
-// @Failure 404 {object} response.Response "没有该公司"
+// @Failure 404 {object} response.Response "没有该公司或该价格等级"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /api/v1/admin/company/price/level/{id} [patch]
 func (ctrl *Controller) UpdatePriceLevel(c *gin.Context) {
@@ -41,13 +41,23 @@ func (ctrl *Controller) UpdatePriceLevel(c *gin.Context) {
 		return
 	}
 
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
 	// 完成参数校验，将剩余的工作交由service处理
-	err = ctrl.companyService.UpdatePriceLevel(id, req.PriceLevel)
+	err = ctrl.companyService.UpdatePriceLevel(actor, id, req.PriceLevel)
 	if err != nil {
 		switch err.Error() {
 		case stderr.ErrorCompanyNotFound:
 			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorCompanyNotFound)
 			logger.Error(fmt.Sprintf("/admin/company/price/level/patch 修改公司价格等级失败! 公司ID: %d 错误: %s", id, err.Error()))
+		case stderr.ErrorPriceLevelNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorPriceLevelNotFound)
+			logger.Error(fmt.Sprintf("/admin/company/price/level/patch 修改公司价格等级失败! 公司ID: %d 错误: %s", id, err.Error()))
 		default:
 			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
 			logger.Error(fmt.Sprintf("/admin/company/price/level/patch 修改公司价格等级失败! 公司ID: %d 错误: %s", id, err.Error()))
@@ -4,7 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"net/http"
 	dto "xinde/internal/dto/device"
-	"xinde/internal/middleware/auth"
+	"xinde/internal/handler/common"
 	"xinde/pkg/logger"
 	"xinde/pkg/response"
 	"xinde/pkg/stderr"
@@ -42,14 +42,14 @@ func (ctrl *Controller) CreateFilterImage(c *gin.Context) {
 		return
 	}
 
-	adminUID, err := auth.GetCurrentUserID(c)
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
 	if err != nil {
-		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取管理员ID: "+err.Error())
-		logger.Error("/admin/filter_image/create 无法获取管理员ID" + err.Error())
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
 		return
 	}
 
-	err = ctrl.service.CreateFilterImage(adminUID, req.DeviceTypeID, req.FilterValue, imageFile)
+	err = ctrl.service.CreateFilterImage(actor, req.DeviceTypeID, req.FilterValue, imageFile)
 	if err != nil {
 		switch err.Error() {
 		case stderr.ErrorDeviceNotFound:
@@ -31,8 +31,15 @@ func (ctrl *Controller) DeleteFilterImage(c *gin.Context) {
 		return
 	}
 
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
 	// 剩余的工作交由service处理
-	err = ctrl.service.DeleteFilterImage(id)
+	err = ctrl.service.DeleteFilterImage(actor, id)
 	if err != nil {
 		switch err.Error() {
 		case gorm.ErrRecordNotFound.Error():
@@ -12,7 +12,7 @@ import (
 
 // GroupDeviceList handles fetching a list of device types.
 // @Summary      根据分组获取设备类型列表
-// @Description  分页获取设备类型列表，用于前台展示
+// @Description  分页获取设备类型列表，用于前台展示。前台接口只返回当前用户可见的设备类型，分组对当前用户隐藏时返回404
 // @Tags         Group
 // @Tags         Solution
 // @Accept       json
@@ -33,8 +33,15 @@ func (ctrl *Controller) GroupDeviceList(c *gin.Context) {
 		return
 	}
 	_ = dto.ListPageData{}
+	viewer, err := common.GetCatalogViewer(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前的用户ID: "+err.Error())
+		logger.Error("/groups/device_types 无法获取当前的用户ID: " + err.Error())
+		return
+	}
+
 	// 剩余的工作交由service层处理
-	list, err := ctrl.service.GroupDeviceList(groupID)
+	list, err := ctrl.service.GroupDeviceList(groupID, viewer)
 	if err != nil {
 		switch err.Error() {
 		case stderr.ErrorGroupNotFound:
@@ -5,7 +5,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"net/http"
 	dto "xinde/internal/dto/device"
-	"xinde/internal/middleware/auth"
+	"xinde/internal/handler/common"
 	"xinde/internal/service/device"
 	"xinde/pkg/logger"
 	"xinde/pkg/response"
@@ -32,6 +32,7 @@ func NewDeviceController() (*Controller, error) {
 // @Produce      json
 // @Param        group_id formData int true "目标分组ID"
 // @Param        name formData string true "设备的名称"
+// @Param        status formData string false "设备类型状态: draft 或 published。新建时默认 published，已存在时默认保持不变；指定时清除计划的发布/下架时间"
 // @Param        device formData file true "包含设备数据的Excel文件"
 // @Param        image formData file true "设备的主图"
 // @Security     ApiKeyAuth
@@ -61,15 +62,15 @@ func (ctrl *Controller) Import(c *gin.Context) {
 		return
 	}
 
-	adminID, err := auth.GetCurrentUserID(c)
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
 	if err != nil {
-		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前操作的管理员ID"+err.Error())
-		logger.Error("/admin/device/import 无法获取当前操作的管理员ID: " + err.Error())
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
 		return
 	}
 
 	// 将剩余的工作交由service处理
-	err = ctrl.service.ImportFromExcel(adminID, req.GroupID, req.DeviceTypeName, excelFile, imageFile)
+	err = ctrl.service.ImportFromExcel(actor, req.GroupID, req.DeviceTypeName, req.Status, excelFile, imageFile)
 	if err != nil {
 		switch err.Error() {
 		default:
@@ -0,0 +1,54 @@
+package device
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	dto "xinde/internal/dto/device"
+	"xinde/internal/handler/common"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// Move handles moving several device types to a group or reordering them.
+// @Summary      批量移动设备类型或调整顺序
+// @Description  把多个设备类型按请求中的顺序移动到目标分组的指定位置，目标分组为设备类型当前的分组时即调整顺序
+// @Tags         Device
+// @Accept       json
+// @Produce      json
+// @Param        body body      dto.MoveReq true "设备类型ID列表、目标分组和位置"
+// @Security     ApiKeyAuth
+// @Success      200 {object} response.Response "移动成功"
+// @Failure      400 {object} response.Response "请求参数错误"
+// @Failure      404 {object} response.Response "设备类型或分组不存在"
+// @Failure      500 {object} response.Response "服务器内部错误"
+// @Router       /api/v1/admin/device/move [patch]
+func (ctrl *Controller) Move(c *gin.Context) {
+	var req dto.MoveReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/admin/device/move 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	// 将剩余的工作交由service处理
+	err = ctrl.service.Move(actor, req.DeviceTypeIDs, req.GroupID, req.Position)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorDeviceNotFound, stderr.ErrorGroupNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/admin/device/move 移动设备类型失败: " + err.Error())
+		}
+		return
+	}
+	response.Success(c, nil)
+}
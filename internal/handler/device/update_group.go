@@ -39,12 +39,21 @@ func (ctrl *Controller) UpdateGroup(c *gin.Context) {
 		return
 	}
 
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
 	// 剩余的工作交由service处理
-	err = ctrl.service.UpdateGroup(deviceTypeID, req.GroupID)
+	err = ctrl.service.UpdateGroup(actor, deviceTypeID, req.GroupID)
 	if err != nil {
 		switch err.Error() {
 		case stderr.ErrorGroupNotFound:
 			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorGroupNotFound)
+		case stderr.ErrorDeviceNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorDeviceNotFound)
 		default:
 			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
 			logger.Error("/admin/device/update/group 更新设备类型分组错误: " + err.Error())
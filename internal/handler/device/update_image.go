@@ -4,7 +4,6 @@ import (
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"xinde/internal/handler/common"
-	"xinde/internal/middleware/auth"
 	"xinde/pkg/logger"
 	"xinde/pkg/response"
 	"xinde/pkg/stderr"
@@ -39,15 +38,15 @@ func (ctrl *Controller) UpdateImage(c *gin.Context) {
 		return
 	}
 
-	adminUID, err := auth.GetCurrentUserID(c)
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
 	if err != nil {
-		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前操作的管理员ID")
-		logger.Error("/admin/device/update/image 无法获取当前操作的管理员ID: " + err.Error())
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
 		return
 	}
 
 	// 剩余的工作交由service处理
-	err = ctrl.service.UpdateImage(deviceTypeID, adminUID, imageFile)
+	err = ctrl.service.UpdateImage(actor, deviceTypeID, imageFile)
 	if err != nil {
 		switch err.Error() {
 		case stderr.ErrorDeviceNotFound:
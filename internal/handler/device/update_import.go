@@ -3,8 +3,8 @@ package device
 import (
 	"github.com/gin-gonic/gin"
 	"net/http"
+	dto "xinde/internal/dto/device"
 	"xinde/internal/handler/common"
-	"xinde/internal/middleware/auth"
 	"xinde/pkg/logger"
 	"xinde/pkg/response"
 	"xinde/pkg/stderr"
@@ -17,6 +17,7 @@ import (
 // @Accept       multipart/form-data
 // @Produce      json
 // @Param        id   path      int  true  "设备类型 ID"
+// @Param        status formData string false "设备类型状态: draft 或 published，不填时保持不变；指定时清除计划的发布/下架时间"
 // @Param        device formData file true "包含新设备方案的Excel文件"
 // @Security     ApiKeyAuth
 // @Success      200 {object} response.Response "更新导入成功"
@@ -32,6 +33,13 @@ func (ctrl *Controller) UpdateImport(c *gin.Context) {
 		return
 	}
 
+	var req dto.UpdateImportReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数失败: "+err.Error())
+		logger.Error("/admin/device/import/:id 绑定参数失败: " + err.Error())
+		return
+	}
+
 	excelFile, err := c.FormFile("device")
 	if err != nil {
 		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "获取Excel文件失败: "+err.Error())
@@ -39,15 +47,15 @@ func (ctrl *Controller) UpdateImport(c *gin.Context) {
 		return
 	}
 
-	adminID, err := auth.GetCurrentUserID(c)
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
 	if err != nil {
-		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前操作的管理员ID"+err.Error())
-		logger.Error("/admin/device/import/:id 无法获取当前操作的管理员ID: " + err.Error())
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
 		return
 	}
 
 	// 将剩余的工作交由service处理
-	err = ctrl.service.UpdateImport(id, adminID, excelFile)
+	err = ctrl.service.UpdateImport(actor, id, req.Status, excelFile)
 	if err != nil {
 		switch err.Error() {
 		case stderr.ErrorDeviceNotFound:
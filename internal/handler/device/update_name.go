@@ -39,11 +39,23 @@ func (ctrl *Controller) UpdateName(c *gin.Context) {
 		return
 	}
 
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
 	// 将剩余的工作交由service处理
-	err = ctrl.service.UpdateName(deviceTypeID, req.Name)
+	err = ctrl.service.UpdateName(actor, deviceTypeID, req.Name)
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, response.CodeInternalError, "更改设备名称类型名称失败: "+err.Error())
-		logger.Error("/admin/device/update/name 更改设备名称类型失败: " + err.Error())
+		switch err.Error() {
+		case stderr.ErrorDeviceNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorDeviceNotFound)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, "更改设备名称类型名称失败: "+err.Error())
+			logger.Error("/admin/device/update/name 更改设备名称类型失败: " + err.Error())
+		}
 		return
 	}
 	response.Success(c, nil)
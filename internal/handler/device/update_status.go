@@ -0,0 +1,64 @@
+package device
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	dto "xinde/internal/dto/device"
+	"xinde/internal/handler/common"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// UpdateStatus handles changing the lifecycle status of a device type.
+// @Summary      更改设备类型的发布状态
+// @Description  将设备类型设为草稿(draft)、已发布(published)或已下架(archived)，可以同时指定计划发布和下架时间。只有已发布的设备类型对前台用户可见
+// @Tags         Device
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "设备类型 ID"
+// @Param        body body      dto.UpdateStatusReq true "新的状态和计划时间"
+// @Security     ApiKeyAuth
+// @Success      200 {object} response.Response "更改成功"
+// @Failure      400 {object} response.Response "请求参数错误、无效ID或时间格式错误"
+// @Failure      404 {object} response.Response "设备类型不存在"
+// @Failure      500 {object} response.Response "服务器内部错误"
+// @Router       /api/v1/admin/device/update/status/{id} [patch]
+func (ctrl *Controller) UpdateStatus(c *gin.Context) {
+	deviceTypeID, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorDeviceIDInvalid)
+		logger.Error("/admin/device/update/status 无效的设备类型ID格式: " + err.Error())
+		return
+	}
+
+	var req *dto.UpdateStatusReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/admin/device/update/status 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	// 将剩余的工作交由service处理
+	err = ctrl.service.UpdateStatus(actor, deviceTypeID, req)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorDeviceStatusTimeInvalid, stderr.ErrorDeviceStatusWindowWrong:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		case stderr.ErrorDeviceNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorDeviceNotFound)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, "更改设备类型状态失败: "+err.Error())
+			logger.Error("/admin/device/update/status 更改设备类型状态失败: " + err.Error())
+		}
+		return
+	}
+	response.Success(c, nil)
+}
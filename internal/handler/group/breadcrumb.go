@@ -0,0 +1,61 @@
+package group
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	dto "xinde/internal/dto/group"
+	"xinde/internal/handler/common"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// Breadcrumb handles fetching the full path of a group or device type.
+// @Summary      获取分组或设备类型的完整路径
+// @Description  返回从最上层分组开始的路径（不包含root分组），查询设备类型时最后一个节点是设备类型本身。group_id和device_type_id必须且只能填写一个。前台接口中对当前用户隐藏或未发布的节点返回404
+// @Tags         Group
+// @Tags         Solution
+// @Accept       json
+// @Produce      json
+// @Param        group_id query int false "分组 ID"
+// @Param        device_type_id query int false "设备类型 ID"
+// @Security     ApiKeyAuth
+// @Success      200 {object} dto.BreadcrumbResp "成功返回路径"
+// @Failure      400 {object} response.Response "请求参数错误"
+// @Failure      404 {object} response.Response "分组或设备类型不存在"
+// @Failure      500 {object} response.Response "服务器内部错误"
+// @Router       /api/v1/admin/group/breadcrumb [get]
+// @Router       /api/v1/groups/breadcrumb [get]
+func (ctrl *Controller) Breadcrumb(c *gin.Context) {
+	var req dto.BreadcrumbReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/groups/breadcrumb 绑定参数错误: " + err.Error())
+		return
+	}
+
+	viewer, err := common.GetCatalogViewer(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前的用户ID: "+err.Error())
+		logger.Error("/groups/breadcrumb 无法获取当前的用户ID: " + err.Error())
+		return
+	}
+
+	// 剩余的工作交由service处理
+	data, err := ctrl.Service.Breadcrumb(&req, viewer)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorBreadcrumbNodeInvalid:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorBreadcrumbNodeInvalid)
+		case stderr.ErrorGroupNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorGroupNotFound)
+		case stderr.ErrorDeviceNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorDeviceNotFound)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/groups/breadcrumb 获取路径出错: " + err.Error())
+		}
+		return
+	}
+	response.Success(c, data)
+}
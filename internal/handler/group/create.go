@@ -5,6 +5,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"net/http"
 	dto "xinde/internal/dto/group"
+	"xinde/internal/handler/common"
 	"xinde/internal/middleware/auth"
 	"xinde/internal/service/group"
 	"xinde/pkg/logger"
@@ -52,20 +53,20 @@ func (ctrl *Controller) Create(c *gin.Context) {
 	// 从表单中获取上传的icon（可选，如果没有iconFile为nil）
 	iconFile, _ := c.FormFile("icon")
 
-	// 获取当前操作的管理员ID
-	adminID, err := auth.GetCurrentUserID(c)
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
 	if err != nil {
-		response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, "无法获取当前的用户ID")
-		logger.Error("/admin/group/create 无法获取当前的用户ID: " + err.Error())
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
 	}
 
 	if !auth.IsAdmin(c) {
 		response.Error(c, http.StatusForbidden, response.CodeForbidden, stderr.ErrorTokenNotAdmin)
-		logger.Error(fmt.Sprintf("/admin/group/create 当前用户ID: %d 非管理员，权限不足", adminID))
+		logger.Error(fmt.Sprintf("/admin/group/create 当前用户ID: %d 非管理员，权限不足", actor.UID))
 	}
 
 	// 剩余工作交由Service层处理
-	err = ctrl.Service.Create(req.Name, req.ParentID, adminID, iconFile)
+	err = ctrl.Service.Create(actor, req.Name, req.ParentID, iconFile)
 	if err != nil {
 		switch err.Error() {
 		default:
@@ -3,6 +3,7 @@ package group
 import (
 	"github.com/gin-gonic/gin"
 	"net/http"
+	dto "xinde/internal/dto/group"
 	"xinde/internal/handler/common"
 	"xinde/pkg/logger"
 	"xinde/pkg/response"
@@ -11,16 +12,21 @@ import (
 
 // Delete handles the deletion of a group and its descendants.
 // @Summary      删除分组
-// @Description  删除一个分组及其所有子孙分组，并处理关联的设备
+// @Description  删除一个分组及其所有子孙分组。mode=move（默认）时把其下的设备类型移动到 target_group_id 分组（默认 root 分组），
+// @Description  mode=refuse 时如果其下还有设备类型则拒绝删除。设备类型移动后会失去的可见性规则（被删除的分组，以及不是目标分组祖先的原祖先分组上的规则）
+// @Description  会转换为设备类型自己的规则，会失去多层规则而无法转换时拒绝删除
 // @Tags         Group
 // @Accept       json
 // @Produce      json
 // @Param        id   path      int  true  "分组 ID"
+// @Param        mode query string false "设备类型的处理方式: move 或 refuse，默认 move"
+// @Param        target_group_id query int false "接收设备类型的分组ID，默认 root 分组"
 // @Security     ApiKeyAuth
 // @Success      200 {object} response.Response "删除成功"
-// @Failure      400 {object} response.Response "请求参数错误或无效ID"
+// @Failure      400 {object} response.Response "请求参数错误、无效ID或目标分组无效"
 // @Failure      403 {object} response.Response "禁止删除Root分组"
 // @Failure      404 {object} response.Response "分组不存在"
+// @Failure      409 {object} response.Response "mode=refuse 时分组下还有设备类型，或设备类型上的可见性规则无法转换"
 // @Failure      500 {object} response.Response "服务器内部错误"
 // @Router       /api/v1/admin/group/delete/{id} [delete]
 func (ctrl *Controller) Delete(c *gin.Context) {
@@ -31,15 +37,33 @@ func (ctrl *Controller) Delete(c *gin.Context) {
 		return
 	}
 
-	err = ctrl.Service.Delete(groupID)
+	var req dto.DeleteReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/admin/group/delete 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.Service.Delete(actor, groupID, &req)
 	if err != nil {
 		switch err.Error() {
 		case stderr.ErrorRootGroupCannotBeDeleted:
 			response.Error(c, http.StatusForbidden, response.CodeForbidden, stderr.ErrorRootGroupCannotBeDeleted)
 		case stderr.ErrorGroupNotFound:
 			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorGroupNotFound)
+		case stderr.ErrorGroupDeleteTargetNotFound, stderr.ErrorGroupDeleteTargetInvalid:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		case stderr.ErrorGroupNotEmpty, stderr.ErrorGroupDeleteRuleConflict:
+			response.Error(c, http.StatusConflict, response.CodeConflict, err.Error())
 		default:
-			response.Error(c, http.StatusInternalServerError, http.StatusInternalServerError, stderr.ErrorGroupNotFound)
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
 			logger.Error("/admin/group/delete 删除分组发生错误: " + err.Error())
 		}
 		return
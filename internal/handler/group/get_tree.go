@@ -3,6 +3,8 @@ package group
 import (
 	"github.com/gin-gonic/gin"
 	"net/http"
+	dto "xinde/internal/dto/group"
+	"xinde/internal/handler/common"
 	"xinde/pkg/logger"
 	"xinde/pkg/response"
 	"xinde/pkg/stderr"
@@ -10,29 +12,38 @@ import (
 
 // GetTree handles fetching the group tree structure.
 // @Summary      获取树状分组列表。用于前台展示分组，和后台需要树状分组的地方。
-// @Description  获取一个完整的、嵌套的树状分组结构
+// @Description  获取一个完整的、嵌套的树状分组结构。前台接口只返回当前用户可见的分组和已发布的设备类型，见产品目录可见性规则；后台接口返回全部分组和全部状态的设备类型。device_types为true时设备类型作为叶子节点一起返回，不需要再逐个分组查询设备类型
 // @Tags         Group
 // @Tags         Solution
 // @Accept       json
 // @Produce      json
-// @Param        icon query string true "是否包含图标URL (必填，true或者false)"
+// @Param        icon query string false "是否包含图标URL (true或者false)，为true时设备类型也返回图片"
+// @Param        device_types query bool false "是否把设备类型作为叶子节点返回，并返回每个分组（含子孙分组）下的设备类型数量"
+// @Param        prune_empty query bool false "是否去掉（含子孙分组）没有设备类型的分组，root分组始终保留"
+// @Param        max_depth query int false "返回的分组层数，root分组为第1层，不填表示不限制"
 // @Security     ApiKeyAuth
 // @Success      200 {object} dto.TreeResp "成功返回分组树"
+// @Failure      400 {object} response.Response "请求参数错误"
 // @Failure      500 {object} response.Response "服务器内部错误"
 // @Router       /api/v1/admin/group/tree [get]
 // @Router       /api/v1/groups/tree [get]
 func (ctrl *Controller) GetTree(c *gin.Context) {
-	//var req dto.TreeReq
-	//if err := c.ShouldBind(&req); err != nil {
-	//	response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
-	//	logger.Error("/admin/group/tree 绑定参数错误: " + err.Error())
-	//	return
-	//}
-	includeIcon := "true"
-	includeIcon = c.Query("icon")
+	var req dto.TreeReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/groups/tree 绑定参数错误: " + err.Error())
+		return
+	}
+
+	viewer, err := common.GetCatalogViewer(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前的用户ID: "+err.Error())
+		logger.Error("/groups/tree 无法获取当前的用户ID: " + err.Error())
+		return
+	}
 
 	// 剩余的工作交由service处理
-	tree, err := ctrl.Service.GetTree(includeIcon)
+	tree, err := ctrl.Service.GetTree(&req, viewer)
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
 		logger.Error("/admin/group/tree 获取树状分组列表出错: " + err.Error())
@@ -0,0 +1,67 @@
+package group
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	dto "xinde/internal/dto/group"
+	"xinde/internal/handler/common"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// Move handles reordering a group or moving it under another parent.
+// @Summary      移动分组或调整顺序
+// @Description  把分组移动到新的父级下的指定位置，不填 parent_id 时只在原来的兄弟分组中调整顺序。不能移动到自己或子孙分组下
+// @Tags         Group
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "分组 ID"
+// @Param        body body      dto.MoveReq true "新的父级和位置"
+// @Security     ApiKeyAuth
+// @Success      200 {object} response.Response "移动成功"
+// @Failure      400 {object} response.Response "请求参数错误、无效ID或移动到自己的子孙分组下"
+// @Failure      403 {object} response.Response "禁止移动Root分组"
+// @Failure      404 {object} response.Response "分组或新的父级分组不存在"
+// @Failure      500 {object} response.Response "服务器内部错误"
+// @Router       /api/v1/admin/group/move/{id} [patch]
+func (ctrl *Controller) Move(c *gin.Context) {
+	groupID, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorGroupIDInvalid)
+		logger.Error("/admin/group/move 无效的分组ID格式: " + err.Error())
+		return
+	}
+
+	var req dto.MoveReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/admin/group/move 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	// 将剩余的工作交由service处理
+	err = ctrl.Service.Move(actor, groupID, req.ParentID, req.Position)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorRootGroupCannotBeMoved:
+			response.Error(c, http.StatusForbidden, response.CodeForbidden, stderr.ErrorRootGroupCannotBeMoved)
+		case stderr.ErrorGroupNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorGroupNotFound)
+		case stderr.ErrorCannotMoveGroupIntoItself:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorCannotMoveGroupIntoItself)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/admin/group/move 移动分组失败: " + err.Error())
+		}
+		return
+	}
+	response.Success(c, nil)
+}
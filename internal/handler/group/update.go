@@ -5,7 +5,6 @@ import (
 	"net/http"
 	dto "xinde/internal/dto/group"
 	"xinde/internal/handler/common"
-	"xinde/internal/middleware/auth"
 	"xinde/pkg/logger"
 	"xinde/pkg/response"
 	"xinde/pkg/stderr"
@@ -44,15 +43,16 @@ func (ctrl *Controller) Update(c *gin.Context) {
 	}
 
 	file, _ := c.FormFile("icon")
-	adminID, err := auth.GetCurrentUserID(c)
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
 	if err != nil {
-		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前操作的用户ID")
-		logger.Error("/admin/group/update 无法获取当前操作的用户ID: " + err.Error())
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
 		return
 	}
 
 	// 将剩余的工作交由service处理
-	err = ctrl.Service.Update(adminID, groupID, req.ParentID, req.Name, file)
+	err = ctrl.Service.Update(actor, groupID, req.ParentID, req.Name, file)
 	if err != nil {
 		switch err.Error() {
 		case stderr.ErrorGroupNotFound:
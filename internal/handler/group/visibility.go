@@ -0,0 +1,146 @@
+package group
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	dto "xinde/internal/dto/group"
+	"xinde/internal/handler/common"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// VisibilityList handles listing catalog visibility rules.
+// @Summary 查看产品目录可见性规则
+// @Description 分组或设备类型没有规则时对所有用户可见；有规则时只对规则中的公司和拥有规则中角色的用户可见（满足任意一条即可）。
+// @Description 分组上的规则同样作用于子孙分组和分组下的设备类型，祖先分组隐藏时子孙一定隐藏。通过 API Key 访问时按 Key 所属的公司判断
+// @Tags Group
+// @Accept json
+// @Produce json
+// @Param group_id query int false "按分组过滤"
+// @Param device_type_id query int false "按设备类型过滤"
+// @Param target_type query string false "company 或 role"
+// @Param target_id query int false "公司ID或角色ID，需要同时指定target_type"
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.VisibilityListResp "查询成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "access_token有错误"
+// @Failure 403 {object} response.Response "没有管理员权限"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/group/visibility/list [get]
+func (ctrl *Controller) VisibilityList(c *gin.Context) {
+	var req dto.VisibilityListReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/admin/group/visibility/list 绑定参数错误: " + err.Error())
+		return
+	}
+
+	list, err := ctrl.Service.GetVisibilityList(&req)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorVisibilityRuleTargetFilter:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/admin/group/visibility/list 查询可见性规则失败: " + err.Error())
+		}
+		return
+	}
+	response.Success(c, list)
+}
+
+// CreateVisibility handles the creation of a catalog visibility rule.
+// @Summary 创建产品目录可见性规则
+// @Description 把分组（含子孙分组及其设备类型）或设备类型限定为只对某个公司或拥有某个角色的用户可见。
+// @Description 隐藏的分组不会出现在前台分组树中，隐藏的设备类型不会出现在设备类型列表中，选型查询返回404
+// @Tags Group
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateVisibilityReq true "CreateVisibility Request"
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response "创建成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "access_token有错误"
+// @Failure 403 {object} response.Response "没有管理员权限"
+// @Failure 404 {object} response.Response "分组、设备类型、公司或角色不存在"
+// @Failure 409 {object} response.Response "已存在相同的规则"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/group/visibility/create [post]
+func (ctrl *Controller) CreateVisibility(c *gin.Context) {
+	var req dto.CreateVisibilityReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/admin/group/visibility/create 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.Service.CreateVisibility(actor, &req)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorVisibilityRuleNodeInvalid:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		case stderr.ErrorGroupNotFound, stderr.ErrorDeviceNotFound, stderr.ErrorCompanyNotFound, stderr.ErrorRoleNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		case stderr.ErrorVisibilityRuleConflict:
+			response.Error(c, http.StatusConflict, response.CodeConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/admin/group/visibility/create 创建可见性规则失败: " + err.Error())
+		}
+		return
+	}
+	response.Success(c, nil)
+}
+
+// DeleteVisibility handles the deletion of a catalog visibility rule.
+// @Summary 删除产品目录可见性规则
+// @Description 管理员根据规则ID删除可见性规则，分组或设备类型的最后一条规则删除后对所有用户可见
+// @Tags Group
+// @Accept json
+// @Produce json
+// @Param id path int true "可见性规则ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response "删除成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "access_token有错误"
+// @Failure 403 {object} response.Response "没有管理员权限"
+// @Failure 404 {object} response.Response "可见性规则不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/group/visibility/delete/{id} [delete]
+func (ctrl *Controller) DeleteVisibility(c *gin.Context) {
+	id, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorVisibilityRuleIDInvalid)
+		logger.Error("/admin/group/visibility/delete 无效的可见性规则ID格式: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.Service.DeleteVisibility(actor, id)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorVisibilityRuleNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/group/visibility/delete 删除可见性规则失败! ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, nil)
+}
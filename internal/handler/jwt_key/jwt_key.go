@@ -0,0 +1,109 @@
+package jwt_key
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	dto "xinde/internal/dto/jwt_key"
+	"xinde/internal/handler/common"
+	"xinde/internal/service/jwt_key"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+type Controller struct {
+	jwtKeyService *jwt_key.Service
+}
+
+func NewJWTKeyController() (*Controller, error) {
+	jwtKeyService, err := jwt_key.NewJWTKeyService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Controller{
+		jwtKeyService: jwtKeyService,
+	}, nil
+}
+
+// List handles jwt signing key list.
+// @Summary 查看JWT签名密钥列表
+// @Description 返回全部签名密钥（包括已退役的），不包含密钥材料。从未轮换过时只有配置文件中的 legacy 密钥
+// @Tags JWTKey
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.ListResp "查询成功"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/jwt/keys [get]
+func (ctrl *Controller) List(c *gin.Context) {
+	list, err := ctrl.jwtKeyService.GetKeyList()
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+		logger.Error("/admin/jwt/keys " + err.Error())
+		return
+	}
+	response.Success(c, list)
+}
+
+// Rotate handles jwt signing key rotation.
+// @Summary 轮换JWT签名密钥
+// @Description 生成一把新的签名密钥用于签发 token，原密钥退役，在已签发的 token 过期之前仍可验证，用户不会因此掉线。
+// @Description 其他实例在 jwt.keyRefreshInterval 内开始使用新密钥。RS256 和 EdDSA 密钥的公钥通过 /.well-known/jwks.json 公开
+// @Tags JWTKey
+// @Accept json
+// @Produce json
+// @Param request body dto.RotateReq false "Rotate Request"
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.RotateResp "轮换成功"
+// @Failure 400 {object} response.Response "参数错误或不支持的签名算法"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/jwt/rotate [post]
+func (ctrl *Controller) Rotate(c *gin.Context) {
+	var req dto.RotateReq
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBind(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+			logger.Error("/admin/jwt/rotate 绑定参数错误: " + err.Error())
+			return
+		}
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	data, err := ctrl.jwtKeyService.RotateKey(actor, req.Alg)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorJWTKeyAlgInvalid:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/admin/jwt/rotate 轮换JWT签名密钥失败: " + err.Error())
+		}
+		return
+	}
+	response.Success(c, data)
+}
+
+// JWKS handles the public json web key set.
+// @Summary 获取JWT验证公钥
+// @Description 以 JWKS（RFC 7517）格式返回仍可用于验证的 RS256 和 EdDSA 公钥，其他服务可据此离线验证 token，按 token 头部的 kid 选择公钥。
+// @Description HS256 密钥不会公开。响应不使用统一的返回格式
+// @Tags JWTKey
+// @Produce json
+// @Success 200 {object} dto.JWKSResp "查询成功"
+// @Router /.well-known/jwks.json [get]
+func (ctrl *Controller) JWKS(c *gin.Context) {
+	// 允许其他服务缓存一段时间，轮换后的新公钥在未知 kid 时重新获取即可
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, ctrl.jwtKeyService.GetJWKS())
+}
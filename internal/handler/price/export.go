@@ -0,0 +1,63 @@
+package price
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+	dto "xinde/internal/dto/price"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+// Export handles exporting prices to an Excel file.
+// @Summary      导出价格Excel文件
+// @Description  按与价格列表相同的查询条件导出全部符合条件的价格（不分页），列的布局与导入文件一致，修改后可以直接重新导入
+// @Tags         Price
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        keyword query string false "按产品编码或规格型号搜索"
+// @Param        match_mode query string false "prefix(前缀匹配) 或 contains(包含匹配，默认)"
+// @Param        price_filter query string false "missing(缺少价格)、zero(价格为0) 或 missing_or_zero"
+// @Param        level_code query string false "price_filter只检查该价格等级，默认检查全部等级"
+// @Param        updated_since query string false "只导出该时间之后更新过的价格，格式YYYY-MM-DD或YYYY-MM-DD HH:MM:SS"
+// @Param        sort_by query string false "排序字段: id(默认)、product_code、spec_code、updated_at或价格等级编码"
+// @Param        sort_order query string false "asc(默认) 或 desc"
+// @Security     ApiKeyAuth
+// @Success      200 {file} file "Excel文件流"
+// @Failure      400 {object} response.Response "参数错误"
+// @Failure      401 {object} response.Response "Token错误"
+// @Failure      403 {object} response.Response "没有管理员权限"
+// @Failure      500 {object} response.Response "服务器内部错误"
+// @Router       /api/v1/admin/price/export [get]
+func (ctrl *Controller) Export(c *gin.Context) {
+	var req dto.FilterReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/admin/price/export 绑定参数错误: " + err.Error())
+		return
+	}
+
+	file, err := ctrl.priceService.ExportPrices(&req)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorPriceLevelNotFound, stderr.ErrorPriceSortByInvalid, stderr.ErrorPriceUpdatedSinceInvalid:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/admin/price/export 导出价格失败: " + err.Error())
+		}
+		return
+	}
+	defer file.Close()
+
+	fileName := fmt.Sprintf("价格导出_%s.xlsx", time.Now().Format("20060102150405"))
+	c.Header("Content-Disposition", util.FormatContentDisposition(fileName))
+	c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+
+	// 直接将文件写入响应体
+	if err := file.Write(c.Writer); err != nil {
+		logger.Error("/admin/price/export 向客户端写入文件流时出错: " + err.Error())
+	}
+}
@@ -0,0 +1,41 @@
+package price
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	dto "xinde/internal/dto/price"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// History handles the price timeline of a product.
+// @Summary 管理员查看产品价格历史
+// @Description 返回某个产品编码的全部价格记录（含预先导入、尚未生效的价格），按生效时间倒序排列，并标注每条记录的状态及来源导入文件
+// @Tags Price
+// @Accept json
+// @Produce json
+// @Param product_code query string true "产品编码"
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.HistoryResp "查询成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "没有管理员权限"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/price/history [get]
+func (ctrl *Controller) History(c *gin.Context) {
+	var req dto.HistoryReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/admin/price/history 绑定参数错误: " + err.Error())
+		return
+	}
+
+	list, err := ctrl.priceService.GetPriceHistory(req.ProductCode)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+		logger.Error("/admin/price/history " + err.Error())
+		return
+	}
+	response.Success(c, list)
+}
@@ -3,46 +3,65 @@ package price
 import (
 	"github.com/gin-gonic/gin"
 	"net/http"
-	"xinde/internal/middleware/auth"
+	dto "xinde/internal/dto/price"
+	"xinde/internal/handler/common"
 	"xinde/pkg/logger"
 	"xinde/pkg/response"
+	"xinde/pkg/stderr"
 )
 
 // Import handles the import of price data from an Excel file.
 // @Summary      导入价格Excel文件
-// @Description  上传一个包含价格信息的Excel文件，系统将解析文件内容并批量更新或插入价格数据。如果产品编码已存在，则会用新数据覆盖。
+// @Description  上传一个包含价格信息的Excel文件，系统按表头名称定位各列（列顺序不限），逐行校验后批量更新或插入价格数据。如果产品编码已存在，则会用新数据覆盖。
+// @Description  价格列的表头为价格等级的编码或名称，文件中未出现的价格等级保持原价格不变；可选的effective_from(生效时间)列用于预先导入未来生效的价格或补录过去的价格，留空表示立即生效；未来生效的价格在到达生效时间后自动成为当前价格。
+// @Description  strict模式下任意一行有错误则全部不导入；lenient模式下跳过错误行、导入其余行。存在错误行时会生成带错误原因列的Excel报告，可通过附件下载接口下载。
 // @Tags         Price
 // @Accept       multipart/form-data
 // @Produce      json
 // @Param        file formData file true "要上传的Excel文件 (格式: .xlsx)"
+// @Param        mode formData string false "导入模式: strict(默认) 或 lenient"
 // @Security     ApiKeyAuth
-// @Success      200 {object} response.Response "操作成功"
-// @Failure      400 {object} response.Response "文件上传失败或文件内容/格式错误"
+// @Success      200 {object} dto.ImportResp "导入完成，返回逐行的导入结果"
+// @Failure      400 {object} dto.ImportResp "文件上传失败、缺少必需的列，或strict模式下存在错误数据"
 // @Failure      401 {object} response.Response "Token错误"
 // @Failure      403 {object} response.Response "没有管理员权限"
 // @Failure      500 {object} response.Response "服务器内部错误"
-// @Router       /api/v1/admin/prices/import [post]
+// @Router       /api/v1/admin/price/import [post]
 func (ctrl *Controller) Import(c *gin.Context) {
+	var req dto.ImportReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/admin/price/import 绑定参数错误: " + err.Error())
+		return
+	}
+
 	file, err := c.FormFile("file")
 	if err != nil {
 		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "文件上传失败: "+err.Error())
 		return
 	}
 
-	// 从上下文中获取当前操作的管理员ID
-	adminID, err := auth.GetCurrentUserID(c)
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
 	if err != nil {
-		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的ID信息: "+err.Error())
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
 		return
 	}
 
 	// 调用Service层处理文件
-	err = ctrl.priceService.ImportPricesFromFile(c, file, adminID)
+	result, err := ctrl.priceService.ImportPricesFromFile(c, file, actor, req.Mode)
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, response.CodeInternalError, err.Error())
-		logger.Error("/admin/price/import " + err.Error())
+		switch err.Error() {
+		case stderr.ErrorPriceImportHasInvalidRows:
+			response.ErrorWithData(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error(), result)
+		case stderr.ErrorPriceImportEmpty:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, err.Error())
+			logger.Error("/admin/price/import " + err.Error())
+		}
 		return
 	}
 
-	response.Success(c, nil)
+	response.Success(c, result)
 }
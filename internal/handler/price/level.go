@@ -0,0 +1,182 @@
+package price
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	dto "xinde/internal/dto/price"
+	"xinde/internal/handler/common"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// LevelList handles price level list.
+// @Summary 管理员查看价格等级列表
+// @Description 返回全部价格等级，按排序升序排列，第一个为默认等级
+// @Tags Price
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.LevelListResp "查询成功"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "没有管理员权限"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/price/level/list [get]
+func (ctrl *Controller) LevelList(c *gin.Context) {
+	list, err := ctrl.priceService.GetPriceLevelList()
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+		logger.Error("/admin/price/level/list " + err.Error())
+		return
+	}
+	response.Success(c, list)
+}
+
+// CreateLevel handles the creation of a new price level.
+// @Summary 创建价格等级
+// @Description 创建一个新的价格等级，编码创建后不可修改。导入价格时，表头为等级编码或名称的列会被识别为该等级的价格
+// @Tags Price
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateLevelReq true "CreateLevel Request"
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response "创建成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "没有管理员权限"
+// @Failure 409 {object} response.Response "编码或名称已存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/price/level/create [post]
+func (ctrl *Controller) CreateLevel(c *gin.Context) {
+	var req dto.CreateLevelReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/admin/price/level/create 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.priceService.CreatePriceLevel(actor, req.Code, req.Name, req.SortOrder)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorPriceLevelCodeConflict, stderr.ErrorPriceLevelNameConflict:
+			response.Error(c, http.StatusConflict, response.CodeConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/admin/price/level/create 创建价格等级失败: " + err.Error())
+		}
+		return
+	}
+	response.Success(c, nil)
+}
+
+// UpdateLevel handles the update of a price level.
+// @Summary 修改价格等级
+// @Description 根据ID修改价格等级的名称或排序，编码不可修改
+// @Tags Price
+// @Accept json
+// @Produce json
+// @Param id path int true "价格等级ID"
+// @Param request body dto.UpdateLevelReq true "UpdateLevel Request"
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response "修改成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "没有管理员权限"
+// @Failure 404 {object} response.Response "价格等级不存在"
+// @Failure 409 {object} response.Response "名称已存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/price/level/update/{id} [put]
+func (ctrl *Controller) UpdateLevel(c *gin.Context) {
+	id, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorPriceLevelIDInvalid)
+		logger.Error("/admin/price/level/update 无效的价格等级ID格式: " + err.Error())
+		return
+	}
+
+	var req dto.UpdateLevelReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/admin/price/level/update 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.priceService.UpdatePriceLevel(actor, id, req.Name, req.SortOrder)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorPriceLevelNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorPriceLevelNotFound)
+		case stderr.ErrorPriceLevelNameConflict:
+			response.Error(c, http.StatusConflict, response.CodeConflict, stderr.ErrorPriceLevelNameConflict)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/price/level/update 修改价格等级失败! 价格等级ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, nil)
+}
+
+// DeleteLevel handles the deletion of a price level.
+// @Summary 删除价格等级
+// @Description 根据ID删除价格等级及该等级下的当前价格（价格历史保留）。仍有公司使用的等级和新建公司使用的默认等级(price_1)不能删除，且至少保留一个等级
+// @Tags Price
+// @Accept json
+// @Produce json
+// @Param id path int true "价格等级ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response "删除成功"
+// @Failure 400 {object} response.Response "参数错误、为最后一个价格等级或为默认价格等级"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "没有管理员权限"
+// @Failure 404 {object} response.Response "价格等级不存在"
+// @Failure 409 {object} response.Response "仍有公司使用该价格等级"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/price/level/delete/{id} [delete]
+func (ctrl *Controller) DeleteLevel(c *gin.Context) {
+	id, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorPriceLevelIDInvalid)
+		logger.Error("/admin/price/level/delete 无效的价格等级ID格式: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.priceService.DeletePriceLevel(actor, id)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorPriceLevelNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorPriceLevelNotFound)
+		case stderr.ErrorPriceLevelInUse:
+			response.Error(c, http.StatusConflict, response.CodeConflict, stderr.ErrorPriceLevelInUse)
+		case stderr.ErrorPriceLevelLastOne, stderr.ErrorPriceLevelDefault:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/price/level/delete 删除价格等级失败! 价格等级ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, nil)
+}
@@ -29,12 +29,19 @@ func NewController() (*Controller, error) {
 
 // List handles price list.
 // @Summary 管理员查看价格列表
-// @Description 返回所有产品的价格信息
+// @Description 返回产品的价格信息，支持按产品编码或规格型号搜索、按缺少价格/价格为0/更新时间过滤，以及按任意价格等级的价格排序
 // @Tags Price
 // @Accept json
 // @Produce json
 // @Param page query int false "当前页数，可选，默认为1"
 // @Param page_size query int false "一页的内容数量，可选，默认为设置的默认值"
+// @Param keyword query string false "按产品编码或规格型号搜索"
+// @Param match_mode query string false "prefix(前缀匹配) 或 contains(包含匹配，默认)"
+// @Param price_filter query string false "missing(缺少价格)、zero(价格为0) 或 missing_or_zero"
+// @Param level_code query string false "price_filter只检查该价格等级，默认检查全部等级"
+// @Param updated_since query string false "只返回该时间之后更新过的价格，格式YYYY-MM-DD或YYYY-MM-DD HH:MM:SS"
+// @Param sort_by query string false "排序字段: id(默认)、product_code、spec_code、updated_at或价格等级编码"
+// @Param sort_order query string false "asc(默认) 或 desc"
 // @Success 200 {object} dto.ListResp "查询成功"
 // @Failure 400 {object} response.Response "参数错误"
 // @Failure 401 {object} response.Response "token错误"
@@ -54,9 +61,11 @@ func (ctrl *Controller) List(c *gin.Context) {
 	}
 
 	// 参数校验完毕，剩余的工作交由service处理
-	list, err := ctrl.priceService.GetPriceList(req.Page, req.PageSize)
+	list, err := ctrl.priceService.GetPriceList(&req)
 	if err != nil {
 		switch err.Error() {
+		case stderr.ErrorPriceLevelNotFound, stderr.ErrorPriceSortByInvalid, stderr.ErrorPriceUpdatedSinceInvalid:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
 		case stderr.ErrorDbNil:
 			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
 			logger.Error("/admin/price/list " + err.Error())
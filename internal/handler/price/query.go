@@ -0,0 +1,62 @@
+package price
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	dto "xinde/internal/dto/price"
+	"xinde/internal/handler/common"
+	"xinde/internal/middleware/auth"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// Query handles querying the final prices of product codes.
+// @Summary 查询产品价格
+// @Description 查询一组产品编码的最终价格（已计入公司专属价格和按产品编码前缀的折扣规则），没有价格的产品不会返回。
+// @Description 产品只出现在未发布或对调用方隐藏的设备类型的方案中时，与没有价格一样不返回
+// @Description 用户 token 调用时按用户所在公司计算；也可以使用拥有 price:query 范围的 API Key 签名调用，价格按 Key 所属的公司计算
+// @Tags Price
+// @Accept json
+// @Produce json
+// @Param request body dto.QueryReq true "Query Request"
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.QueryResp "查询成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "Token错误，或API Key无效、签名错误、请求被重放"
+// @Failure 403 {object} response.Response "API Key没有访问该接口的权限"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/prices/query [post]
+func (ctrl *Controller) Query(c *gin.Context) {
+	var req dto.QueryReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		logger.Error("/prices/query 参数绑定错误: " + err.Error())
+		return
+	}
+
+	// 和查看方案一样受产品目录可见性规则和发布状态的限制
+	viewer, err := common.GetCatalogViewer(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+		return
+	}
+
+	var data []*dto.QueryData
+	if key, ok := auth.GetAPIKey(c); ok {
+		data, err = ctrl.priceService.QueryPricesForCompany(viewer, key.CompanyID, req.ProductCodes)
+	} else {
+		uid, idErr := auth.GetCurrentUserID(c)
+		if idErr != nil {
+			response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, idErr.Error())
+			return
+		}
+		data, err = ctrl.priceService.QueryPricesForUser(viewer, uid, req.ProductCodes)
+	}
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+		logger.Error("/prices/query 查询价格失败: " + err.Error())
+		return
+	}
+	response.Success(c, data)
+}
@@ -0,0 +1,211 @@
+package role
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	dto "xinde/internal/dto/role"
+	"xinde/internal/handler/common"
+	"xinde/internal/service/role"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+type Controller struct {
+	roleService *role.Service
+}
+
+func NewRoleController() (*Controller, error) {
+	roleService, err := role.NewRoleService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Controller{
+		roleService: roleService,
+	}, nil
+}
+
+// PermissionList handles permission list.
+// @Summary 查看可分配的权限
+// @Description 返回全部权限编码及说明。*为全部权限，只属于内置的超级管理员角色
+// @Tags Role
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.PermissionListResp "查询成功"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Router /api/v1/admin/role/permissions [get]
+func (ctrl *Controller) PermissionList(c *gin.Context) {
+	response.Success(c, ctrl.roleService.GetPermissionList())
+}
+
+// List handles role list.
+// @Summary 查看角色列表
+// @Description 返回全部角色及其权限
+// @Tags Role
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.RoleListResp "查询成功"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/role/list [get]
+func (ctrl *Controller) List(c *gin.Context) {
+	list, err := ctrl.roleService.GetRoleList()
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+		logger.Error("/admin/role/list " + err.Error())
+		return
+	}
+	response.Success(c, list)
+}
+
+// Create handles the creation of a new role.
+// @Summary 创建角色
+// @Description 创建一个自定义角色，编码创建后不可修改。权限编码见 /admin/role/permissions，不能包含*。不传权限时创建标签角色，只用作产品目录可见性规则的对象，拥有它的用户不会成为管理员
+// @Tags Role
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateRoleReq true "CreateRole Request"
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response "创建成功"
+// @Failure 400 {object} response.Response "参数错误或权限编码无效"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 409 {object} response.Response "编码已存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/role/create [post]
+func (ctrl *Controller) Create(c *gin.Context) {
+	var req dto.CreateRoleReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/admin/role/create 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.roleService.CreateRole(actor, &req)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorRolePermissionInvalid:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		case stderr.ErrorRoleCodeConflict:
+			response.Error(c, http.StatusConflict, response.CodeConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/admin/role/create 创建角色失败: " + err.Error())
+		}
+		return
+	}
+	response.Success(c, nil)
+}
+
+// Update handles the update of a role.
+// @Summary 修改角色
+// @Description 根据ID修改角色的名称、说明或权限，修改后立即对拥有该角色的用户生效。内置角色不能修改
+// @Tags Role
+// @Accept json
+// @Produce json
+// @Param id path int true "角色ID"
+// @Param request body dto.UpdateRoleReq true "UpdateRole Request"
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response "修改成功"
+// @Failure 400 {object} response.Response "参数错误、权限编码无效或为内置角色"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "角色不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/role/update/{id} [put]
+func (ctrl *Controller) Update(c *gin.Context) {
+	id, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorRoleIDInvalid)
+		logger.Error("/admin/role/update 无效的角色ID格式: " + err.Error())
+		return
+	}
+
+	var req dto.UpdateRoleReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/admin/role/update 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.roleService.UpdateRole(actor, id, &req)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorRoleNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		case stderr.ErrorRolePermissionInvalid, stderr.ErrorRoleSystemReadOnly:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/role/update 修改角色失败! 角色ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, nil)
+}
+
+// Delete handles the deletion of a role.
+// @Summary 删除角色
+// @Description 根据ID删除角色，拥有该角色的用户失去相应权限并需要重新登录。内置角色不能删除
+// @Tags Role
+// @Accept json
+// @Produce json
+// @Param id path int true "角色ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response "删除成功"
+// @Failure 400 {object} response.Response "参数错误或为内置角色"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "角色不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/role/delete/{id} [delete]
+func (ctrl *Controller) Delete(c *gin.Context) {
+	id, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorRoleIDInvalid)
+		logger.Error("/admin/role/delete 无效的角色ID格式: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.roleService.DeleteRole(actor, id)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorRoleNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		case stderr.ErrorRoleSystemReadOnly:
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/role/delete 删除角色失败! 角色ID: %d 错误: %s", id, err.Error()))
+		}
+		return
+	}
+	response.Success(c, nil)
+}
@@ -0,0 +1,104 @@
+package role
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	dto "xinde/internal/dto/role"
+	"xinde/internal/handler/common"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// UserRoles handles user role list.
+// @Summary 查看用户的角色
+// @Description 根据用户ID返回该用户拥有的角色及其权限
+// @Tags Role
+// @Accept json
+// @Produce json
+// @Param id path int true "用户ID"
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.UserRoleResp "查询成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "用户不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/account/role/{id} [get]
+func (ctrl *Controller) UserRoles(c *gin.Context) {
+	uid, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorUserIDInvalid)
+		logger.Error("/admin/account/role 无效的用户ID格式: " + err.Error())
+		return
+	}
+
+	list, err := ctrl.roleService.GetUserRoles(uid)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorUserNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, stderr.ErrorUserNotFound)
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/account/role 查看用户角色失败! 用户ID: %d 错误: %s", uid, err.Error()))
+		}
+		return
+	}
+	response.Success(c, list)
+}
+
+// SetUserRoles handles user role assignment.
+// @Summary 设置用户的角色
+// @Description 整体替换用户的角色，拥有任意角色的用户即为管理员。修改后该用户需要重新登录；不能拿掉最后一名超级管理员的全部权限
+// @Tags Role
+// @Accept json
+// @Produce json
+// @Param id path int true "用户ID"
+// @Param request body dto.SetUserRolesReq true "SetUserRoles Request"
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response "设置成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "token错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "用户或角色不存在"
+// @Failure 409 {object} response.Response "为最后一名超级管理员"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/admin/account/role/{id} [put]
+func (ctrl *Controller) SetUserRoles(c *gin.Context) {
+	uid, err := common.GetIDFromUrl(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, stderr.ErrorUserIDInvalid)
+		logger.Error("/admin/account/role 无效的用户ID格式: " + err.Error())
+		return
+	}
+
+	var req dto.SetUserRolesReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "绑定参数错误: "+err.Error())
+		logger.Error("/admin/account/role 绑定参数错误: " + err.Error())
+		return
+	}
+
+	// 从上下文中获取当前操作的管理员信息，用于记录审计日志
+	actor, err := common.GetActor(c)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前管理员的信息: "+err.Error())
+		return
+	}
+
+	err = ctrl.roleService.SetUserRoles(actor, uid, req.RoleIDs)
+	if err != nil {
+		switch err.Error() {
+		case stderr.ErrorUserNotFound, stderr.ErrorRoleNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		case stderr.ErrorRoleLastSuperAdmin:
+			response.Error(c, http.StatusConflict, response.CodeConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error(fmt.Sprintf("/admin/account/role 设置用户角色失败! 用户ID: %d 错误: %s", uid, err.Error()))
+		}
+		return
+	}
+	response.Success(c, nil)
+}
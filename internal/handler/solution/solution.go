@@ -6,6 +6,7 @@ import (
 	"net/http"
 	dto "xinde/internal/dto/solution"
 	"xinde/internal/middleware/auth"
+	roleModel "xinde/internal/model/role"
 	"xinde/internal/service/solution"
 	"xinde/pkg/logger"
 	"xinde/pkg/response"
@@ -27,6 +28,8 @@ func NewSolutionController() (*Controller, error) {
 // Query handles the dynamic querying of solutions.
 // @Summary      查询/筛选选型方案
 // @Description  根据用户提供的筛选条件，动态查询方案列表，并返回下一步可用的筛选选项。传入空的筛选对象可获取初始状态。
+// @Description  管理员（需要 catalog:read 权限）可以传 preview=true 预览草稿或已下架的设备类型，预览不受可见性规则限制。
+// @Description  也可以使用拥有 solution:query 范围的 API Key 签名调用（X-Api-Key、X-Timestamp、X-Nonce、X-Signature），价格按 Key 所属的公司计算。
 // @Tags         Solution
 // @Accept       json
 // @Produce      json
@@ -34,7 +37,9 @@ func NewSolutionController() (*Controller, error) {
 // @Security     ApiKeyAuth
 // @Success      200 {object} response.Response{data=dto.QueryResp} "查询成功"
 // @Failure      400 {object} response.Response "请求参数错误"
-// @Failure      401 {object} response.Response "Token错误"
+// @Failure      401 {object} response.Response "Token错误，或API Key无效、签名错误、请求被重放"
+// @Failure      403 {object} response.Response "API Key没有访问该接口的权限，或没有预览权限"
+// @Failure      404 {object} response.Response "设备类型不存在、未发布，或按产品目录可见性规则对当前用户（API Key所属的公司）隐藏"
 // @Failure      500 {object} response.Response "服务器内部错误"
 // @Router       /api/v1/solutions/query [post]
 func (ctrl *Controller) Query(c *gin.Context) {
@@ -45,17 +50,38 @@ func (ctrl *Controller) Query(c *gin.Context) {
 		return
 	}
 
-	userID, err := auth.GetCurrentUserID(c)
-	if err != nil {
-		response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前的用户ID: "+err.Error())
-		logger.Error("/solutions/query 无法获取当前的用户ID: " + err.Error())
+	var resp *dto.QueryResp
+	var err error
+	key, isAPIKey := auth.GetAPIKey(c)
+	// 预览未发布的设备类型只对拥有目录查看权限的管理员开放
+	if req.Preview && (isAPIKey || !auth.HasPermission(c, roleModel.PermCatalogRead)) {
+		response.Error(c, http.StatusForbidden, response.CodeForbidden, stderr.ErrorDevicePreviewNotAllowed)
 		return
 	}
-
-	resp, err := ctrl.service.Query(userID, req)
+	if isAPIKey {
+		// 通过 API Key 调用时价格按 Key 所属的公司计算
+		resp, err = ctrl.service.QueryForCompany(key.CompanyID, req)
+	} else {
+		userID, idErr := auth.GetCurrentUserID(c)
+		if idErr != nil {
+			response.Error(c, http.StatusForbidden, response.CodeForbidden, "无法获取当前的用户ID: "+idErr.Error())
+			logger.Error("/solutions/query 无法获取当前的用户ID: " + idErr.Error())
+			return
+		}
+		if req.Preview {
+			resp, err = ctrl.service.Preview(userID, req)
+		} else {
+			resp, err = ctrl.service.Query(userID, req)
+		}
+	}
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
-		logger.Error("/solutions/query 查询方案失败: " + err.Error())
+		switch err.Error() {
+		case stderr.ErrorDeviceNotFound:
+			response.Error(c, http.StatusNotFound, response.CodeNotFound, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("/solutions/query 查询方案失败: " + err.Error())
+		}
 		return
 	}
 	response.Success(c, resp)
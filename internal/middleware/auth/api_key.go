@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"io"
+	"net/http"
+	model "xinde/internal/model/api_key"
+	apiKeyService "xinde/internal/service/api_key"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/signer"
+	"xinde/pkg/stderr"
+)
+
+// apiKeyKey 通过 API Key 认证的请求在 gin.Context 中保存 Key 的键
+const apiKeyKey = "api_key"
+
+// APIKeyAuth API Key 认证中间件，供 ERP 等系统在服务端调用。请求需要带上 X-Api-Key、X-Timestamp、X-Nonce 和 X-Signature，
+// 签名规则见 pkg/signer。通过后上下文中只有 API Key，没有用户信息，价格按 Key 所属的公司计算
+func APIKeyAuth() gin.HandlerFunc {
+	service, serviceErr := apiKeyService.NewAPIKeyService()
+
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if serviceErr != nil {
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("APIKeyAuth 创建Service失败: " + serviceErr.Error())
+			c.Abort()
+			return
+		}
+
+		// 读取请求体参与签名，再放回去供后续绑定参数
+		var body []byte
+		if c.Request.Body != nil {
+			maxBodySize := viper.GetInt64("apiKey.maxBodySize")
+			var err error
+			body, err = io.ReadAll(io.LimitReader(c.Request.Body, maxBodySize+1))
+			if err != nil {
+				response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, "读取请求体失败: "+err.Error())
+				c.Abort()
+				return
+			}
+			if int64(len(body)) > maxBodySize {
+				response.Error(c, http.StatusRequestEntityTooLarge, response.CodeInvalidParams, stderr.ErrorAPIKeyBodyTooLarge)
+				c.Abort()
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		key, err := service.Authenticate(&apiKeyService.SignedRequest{
+			KeyID:     c.GetHeader(signer.HeaderKeyID),
+			Timestamp: c.GetHeader(signer.HeaderTimestamp),
+			Nonce:     c.GetHeader(signer.HeaderNonce),
+			Signature: c.GetHeader(signer.HeaderSignature),
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.RequestURI(),
+			Body:      body,
+			IP:        c.ClientIP(),
+		})
+		if err != nil {
+			switch err.Error() {
+			case stderr.ErrorAPIKeyMissing, stderr.ErrorAPIKeyNonceInvalid:
+				response.Error(c, http.StatusBadRequest, response.CodeInvalidParams, err.Error())
+			case stderr.ErrorAPIKeyInvalid, stderr.ErrorAPIKeyTimestampInvalid,
+				stderr.ErrorAPIKeySignatureInvalid, stderr.ErrorAPIKeyReplayed:
+				response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, err.Error())
+				logger.Warn(fmt.Sprintf("API Key 认证失败: %s key: %s IP: %s", err.Error(), c.GetHeader(signer.HeaderKeyID), c.ClientIP()))
+			default:
+				response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+				logger.Error("APIKeyAuth 校验API Key失败: " + err.Error())
+			}
+			c.Abort()
+			return
+		}
+
+		c.Set(apiKeyKey, key)
+		c.Next()
+	})
+}
+
+// JWTOrAPIKeyAuth 同时接受用户 token 和 API Key 的接口使用。带有 X-Api-Key 头时按 API Key 认证，否则按 JWTAuth 认证
+func JWTOrAPIKeyAuth() gin.HandlerFunc {
+	jwtAuth := JWTAuth()
+	apiKeyAuth := APIKeyAuth()
+
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if c.GetHeader(signer.HeaderKeyID) != "" {
+			apiKeyAuth(c)
+			return
+		}
+		jwtAuth(c)
+	})
+}
+
+// RequireScope 通过 API Key 访问时要求 Key 拥有 scope，用户 token 访问时不做限制（需要先经过 JWTOrAPIKeyAuth）
+func RequireScope(scope string) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		key, ok := GetAPIKey(c)
+		if ok && !key.HasScope(scope) {
+			response.Error(c, http.StatusForbidden, response.CodeForbidden, stderr.ErrorAPIKeyScopeDenied)
+			c.Abort()
+			return
+		}
+		c.Next()
+	})
+}
+
+// GetAPIKey 从上下文中获取当前请求使用的 API Key，用户 token 认证的请求返回 false
+func GetAPIKey(c *gin.Context) (*model.APIKey, bool) {
+	value, exists := c.Get(apiKeyKey)
+	if !exists {
+		return nil, false
+	}
+	key, ok := value.(*model.APIKey)
+	return key, ok
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	accountDao "xinde/internal/dao/account"
+	model "xinde/internal/model/account"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// companyScopeKey 公司管理员所在公司ID在 gin.Context 中的键
+const companyScopeKey = "company_scope"
+
+// CompanyAdminAuth 公司管理员中间件（需要先经过JWTAuth），当前用户必须是已通过注册申请的公司管理员。
+// 每次请求都重新查询用户，取消公司管理员身份或更换公司后立即生效。
+// 通过后把所在公司ID存入上下文，之后的接口只能访问该公司的数据，见 GetCompanyScope
+func CompanyAdminAuth() gin.HandlerFunc {
+	dao, daoErr := accountDao.NewRegisterDao()
+
+	return gin.HandlerFunc(func(c *gin.Context) {
+		uid, err := GetCurrentUserID(c)
+		if err != nil {
+			response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, "未认证")
+			c.Abort()
+			return
+		}
+		if daoErr != nil {
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("CompanyAdminAuth 创建DAO失败: " + daoErr.Error())
+			c.Abort()
+			return
+		}
+
+		user, err := dao.GetUserProfileByID(dao.DB(), uid)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, stderr.ErrorTokenRevoked)
+			} else {
+				response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+				logger.Error(fmt.Sprintf("CompanyAdminAuth 查询用户失败! 用户ID: %d 错误: %s", uid, err.Error()))
+			}
+			c.Abort()
+			return
+		}
+		if !user.IsCompanyAdmin || user.IsUser != model.UserApproved || user.CompanyID == 0 {
+			response.Error(c, http.StatusForbidden, response.CodeForbidden, stderr.ErrorNotCompanyAdmin)
+			c.Abort()
+			return
+		}
+
+		c.Set(companyScopeKey, user.CompanyID)
+		c.Next()
+	})
+}
+
+// GetCompanyScope 返回公司管理员所在的公司ID，只有经过 CompanyAdminAuth 的请求才返回 true
+func GetCompanyScope(c *gin.Context) (uint, bool) {
+	value, exists := c.Get(companyScopeKey)
+	if !exists {
+		return 0, false
+	}
+	companyID, ok := value.(uint)
+	return companyID, ok && companyID != 0
+}
@@ -1,19 +1,24 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 	"net/http"
 	"strings"
+	accountDao "xinde/internal/dao/account"
+	model "xinde/internal/model/account"
 	"xinde/pkg/jwt"
 	"xinde/pkg/logger"
 	"xinde/pkg/response"
 	"xinde/pkg/stderr"
 )
 
-// JWTAuth JWT认证中间件
+// JWTAuth JWT认证中间件，除了校验签名和有效期，还会检查token是否已被吊销
 func JWTAuth() gin.HandlerFunc {
 	jwtService := jwt.NewJWTService()
+	dao, daoErr := accountDao.NewRegisterDao()
 
 	return gin.HandlerFunc(func(c *gin.Context) {
 		// 从请求头获取token
@@ -32,42 +37,31 @@ func JWTAuth() gin.HandlerFunc {
 			return
 		}
 
-		// 将用户信息存储到上下文中
-		c.Set("user_id", claims.UID)
-		c.Set("username", claims.Username)
-		c.Set("is_admin", claims.IsAdmin)
-		c.Set("user_claims", claims) // 存储完整的claims
-
-		// 继续处理请求
-		c.Next()
-	})
-}
-
-// AdminAuth 管理员权限中间件（需要先经过JWTAuth）
-func AdminAuth() gin.HandlerFunc {
-	return gin.HandlerFunc(func(c *gin.Context) {
-		// 检查是否已通过JWT认证
-		claims, exists := c.Get("user_claims")
-		if !exists {
-			response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, "未认证")
+		// 检查token是否已被吊销
+		if daoErr != nil {
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+			logger.Error("JWTAuth 创建DAO失败: " + daoErr.Error())
 			c.Abort()
 			return
 		}
-
-		userClaims, ok := claims.(*jwt.CustomClaims)
-		if !ok {
-			response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, "认证信息错误")
+		if err := checkRevoked(dao, claims); err != nil {
+			if err.Error() == stderr.ErrorTokenRevoked {
+				handleTokenError(c, err)
+			} else {
+				response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+				logger.Error("JWTAuth 检查token是否被吊销失败: " + err.Error())
+			}
 			c.Abort()
 			return
 		}
 
-		// 检查管理员权限
-		if !userClaims.IsAdmin {
-			response.Error(c, http.StatusForbidden, response.CodeForbidden, "需要管理员权限")
-			c.Abort()
-			return
-		}
+		// 将用户信息存储到上下文中
+		c.Set("user_id", claims.UID)
+		c.Set("username", claims.Username)
+		c.Set("is_admin", claims.IsAdmin)
+		c.Set("user_claims", claims) // 存储完整的claims
 
+		// 继续处理请求
 		c.Next()
 	})
 }
@@ -75,6 +69,7 @@ func AdminAuth() gin.HandlerFunc {
 // OptionalAuth 可选认证中间件（token存在则验证，不存在也放行）
 func OptionalAuth() gin.HandlerFunc {
 	jwtService := jwt.NewJWTService()
+	dao, daoErr := accountDao.NewRegisterDao()
 
 	return gin.HandlerFunc(func(c *gin.Context) {
 		token := getTokenFromHeader(c)
@@ -87,8 +82,15 @@ func OptionalAuth() gin.HandlerFunc {
 
 		// 有token，尝试验证
 		claims, err := jwtService.ValidateToken(token)
+		if err == nil {
+			if daoErr != nil {
+				err = daoErr
+			} else {
+				err = checkRevoked(dao, claims)
+			}
+		}
 		if err != nil {
-			// token无效，但不阻止请求（降级为游客模式）
+			// token无效或已被吊销，但不阻止请求（降级为游客模式）
 			c.Set("is_authenticated", false)
 			c.Next()
 			return
@@ -105,6 +107,22 @@ func OptionalAuth() gin.HandlerFunc {
 	})
 }
 
+// checkRevoked 检查token是否已被吊销：用户已删除、不再是通过审核的状态、
+// token 版本号落后（吊销了全部token）或该token已注销时返回 ErrorTokenRevoked
+func checkRevoked(dao *accountDao.Dao, claims *jwt.CustomClaims) error {
+	state, err := dao.GetTokenState(dao.DB(), claims.UID, claims.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf(stderr.ErrorTokenRevoked)
+		}
+		return err
+	}
+	if state.IsUser != model.UserApproved || state.TokenVersion != claims.TokenVersion || state.JTIRevoked {
+		return fmt.Errorf(stderr.ErrorTokenRevoked)
+	}
+	return nil
+}
+
 // getTokenFromHeader 从请求头中提取token
 func getTokenFromHeader(c *gin.Context) string {
 	// 支持多种token传递方式
@@ -119,12 +137,8 @@ func getTokenFromHeader(c *gin.Context) string {
 	}
 
 	// 方式2: 直接从 Authorization 头获取（兼容老接口）
-	if authHeader != "" {
-		return authHeader
-	}
-
-	// 方式3: 从查询参数获取（不推荐，但有时需要）
-	return c.Query("token")
+	// 不从查询参数获取token，URL中的token会被记录到访问日志、代理和Referer中
+	return authHeader
 }
 
 // handleTokenError 处理token验证错误
@@ -138,6 +152,8 @@ func handleTokenError(c *gin.Context, err error) {
 		response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, stderr.ErrorTokenNotValidYet)
 	case stderr.ErrorTokenInvalid:
 		response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, stderr.ErrorTokenInvalid)
+	case stderr.ErrorTokenRevoked:
+		response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, stderr.ErrorTokenRevoked)
 	default:
 		response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, stderr.ErrorTokenInvalid)
 	}
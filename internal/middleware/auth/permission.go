@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	roleDao "xinde/internal/dao/role"
+	model "xinde/internal/model/role"
+	"xinde/pkg/logger"
+	"xinde/pkg/response"
+	"xinde/pkg/stderr"
+)
+
+// permissionsKey 当前用户权限集合在 gin.Context 中的键，同一个请求只查询一次数据库
+const permissionsKey = "permissions"
+
+// AdminAuth 管理员权限中间件（需要先经过JWTAuth），拥有任意权限的用户即为管理员。
+// 具体接口需要的权限由 RequirePermission 校验
+func AdminAuth() gin.HandlerFunc {
+	dao, daoErr := roleDao.NewRoleDao()
+
+	return gin.HandlerFunc(func(c *gin.Context) {
+		permissions, ok := loadPermissions(c, dao, daoErr)
+		if !ok {
+			return
+		}
+		if len(permissions) == 0 {
+			response.Error(c, http.StatusForbidden, response.CodeForbidden, "需要管理员权限")
+			c.Abort()
+			return
+		}
+		c.Next()
+	})
+}
+
+// RequirePermission 接口级权限中间件（需要先经过JWTAuth），当前用户需要拥有 permission 或全部权限(*)
+func RequirePermission(permission string) gin.HandlerFunc {
+	dao, daoErr := roleDao.NewRoleDao()
+
+	return gin.HandlerFunc(func(c *gin.Context) {
+		permissions, ok := loadPermissions(c, dao, daoErr)
+		if !ok {
+			return
+		}
+		if !permissions[permission] && !permissions[model.PermAll] {
+			response.Error(c, http.StatusForbidden, response.CodeForbidden, stderr.ErrorPermissionDenied)
+			c.Abort()
+			return
+		}
+		c.Next()
+	})
+}
+
+// LoadPermissions 只加载当前用户的权限而不做校验，供普通用户也能访问、但部分功能需要权限的接口使用。
+// 通过 API Key 调用时没有用户，不加载任何权限
+func LoadPermissions() gin.HandlerFunc {
+	dao, daoErr := roleDao.NewRoleDao()
+
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if _, ok := GetAPIKey(c); ok {
+			c.Next()
+			return
+		}
+		if _, ok := loadPermissions(c, dao, daoErr); !ok {
+			return
+		}
+		c.Next()
+	})
+}
+
+// HasPermission 判断当前用户是否拥有某个权限，只能在 AdminAuth、RequirePermission 或 LoadPermissions 之后使用
+func HasPermission(c *gin.Context, permission string) bool {
+	value, exists := c.Get(permissionsKey)
+	if !exists {
+		return false
+	}
+	permissions, ok := value.(map[string]bool)
+	return ok && (permissions[permission] || permissions[model.PermAll])
+}
+
+// loadPermissions 查询当前用户的权限集合并缓存在上下文中。失败时已写入响应并中止请求，返回 false
+func loadPermissions(c *gin.Context, dao *roleDao.Dao, daoErr error) (map[string]bool, bool) {
+	if value, exists := c.Get(permissionsKey); exists {
+		if permissions, ok := value.(map[string]bool); ok {
+			return permissions, true
+		}
+	}
+
+	uid, err := GetCurrentUserID(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, response.CodeUnauthorized, "未认证")
+		c.Abort()
+		return nil, false
+	}
+	if daoErr != nil {
+		response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+		logger.Error("加载用户权限时创建DAO失败: " + daoErr.Error())
+		c.Abort()
+		return nil, false
+	}
+
+	list, err := dao.FindPermissionsByUID(dao.DB(), uid)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, response.CodeInternalError, stderr.ErrorInternalServerError)
+		logger.Error(fmt.Sprintf("加载用户权限失败! 用户ID: %d 错误: %s", uid, err.Error()))
+		c.Abort()
+		return nil, false
+	}
+
+	permissions := make(map[string]bool, len(list))
+	for _, p := range list {
+		permissions[p] = true
+	}
+	c.Set(permissionsKey, permissions)
+	return permissions, true
+}
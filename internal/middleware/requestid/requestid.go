@@ -0,0 +1,36 @@
+package requestid
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"regexp"
+)
+
+const (
+	// HeaderName 请求ID的请求头/响应头
+	HeaderName = "X-Request-ID"
+	// contextKey 请求ID在 gin.Context 中的键
+	contextKey = "request_id"
+)
+
+// validRequestID 只接受由字母、数字和 -_. 组成的请求ID，避免把任意内容写进日志和审计记录
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9\-_.]{1,64}$`)
+
+// RequestID 为每个请求分配请求ID：优先沿用上游（如网关）传入的 X-Request-ID，否则生成一个新的，
+// 并通过响应头返回，便于把客户端报错和审计日志对应起来
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(HeaderName)
+		if !validRequestID.MatchString(id) {
+			id = uuid.NewString()
+		}
+		c.Set(contextKey, id)
+		c.Header(HeaderName, id)
+		c.Next()
+	}
+}
+
+// Get 返回当前请求的请求ID，未经过 RequestID 中间件时返回空字符串
+func Get(c *gin.Context) string {
+	return c.GetString(contextKey)
+}
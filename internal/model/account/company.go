@@ -17,6 +17,10 @@ type Company struct {
 
 	// 可为空的字段，使用指针类型
 	Address *string `gorm:"column:address;comment:公司地址"`
+	Notes   *string `gorm:"column:notes;comment:备注"`
+
+	// 被合并到的公司ID。合并后该公司被软删除，以后按原名称注册的用户直接归入合并后的公司
+	MergedInto *uint `gorm:"column:merged_into;comment:被合并到的公司ID"`
 
 	// 自动管理的时间戳与软删除字段
 	CreatedAt time.Time      `gorm:"column:created_at;not null;autoCreateTime"`
@@ -0,0 +1,34 @@
+package account
+
+import "time"
+
+// 登录失败的原因，登录成功时为空
+const (
+	LoginReasonBadCredentials     = "bad_credentials"
+	LoginReasonNotApproved        = "not_approved"
+	LoginReasonRejected           = "rejected"
+	LoginReasonMustChangePassword = "must_change_password"
+	LoginReasonUserLocked         = "user_locked"
+	LoginReasonIPLocked           = "ip_locked"
+	LoginReasonBadMFACode         = "bad_mfa_code"
+	LoginReasonError              = "error"
+)
+
+// LoginHistory represents the t_login_history table in the database.
+// 每次登录尝试记录一条，用户名不存在时 UID 为 NULL
+type LoginHistory struct {
+	ID        uint   `gorm:"primaryKey;column:id;autoIncrement"`
+	UID       *uint  `gorm:"column:uid;index:idx_uid"`
+	Username  string `gorm:"column:username;not null;index:idx_username"`
+	Success   bool   `gorm:"column:success;not null"`
+	Reason    string `gorm:"column:reason;not null;default:''"`
+	IP        string `gorm:"column:ip;not null;default:'';index:idx_ip"`
+	UserAgent string `gorm:"column:user_agent;not null;default:''"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime"`
+}
+
+// TableName specifies the table name for the LoginHistory model.
+func (LoginHistory) TableName() string {
+	return "t_login_history"
+}
@@ -0,0 +1,43 @@
+package account
+
+import "time"
+
+// UserMFA represents the t_user_mfa table in the database.
+// 每个用户最多一条。开始绑定时生成密钥，用户用验证器应用输入一次验证码确认后 EnabledAt 才有值
+type UserMFA struct {
+	UID uint `gorm:"primaryKey;column:uid"`
+	// 加密后的 TOTP 密钥
+	Secret    string     `gorm:"column:secret;not null"`
+	EnabledAt *time.Time `gorm:"column:enabled_at"`
+	// 最近一次使用的验证码所在的时间步，不大于它的验证码不能再使用
+	LastUsedStep int64 `gorm:"column:last_used_step;not null;default:0"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null;autoUpdateTime"`
+}
+
+// TableName specifies the table name for the UserMFA model.
+func (UserMFA) TableName() string {
+	return "t_user_mfa"
+}
+
+// Enabled 是否已经确认绑定
+func (m *UserMFA) Enabled() bool {
+	return m != nil && m.EnabledAt != nil
+}
+
+// MFARecoveryCode represents the t_mfa_recovery_code table in the database.
+// 丢失验证器时用于登录，只保存哈希，每个只能使用一次
+type MFARecoveryCode struct {
+	ID       uint       `gorm:"primaryKey;column:id;autoIncrement"`
+	UID      uint       `gorm:"column:uid;not null;index:idx_uid"`
+	CodeHash string     `gorm:"column:code_hash;not null"`
+	UsedAt   *time.Time `gorm:"column:used_at"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime"`
+}
+
+// TableName specifies the table name for the MFARecoveryCode model.
+func (MFARecoveryCode) TableName() string {
+	return "t_mfa_recovery_code"
+}
@@ -0,0 +1,39 @@
+package account
+
+import "time"
+
+// RefreshToken represents the t_refresh_token table in the database.
+// 只保存 refresh token 的哈希值；每次刷新都会吊销旧 token 并签发新 token（轮换），
+// 已吊销的 token 再次被使用时视为泄露，吊销该用户的全部 token。
+type RefreshToken struct {
+	ID         uint       `gorm:"primaryKey;column:id;autoIncrement"`
+	UID        uint       `gorm:"column:uid;not null;index:idx_uid"`
+	TokenHash  string     `gorm:"column:token_hash;not null;uniqueIndex:uk_token_hash"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at;not null"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at"`
+	ReplacedBy *uint      `gorm:"column:replaced_by;comment:轮换后新token的ID"`
+	UserAgent  string     `gorm:"column:user_agent"`
+	IP         string     `gorm:"column:ip"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime"`
+}
+
+// TableName specifies the table name for the RefreshToken model.
+func (RefreshToken) TableName() string {
+	return "t_refresh_token"
+}
+
+// RevokedToken represents the t_revoked_token table in the database.
+// 注销时把当前 access token 的 jti 加入黑名单，过期之后的记录可以清理。
+type RevokedToken struct {
+	JTI       string    `gorm:"primaryKey;column:jti"`
+	UID       uint      `gorm:"column:uid;not null"`
+	ExpiresAt time.Time `gorm:"column:expires_at;not null;index:idx_expires_at"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime"`
+}
+
+// TableName specifies the table name for the RevokedToken model.
+func (RevokedToken) TableName() string {
+	return "t_revoked_token"
+}
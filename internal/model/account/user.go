@@ -25,7 +25,8 @@ type User struct {
 	CompanyAddress *string `gorm:"column:company_address;comment:公司地址"`
 	CompanyID      uint    `gorm:"column:company_id;comment:用户对应的公司ID"` // 使用指针 *uint 来处理可为 NULL 的情况
 	// --- 核心修改：使用 readonly 标签 ---
-	PriceLevel string `gorm:"->"`
+	PriceLevel     string `gorm:"->"`
+	PriceLevelName string `gorm:"->"`
 
 	// 用户个人信息
 	Name      string  `gorm:"column:name;not null;comment:用户真实姓名"`
@@ -37,6 +38,15 @@ type User struct {
 	HandledAt *time.Time `gorm:"column:handled_at;comment:注册申请处理时间"` // 指针处理 NULL
 	IsUser    int        `gorm:"column:is_user;not null;comment:是否审核通过,0为未处理, 1为通过, 2为拒绝"`
 	Why       *string    `gorm:"column:why;comment:审核通过/拒绝的原因"`
+
+	// token 版本号，吊销用户全部 token 时递增，签发时版本号更小的 access token 随之失效
+	TokenVersion uint `gorm:"column:token_version;not null;default:0;comment:token版本号"`
+
+	// 管理员重置密码后为 true，用户必须先修改这个一次性密码才能登录
+	MustChangePassword bool `gorm:"column:must_change_password;not null;default:false;comment:是否必须修改密码"`
+
+	// 公司管理员可以审批声称属于本公司的注册申请、重置本公司用户的密码，以及查看本公司的价格和搜索记录
+	IsCompanyAdmin bool `gorm:"column:is_company_admin;not null;default:false;comment:是否为公司管理员"`
 }
 
 // TableName specifies the table name for the User model.
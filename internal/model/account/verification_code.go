@@ -0,0 +1,38 @@
+package account
+
+import "time"
+
+// 验证码的用途
+const (
+	CodePurposeResetPassword = "reset_password"
+)
+
+// 验证码的发送通道
+const (
+	CodeChannelEmail = "email"
+	CodeChannelPhone = "phone"
+)
+
+// VerificationCode represents the t_verification_code table in the database.
+// 验证码只保存哈希值；校验通过后签发一个重置令牌（同样只保存哈希），凭令牌设置新密码。
+// 同一用户同一用途只有最新的一条验证码有效，重新发送时旧的验证码随之作废
+type VerificationCode struct {
+	ID             uint       `gorm:"primaryKey;column:id;autoIncrement"`
+	UID            uint       `gorm:"column:uid;not null;index:idx_uid_purpose"`
+	Purpose        string     `gorm:"column:purpose;not null;index:idx_uid_purpose"`
+	Channel        string     `gorm:"column:channel;not null"`
+	Target         string     `gorm:"column:target;not null"`
+	CodeHash       string     `gorm:"column:code_hash;not null"`
+	Attempts       int        `gorm:"column:attempts;not null;default:0"`
+	ExpiresAt      time.Time  `gorm:"column:expires_at;not null"`
+	VerifiedAt     *time.Time `gorm:"column:verified_at"`
+	ResetTokenHash *string    `gorm:"column:reset_token_hash;uniqueIndex:uk_reset_token_hash"`
+	ConsumedAt     *time.Time `gorm:"column:consumed_at"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime"`
+}
+
+// TableName specifies the table name for the VerificationCode model.
+func (VerificationCode) TableName() string {
+	return "t_verification_code"
+}
@@ -0,0 +1,98 @@
+package api_key
+
+import "time"
+
+// API Key 可以访问的范围，只对接受 API Key 的接口生效，路由通过 auth.RequireScope 声明
+const (
+	ScopeSolutionQuery = "solution:query" // 查询选型方案
+	ScopePriceQuery    = "price:query"    // 查询所属公司的价格
+)
+
+// ScopeDef 访问范围的说明，用于管理端展示可分配的范围
+type ScopeDef struct {
+	Code string
+	Name string
+}
+
+// Scopes 全部可分配的访问范围，顺序即展示顺序
+var Scopes = []ScopeDef{
+	{ScopeSolutionQuery, "查询选型方案"},
+	{ScopePriceQuery, "查询价格"},
+}
+
+// IsValidScope 判断访问范围编码是否存在
+func IsValidScope(code string) bool {
+	for _, s := range Scopes {
+		if s.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey represents the t_api_key table in the database.
+// 供 ERP 等系统服务端调用接口，按所属公司计算价格。请求需要用 Secret 做 HMAC 签名，Secret 本身不在请求中传输。
+type APIKey struct {
+	ID uint `gorm:"primaryKey;column:id;autoIncrement"`
+	// 公开的标识，请求时放在 X-Api-Key 头中
+	KeyID string `gorm:"column:key_id;unique;not null"`
+	// 签名密钥，加密保存，只在创建和轮换时返回一次
+	Secret    string `gorm:"column:secret;not null"`
+	Name      string `gorm:"column:name;not null"`
+	CompanyID uint   `gorm:"column:company_id;not null;index;comment:价格按该公司计算"`
+
+	ExpiresAt  *time.Time `gorm:"column:expires_at;comment:为空表示永不过期"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at"`
+	LastUsedIP string     `gorm:"column:last_used_ip;not null;default:''"`
+	CreatedBy  uint       `gorm:"column:created_by;not null"`
+
+	Scopes []*APIKeyScope `gorm:"foreignKey:APIKeyID"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null;autoUpdateTime"`
+}
+
+// TableName explicitly sets the table name.
+func (APIKey) TableName() string {
+	return "t_api_key"
+}
+
+// Active 未被吊销且未过期
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// HasScope 是否拥有某个访问范围
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s.Scope == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyScope represents the t_api_key_scope table in the database.
+type APIKeyScope struct {
+	APIKeyID uint   `gorm:"primaryKey;column:api_key_id"`
+	Scope    string `gorm:"primaryKey;column:scope"`
+}
+
+// TableName explicitly sets the table name.
+func (APIKeyScope) TableName() string {
+	return "t_api_key_scope"
+}
+
+// APIKeyNonce represents the t_api_key_nonce table in the database.
+// 记录时间窗口内已经使用过的 nonce，防止签名后的请求被重放
+type APIKeyNonce struct {
+	APIKeyID  uint      `gorm:"primaryKey;column:api_key_id"`
+	Nonce     string    `gorm:"primaryKey;column:nonce"`
+	ExpiresAt time.Time `gorm:"column:expires_at;not null;index"`
+}
+
+// TableName explicitly sets the table name.
+func (APIKeyNonce) TableName() string {
+	return "t_api_key_nonce"
+}
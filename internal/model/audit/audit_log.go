@@ -0,0 +1,208 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"gorm.io/datatypes"
+	"reflect"
+	"time"
+)
+
+// 被操作的对象类型
+const (
+	EntityUser        = "user"
+	EntityRole        = "role"
+	EntityCompany     = "company"
+	EntityPrice       = "price"
+	EntityPriceLevel  = "price_level"
+	EntityAttachment  = "attachment"
+	EntityGroup       = "group"
+	EntityDeviceType  = "device_type"
+	EntityFilterImage = "filter_image"
+	EntityVisibility  = "visibility_rule"
+	EntityIP          = "ip"
+	EntityAPIKey      = "api_key"
+	EntityJWTKey      = "jwt_key"
+)
+
+// 操作类型，格式为 对象.动作
+const (
+	ActionUserCreate          = "user.create"
+	ActionUserImport          = "user.import"
+	ActionUserApprove         = "user.approve"
+	ActionUserReject          = "user.reject"
+	ActionUserReopen          = "user.reopen"
+	ActionUserDelete          = "user.delete"
+	ActionUserResetPassword   = "user.reset_password"
+	ActionUserSetPassword     = "user.set_password"
+	ActionUserUpdateRemark    = "user.update_remark"
+	ActionUserSetRoles        = "user.set_roles"
+	ActionUserUnlock          = "user.unlock"
+	ActionIPUnlock            = "ip.unlock"
+	ActionUserResetMFA        = "user.reset_mfa"
+	ActionUserSetCompanyAdmin = "user.set_company_admin"
+
+	// 用户自己的操作，操作人即被操作的用户
+	ActionUserUpdateProfile  = "user.update_profile"
+	ActionUserChangePassword = "user.change_password"
+	ActionUserForgotPassword = "user.forgot_password"
+	ActionUserEnableMFA      = "user.enable_mfa"
+	ActionUserDisableMFA     = "user.disable_mfa"
+	ActionUserRecoveryCodes  = "user.regenerate_recovery_codes"
+
+	ActionRoleCreate = "role.create"
+	ActionRoleUpdate = "role.update"
+	ActionRoleDelete = "role.delete"
+
+	ActionAPIKeyCreate = "api_key.create"
+	ActionAPIKeyUpdate = "api_key.update"
+	ActionAPIKeyRotate = "api_key.rotate"
+	ActionAPIKeyRevoke = "api_key.revoke"
+
+	ActionJWTKeyRotate = "jwt_key.rotate"
+
+	ActionCompanyCreate        = "company.create"
+	ActionCompanyUpdate        = "company.update"
+	ActionCompanyDelete        = "company.delete"
+	ActionCompanySetPriceLevel = "company.set_price_level"
+	ActionCompanyMerge         = "company.merge"
+
+	ActionPriceImport = "price.import"
+
+	ActionPriceLevelCreate = "price_level.create"
+	ActionPriceLevelUpdate = "price_level.update"
+	ActionPriceLevelDelete = "price_level.delete"
+
+	ActionPriceOverrideSave   = "price_override.save"
+	ActionPriceOverrideDelete = "price_override.delete"
+	ActionPriceRuleCreate     = "price_rule.create"
+	ActionPriceRuleDelete     = "price_rule.delete"
+
+	ActionAttachmentDelete    = "attachment.delete"
+	ActionAttachmentFixOrphan = "attachment.fix_orphan"
+
+	ActionGroupCreate = "group.create"
+	ActionGroupUpdate = "group.update"
+	ActionGroupDelete = "group.delete"
+	ActionGroupMove   = "group.move"
+
+	ActionDeviceTypeImport       = "device_type.import"
+	ActionDeviceTypeReimport     = "device_type.reimport"
+	ActionDeviceTypeUpdateGroup  = "device_type.update_group"
+	ActionDeviceTypeUpdateName   = "device_type.update_name"
+	ActionDeviceTypeUpdateStatus = "device_type.update_status"
+	ActionDeviceTypeUpdateImage  = "device_type.update_image"
+	ActionDeviceTypeDelete       = "device_type.delete"
+	ActionDeviceTypeMove         = "device_type.move"
+	ActionFilterImageCreate      = "filter_image.create"
+	ActionFilterImageDelete      = "filter_image.delete"
+	ActionFilterImageChangeOwner = "filter_image.change_device_type"
+
+	ActionVisibilityRuleCreate = "visibility_rule.create"
+	ActionVisibilityRuleDelete = "visibility_rule.delete"
+)
+
+// Actor 执行操作的管理员及请求信息，由 handler 从请求中提取后传给 service
+type Actor struct {
+	UID       uint
+	Username  string
+	IP        string
+	RequestID string
+}
+
+// AuditLog represents the t_audit_log table in the database.
+// 只追加不修改。Before/After 为操作前后对象的快照，Diff 为二者都是 JSON 对象时发生变化的字段
+type AuditLog struct {
+	ID uint `gorm:"primaryKey;column:id;autoIncrement"`
+	// 操作人，用户名保存操作时的快照，用户被删除或改名后仍可追溯
+	ActorUID   uint           `gorm:"column:actor_uid;not null;index:idx_actor_uid"`
+	ActorName  string         `gorm:"column:actor_name;not null"`
+	Action     string         `gorm:"column:action;not null;index:idx_action"`
+	EntityType string         `gorm:"column:entity_type;not null;index:idx_entity"`
+	EntityID   string         `gorm:"column:entity_id;not null;index:idx_entity"`
+	Before     datatypes.JSON `gorm:"column:before_data"`
+	After      datatypes.JSON `gorm:"column:after_data"`
+	Diff       datatypes.JSON `gorm:"column:diff"`
+	IP         string         `gorm:"column:ip;not null"`
+	RequestID  string         `gorm:"column:request_id;not null;index:idx_request_id"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime;index:idx_created_at"`
+}
+
+// TableName explicitly sets the table name.
+func (AuditLog) TableName() string {
+	return "t_audit_log"
+}
+
+// FieldChange 某个字段操作前后的值
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// NewAuditLog 构造一条审计记录。before/after 可以为 nil（如创建没有 before，删除没有 after），
+// 不要传入密码等敏感字段
+func NewAuditLog(actor *Actor, action, entityType string, entityID interface{}, before, after interface{}) (*AuditLog, error) {
+	log := &AuditLog{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   fmt.Sprint(entityID),
+	}
+	if actor != nil {
+		log.ActorUID = actor.UID
+		log.ActorName = actor.Username
+		log.IP = actor.IP
+		log.RequestID = actor.RequestID
+	}
+
+	var err error
+	if log.Before, err = toJSON(before); err != nil {
+		return nil, err
+	}
+	if log.After, err = toJSON(after); err != nil {
+		return nil, err
+	}
+	if log.Diff, err = diffJSON(log.Before, log.After); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+func toJSON(v interface{}) (datatypes.JSON, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("序列化审计数据失败: %w", err)
+	}
+	return b, nil
+}
+
+// diffJSON 比较两个 JSON 对象，返回值发生变化（含新增、删除）的字段，
+// 任意一方不是 JSON 对象时返回 nil
+func diffJSON(before, after datatypes.JSON) (datatypes.JSON, error) {
+	if before == nil || after == nil {
+		return nil, nil
+	}
+	var b, a map[string]interface{}
+	if json.Unmarshal(before, &b) != nil || json.Unmarshal(after, &a) != nil {
+		return nil, nil
+	}
+
+	changes := make(map[string]*FieldChange)
+	for k, bv := range b {
+		if av, ok := a[k]; !ok || !reflect.DeepEqual(av, bv) {
+			changes[k] = &FieldChange{Before: bv, After: a[k]}
+		}
+	}
+	for k, av := range a {
+		if _, ok := b[k]; !ok {
+			changes[k] = &FieldChange{After: av}
+		}
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return toJSON(changes)
+}
@@ -5,15 +5,49 @@ import (
 	"time"
 )
 
+// 设备类型的状态，只有已发布的设备类型对前台用户可见
+const (
+	DeviceTypeDraft     = "draft"
+	DeviceTypePublished = "published"
+	DeviceTypeArchived  = "archived"
+)
+
 type DeviceType struct {
-	ID        uint           `gorm:"primaryKey;column:id"`
-	Name      string         `gorm:"column:name;not null"`
-	GroupID   uint           `gorm:"column:group_id;not null"`
-	CreatedAt time.Time      `gorm:"column:created_at"`
-	UpdatedAt time.Time      `gorm:"column:updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at"`
+	ID      uint   `gorm:"primaryKey;column:id"`
+	Name    string `gorm:"column:name;not null"`
+	GroupID uint   `gorm:"column:group_id;not null"`
+	// 在同一分组中的顺序，从小到大
+	SortOrder int    `gorm:"column:sort_order;not null;default:0"`
+	Status    string `gorm:"column:status;not null;default:published"`
+	// 计划发布和下架的时间，到时间后 EffectiveStatus 自动按新的状态计算，不需要定时任务修改 Status
+	PublishAt   *time.Time     `gorm:"column:publish_at"`
+	UnpublishAt *time.Time     `gorm:"column:unpublish_at"`
+	CreatedAt   time.Time      `gorm:"column:created_at"`
+	UpdatedAt   time.Time      `gorm:"column:updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at"`
 }
 
 func (DeviceType) TableName() string {
 	return "t_device_type"
 }
+
+// EffectiveStatus 返回 now 时刻实际的状态：未发布的设备类型到了 PublishAt 视为已发布，
+// 已发布的设备类型到了 UnpublishAt 视为已下架
+func (dt *DeviceType) EffectiveStatus(now time.Time) string {
+	status := dt.Status
+	if status == "" {
+		status = DeviceTypePublished
+	}
+	if status != DeviceTypePublished && dt.PublishAt != nil && !now.Before(*dt.PublishAt) {
+		status = DeviceTypePublished
+	}
+	if status == DeviceTypePublished && dt.UnpublishAt != nil && !now.Before(*dt.UnpublishAt) {
+		status = DeviceTypeArchived
+	}
+	return status
+}
+
+// IsPublished 判断 now 时刻是否对前台用户可见
+func (dt *DeviceType) IsPublished(now time.Time) bool {
+	return dt.EffectiveStatus(now) == DeviceTypePublished
+}
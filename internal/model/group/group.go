@@ -10,6 +10,7 @@ type Group struct {
 	ID        uint                   `gorm:"primaryKey;column:id"`
 	Name      string                 `gorm:"type:varchar(255);column:name;not null;comment:分组名称"`
 	ParentID  uint                   `gorm:"not null;column:parent_id;comment:父级分组ID"`
+	SortOrder int                    `gorm:"not null;default:0;column:sort_order;comment:在兄弟分组中的顺序，从小到大"`
 	Icon      *attachment.Attachment `gorm:"-"` // 不在数据库中创建字段，仅用于业务逻辑
 	CreatedAt time.Time              `gorm:"column:created_at"`
 	UpdatedAt time.Time              `gorm:"column:updated_at"`
@@ -19,3 +20,28 @@ type Group struct {
 func (Group) TableName() string {
 	return "t_group"
 }
+
+// RootGroupID root 分组的ID，root 分组只作为树的根，不出现在分组路径中
+const RootGroupID uint = 1
+
+// GroupPath 返回从最上层分组到 groupID 的分组路径（不包含 root 分组）。
+// groupMap 中找不到 groupID 时返回空，遇到脏数据中的环时停止回溯
+func GroupPath(groupID uint, groupMap map[uint]*Group) []*Group {
+	var path []*Group
+	seen := make(map[uint]bool)
+	for id := groupID; id != 0 && id != RootGroupID && !seen[id]; {
+		g, ok := groupMap[id]
+		if !ok {
+			break
+		}
+		seen[id] = true
+		path = append(path, g)
+		id = g.ParentID
+	}
+
+	// path 现在是 [groupID, ..., 最上层分组]，需要反转
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
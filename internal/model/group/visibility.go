@@ -0,0 +1,131 @@
+package group
+
+import "time"
+
+// 可见性规则的对象类型
+const (
+	VisibilityTargetCompany = "company"
+	VisibilityTargetRole    = "role"
+)
+
+// VisibilityRule represents the t_catalog_visibility table in the database.
+// 把分组或设备类型限定为只对某个公司或拥有某个角色的用户可见，GroupID 和 DeviceTypeID 只有一个不为 0。
+// 给普通用户分组时使用没有权限的标签角色，授予了权限的角色会使用户成为管理员
+// 分组上的规则对其子孙分组和分组下的设备类型同样生效
+type VisibilityRule struct {
+	ID           uint   `gorm:"primaryKey;column:id;autoIncrement"`
+	GroupID      uint   `gorm:"column:group_id;not null;default:0"`
+	DeviceTypeID uint   `gorm:"column:device_type_id;not null;default:0"`
+	TargetType   string `gorm:"column:target_type;not null"`
+	TargetID     uint   `gorm:"column:target_id;not null"`
+	CreatedByUID uint   `gorm:"column:created_by_uid;not null"`
+
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (VisibilityRule) TableName() string {
+	return "t_catalog_visibility"
+}
+
+// Viewer 查看产品目录的人。All 为 true 时不受可见性规则限制（后台管理员）；
+// 通过 API Key 访问时只有 CompanyID，用户访问时由 UID 查出所在公司和角色
+type Viewer struct {
+	All       bool
+	UID       uint
+	CompanyID uint
+	RoleIDs   []uint
+}
+
+func (v *Viewer) matches(rule *VisibilityRule) bool {
+	switch rule.TargetType {
+	case VisibilityTargetCompany:
+		return v.CompanyID != 0 && rule.TargetID == v.CompanyID
+	case VisibilityTargetRole:
+		for _, id := range v.RoleIDs {
+			if id == rule.TargetID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Visibility 判断某个查看者能看到哪些分组和设备类型。
+// 一个节点上有规则时，查看者需要满足其中任意一条；从该节点到 root 路径上每个有规则的节点都满足时才可见，
+// 因此隐藏的分组下的子分组和设备类型也一定隐藏
+type Visibility struct {
+	viewer      *Viewer
+	parents     map[uint]uint
+	groupRules  map[uint][]*VisibilityRule
+	deviceRules map[uint][]*VisibilityRule
+	cache       map[uint]bool
+}
+
+// NewVisibility 由全部规则和全部分组构造 Visibility
+func NewVisibility(viewer *Viewer, rules []*VisibilityRule, groups []*Group) *Visibility {
+	v := &Visibility{
+		viewer:      viewer,
+		parents:     make(map[uint]uint, len(groups)),
+		groupRules:  make(map[uint][]*VisibilityRule),
+		deviceRules: make(map[uint][]*VisibilityRule),
+		cache:       make(map[uint]bool),
+	}
+	for _, g := range groups {
+		v.parents[g.ID] = g.ParentID
+	}
+	for _, r := range rules {
+		if r.DeviceTypeID != 0 {
+			v.deviceRules[r.DeviceTypeID] = append(v.deviceRules[r.DeviceTypeID], r)
+		} else {
+			v.groupRules[r.GroupID] = append(v.groupRules[r.GroupID], r)
+		}
+	}
+	return v
+}
+
+// GroupVisible 判断分组是否可见
+func (v *Visibility) GroupVisible(groupID uint) bool {
+	if v.viewer.All {
+		return true
+	}
+
+	// 向上找到 root 或已经计算过的祖先，seen 防止脏数据中的环导致死循环
+	var path []uint
+	seen := make(map[uint]bool)
+	visible := true
+	for id := groupID; id != 0 && !seen[id]; id = v.parents[id] {
+		if cached, ok := v.cache[id]; ok {
+			visible = cached
+			break
+		}
+		seen[id] = true
+		path = append(path, id)
+	}
+
+	// 从上往下计算，祖先隐藏时子孙也隐藏
+	for i := len(path) - 1; i >= 0; i-- {
+		visible = visible && v.allowed(v.groupRules[path[i]])
+		v.cache[path[i]] = visible
+	}
+	return visible
+}
+
+// DeviceTypeVisible 判断设备类型是否可见，groupID 为设备类型所在的分组
+func (v *Visibility) DeviceTypeVisible(deviceTypeID, groupID uint) bool {
+	if v.viewer.All {
+		return true
+	}
+	return v.allowed(v.deviceRules[deviceTypeID]) && v.GroupVisible(groupID)
+}
+
+func (v *Visibility) allowed(rules []*VisibilityRule) bool {
+	if len(rules) == 0 {
+		return true
+	}
+	for _, r := range rules {
+		if v.viewer.matches(r) {
+			return true
+		}
+	}
+	return false
+}
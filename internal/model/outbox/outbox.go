@@ -0,0 +1,88 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"gorm.io/datatypes"
+	"time"
+	attachmentModel "xinde/internal/model/attachment"
+	auditModel "xinde/internal/model/audit"
+)
+
+// 待执行操作的状态
+const (
+	StatusPending = "pending"
+	StatusDone    = "done"
+	StatusFailed  = "failed" // 重试次数用完，需要人工处理
+)
+
+// 操作类型
+const (
+	// OpCatalogSync 产品目录在PostgreSQL中的修改提交后，需要在MySQL中完成的附件、可见性规则和审计日志修改
+	OpCatalogSync = "catalog.sync"
+)
+
+// Operation 待执行的跨库操作，保存在PostgreSQL中，和产品目录的修改在同一个事务里写入，
+// 保证目录修改提交后后续的MySQL操作不会因为进程崩溃而丢失
+type Operation struct {
+	ID            uint           `gorm:"primaryKey;column:id"`
+	OpType        string         `gorm:"column:op_type;not null"`
+	EntityKey     string         `gorm:"column:entity_key;not null;default:''"`
+	Payload       datatypes.JSON `gorm:"column:payload;not null"`
+	Status        string         `gorm:"column:status;not null;default:pending"`
+	Attempts      int            `gorm:"column:attempts;not null;default:0"`
+	LastError     string         `gorm:"column:last_error;not null;default:''"`
+	NextAttemptAt time.Time      `gorm:"column:next_attempt_at;not null"`
+	CreatedAt     time.Time      `gorm:"column:created_at"`
+	UpdatedAt     time.Time      `gorm:"column:updated_at"`
+}
+
+func (Operation) TableName() string {
+	return "t_outbox"
+}
+
+// Applied 已经在MySQL中执行过的操作，和操作本身在同一个事务里写入，重试时据此跳过，保证每个操作只执行一次
+type Applied struct {
+	OperationID uint      `gorm:"primaryKey;autoIncrement:false;column:operation_id"`
+	AppliedAt   time.Time `gorm:"column:applied_at;autoCreateTime"`
+}
+
+func (Applied) TableName() string {
+	return "t_outbox_applied"
+}
+
+// AttachmentRef 按业务类型和业务ID定位的一组附件
+type AttachmentRef struct {
+	BusinessType string `json:"business_type"`
+	BusinessIDs  []uint `json:"business_ids"`
+}
+
+// CatalogSync OpCatalogSync 的内容。附件文件在写入操作之前已经保存到磁盘，这里只记录数据库中的附件记录
+type CatalogSync struct {
+	DeleteAttachments []*AttachmentRef              `json:"delete_attachments,omitempty"`
+	CreateAttachments []*attachmentModel.Attachment `json:"create_attachments,omitempty"`
+	// 删除该设备类型上的可见性规则
+	DeleteVisibilityDeviceTypeID uint `json:"delete_visibility_device_type_id,omitempty"`
+	// 审计日志在修改时就已经生成，执行时原样写入，保留修改时的操作人和请求信息
+	Audit *auditModel.AuditLog `json:"audit,omitempty"`
+}
+
+// DeviceTypeEntity 设备类型的实体标识。设备类型及其筛选图片、方案的修改都使用这个标识，保证按提交顺序在MySQL中执行
+func DeviceTypeEntity(deviceTypeID uint) string {
+	return fmt.Sprintf("device_type:%d", deviceTypeID)
+}
+
+// NewOperation 构造一条待执行的操作，entityKey 相同的操作按ID顺序执行
+func NewOperation(opType, entityKey string, payload interface{}) (*Operation, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("序列化待执行操作失败: %w", err)
+	}
+	return &Operation{
+		OpType:        opType,
+		EntityKey:     entityKey,
+		Payload:       b,
+		Status:        StatusPending,
+		NextAttemptAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,62 @@
+package price
+
+import "time"
+
+// 价格来源，说明用户看到的价格是由哪一种规则得出的。优先级: 专属价格 > 折扣规则 > 价格等级
+const (
+	PriceSourceOverride = "override" // 公司专属价格
+	PriceSourceRule     = "rule"     // 公司折扣规则
+	PriceSourceLevel    = "level"    // 公司所在价格等级
+)
+
+// 折扣规则的适用范围
+const (
+	RuleScopeProductPrefix = "product_prefix" // 产品编码前缀
+	RuleScopeGroup         = "group"          // 设备分组（含子孙分组）
+)
+
+// CompanyPriceOverride represents the t_company_price_override table in the database.
+// 大客户针对单个产品协商的专属价格，(company_id, product_code) 唯一。
+type CompanyPriceOverride struct {
+	ID           uint    `gorm:"primaryKey;column:id;autoIncrement"`
+	CompanyID    uint    `gorm:"column:company_id;not null;uniqueIndex:uk_company_product"`
+	ProductCode  string  `gorm:"column:product_code;not null;uniqueIndex:uk_company_product"`
+	Price        float64 `gorm:"column:price;type:decimal(10,2);not null"`
+	Remark       *string `gorm:"column:remark"`
+	CreatedByUID uint    `gorm:"column:created_by_uid;not null"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null;autoUpdateTime"`
+}
+
+// TableName explicitly sets the table name.
+func (CompanyPriceOverride) TableName() string {
+	return "t_company_price_override"
+}
+
+// CompanyPriceRule represents the t_company_price_rule table in the database.
+// 在公司价格等级的基础上按百分比打折，按产品编码前缀或设备分组限定范围。
+type CompanyPriceRule struct {
+	ID        uint   `gorm:"primaryKey;column:id;autoIncrement"`
+	CompanyID uint   `gorm:"column:company_id;not null;index"`
+	ScopeType string `gorm:"column:scope_type;not null"`
+	// ScopeType 为 product_prefix 时有效
+	ProductPrefix string `gorm:"column:product_prefix;not null;default:''"`
+	// ScopeType 为 group 时有效
+	GroupID uint `gorm:"column:group_id;not null;default:0"`
+	// 折扣百分比，10 表示在等级价格基础上减 10%
+	DiscountPercent float64 `gorm:"column:discount_percent;type:decimal(5,2);not null"`
+	Remark          *string `gorm:"column:remark"`
+	CreatedByUID    uint    `gorm:"column:created_by_uid;not null"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null;autoUpdateTime"`
+
+	// 使用ReadOnly标签，联表查询时填充
+	GroupName string `gorm:"->"`
+}
+
+// TableName explicitly sets the table name.
+func (CompanyPriceRule) TableName() string {
+	return "t_company_price_rule"
+}
@@ -0,0 +1,33 @@
+package price
+
+import "time"
+
+// PriceHistory represents the t_price_history table in the database.
+// 每次导入的每一行价格都会追加一条记录（只增不改），用于回溯历史价格和预先导入未来生效的价格。
+type PriceHistory struct {
+	ID          uint   `gorm:"primaryKey;column:id;autoIncrement"`
+	ProductCode string `gorm:"column:product_code;not null;index:idx_product_effective"`
+	Unit        string `gorm:"column:unit;not null"`
+	SpecCode    string `gorm:"column:spec_code;not null"`
+
+	// 本次变更中各价格等级的价格，随历史记录一起创建
+	Values []*PriceHistoryValue `gorm:"foreignKey:HistoryID"`
+
+	// 生效时间，导入时未指定则为导入时间
+	EffectiveFrom time.Time `gorm:"column:effective_from;not null;index:idx_product_effective"`
+	// 写入当前价格 (t_price / t_price_value) 的时间，未来生效的记录在到达生效时间后才写入
+	PromotedAt *time.Time `gorm:"column:promoted_at;index:idx_promoted_effective"`
+	// 导致本次变更的导入文件 (t_attachment.id)
+	AttachmentID uint      `gorm:"column:attachment_id;index"`
+	CreatedByUID uint      `gorm:"column:created_by_uid;not null"`
+	CreatedAt    time.Time `gorm:"column:created_at;not null;autoCreateTime"`
+
+	// 使用ReadOnly标签，联表查询时填充
+	AttachmentName string `gorm:"->"`
+	OperatorName   string `gorm:"->"`
+}
+
+// TableName explicitly sets the table name.
+func (PriceHistory) TableName() string {
+	return "t_price_history"
+}
@@ -0,0 +1,55 @@
+package price
+
+import "time"
+
+// DefaultPriceLevelCode 新建公司未指定价格等级时使用的等级，与 t_company.price_level 的默认值一致，不能删除
+const DefaultPriceLevelCode = "price_1"
+
+// PriceLevel represents the t_price_level table in the database.
+// 价格等级可由管理员配置，公司通过 t_company.price_level 引用等级编码。
+type PriceLevel struct {
+	ID   uint   `gorm:"primaryKey;column:id;autoIncrement"`
+	Code string `gorm:"column:code;unique;not null;comment:等级编码，创建后不可修改"`
+	Name string `gorm:"column:name;not null;comment:展示名称"`
+	// 数值越小越靠前，排在第一位的等级作为默认等级
+	SortOrder int `gorm:"column:sort_order;not null;default:0"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null;autoUpdateTime"`
+}
+
+// TableName explicitly sets the table name.
+func (PriceLevel) TableName() string {
+	return "t_price_level"
+}
+
+// PriceValue represents the t_price_value table in the database.
+// 每个产品在每个价格等级下的当前价格，(product_code, level_code) 唯一。
+type PriceValue struct {
+	ID          uint    `gorm:"primaryKey;column:id;autoIncrement"`
+	ProductCode string  `gorm:"column:product_code;not null;uniqueIndex:uk_product_level"`
+	LevelCode   string  `gorm:"column:level_code;not null;uniqueIndex:uk_product_level"`
+	Price       float64 `gorm:"column:price;type:decimal(10,2);not null"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null;autoUpdateTime"`
+}
+
+// TableName explicitly sets the table name.
+func (PriceValue) TableName() string {
+	return "t_price_value"
+}
+
+// PriceHistoryValue represents the t_price_history_value table in the database.
+// 一条价格历史记录在各价格等级下的价格，只包含导入文件中出现的等级。
+type PriceHistoryValue struct {
+	ID        uint    `gorm:"primaryKey;column:id;autoIncrement"`
+	HistoryID uint    `gorm:"column:history_id;not null;index"`
+	LevelCode string  `gorm:"column:level_code;not null"`
+	Price     float64 `gorm:"column:price;type:decimal(10,2);not null"`
+}
+
+// TableName explicitly sets the table name.
+func (PriceHistoryValue) TableName() string {
+	return "t_price_history_value"
+}
@@ -0,0 +1,67 @@
+package role
+
+// 权限编码，格式为 模块:操作。路由通过 auth.RequirePermission 声明所需的权限
+const (
+	// PermAll 通配符，拥有全部权限，只授予超级管理员
+	PermAll = "*"
+
+	PermAccountRead    = "account:read"    // 查看和导出用户列表、查看注册申请
+	PermAccountApprove = "account:approve" // 审批注册申请
+	PermAccountWrite   = "account:write"   // 创建和导入用户、删除用户、重置密码、修改备注
+
+	PermRoleManage = "role:manage" // 管理角色及用户的角色
+
+	PermCompanyRead  = "company:read"  // 查看公司列表、详情、用户和疑似重复的公司
+	PermCompanyWrite = "company:write" // 创建、修改、删除和合并公司
+
+	PermPriceRead  = "price:read"  // 查看价格、价格等级、价格历史和公司专属价格
+	PermPriceWrite = "price:write" // 导入价格、维护价格等级、公司价格等级、专属价格和折扣规则
+
+	PermCatalogRead  = "catalog:read"  // 查看分组、设备类型、筛选图片和可见性规则
+	PermCatalogWrite = "catalog:write" // 维护分组、设备类型、筛选图片和可见性规则
+
+	PermAttachmentRead  = "attachment:read"  // 查看和下载附件
+	PermAttachmentWrite = "attachment:write" // 删除附件、修复孤儿附件
+
+	PermAuditRead = "audit:read" // 查看和导出审计日志
+
+	PermAPIKeyManage = "api_key:manage" // 管理供外部系统调用的 API Key
+
+	PermJWTKeyManage = "jwt_key:manage" // 查看和轮换 JWT 签名密钥
+)
+
+// PermissionDef 权限的说明，用于管理端展示可分配的权限
+type PermissionDef struct {
+	Code string
+	Name string
+}
+
+// Permissions 全部可分配的权限，顺序即展示顺序
+var Permissions = []PermissionDef{
+	{PermAll, "全部权限"},
+	{PermAccountRead, "查看用户"},
+	{PermAccountApprove, "审批注册申请"},
+	{PermAccountWrite, "管理用户"},
+	{PermRoleManage, "管理角色"},
+	{PermCompanyRead, "查看公司"},
+	{PermCompanyWrite, "管理公司"},
+	{PermPriceRead, "查看价格"},
+	{PermPriceWrite, "管理价格"},
+	{PermCatalogRead, "查看产品目录"},
+	{PermCatalogWrite, "管理产品目录"},
+	{PermAttachmentRead, "查看附件"},
+	{PermAttachmentWrite, "管理附件"},
+	{PermAuditRead, "查看审计日志"},
+	{PermAPIKeyManage, "管理API Key"},
+	{PermJWTKeyManage, "管理JWT签名密钥"},
+}
+
+// IsValidPermission 判断权限编码是否存在
+func IsValidPermission(code string) bool {
+	for _, p := range Permissions {
+		if p.Code == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package role
+
+import "time"
+
+// SuperAdminCode 内置超级管理员角色的编码，拥有全部权限且不能修改或删除
+const SuperAdminCode = "super_admin"
+
+// Role represents the t_role table in the database.
+// 角色是一组权限的集合，用户可以拥有多个角色，最终权限为各角色权限的并集。
+// 没有任何权限的角色是标签角色，只用作产品目录可见性规则的对象，拥有它的用户不会因此成为管理员。
+type Role struct {
+	ID          uint    `gorm:"primaryKey;column:id;autoIncrement"`
+	Code        string  `gorm:"column:code;unique;not null;comment:角色编码，创建后不可修改"`
+	Name        string  `gorm:"column:name;not null;comment:展示名称"`
+	Description *string `gorm:"column:description"`
+	// 内置角色不能修改或删除
+	IsSystem bool `gorm:"column:is_system;not null;default:false"`
+
+	Permissions []*RolePermission `gorm:"foreignKey:RoleID"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null;autoUpdateTime"`
+}
+
+// TableName explicitly sets the table name.
+func (Role) TableName() string {
+	return "t_role"
+}
+
+// RolePermission represents the t_role_permission table in the database.
+type RolePermission struct {
+	RoleID     uint   `gorm:"primaryKey;column:role_id"`
+	Permission string `gorm:"primaryKey;column:permission"`
+}
+
+// TableName explicitly sets the table name.
+func (RolePermission) TableName() string {
+	return "t_role_permission"
+}
+
+// UserRole represents the t_user_role table in the database.
+type UserRole struct {
+	UID    uint `gorm:"primaryKey;column:uid"`
+	RoleID uint `gorm:"primaryKey;column:role_id"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime"`
+}
+
+// TableName explicitly sets the table name.
+func (UserRole) TableName() string {
+	return "t_user_role"
+}
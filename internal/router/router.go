@@ -8,17 +8,30 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"xinde/internal/handler"
 	"xinde/internal/handler/account"
+	apiKey "xinde/internal/handler/api_key"
 	"xinde/internal/handler/attachment"
+	"xinde/internal/handler/audit"
 	"xinde/internal/handler/company"
 	"xinde/internal/handler/device"
 	"xinde/internal/handler/group"
+	jwtKey "xinde/internal/handler/jwt_key"
 	"xinde/internal/handler/price"
+	"xinde/internal/handler/role"
 	"xinde/internal/handler/solution"
 	"xinde/internal/middleware/auth"
+	"xinde/internal/middleware/requestid"
+	apiKeyModel "xinde/internal/model/api_key"
+	roleModel "xinde/internal/model/role"
 )
 
 func InitRouter() (*gin.Engine, error) {
 	router := gin.Default()
+	// 只信任配置的反向代理转发的 X-Forwarded-For，未配置时 c.ClientIP() 返回连接的对端地址
+	if err := router.SetTrustedProxies(viper.GetStringSlice("server.trustedProxies")); err != nil {
+		return nil, fmt.Errorf("配置反向代理地址失败: %w", err)
+	}
+	// 为每个请求分配请求ID，审计日志中据此关联同一次请求的所有操作
+	router.Use(requestid.RequestID())
 
 	uploadUrlPrefix := viper.GetString("attachment.upload_url_prefix") // -> "/static/uploads"
 	savePath := viper.GetString("attachment.save_path")                // -> "uploads"
@@ -56,6 +69,26 @@ func InitRouter() (*gin.Engine, error) {
 	if err != nil {
 		return nil, fmt.Errorf("初始化SolutionController失败: %w", err)
 	}
+	roleCtrl, err := role.NewRoleController()
+	if err != nil {
+		return nil, fmt.Errorf("初始化RoleController失败: %w", err)
+	}
+	auditCtrl, err := audit.NewAuditController()
+	if err != nil {
+		return nil, fmt.Errorf("初始化AuditController失败: %w", err)
+	}
+	apiKeyCtrl, err := apiKey.NewAPIKeyController()
+	if err != nil {
+		return nil, fmt.Errorf("初始化APIKeyController失败: %w", err)
+	}
+	jwtKeyCtrl, err := jwtKey.NewJWTKeyController()
+	if err != nil {
+		return nil, fmt.Errorf("初始化JWTKeyController失败: %w", err)
+	}
+
+	// JWT 验证公钥，供其他服务离线验证 token
+	router.GET("/.well-known/jwks.json", jwtKeyCtrl.JWKS)
+
 	// API v1 routes
 	apiV1 := router.Group("/api/v1")
 	{
@@ -70,72 +103,174 @@ func InitRouter() (*gin.Engine, error) {
 		{
 			accountGroup.POST("/register", accountCtrl.Register)
 			accountGroup.POST("/login", accountCtrl.Login)
+			accountGroup.POST("/login/mfa", accountCtrl.LoginMFA)
+			accountGroup.POST("/login/mfa/enroll", accountCtrl.LoginMFAEnroll)
+			accountGroup.POST("/login/mfa/enroll/confirm", accountCtrl.LoginMFAConfirm)
+			accountGroup.POST("/refresh", accountCtrl.Refresh)
+			accountGroup.POST("/password/forgot", accountCtrl.ForgotPassword)
+			accountGroup.POST("/password/verify", accountCtrl.VerifyResetCode)
+			accountGroup.POST("/password/reset", accountCtrl.ResetPasswordByCode)
+			accountGroup.POST("/password/initial", accountCtrl.ChangeInitialPassword)
 		}
 
-		// ========== 管理员接口（需要管理员权限）==========
+		// ========== 管理员接口（需要管理员权限，各接口再按权限细分）==========
 		adminGroup := apiV1.Group("/admin")
 		adminGroup.Use(auth.JWTAuth(), auth.AdminAuth())
 		{
 			//TODO 用户访问记录
 			adminAccountGroup := adminGroup.Group("/account")
 			{
-				adminAccountGroup.GET("/list", accountCtrl.List) //TODO 接入用户访问记录
-				adminAccountGroup.GET("/approval/list", accountCtrl.ApprovalList)
-				adminAccountGroup.POST("/approval/:id", accountCtrl.Approve)
-				adminAccountGroup.DELETE("/:id", accountCtrl.DeleteUser)
-				adminAccountGroup.POST("/reset/password/:id", accountCtrl.ResetPassword)
-				adminAccountGroup.PATCH("/remark/:id", accountCtrl.ResetRemark)
-				adminAccountGroup.PATCH("/password/:id", accountCtrl.UpdatePassword)
+				adminAccountGroup.GET("/list", auth.RequirePermission(roleModel.PermAccountRead), accountCtrl.List) //TODO 接入用户访问记录
+				adminAccountGroup.GET("/approval/list", auth.RequirePermission(roleModel.PermAccountRead), accountCtrl.ApprovalList)
+				adminAccountGroup.GET("/export", auth.RequirePermission(roleModel.PermAccountRead), accountCtrl.Export)
+				adminAccountGroup.POST("/create", auth.RequirePermission(roleModel.PermAccountWrite), accountCtrl.CreateUser)
+				adminAccountGroup.POST("/import", auth.RequirePermission(roleModel.PermAccountWrite), accountCtrl.ImportUsers)
+				adminAccountGroup.POST("/approval/batch", auth.RequirePermission(roleModel.PermAccountApprove), accountCtrl.BatchApprove)
+				adminAccountGroup.POST("/approval/reopen/:id", auth.RequirePermission(roleModel.PermAccountApprove), accountCtrl.Reopen)
+				adminAccountGroup.POST("/approval/:id", auth.RequirePermission(roleModel.PermAccountApprove), accountCtrl.Approve)
+				adminAccountGroup.DELETE("/:id", auth.RequirePermission(roleModel.PermAccountWrite), accountCtrl.DeleteUser)
+				adminAccountGroup.POST("/reset/password/:id", auth.RequirePermission(roleModel.PermAccountWrite), accountCtrl.ResetPassword)
+				adminAccountGroup.PATCH("/remark/:id", auth.RequirePermission(roleModel.PermAccountWrite), accountCtrl.ResetRemark)
+				adminAccountGroup.PATCH("/password/:id", auth.RequirePermission(roleModel.PermAccountWrite), accountCtrl.UpdatePassword)
+				adminAccountGroup.GET("/role/:id", auth.RequirePermission(roleModel.PermAccountRead), roleCtrl.UserRoles)
+				adminAccountGroup.PUT("/role/:id", auth.RequirePermission(roleModel.PermRoleManage), roleCtrl.SetUserRoles)
+				adminAccountGroup.GET("/login/history", auth.RequirePermission(roleModel.PermAccountRead), accountCtrl.LoginHistory)
+				adminAccountGroup.GET("/lock/list", auth.RequirePermission(roleModel.PermAccountRead), accountCtrl.LockList)
+				adminAccountGroup.DELETE("/lock/:id", auth.RequirePermission(roleModel.PermAccountWrite), accountCtrl.UnlockUser)
+				adminAccountGroup.DELETE("/ip/lock", auth.RequirePermission(roleModel.PermAccountWrite), accountCtrl.UnlockIP)
+				adminAccountGroup.DELETE("/mfa/:id", auth.RequirePermission(roleModel.PermAccountWrite), accountCtrl.ResetMFA)
+				adminAccountGroup.PUT("/company_admin/:id", auth.RequirePermission(roleModel.PermAccountWrite), accountCtrl.SetCompanyAdmin)
+			}
+
+			adminRoleGroup := adminGroup.Group("/role")
+			adminRoleGroup.Use(auth.RequirePermission(roleModel.PermRoleManage))
+			{
+				adminRoleGroup.GET("/permissions", roleCtrl.PermissionList)
+				adminRoleGroup.GET("/list", roleCtrl.List)
+				adminRoleGroup.POST("/create", roleCtrl.Create)
+				adminRoleGroup.PUT("/update/:id", roleCtrl.Update)
+				adminRoleGroup.DELETE("/delete/:id", roleCtrl.Delete)
 			}
 
 			adminCompanyGroup := adminGroup.Group("/company")
 			{
-				adminCompanyGroup.GET("/list", companyCtrl.List)
-				adminCompanyGroup.PATCH("/price/level/:id", companyCtrl.UpdatePriceLevel)
+				adminCompanyGroup.GET("/list", auth.RequirePermission(roleModel.PermCompanyRead), companyCtrl.List)
+				adminCompanyGroup.GET("/detail/:id", auth.RequirePermission(roleModel.PermCompanyRead), companyCtrl.Detail)
+				adminCompanyGroup.GET("/members/:id", auth.RequirePermission(roleModel.PermCompanyRead), companyCtrl.Members)
+				adminCompanyGroup.GET("/duplicates", auth.RequirePermission(roleModel.PermCompanyRead), companyCtrl.Duplicates)
+				adminCompanyGroup.GET("/activity/:id", auth.RequirePermission(roleModel.PermCompanyRead), companyCtrl.Activity)
+				adminCompanyGroup.POST("/create", auth.RequirePermission(roleModel.PermCompanyWrite), companyCtrl.Create)
+				adminCompanyGroup.PATCH("/update/:id", auth.RequirePermission(roleModel.PermCompanyWrite), companyCtrl.Update)
+				adminCompanyGroup.DELETE("/delete/:id", auth.RequirePermission(roleModel.PermCompanyWrite), companyCtrl.Delete)
+				adminCompanyGroup.POST("/merge", auth.RequirePermission(roleModel.PermCompanyWrite), companyCtrl.Merge)
+				adminCompanyGroup.PATCH("/price/level/:id", auth.RequirePermission(roleModel.PermPriceWrite), companyCtrl.UpdatePriceLevel)
+				adminCompanyGroup.GET("/price/override/list/:id", auth.RequirePermission(roleModel.PermPriceRead), companyCtrl.OverrideList)
+				adminCompanyGroup.POST("/price/override/save/:id", auth.RequirePermission(roleModel.PermPriceWrite), companyCtrl.SaveOverride)
+				adminCompanyGroup.DELETE("/price/override/delete/:id", auth.RequirePermission(roleModel.PermPriceWrite), companyCtrl.DeleteOverride)
+				adminCompanyGroup.GET("/price/rule/list/:id", auth.RequirePermission(roleModel.PermPriceRead), companyCtrl.RuleList)
+				adminCompanyGroup.POST("/price/rule/create/:id", auth.RequirePermission(roleModel.PermPriceWrite), companyCtrl.CreateRule)
+				adminCompanyGroup.DELETE("/price/rule/delete/:id", auth.RequirePermission(roleModel.PermPriceWrite), companyCtrl.DeleteRule)
 			}
 
 			adminPriceGroup := adminGroup.Group("/price")
 			{
-				adminPriceGroup.GET("/list", priceCtrl.List)
-				adminPriceGroup.POST("/import", priceCtrl.Import)
+				adminPriceGroup.GET("/list", auth.RequirePermission(roleModel.PermPriceRead), priceCtrl.List)
+				adminPriceGroup.POST("/import", auth.RequirePermission(roleModel.PermPriceWrite), priceCtrl.Import)
+				adminPriceGroup.GET("/history", auth.RequirePermission(roleModel.PermPriceRead), priceCtrl.History)
+				adminPriceGroup.GET("/export", auth.RequirePermission(roleModel.PermPriceRead), priceCtrl.Export)
+				adminPriceGroup.GET("/level/list", auth.RequirePermission(roleModel.PermPriceRead), priceCtrl.LevelList)
+				adminPriceGroup.POST("/level/create", auth.RequirePermission(roleModel.PermPriceWrite), priceCtrl.CreateLevel)
+				adminPriceGroup.PUT("/level/update/:id", auth.RequirePermission(roleModel.PermPriceWrite), priceCtrl.UpdateLevel)
+				adminPriceGroup.DELETE("/level/delete/:id", auth.RequirePermission(roleModel.PermPriceWrite), priceCtrl.DeleteLevel)
 			}
 
 			attachmentGroup := adminGroup.Group("/attachment")
 			{
-				attachmentGroup.GET("/list", attachmentCtrl.List)
-				attachmentGroup.GET("/download/:id", attachmentCtrl.Download)
-				attachmentGroup.DELETE("/:id", attachmentCtrl.Delete)
-				attachmentGroup.GET("/scan/invalid", attachmentCtrl.ScanInvalid)
-				attachmentGroup.POST("/fix/orphan", attachmentCtrl.FixOrphan)
+				attachmentGroup.GET("/list", auth.RequirePermission(roleModel.PermAttachmentRead), attachmentCtrl.List)
+				attachmentGroup.GET("/download/:id", auth.RequirePermission(roleModel.PermAttachmentRead), attachmentCtrl.Download)
+				attachmentGroup.DELETE("/:id", auth.RequirePermission(roleModel.PermAttachmentWrite), attachmentCtrl.Delete)
+				attachmentGroup.GET("/scan/invalid", auth.RequirePermission(roleModel.PermAttachmentRead), attachmentCtrl.ScanInvalid)
+				attachmentGroup.GET("/scan/dangling", auth.RequirePermission(roleModel.PermAttachmentRead), attachmentCtrl.ScanDangling)
+				attachmentGroup.POST("/fix/orphan", auth.RequirePermission(roleModel.PermAttachmentWrite), attachmentCtrl.FixOrphan)
 			}
 
 			groupGroup := adminGroup.Group("/group")
 			{
-				groupGroup.POST("/create", groupCtrl.Create)
-				groupGroup.GET("/tree", groupCtrl.GetTree)
-				groupGroup.GET("/list", groupCtrl.List)
-				groupGroup.PUT("/update/:id", groupCtrl.Update)
-				groupGroup.DELETE("/delete/:id", groupCtrl.Delete)
-				groupGroup.GET("/device/list/:id", deviceCtrl.GroupDeviceList)
+				groupGroup.POST("/create", auth.RequirePermission(roleModel.PermCatalogWrite), groupCtrl.Create)
+				groupGroup.GET("/tree", auth.RequirePermission(roleModel.PermCatalogRead), groupCtrl.GetTree)
+				groupGroup.GET("/breadcrumb", auth.RequirePermission(roleModel.PermCatalogRead), groupCtrl.Breadcrumb)
+				groupGroup.GET("/list", auth.RequirePermission(roleModel.PermCatalogRead), groupCtrl.List)
+				groupGroup.PUT("/update/:id", auth.RequirePermission(roleModel.PermCatalogWrite), groupCtrl.Update)
+				groupGroup.PATCH("/move/:id", auth.RequirePermission(roleModel.PermCatalogWrite), groupCtrl.Move)
+				groupGroup.DELETE("/delete/:id", auth.RequirePermission(roleModel.PermCatalogWrite), groupCtrl.Delete)
+				groupGroup.GET("/device/list/:id", auth.RequirePermission(roleModel.PermCatalogRead), deviceCtrl.GroupDeviceList)
+				groupGroup.GET("/visibility/list", auth.RequirePermission(roleModel.PermCatalogRead), groupCtrl.VisibilityList)
+				groupGroup.POST("/visibility/create", auth.RequirePermission(roleModel.PermCatalogWrite), groupCtrl.CreateVisibility)
+				groupGroup.DELETE("/visibility/delete/:id", auth.RequirePermission(roleModel.PermCatalogWrite), groupCtrl.DeleteVisibility)
 			}
 
 			deviceGroup := adminGroup.Group("/device")
 			{
-				deviceGroup.POST("/import", deviceCtrl.Import)
-				deviceGroup.GET("/list", deviceCtrl.List)
-				deviceGroup.PUT("/import/:id", deviceCtrl.UpdateImport)
-				deviceGroup.PATCH("/update/group/:id", deviceCtrl.UpdateGroup)
-				deviceGroup.PATCH("/update/name/:id", deviceCtrl.UpdateName)
-				deviceGroup.POST("/update/image/:id", deviceCtrl.UpdateImage)
-				deviceGroup.DELETE("/delete/:id", deviceCtrl.Delete)
+				deviceGroup.POST("/import", auth.RequirePermission(roleModel.PermCatalogWrite), deviceCtrl.Import)
+				deviceGroup.GET("/list", auth.RequirePermission(roleModel.PermCatalogRead), deviceCtrl.List)
+				deviceGroup.PUT("/import/:id", auth.RequirePermission(roleModel.PermCatalogWrite), deviceCtrl.UpdateImport)
+				deviceGroup.PATCH("/update/group/:id", auth.RequirePermission(roleModel.PermCatalogWrite), deviceCtrl.UpdateGroup)
+				deviceGroup.PATCH("/move", auth.RequirePermission(roleModel.PermCatalogWrite), deviceCtrl.Move)
+				deviceGroup.PATCH("/update/name/:id", auth.RequirePermission(roleModel.PermCatalogWrite), deviceCtrl.UpdateName)
+				deviceGroup.PATCH("/update/status/:id", auth.RequirePermission(roleModel.PermCatalogWrite), deviceCtrl.UpdateStatus)
+				deviceGroup.POST("/update/image/:id", auth.RequirePermission(roleModel.PermCatalogWrite), deviceCtrl.UpdateImage)
+				deviceGroup.DELETE("/delete/:id", auth.RequirePermission(roleModel.PermCatalogWrite), deviceCtrl.Delete)
 			}
 
 			filterImageGroup := adminGroup.Group("/filter_image")
 			{
-				filterImageGroup.POST("/create", deviceCtrl.CreateFilterImage)
-				filterImageGroup.GET("/list", deviceCtrl.FilterImageList)
-				filterImageGroup.DELETE("/delete/:id", deviceCtrl.DeleteFilterImage)
-				filterImageGroup.PATCH("/change/device_type/:id", deviceCtrl.ChangeFilterImageDevice)
+				filterImageGroup.POST("/create", auth.RequirePermission(roleModel.PermCatalogWrite), deviceCtrl.CreateFilterImage)
+				filterImageGroup.GET("/list", auth.RequirePermission(roleModel.PermCatalogRead), deviceCtrl.FilterImageList)
+				filterImageGroup.DELETE("/delete/:id", auth.RequirePermission(roleModel.PermCatalogWrite), deviceCtrl.DeleteFilterImage)
+				filterImageGroup.PATCH("/change/device_type/:id", auth.RequirePermission(roleModel.PermCatalogWrite), deviceCtrl.ChangeFilterImageDevice)
+			}
+
+			apiKeyGroup := adminGroup.Group("/api_key")
+			apiKeyGroup.Use(auth.RequirePermission(roleModel.PermAPIKeyManage))
+			{
+				apiKeyGroup.GET("/scopes", apiKeyCtrl.ScopeList)
+				apiKeyGroup.GET("/list", apiKeyCtrl.List)
+				apiKeyGroup.POST("/create", apiKeyCtrl.Create)
+				apiKeyGroup.PATCH("/update/:id", apiKeyCtrl.Update)
+				apiKeyGroup.POST("/rotate/:id", apiKeyCtrl.Rotate)
+				apiKeyGroup.DELETE("/revoke/:id", apiKeyCtrl.Revoke)
+			}
+
+			jwtKeyGroup := adminGroup.Group("/jwt")
+			jwtKeyGroup.Use(auth.RequirePermission(roleModel.PermJWTKeyManage))
+			{
+				jwtKeyGroup.GET("/keys", jwtKeyCtrl.List)
+				jwtKeyGroup.POST("/rotate", jwtKeyCtrl.Rotate)
+			}
+
+			auditGroup := adminGroup.Group("/audit")
+			auditGroup.Use(auth.RequirePermission(roleModel.PermAuditRead))
+			{
+				auditGroup.GET("/list", auditCtrl.List)
+				auditGroup.GET("/export", auditCtrl.Export)
+			}
+		}
+
+		// ========== 公司管理员接口（只能访问本公司的数据，复用管理员接口的 handler）==========
+		companyAdminGroup := apiV1.Group("/company")
+		companyAdminGroup.Use(auth.JWTAuth(), auth.CompanyAdminAuth())
+		{
+			companyAdminGroup.GET("/info", companyCtrl.Detail)
+			companyAdminGroup.GET("/price/override/list", companyCtrl.OverrideList)
+			companyAdminGroup.GET("/price/rule/list", companyCtrl.RuleList)
+			companyAdminGroup.GET("/activity", companyCtrl.Activity)
+
+			companyAccountGroup := companyAdminGroup.Group("/account")
+			{
+				companyAccountGroup.GET("/list", accountCtrl.List)
+				companyAccountGroup.GET("/approval/list", accountCtrl.ApprovalList)
+				companyAccountGroup.POST("/approval/:id", accountCtrl.Approve)
+				companyAccountGroup.POST("/reset/password/:id", accountCtrl.ResetPassword)
 			}
 		}
 
@@ -143,18 +278,43 @@ func InitRouter() (*gin.Engine, error) {
 		mobGroup := apiV1.Group("/")
 		mobGroup.Use(auth.JWTAuth())
 		{
-			solutionGroup := mobGroup.Group("/solutions")
+			mobAccountGroup := mobGroup.Group("/account")
 			{
-				solutionGroup.POST("/query", solutionCtrl.Query)
+				mobAccountGroup.POST("/logout", accountCtrl.Logout)
+				mobAccountGroup.GET("/me", accountCtrl.Profile)
+				mobAccountGroup.PATCH("/me", accountCtrl.UpdateProfile)
+				mobAccountGroup.PUT("/me/password", accountCtrl.ChangePassword)
+				mobAccountGroup.GET("/me/logins", accountCtrl.RecentLogins)
+				mobAccountGroup.GET("/me/mfa", accountCtrl.MFAStatus)
+				mobAccountGroup.POST("/me/mfa/enroll", accountCtrl.MFAEnroll)
+				mobAccountGroup.POST("/me/mfa/confirm", accountCtrl.MFAConfirm)
+				mobAccountGroup.DELETE("/me/mfa", accountCtrl.DisableMFA)
+				mobAccountGroup.POST("/me/mfa/recovery", accountCtrl.RegenerateRecoveryCodes)
 			}
 
 			groupGroup := mobGroup.Group("/groups")
 			{
 				groupGroup.GET("/tree", groupCtrl.GetTree)
+				groupGroup.GET("/breadcrumb", groupCtrl.Breadcrumb)
 				groupGroup.GET("/device_types/:id", deviceCtrl.GroupDeviceList)
 			}
 		}
 
+		// ========== 用户token或API Key均可访问的接口（API Key 需要拥有对应的访问范围）==========
+		machineGroup := apiV1.Group("/")
+		machineGroup.Use(auth.JWTOrAPIKeyAuth())
+		{
+			solutionGroup := machineGroup.Group("/solutions")
+			{
+				solutionGroup.POST("/query", auth.RequireScope(apiKeyModel.ScopeSolutionQuery), auth.LoadPermissions(), solutionCtrl.Query)
+			}
+
+			priceGroup := machineGroup.Group("/prices")
+			{
+				priceGroup.POST("/query", auth.RequireScope(apiKeyModel.ScopePriceQuery), priceCtrl.Query)
+			}
+		}
+
 		// ========== 可选认证接口（有token更好，没有也行）==========
 
 	}
@@ -8,11 +8,15 @@ import (
 	"xinde/pkg/util"
 )
 
-func (s *Service) GetApprovalUserList(page, pageSize, status int) (*dto.ApprovalListPageData, error) {
+func (s *Service) GetApprovalUserList(page, pageSize, status int, req *dto.UserFilterReq) (*dto.ApprovalListPageData, error) {
 	tx := s.dao.DB()
+	filter, err := s.buildUserListFilter(tx, status, req)
+	if err != nil {
+		return nil, err
+	}
 
 	// 计算总页数
-	count, err := s.dao.CountUserWithStatus(tx, status)
+	count, err := s.dao.CountUsers(tx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +36,7 @@ func (s *Service) GetApprovalUserList(page, pageSize, status int) (*dto.Approval
 	}
 
 	// 查询数据库获取当前页面的用户数据
-	dbData, err := s.dao.FindUserListWithPagination(tx, currentPage, pageSize, status)
+	dbData, err := s.dao.FindUserListWithPagination(tx, currentPage, pageSize, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -5,56 +5,267 @@ import (
 	"fmt"
 	"gorm.io/gorm"
 	"time"
+	dto "xinde/internal/dto/account"
+	model "xinde/internal/model/account"
+	"xinde/internal/model/audit"
+	"xinde/pkg/logger"
 	"xinde/pkg/stderr"
 )
 
-func (s *Service) ApproveUser(id uint, status int, why string) (err error) {
-	// 启动一个事务
-	tx := s.dao.DB().Begin()
-	if tx.Error != nil {
-		return fmt.Errorf("开启事务失败: %w", tx.Error)
+// ApproveOptions 审批时的附加操作，公司和价格等级只在批准时生效
+type ApproveOptions struct {
+	Why string
+	// 把用户关联到已有的公司，为 0 时保持注册时按公司名称查找或创建的公司
+	CompanyID uint
+	// 为 true 时把注册时创建的公司合并到 CompanyID：该公司的用户、访问记录和 API Key 转到 CompanyID，之后删除该公司
+	MergeCompany bool
+	// 同时设置用户所在公司的价格等级，为空时不修改
+	PriceLevel string
+}
+
+// ApproveUser 批准或拒绝注册申请
+func (s *Service) ApproveUser(actor *audit.Actor, id uint, status int, opts *ApproveOptions) error {
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		return s.approveUser(tx, actor, id, status, opts)
+	})
+}
+
+// BatchApproveUsers 批量批准或拒绝注册申请。每个用户单独处理，一个失败不影响其他用户，返回每个用户的处理结果
+func (s *Service) BatchApproveUsers(actor *audit.Actor, ids []uint, status int, opts *ApproveOptions) *dto.BatchApproveData {
+	data := &dto.BatchApproveData{Results: make([]*dto.BatchApproveResult, 0, len(ids))}
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		result := &dto.BatchApproveResult{ID: id, Success: true}
+		err := s.dao.DB().Transaction(func(tx *gorm.DB) error {
+			return s.approveUser(tx, actor, id, status, opts)
+		})
+		if err != nil {
+			result.Success = false
+			switch err.Error() {
+			case stderr.ErrorUserNotFound, stderr.ErrorUserPassed, stderr.ErrorUserBanned,
+				stderr.ErrorCompanyNotFound, stderr.ErrorPriceLevelNotFound, stderr.ErrorCompanyMergeHasPriceData:
+				result.Error = err.Error()
+			default:
+				result.Error = stderr.ErrorInternalServerError
+				logger.Error(fmt.Sprintf("批量审批失败! 用户ID: %d, 错误: %s", id, err.Error()))
+			}
+			data.Failed++
+		} else {
+			data.Succeeded++
+		}
+		data.Results = append(data.Results, result)
 	}
+	return data
+}
 
-	// 使用 defer 来确保事务在函数退出时能被处理（提交或回滚）
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback() // 如果发生 panic，回滚事务
+// ReopenUser 把已批准或已拒绝的注册申请重新置为待审批，之后可以重新审批。已批准的用户同时吊销其全部 token
+func (s *Service) ReopenUser(actor *audit.Actor, id uint, why string) error {
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		user, err := s.getUserForApproval(tx, id)
+		if err != nil {
+			return err
+		}
+		if user.IsUser == model.UserPending {
+			return fmt.Errorf(stderr.ErrorUserNotHandled)
 		}
-	}()
 
-	// 查找用户是否存在
-	user, err := s.dao.GetUserByIDForUpdate(tx, id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return fmt.Errorf(stderr.ErrorUserNotFound)
+		updateData := map[string]interface{}{
+			"is_user":    model.UserPending,
+			"why":        why,
+			"handled_at": nil,
+		}
+		if err := s.dao.UpdateUser(tx, id, updateData); err != nil {
+			return err
+		}
+		if user.IsUser == model.UserApproved {
+			if err := s.dao.RevokeUserTokens(tx, id); err != nil {
+				return err
+			}
 		}
+
+		return s.auditDao.Record(tx, actor, audit.ActionUserReopen, audit.EntityUser, id,
+			map[string]interface{}{"is_user": user.IsUser, "why": user.Why},
+			map[string]interface{}{"is_user": model.UserPending, "why": why})
+	})
+}
+
+func (s *Service) approveUser(tx *gorm.DB, actor *audit.Actor, id uint, status int, opts *ApproveOptions) error {
+	user, err := s.getUserForApproval(tx, id)
+	if err != nil {
 		return err
 	}
 
-	// 检查用户的`is_user`状态，此时只可能为0
+	// 只有待审批的申请可以审批，已处理的需要先重新打开
 	switch user.IsUser {
-	case 1:
+	case model.UserApproved:
 		return fmt.Errorf(stderr.ErrorUserPassed)
-	case 2:
+	case model.UserRejected:
 		return fmt.Errorf(stderr.ErrorUserBanned)
 	}
 
 	// 更新用户的`is_user`状态
 	updateData := map[string]interface{}{
 		"is_user":    status,
-		"why":        why,
+		"why":        opts.Why,
 		"handled_at": time.Now(),
 	}
-	err = s.dao.UpdateUser(tx, id, updateData)
+	before := map[string]interface{}{"is_user": user.IsUser}
+	after := map[string]interface{}{"is_user": status, "why": opts.Why}
+
+	action := audit.ActionUserApprove
+	if status == model.UserRejected {
+		// 拒绝注册申请时吊销该用户的token
+		action = audit.ActionUserReject
+		if err := s.dao.RevokeUserTokens(tx, id); err != nil {
+			return err
+		}
+	} else {
+		companyID, err := s.linkCompany(tx, actor, user, opts)
+		if err != nil {
+			return err
+		}
+		if companyID != user.CompanyID {
+			before["company_id"] = user.CompanyID
+			after["company_id"] = companyID
+		}
+		if opts.PriceLevel != "" {
+			if err := s.setCompanyPriceLevel(tx, actor, companyID, opts.PriceLevel); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := s.dao.UpdateUser(tx, id, updateData); err != nil {
+		return err
+	}
+
+	// 记录审计日志
+	return s.auditDao.Record(tx, actor, action, audit.EntityUser, id, before, after)
+}
+
+// getUserForApproval 锁定并返回待审批的用户
+func (s *Service) getUserForApproval(tx *gorm.DB, id uint) (*model.User, error) {
+	user, err := s.dao.GetUserByIDForUpdate(tx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf(stderr.ErrorUserNotFound)
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// linkCompany 按审批选项把用户关联到已有的公司或合并公司，返回用户最终所在的公司ID
+func (s *Service) linkCompany(tx *gorm.DB, actor *audit.Actor, user *model.User, opts *ApproveOptions) (uint, error) {
+	if opts.CompanyID == 0 || opts.CompanyID == user.CompanyID {
+		return user.CompanyID, nil
+	}
+
+	isExist, err := s.companyDao.IsExistCompanyByID(tx, opts.CompanyID)
+	if err != nil {
+		return 0, err
+	}
+	if !isExist {
+		return 0, fmt.Errorf(stderr.ErrorCompanyNotFound)
+	}
+	target, err := s.companyDao.GetCompanyByID(tx, opts.CompanyID)
+	if err != nil {
+		return 0, err
+	}
+
+	if opts.MergeCompany && user.CompanyID != 0 {
+		if err := s.mergeCompany(tx, actor, user.CompanyID, target); err != nil {
+			return 0, err
+		}
+	}
+
+	// 合并时用户已随公司转移，这里再更新一次不影响结果
+	err = s.dao.UpdateUser(tx, user.UID, map[string]interface{}{
+		"company_id":   target.ID,
+		"company_name": target.Name,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return target.ID, nil
+}
+
+// mergeCompany 把注册时创建的 sourceID 公司合并到 target，该公司的用户随之转移，之后按原名称注册的用户直接归入 target
+func (s *Service) mergeCompany(tx *gorm.DB, actor *audit.Actor, sourceID uint, target *model.Company) error {
+	isExist, err := s.companyDao.IsExistCompanyByID(tx, sourceID)
 	if err != nil {
 		return err
 	}
+	if !isExist {
+		// 注册时创建的公司已被删除，只需关联到目标公司
+		return nil
+	}
+	source, err := s.companyDao.GetCompanyByID(tx, sourceID)
+	if err != nil {
+		return err
+	}
+
+	// 专属价格和折扣规则无法自动合并，需要管理员先处理
+	hasPriceData, err := s.companyDao.HasCompanyPriceData(tx, sourceID)
+	if err != nil {
+		return err
+	}
+	if hasPriceData {
+		return fmt.Errorf(stderr.ErrorCompanyMergeHasPriceData)
+	}
 
-	// 所有操作成功，提交事务
-	if err := tx.Commit().Error; err != nil {
-		// 提交失败也需要回滚（虽然很少见），并记录错误
-		tx.Rollback()
-		return fmt.Errorf("提交事务失败: %w", err)
+	result, err := s.companyDao.MergeCompanies(tx, target, []uint{sourceID})
+	if err != nil {
+		return err
+	}
+	for _, admin := range result.DemotedAdmins {
+		err := s.auditDao.Record(tx, actor, audit.ActionUserSetCompanyAdmin, audit.EntityUser, admin.UID,
+			map[string]interface{}{"is_company_admin": true, "company_id": admin.CompanyID},
+			map[string]interface{}{"is_company_admin": false, "company_id": target.ID})
+		if err != nil {
+			return err
+		}
+	}
+	return s.auditDao.Record(tx, actor, audit.ActionCompanyMerge, audit.EntityCompany, sourceID,
+		map[string]interface{}{"name": source.Name, "price_level": source.PriceLevel},
+		map[string]interface{}{"merged_into": target.ID, "moved_users": result.Users, "moved_access_logs": result.AccessLogs, "moved_api_keys": result.APIKeys,
+			"moved_visibility_rules": result.VisibilityRules})
+}
+
+// setCompanyPriceLevel 审批时设置公司的价格等级，与原等级相同时不做修改
+func (s *Service) setCompanyPriceLevel(tx *gorm.DB, actor *audit.Actor, companyID uint, priceLevel string) error {
+	isExist, err := s.priceDao.IsExistPriceLevelByCode(tx, priceLevel)
+	if err != nil {
+		return err
+	}
+	if !isExist {
+		return fmt.Errorf(stderr.ErrorPriceLevelNotFound)
+	}
+
+	isExist, err = s.companyDao.IsExistCompanyByID(tx, companyID)
+	if err != nil {
+		return err
+	}
+	if !isExist {
+		return fmt.Errorf(stderr.ErrorCompanyNotFound)
+	}
+	company, err := s.companyDao.GetCompanyByID(tx, companyID)
+	if err != nil {
+		return err
+	}
+	if company.PriceLevel == priceLevel {
+		return nil
+	}
+
+	updateData := map[string]interface{}{"price_level": priceLevel}
+	if err := s.companyDao.UpdateCompany(tx, companyID, updateData); err != nil {
+		return err
 	}
-	return nil
+	return s.auditDao.Record(tx, actor, audit.ActionCompanySetPriceLevel, audit.EntityCompany, companyID,
+		map[string]interface{}{"price_level": company.PriceLevel}, updateData)
 }
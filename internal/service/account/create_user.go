@@ -0,0 +1,164 @@
+package account
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"time"
+	dto "xinde/internal/dto/account"
+	model "xinde/internal/model/account"
+	"xinde/internal/model/audit"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+// newUser 管理员创建的一个用户，公司由 CompanyID 或 CompanyName 确定
+type newUser struct {
+	Username       string
+	Name           string
+	Phone          string
+	Email          string
+	CompanyID      uint
+	CompanyName    string
+	CompanyAddress string
+	IsCompanyAdmin bool
+}
+
+// CreateUser 管理员直接创建一个已通过审批的用户，密码为随机生成的一次性密码，用户首次登录时必须修改。
+// 一次性密码只通过返回值交给管理员一次，数据库中只保存哈希
+func (s *Service) CreateUser(actor *audit.Actor, req *dto.CreateUserReq) (*dto.CreateUserData, error) {
+	if req.CompanyID == 0 && req.CompanyName == "" {
+		return nil, fmt.Errorf(stderr.ErrorUserCompanyMissing)
+	}
+
+	password, hashPassword, err := newOneTimePassword()
+	if err != nil {
+		return nil, err
+	}
+
+	input := &newUser{
+		Username:       req.Username,
+		Name:           req.Name,
+		Phone:          req.Phone,
+		Email:          req.Email,
+		CompanyID:      req.CompanyID,
+		CompanyName:    req.CompanyName,
+		CompanyAddress: req.CompanyAddress,
+		IsCompanyAdmin: req.IsCompanyAdmin,
+	}
+	var user *model.User
+	err = s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		exists, err := s.isUsernameTaken(tx, req.Username)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf(stderr.ErrorUserAlreadyExist)
+		}
+
+		company, err := s.resolveNewUserCompany(tx, input, nil)
+		if err != nil {
+			return err
+		}
+		user = buildApprovedUser(input, company, hashPassword)
+		if err := s.dao.CreateUsers(tx, []*model.User{user}); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, audit.ActionUserCreate, audit.EntityUser, user.UID, nil, newUserSnapshot(user))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.CreateUserData{ID: user.UID, Password: password}, nil
+}
+
+// isUsernameTaken 判断用户名是否已被未删除的用户使用
+func (s *Service) isUsernameTaken(tx *gorm.DB, username string) (bool, error) {
+	exists, err := s.dao.IsExistUser(tx, username)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+	return exists, nil
+}
+
+// resolveNewUserCompany 确定新用户所在的公司：指定了 CompanyID 时公司必须存在，否则按名称查找或新建。
+// cache 不为 nil 时缓存按名称查找的结果，批量导入时同一公司只查找一次
+func (s *Service) resolveNewUserCompany(tx *gorm.DB, input *newUser, cache map[string]*model.Company) (*model.Company, error) {
+	if input.CompanyID != 0 {
+		isExist, err := s.companyDao.IsExistCompanyByID(tx, input.CompanyID)
+		if err != nil {
+			return nil, err
+		}
+		if !isExist {
+			return nil, fmt.Errorf(stderr.ErrorCompanyNotFound)
+		}
+		return s.companyDao.GetCompanyByID(tx, input.CompanyID)
+	}
+
+	if company, ok := cache[input.CompanyName]; ok {
+		return company, nil
+	}
+	// 名称属于已被合并的公司时得到的是合并后的公司，以返回的公司为准
+	companyID, err := s.dao.FindOrCreateCompany(tx, input.CompanyName, input.CompanyAddress)
+	if err != nil {
+		return nil, err
+	}
+	company, err := s.companyDao.GetCompanyByID(tx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		cache[input.CompanyName] = company
+	}
+	return company, nil
+}
+
+// buildApprovedUser 构造已通过审批、首次登录必须修改密码的用户
+func buildApprovedUser(input *newUser, company *model.Company, hashPassword string) *model.User {
+	address := company.Address
+	if input.CompanyAddress != "" {
+		address = util.StringToPointer(input.CompanyAddress)
+	}
+	now := time.Now()
+	return &model.User{
+		Username:           input.Username,
+		Password:           hashPassword,
+		Phone:              input.Phone,
+		Name:               input.Name,
+		UserEmail:          util.StringToPointer(input.Email),
+		CompanyID:          company.ID,
+		CompanyName:        company.Name,
+		CompanyAddress:     address,
+		IsUser:             model.UserApproved,
+		HandledAt:          &now,
+		Why:                util.StringToPointer("管理员创建"),
+		MustChangePassword: true,
+		IsCompanyAdmin:     input.IsCompanyAdmin,
+	}
+}
+
+// newOneTimePassword 生成一次性密码及其哈希
+func newOneTimePassword() (string, string, error) {
+	password, err := util.RandomPassword(viper.GetInt("account.oneTimePasswordLength"))
+	if err != nil {
+		return "", "", err
+	}
+	hashPassword, err := util.HashPassword(password)
+	if err != nil {
+		return "", "", fmt.Errorf("加密密码失败: %w", err)
+	}
+	return password, hashPassword, nil
+}
+
+// newUserSnapshot 审计日志中记录的新用户信息，不包含密码
+func newUserSnapshot(user *model.User) map[string]interface{} {
+	return map[string]interface{}{
+		"username":         user.Username,
+		"name":             user.Name,
+		"phone":            user.Phone,
+		"email":            util.DerefString(user.UserEmail),
+		"company_id":       user.CompanyID,
+		"is_company_admin": user.IsCompanyAdmin,
+	}
+}
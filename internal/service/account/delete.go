@@ -3,10 +3,13 @@ package account
 import (
 	"fmt"
 	"gorm.io/gorm"
+	model "xinde/internal/model/account"
+	"xinde/internal/model/audit"
+	roleModel "xinde/internal/model/role"
 	"xinde/pkg/stderr"
 )
 
-func (s *Service) DeleteUser(uid uint) error {
+func (s *Service) DeleteUser(actor *audit.Actor, uid uint) error {
 	return s.dao.Transaction(func(tx *gorm.DB) error {
 		// 检查用户是否存在
 		isExist, err := s.dao.IsExistUserByID(tx, uid)
@@ -17,11 +20,53 @@ func (s *Service) DeleteUser(uid uint) error {
 			return fmt.Errorf(stderr.ErrorUserNotFound)
 		}
 
-		// 调用dao执行软删除
+		user, err := s.dao.GetUserByID(tx, uid)
+		if err != nil {
+			return err
+		}
+
+		// 不能删除最后一名拥有全部权限的管理员
+		permissions, err := s.roleDao.FindPermissionsByUID(tx, uid)
+		if err != nil {
+			return err
+		}
+		for _, p := range permissions {
+			if p != roleModel.PermAll {
+				continue
+			}
+			count, err := s.roleDao.CountUsersWithPermission(tx, roleModel.PermAll, uid)
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				return fmt.Errorf(stderr.ErrorRoleLastSuperAdmin)
+			}
+		}
+
+		// 吊销该用户已签发的全部token，再执行软删除
+		err = s.dao.RevokeUserTokens(tx, uid)
+		if err != nil {
+			return err
+		}
 		err = s.dao.DeleteUserByID(tx, uid)
 		if err != nil {
 			return err
 		}
-		return nil
+
+		// 记录审计日志
+		return s.auditDao.Record(tx, actor, audit.ActionUserDelete, audit.EntityUser, uid, userSnapshot(user), nil)
 	})
 }
+
+// userSnapshot 审计日志中记录的用户信息，不包含密码
+func userSnapshot(user *model.User) map[string]interface{} {
+	return map[string]interface{}{
+		"username":     user.Username,
+		"name":         user.Name,
+		"phone":        user.Phone,
+		"company_id":   user.CompanyID,
+		"company_name": user.CompanyName,
+		"is_admin":     user.IsAdmin,
+		"is_user":      user.IsUser,
+	}
+}
@@ -0,0 +1,109 @@
+package account
+
+import (
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"strings"
+	dto "xinde/internal/dto/account"
+	model "xinde/internal/model/account"
+	"xinde/pkg/util"
+)
+
+const exportSheetName = "用户"
+
+// exportHeader 导出文件的表头
+var exportHeader = []interface{}{
+	"ID", "用户名", "姓名", "电话", "邮箱", "公司", "价格等级", "角色", "公司管理员",
+	"状态", "注册时间", "审批时间", "上次访问时间", "上次访问设备", "备注",
+}
+
+// ExportUsers 按与用户列表相同的查询条件导出全部符合条件的用户（不分页）。
+// 使用 excelize 的 StreamWriter 逐行写入，数据库按游标逐行读取，不会把整张表读入内存。
+// 调用方负责在写出后关闭返回的文件。
+func (s *Service) ExportUsers(status int, req *dto.UserFilterReq) (*excelize.File, error) {
+	tx := s.dao.DB()
+	filter, err := s.buildUserListFilter(tx, status, req)
+	if err != nil {
+		return nil, err
+	}
+	// 用户的角色数量很少，一次查出所有用户的角色名称，避免逐个用户查询
+	roleNames, err := s.roleDao.FindAllUserRoleNames(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	f := excelize.NewFile()
+	if err := f.SetSheetName(f.GetSheetName(0), exportSheetName); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("设置工作表名称失败: %w", err)
+	}
+	sw, err := f.NewStreamWriter(exportSheetName)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("创建Excel写入器失败: %w", err)
+	}
+	if err := sw.SetRow("A1", exportHeader); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("写入表头失败: %w", err)
+	}
+
+	rowNum := 1
+	err = s.dao.StreamUsers(tx, filter, func(user *model.User) error {
+		rowNum++
+		companyAdmin := "否"
+		if user.IsCompanyAdmin {
+			companyAdmin = "是"
+		}
+		priceLevel := user.PriceLevelName
+		if priceLevel == "" {
+			priceLevel = user.PriceLevel
+		}
+
+		row := []interface{}{
+			user.UID,
+			user.Username,
+			user.Name,
+			user.Phone,
+			util.DerefString(user.UserEmail),
+			user.CompanyName,
+			priceLevel,
+			strings.Join(roleNames[user.UID], "、"),
+			companyAdmin,
+			userStatusName(user.IsUser),
+			util.FormatTimeToStandardString(user.CreatedAt),
+			util.FormatNullableTimeToStandardString(user.HandledAt),
+			util.FormatNullableTimeToStandardString(user.RecentSearchAt),
+			util.DerefString(user.SearchDevice),
+			util.DerefString(user.Remarks),
+		}
+		cell, err := excelize.CoordinatesToCellName(1, rowNum)
+		if err != nil {
+			return err
+		}
+		if err := sw.SetRow(cell, row); err != nil {
+			return fmt.Errorf("写入第 %d 行失败: %w", rowNum, err)
+		}
+		return nil
+	})
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	if err := sw.Flush(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("生成Excel失败: %w", err)
+	}
+	return f, nil
+}
+
+func userStatusName(status int) string {
+	switch status {
+	case model.UserApproved:
+		return "已批准"
+	case model.UserRejected:
+		return "已拒绝"
+	default:
+		return "待审批"
+	}
+}
@@ -0,0 +1,326 @@
+package account
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+	dto "xinde/internal/dto/account"
+	model "xinde/internal/model/account"
+	"xinde/internal/model/audit"
+	"xinde/pkg/logger"
+	"xinde/pkg/notify"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+// ForgotPassword 生成找回密码的验证码，发送到用户注册时填写的邮箱或手机号。
+// 验证码只保存哈希，重新获取时之前的验证码全部作废。
+// 这是无需登录的接口，为了不暴露账号是否存在及其审核状态，除服务器内部错误和IP被限制外都返回相同的结果，未发送的原因只记录在日志中。
+// 每次请求都计入登录失败的IP计数，避免同一个IP不断为不同的账号获取验证码
+func (s *Service) ForgotPassword(username, channel, ip string) (*dto.ForgotPasswordData, error) {
+	if err := s.checkResetIPLimit(ip); err != nil {
+		return nil, err
+	}
+	s.resetAttempted(ip)
+
+	ttl := viper.GetDuration("account.resetCode.ttl")
+	if err := s.sendResetCode(username, channel, ttl); err != nil {
+		switch err.Error() {
+		case stderr.ErrorUserNotFound, stderr.ErrorUserNotPass, stderr.ErrorUserBanned, stderr.ErrorVerificationTargetMissing,
+			stderr.ErrorVerificationTooFrequent, stderr.ErrorVerificationLimitExceeded, stderr.ErrorVerificationSendFailed:
+			logger.Warn(fmt.Sprintf("未发送找回密码验证码 用户: %s 通道: %s 原因: %s", username, channel, err.Error()))
+		default:
+			return nil, err
+		}
+	}
+	return &dto.ForgotPasswordData{
+		Message:   "如果账号存在，验证码已发送到注册时填写的邮箱或手机号",
+		ExpiresIn: int64(ttl.Seconds()),
+	}, nil
+}
+
+// sendResetCode 生成并发送找回密码的验证码，错误原因不能直接返回给调用方，见 ForgotPassword
+func (s *Service) sendResetCode(username, channel string, ttl time.Duration) error {
+	code, err := util.RandomDigits(viper.GetInt("account.resetCode.length"))
+	if err != nil {
+		return err
+	}
+	codeHash, err := util.HashPassword(code)
+	if err != nil {
+		return fmt.Errorf("加密验证码失败: %w", err)
+	}
+
+	var target string
+	var sender notify.Sender
+	err = s.dao.Transaction(func(tx *gorm.DB) error {
+		user, err := s.findUserForReset(tx, username)
+		if err != nil {
+			return err
+		}
+
+		switch channel {
+		case model.CodeChannelEmail:
+			target, sender = util.DerefString(user.UserEmail), s.emailSender
+		case model.CodeChannelPhone:
+			target, sender = user.Phone, s.smsSender
+		}
+		if strings.TrimSpace(target) == "" {
+			return fmt.Errorf(stderr.ErrorVerificationTargetMissing)
+		}
+
+		// 限制发送频率
+		last, err := s.dao.GetLatestVerificationCodeForUpdate(tx, user.UID, model.CodePurposeResetPassword)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if last != nil && time.Since(last.CreatedAt) < viper.GetDuration("account.resetCode.resendInterval") {
+			return fmt.Errorf(stderr.ErrorVerificationTooFrequent)
+		}
+		// 限制一段时间内获取的总数，每个验证码最多校验 maxAttempts 次，总的尝试次数因此也有上限
+		count, err := s.dao.CountVerificationCodesSince(tx, user.UID, model.CodePurposeResetPassword,
+			time.Now().Add(-viper.GetDuration("account.resetCode.window")))
+		if err != nil {
+			return err
+		}
+		if count >= int64(viper.GetInt("account.resetCode.maxPerWindow")) {
+			return fmt.Errorf(stderr.ErrorVerificationLimitExceeded)
+		}
+
+		if err := s.dao.ConsumeVerificationCodes(tx, user.UID, model.CodePurposeResetPassword); err != nil {
+			return err
+		}
+		return s.dao.CreateVerificationCode(tx, &model.VerificationCode{
+			UID:       user.UID,
+			Purpose:   model.CodePurposeResetPassword,
+			Channel:   channel,
+			Target:    target,
+			CodeHash:  codeHash,
+			ExpiresAt: time.Now().Add(ttl),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// 验证码入库后再发送，发送失败时用户可以在间隔时间后重新获取
+	err = sender.Send(&notify.Message{
+		To:      target,
+		Subject: "找回密码验证码",
+		Content: fmt.Sprintf("您正在找回密码，验证码为 %s，%d 分钟内有效。如非本人操作请忽略。", code, int(ttl.Minutes())),
+	})
+	if err != nil {
+		logger.Error(fmt.Sprintf("发送找回密码验证码失败 用户: %s 通道: %s 错误: %s", username, channel, err.Error()))
+		return fmt.Errorf(stderr.ErrorVerificationSendFailed)
+	}
+	return nil
+}
+
+// VerifyResetCode 校验找回密码的验证码，通过后签发一个短期有效的重置令牌。
+// 每次校验失败都会累计次数，超过上限后验证码作废；同时计入登录失败的IP计数，IP被锁定后不能再校验
+func (s *Service) VerifyResetCode(username, code, ip string) (*dto.VerifyCodeData, error) {
+	if err := s.checkResetIPLimit(ip); err != nil {
+		return nil, err
+	}
+
+	tokenTTL := viper.GetDuration("account.resetCode.tokenTTL")
+	resetToken, tokenHash, err := util.RandomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	// 校验失败时累计的次数需要提交，所以错误在事务结束后再返回
+	var verifyErr error
+	err = s.dao.Transaction(func(tx *gorm.DB) error {
+		user, err := s.findUserForReset(tx, username)
+		if err != nil {
+			// 与验证码错误返回相同的结果，不暴露账号是否存在及其审核状态
+			switch err.Error() {
+			case stderr.ErrorUserNotFound, stderr.ErrorUserNotPass, stderr.ErrorUserBanned:
+				logger.Warn(fmt.Sprintf("找回密码验证码校验失败 用户: %s 原因: %s", username, err.Error()))
+				verifyErr = fmt.Errorf(stderr.ErrorVerificationCodeInvalid)
+				return nil
+			}
+			return err
+		}
+
+		record, err := s.dao.GetLatestVerificationCodeForUpdate(tx, user.UID, model.CodePurposeResetPassword)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				verifyErr = fmt.Errorf(stderr.ErrorVerificationCodeInvalid)
+				return nil
+			}
+			return err
+		}
+		// 已经校验通过的验证码不能再次校验，只能使用已签发的重置令牌
+		if record.VerifiedAt != nil || time.Now().After(record.ExpiresAt) {
+			verifyErr = fmt.Errorf(stderr.ErrorVerificationCodeInvalid)
+			return nil
+		}
+
+		maxAttempts := viper.GetInt("account.resetCode.maxAttempts")
+		if !util.CheckPasswordHash(code, record.CodeHash) {
+			updateData := map[string]interface{}{"attempts": record.Attempts + 1}
+			verifyErr = fmt.Errorf(stderr.ErrorVerificationCodeInvalid)
+			if record.Attempts+1 >= maxAttempts {
+				updateData["consumed_at"] = time.Now()
+				verifyErr = fmt.Errorf(stderr.ErrorVerificationTooManyTries)
+			}
+			return s.dao.UpdateVerificationCode(tx, record.ID, updateData)
+		}
+
+		now := time.Now()
+		return s.dao.UpdateVerificationCode(tx, record.ID, map[string]interface{}{
+			"verified_at":      now,
+			"reset_token_hash": tokenHash,
+			"expires_at":       now.Add(tokenTTL),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if verifyErr != nil {
+		s.resetAttempted(ip)
+		return nil, verifyErr
+	}
+
+	return &dto.VerifyCodeData{
+		ResetToken: resetToken,
+		ExpiresIn:  int64(tokenTTL.Seconds()),
+	}, nil
+}
+
+// ResetPasswordByCode 凭重置令牌设置新密码，令牌只能使用一次。
+// 设置成功后该用户原有的登录全部失效，actor 只需要带上请求的IP和请求ID，用户信息在这里补全
+func (s *Service) ResetPasswordByCode(actor *audit.Actor, resetToken, password string) error {
+	hashPassword, err := util.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("加密密码失败: %w", err)
+	}
+
+	return s.dao.Transaction(func(tx *gorm.DB) error {
+		record, err := s.dao.GetVerificationCodeByResetTokenForUpdate(tx, util.HashToken(resetToken))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf(stderr.ErrorResetTokenInvalid)
+			}
+			return err
+		}
+		if record.ConsumedAt != nil || record.VerifiedAt == nil || time.Now().After(record.ExpiresAt) {
+			return fmt.Errorf(stderr.ErrorResetTokenInvalid)
+		}
+
+		user, err := s.dao.GetUserByIDForUpdate(tx, record.UID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf(stderr.ErrorResetTokenInvalid)
+			}
+			return err
+		}
+
+		updateData := map[string]interface{}{
+			"password":             hashPassword,
+			"must_change_password": false,
+		}
+		if err := s.dao.UpdateUser(tx, user.UID, updateData); err != nil {
+			return err
+		}
+		if err := s.dao.ConsumeVerificationCodes(tx, user.UID, model.CodePurposeResetPassword); err != nil {
+			return err
+		}
+		if err := s.dao.RevokeUserTokens(tx, user.UID); err != nil {
+			return err
+		}
+
+		actor.UID, actor.Username = user.UID, user.Username
+		return s.auditDao.Record(tx, actor, audit.ActionUserForgotPassword, audit.EntityUser, user.UID, nil, map[string]interface{}{
+			"channel": record.Channel,
+		})
+	})
+}
+
+// ChangeInitialPassword 管理员重置密码后，用户凭用户名和一次性密码设置自己的密码，成功后直接登录。
+// 只用于必须修改密码的用户，其他用户需要登录后修改密码。与 Login 一样受登录失败锁定的限制，并写入登录记录
+func (s *Service) ChangeInitialPassword(actor *audit.Actor, username, oldPassword, newPassword string, client ClientInfo) (*dto.LoginData, error) {
+	if oldPassword == newPassword {
+		return nil, fmt.Errorf(stderr.ErrorUserPasswordUnchanged)
+	}
+	if err := s.checkLoginLimit(username, client.IP); err != nil {
+		s.recordLogin(nil, username, client, err)
+		return nil, err
+	}
+	hashPassword, err := util.HashPassword(newPassword)
+	if err != nil {
+		return nil, fmt.Errorf("加密密码失败: %w", err)
+	}
+
+	var data *dto.LoginData
+	err = s.dao.Transaction(func(tx *gorm.DB) error {
+		user, err := s.authenticate(tx, username, oldPassword)
+		if err != nil {
+			return err
+		}
+		// 这个接口不需要登录，只允许替换管理员设置的一次性密码，不能用来绕过登录修改密码
+		if !user.MustChangePassword {
+			return fmt.Errorf(stderr.ErrorUserNoInitialPassword)
+		}
+
+		updateData := map[string]interface{}{
+			"password":             hashPassword,
+			"must_change_password": false,
+		}
+		if err := s.dao.UpdateUser(tx, user.UID, updateData); err != nil {
+			return err
+		}
+
+		// 吊销全部 token 会使 token 版本号加一，新签发的 token 要使用递增后的版本号
+		if err := s.dao.RevokeUserTokens(tx, user.UID); err != nil {
+			return err
+		}
+		user.TokenVersion++
+		if data, err = s.completeLogin(tx, user, client); err != nil {
+			return err
+		}
+
+		actor.UID, actor.Username = user.UID, user.Username
+		return s.auditDao.Record(tx, actor, audit.ActionUserChangePassword, audit.EntityUser, user.UID, nil, nil)
+	})
+	if err != nil {
+		if err.Error() == stderr.ErrorUserUnauthorized {
+			s.loginFailed(username, client.IP)
+		}
+		s.recordLogin(nil, username, client, err)
+		// 登录记录中保留真实原因，返回给调用方时与密码错误相同，不暴露账号的审核状态
+		if err.Error() == stderr.ErrorUserNotPass || err.Error() == stderr.ErrorUserBanned {
+			return nil, fmt.Errorf(stderr.ErrorUserUnauthorized)
+		}
+		return nil, err
+	}
+	if data.MFARequired {
+		return data, nil
+	}
+
+	s.loginSucceeded(username)
+	s.recordLogin(nil, username, client, nil)
+	return data, nil
+}
+
+// findUserForReset 查找找回密码的用户，只有注册申请已通过的用户才能找回密码
+func (s *Service) findUserForReset(tx *gorm.DB, username string) (*model.User, error) {
+	user, err := s.dao.FindUserByUsername(tx, username)
+	if err != nil {
+		if err.Error() == stderr.ErrorUserUnauthorized {
+			return nil, fmt.Errorf(stderr.ErrorUserNotFound)
+		}
+		return nil, err
+	}
+	switch user.IsUser {
+	case model.UserPending:
+		return nil, fmt.Errorf(stderr.ErrorUserNotPass)
+	case model.UserRejected:
+		return nil, fmt.Errorf(stderr.ErrorUserBanned)
+	}
+	return user, nil
+}
@@ -0,0 +1,426 @@
+package account
+
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+	dto "xinde/internal/dto/account"
+	model "xinde/internal/model/account"
+	attachmentModel "xinde/internal/model/attachment"
+	"xinde/internal/model/audit"
+	"xinde/pkg/logger"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+const (
+	userImportBusinessType = "user_import"
+	userImportSheetName    = "导入结果"
+
+	importStatusCreated = "已创建"
+	importStatusFailed  = "失败"
+	// strict 模式下存在错误行时，校验通过的行也不会创建
+	importStatusSkipped = "未导入"
+)
+
+// userImportSchema 记录从表头解析出的各字段所在的列索引，可选列不存在时为 -1
+type userImportSchema struct {
+	Username       int
+	Name           int
+	Phone          int
+	Email          int
+	Company        int
+	CompanyAddress int
+	IsCompanyAdmin int
+}
+
+var (
+	importUsernameAliases       = []string{"username", "用户名", "账号"}
+	importNameAliases           = []string{"name", "姓名", "真实姓名"}
+	importPhoneAliases          = []string{"phone", "电话", "手机号"}
+	importEmailAliases          = []string{"email", "邮箱"}
+	importCompanyAliases        = []string{"company", "company_name", "公司", "公司名称"}
+	importCompanyAddressAliases = []string{"company_address", "公司地址"}
+	importAdminAliases          = []string{"is_company_admin", "admin", "管理员", "公司管理员"}
+)
+
+// importBoolValues 管理员列可接受的取值，空值表示否
+var importBoolValues = map[string]bool{
+	"": false, "0": false, "否": false, "false": false, "no": false, "n": false,
+	"1": true, "是": true, "true": true, "yes": true, "y": true,
+}
+
+// importRow 导入文件中的一行及其处理结果
+type importRow struct {
+	RowNum   int
+	Cells    []string
+	User     *newUser
+	Reasons  []string
+	Status   string
+	Password string
+}
+
+// ImportUsersResult 批量导入用户的统计，逐行的结果和临时密码在 Workbook 中
+type ImportUsersResult struct {
+	Mode      string
+	Total     int
+	Succeeded int
+	Failed    int
+	// 原表头之后追加行号、结果、临时密码和错误原因四列，调用方负责关闭
+	Workbook *excelize.File
+}
+
+// ImportUsersFromFile 从Excel批量创建已通过审批的用户。按表头名称定位各列（列顺序不限），逐行校验后创建用户，
+// 公司按名称查找，没有时新建。每个用户都生成一次性密码，首次登录时必须修改。
+// strict 模式下任意一行有错误则全部不创建；lenient 模式下跳过错误行。
+// 临时密码只写入返回的结果文件，不保存在服务器上
+func (s *Service) ImportUsersFromFile(fileHeader *multipart.FileHeader, actor *audit.Actor, mode string) (*ImportUsersResult, error) {
+	if mode == "" {
+		mode = dto.ImportModeStrict
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, fmt.Errorf("打开上传文件失败: %w", err)
+	}
+	defer file.Close()
+
+	// 源文件作为附件保存，审计日志通过附件ID关联到这次导入
+	storagePath, err := util.SaveUploadedFile(fileHeader)
+	if err != nil {
+		return nil, fmt.Errorf("保存上传文件失败: %w", err)
+	}
+	attachment := &attachmentModel.Attachment{
+		Filename:      fileHeader.Filename,
+		StoragePath:   storagePath,
+		FileType:      fileHeader.Header.Get("Content-Type"),
+		FileSize:      uint64(fileHeader.Size),
+		StorageDriver: "local",
+		UploadedByUID: actor.UID,
+		BusinessType:  util.StringToPointer(userImportBusinessType),
+	}
+	if err := s.attachmentDao.Create(s.attachmentDao.DB(), attachment); err != nil {
+		logger.Error("记录上传附件信息到数据库失败: " + err.Error())
+	}
+
+	xlsx, err := excelize.OpenReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("读取Excel文件失败: %w", err)
+	}
+	defer xlsx.Close()
+	sheetList := xlsx.GetSheetList()
+	if len(sheetList) == 0 {
+		return nil, fmt.Errorf("excel文件中没有任何工作表")
+	}
+	rows, err := xlsx.GetRows(sheetList[0])
+	if err != nil {
+		return nil, fmt.Errorf("获取 %s 数据失败: %w", sheetList[0], err)
+	}
+	if len(rows) <= 1 {
+		return nil, fmt.Errorf(stderr.ErrorUserImportEmpty)
+	}
+
+	header := rows[0]
+	schema, err := buildUserImportSchema(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var importRows []*importRow
+	for i, cells := range rows[1:] {
+		if isBlankImportRow(cells) {
+			continue
+		}
+		importRows = append(importRows, &importRow{RowNum: i + 2, Cells: cells})
+	}
+	if len(importRows) == 0 {
+		return nil, fmt.Errorf(stderr.ErrorUserImportEmpty)
+	}
+	if len(importRows) > viper.GetInt("account.importMaxRows") {
+		return nil, fmt.Errorf(stderr.ErrorUserImportTooManyRows)
+	}
+
+	// 逐行校验，收集所有错误而不是遇到第一行错误就中断
+	result := &ImportUsersResult{Mode: mode, Total: len(importRows)}
+	if err := s.validateImportRows(importRows, schema); err != nil {
+		return nil, err
+	}
+	var validRows []*importRow
+	for _, row := range importRows {
+		if len(row.Reasons) > 0 {
+			row.Status = importStatusFailed
+			result.Failed++
+			continue
+		}
+		validRows = append(validRows, row)
+	}
+
+	if result.Failed > 0 && mode == dto.ImportModeStrict {
+		for _, row := range validRows {
+			row.Status = importStatusSkipped
+		}
+	} else if len(validRows) > 0 {
+		if err := s.createImportedUsers(validRows, actor, attachment, result); err != nil {
+			return nil, fmt.Errorf("导入用户失败: %w", err)
+		}
+		result.Succeeded = len(validRows)
+	}
+
+	result.Workbook, err = buildUserImportWorkbook(header, importRows)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// validateImportRows 解析并校验每一行，错误原因写入 row.Reasons。用户名不能与文件中的其他行或已有的用户重复
+func (s *Service) validateImportRows(rows []*importRow, schema *userImportSchema) error {
+	tx := s.dao.DB()
+	seen := make(map[string]int) // username -> 首次出现的行号
+	for _, row := range rows {
+		row.User, row.Reasons = parseUserImportRow(row.Cells, schema)
+		username := row.User.Username
+		if username == "" {
+			continue
+		}
+		if firstRow, ok := seen[username]; ok {
+			row.Reasons = append(row.Reasons, fmt.Sprintf("用户名与第 %d 行重复", firstRow))
+			continue
+		}
+		seen[username] = row.RowNum
+
+		exists, err := s.isUsernameTaken(tx, username)
+		if err != nil {
+			return err
+		}
+		if exists {
+			row.Reasons = append(row.Reasons, stderr.ErrorUserAlreadyExist)
+		}
+	}
+	return nil
+}
+
+// createImportedUsers 为校验通过的行生成一次性密码，并在一个事务中创建全部用户
+func (s *Service) createImportedUsers(rows []*importRow, actor *audit.Actor, attachment *attachmentModel.Attachment, result *ImportUsersResult) error {
+	// 生成密码哈希比较耗时，放在事务之外
+	hashPasswords := make([]string, len(rows))
+	for i, row := range rows {
+		password, hashPassword, err := newOneTimePassword()
+		if err != nil {
+			return err
+		}
+		row.Password = password
+		hashPasswords[i] = hashPassword
+	}
+
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		companies := make(map[string]*model.Company)
+		users := make([]*model.User, 0, len(rows))
+		for i, row := range rows {
+			company, err := s.resolveNewUserCompany(tx, row.User, companies)
+			if err != nil {
+				return err
+			}
+			users = append(users, buildApprovedUser(row.User, company, hashPasswords[i]))
+		}
+
+		// 用户名在校验后可能被其他请求注册，创建前再检查一次
+		for _, user := range users {
+			exists, err := s.isUsernameTaken(tx, user.Username)
+			if err != nil {
+				return err
+			}
+			if exists {
+				return fmt.Errorf("用户名 %s 已被使用，请重新导入", user.Username)
+			}
+		}
+		if err := s.dao.CreateUsers(tx, users); err != nil {
+			return err
+		}
+
+		for _, user := range users {
+			if err := s.auditDao.Record(tx, actor, audit.ActionUserCreate, audit.EntityUser, user.UID, nil, newUserSnapshot(user)); err != nil {
+				return err
+			}
+		}
+		for _, row := range rows {
+			row.Status = importStatusCreated
+		}
+		return s.auditDao.Record(tx, actor, audit.ActionUserImport, audit.EntityAttachment, attachment.ID, nil, map[string]interface{}{
+			"filename":  attachment.Filename,
+			"mode":      result.Mode,
+			"total":     result.Total,
+			"failed":    result.Failed,
+			"succeeded": len(users),
+		})
+	})
+}
+
+// buildUserImportSchema 根据表头找到每个字段所在的列，缺少必需的列时返回错误
+func buildUserImportSchema(header []string) (*userImportSchema, error) {
+	columnIndex := make(map[string]int, len(header))
+	for idx, name := range header {
+		key := normalizeImportHeader(name)
+		if key == "" {
+			continue
+		}
+		// 同名列以第一次出现的为准
+		if _, exists := columnIndex[key]; !exists {
+			columnIndex[key] = idx
+		}
+	}
+	find := func(aliases []string) int {
+		for _, alias := range aliases {
+			if idx, ok := columnIndex[normalizeImportHeader(alias)]; ok {
+				return idx
+			}
+		}
+		return -1
+	}
+
+	schema := &userImportSchema{
+		Username:       find(importUsernameAliases),
+		Name:           find(importNameAliases),
+		Phone:          find(importPhoneAliases),
+		Email:          find(importEmailAliases),
+		Company:        find(importCompanyAliases),
+		CompanyAddress: find(importCompanyAddressAliases),
+		IsCompanyAdmin: find(importAdminAliases),
+	}
+	if schema.Username < 0 || schema.Name < 0 || schema.Phone < 0 || schema.Company < 0 {
+		return nil, fmt.Errorf(stderr.ErrorUserImportMissingColumns)
+	}
+	return schema, nil
+}
+
+// parseUserImportRow 解析一行用户数据，校验规则与注册时相同，返回解析出的数据以及该行所有的错误原因
+func parseUserImportRow(cells []string, schema *userImportSchema) (*newUser, []string) {
+	var reasons []string
+
+	user := &newUser{
+		Username:       importCellValue(cells, schema.Username),
+		Name:           importCellValue(cells, schema.Name),
+		Phone:          importCellValue(cells, schema.Phone),
+		Email:          importCellValue(cells, schema.Email),
+		CompanyName:    importCellValue(cells, schema.Company),
+		CompanyAddress: importCellValue(cells, schema.CompanyAddress),
+	}
+	switch {
+	case user.Username == "":
+		reasons = append(reasons, "用户名为空")
+	case utf8.RuneCountInString(user.Username) > 255:
+		reasons = append(reasons, "用户名过长")
+	}
+	switch {
+	case user.Name == "":
+		reasons = append(reasons, "姓名为空")
+	case utf8.RuneCountInString(user.Name) > 32:
+		reasons = append(reasons, "姓名过长")
+	}
+	if !isValidPhone(user.Phone) {
+		reasons = append(reasons, "电话应为7-20位数字")
+	}
+	if user.Email != "" {
+		if addr, err := mail.ParseAddress(user.Email); err != nil || addr.Address != user.Email || utf8.RuneCountInString(user.Email) > 255 {
+			reasons = append(reasons, "邮箱格式不正确")
+		}
+	}
+	switch {
+	case user.CompanyName == "":
+		reasons = append(reasons, "公司为空")
+	case utf8.RuneCountInString(user.CompanyName) > 255:
+		reasons = append(reasons, "公司名称过长")
+	}
+	if utf8.RuneCountInString(user.CompanyAddress) > 255 {
+		reasons = append(reasons, "公司地址过长")
+	}
+
+	raw := importCellValue(cells, schema.IsCompanyAdmin)
+	isAdmin, ok := importBoolValues[strings.ToLower(raw)]
+	if !ok {
+		reasons = append(reasons, fmt.Sprintf("管理员应为是或否: %s", raw))
+	}
+	user.IsCompanyAdmin = isAdmin
+
+	return user, reasons
+}
+
+// buildUserImportWorkbook 按原表头写出全部数据行，末尾追加行号、结果、临时密码和错误原因
+func buildUserImportWorkbook(header []string, rows []*importRow) (*excelize.File, error) {
+	f := excelize.NewFile()
+	if err := f.SetSheetName(f.GetSheetName(0), userImportSheetName); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("设置工作表名称失败: %w", err)
+	}
+
+	line := make([]interface{}, 0, len(header)+4)
+	for _, name := range header {
+		line = append(line, name)
+	}
+	line = append(line, "行号", "结果", "临时密码", "错误原因")
+	if err := writeImportResultRow(f, 1, line); err != nil {
+		f.Close()
+		return nil, err
+	}
+	for i, row := range rows {
+		line = line[:0]
+		for col := range header {
+			line = append(line, importCellValue(row.Cells, col))
+		}
+		line = append(line, row.RowNum, row.Status, row.Password, strings.Join(row.Reasons, "; "))
+		if err := writeImportResultRow(f, i+2, line); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func writeImportResultRow(f *excelize.File, rowNum int, values []interface{}) error {
+	cell, err := excelize.CoordinatesToCellName(1, rowNum)
+	if err != nil {
+		return err
+	}
+	if err := f.SetSheetRow(userImportSheetName, cell, &values); err != nil {
+		return fmt.Errorf("写入导入结果第 %d 行失败: %w", rowNum, err)
+	}
+	return nil
+}
+
+func isValidPhone(phone string) bool {
+	if len(phone) < 7 || len(phone) > 20 {
+		return false
+	}
+	for _, r := range phone {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// importCellValue 安全地读取一个单元格，excelize 会省略行尾的空单元格，所以短行不能直接按下标访问
+func importCellValue(row []string, idx int) string {
+	if idx < 0 || idx >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[idx])
+}
+
+func isBlankImportRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeImportHeader(name string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), " ", ""))
+}
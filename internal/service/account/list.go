@@ -1,21 +1,27 @@
 package account
 
 import (
+	"errors"
 	"fmt"
-	_ "math"
-	_ "xinde/internal/dao/account"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+	accountDao "xinde/internal/dao/account"
 	dto "xinde/internal/dto/account"
 	model "xinde/internal/model/account"
 	"xinde/pkg/stderr"
-	_ "xinde/pkg/stderr"
 	"xinde/pkg/util"
 )
 
-func (s *Service) GetUserList(page, pageSize int) (*dto.ListPageData, error) {
+func (s *Service) GetUserList(page, pageSize int, req *dto.UserFilterReq) (*dto.ListPageData, error) {
 	tx := s.dao.DB()
+	filter, err := s.buildUserListFilter(tx, model.UserApproved, req)
+	if err != nil {
+		return nil, err
+	}
 
 	// 计算总页数
-	count, err := s.dao.CountUserWithStatus(tx, model.UserApproved)
+	count, err := s.dao.CountUsers(tx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +41,7 @@ func (s *Service) GetUserList(page, pageSize int) (*dto.ListPageData, error) {
 	}
 
 	// 查询数据库获取当前页面的用户数据
-	dbData, err := s.dao.FindUserListWithPagination(tx, currentPage, pageSize, model.UserApproved)
+	dbData, err := s.dao.FindUserListWithPagination(tx, currentPage, pageSize, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -67,6 +73,70 @@ func (s *Service) GetUserList(page, pageSize int) (*dto.ListPageData, error) {
 	return pageData, nil
 }
 
+// buildUserListFilter 校验并转换查询条件，排序字段必须在白名单中，价格等级和角色必须存在
+func (s *Service) buildUserListFilter(tx *gorm.DB, status int, req *dto.UserFilterReq) (*accountDao.UserListFilter, error) {
+	filter := &accountDao.UserListFilter{
+		Status:     status,
+		Keyword:    strings.TrimSpace(req.Keyword),
+		CompanyID:  req.CompanyID,
+		PriceLevel: req.PriceLevel,
+		RoleID:     req.RoleID,
+		SortBy:     req.SortBy,
+		SortDesc:   req.SortOrder == dto.SortOrderDesc,
+	}
+
+	if req.SortBy != "" && !accountDao.IsUserSortColumn(req.SortBy) {
+		return nil, fmt.Errorf(stderr.ErrorUserSortByInvalid)
+	}
+
+	var err error
+	if filter.CreatedFrom, err = parseUserFilterTime(req.CreatedFrom); err != nil {
+		return nil, err
+	}
+	if filter.CreatedTo, err = parseUserFilterTime(req.CreatedTo); err != nil {
+		return nil, err
+	}
+	if filter.ActiveFrom, err = parseUserFilterTime(req.ActiveFrom); err != nil {
+		return nil, err
+	}
+	if filter.ActiveTo, err = parseUserFilterTime(req.ActiveTo); err != nil {
+		return nil, err
+	}
+
+	if req.PriceLevel != "" {
+		isExist, err := s.priceDao.IsExistPriceLevelByCode(tx, req.PriceLevel)
+		if err != nil {
+			return nil, err
+		}
+		if !isExist {
+			return nil, fmt.Errorf(stderr.ErrorPriceLevelNotFound)
+		}
+	}
+
+	if req.RoleID != 0 {
+		if _, err := s.roleDao.GetRoleByID(tx, req.RoleID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf(stderr.ErrorRoleNotFound)
+			}
+			return nil, err
+		}
+	}
+
+	return filter, nil
+}
+
+func parseUserFilterTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{util.StandardDateTimeFormat, util.StandardDateFormat} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf(stderr.ErrorUserFilterTimeInvalid)
+}
+
 func (s *Service) convertUserToDTOListData(user *model.User) *dto.ListData {
 	var userRole string
 	if user.IsAdmin == 1 {
@@ -84,8 +154,10 @@ func (s *Service) convertUserToDTOListData(user *model.User) *dto.ListData {
 		Email:          util.DerefString(user.UserEmail),
 		CompanyName:    user.CompanyName,
 		PriceLevel:     user.PriceLevel,
+		PriceLevelName: user.PriceLevelName,
 		Remark:         util.DerefString(user.Remarks),
 		Role:           userRole,
+		IsCompanyAdmin: user.IsCompanyAdmin,
 		CreatedAt:      util.FormatNullableTimeToStandardString(user.HandledAt),
 		RecentSearchAt: util.FormatNullableTimeToStandardString(user.RecentSearchAt),
 		SearchDevice:   util.DerefString(user.SearchDevice),
@@ -2,14 +2,57 @@ package account
 
 import (
 	"fmt"
+	"gorm.io/gorm"
 	dto "xinde/internal/dto/account"
+	model "xinde/internal/model/account"
 	"xinde/pkg/stderr"
 	"xinde/pkg/util"
 )
 
-func (s *Service) Login(username, password string) (*dto.LoginData, error) {
+// Login 校验用户名和密码并签发 token，开启了二次验证的用户先签发 mfa token。用户名或IP失败次数过多时临时锁定，每次尝试都写入登录记录
+func (s *Service) Login(username, password string, client ClientInfo) (*dto.LoginData, error) {
+	// 用户名或IP处于锁定状态时直接拒绝，不再校验密码
+	if err := s.checkLoginLimit(username, client.IP); err != nil {
+		s.recordLogin(nil, username, client, err)
+		return nil, err
+	}
+
 	tx := s.dao.DB()
 
+	user, err := s.authenticate(tx, username, password)
+	if err != nil {
+		if err.Error() == stderr.ErrorUserUnauthorized {
+			s.loginFailed(username, client.IP)
+		}
+		s.recordLogin(nil, username, client, err)
+		return nil, err
+	}
+
+	// 管理员重置密码后，必须先把一次性密码改成自己的密码
+	if user.MustChangePassword {
+		err := fmt.Errorf(stderr.ErrorUserMustChangePassword)
+		s.recordLogin(user, username, client, err)
+		return nil, err
+	}
+
+	// 一切正常，生成 access token 和 refresh token；需要二次验证时只生成 mfa token
+	data, err := s.completeLogin(tx, user, client)
+	if err != nil {
+		s.recordLogin(user, username, client, err)
+		return nil, fmt.Errorf("user: %s Login, %s", user.Username, err.Error())
+	}
+	// 二次验证通过后才算登录成功，在此之前不清除失败次数
+	if data.MFARequired {
+		return data, nil
+	}
+
+	s.loginSucceeded(username)
+	s.recordLogin(user, username, client, nil)
+	return data, nil
+}
+
+// authenticate 校验用户名和密码，并确认用户的注册申请已经通过
+func (s *Service) authenticate(tx *gorm.DB, username, password string) (*model.User, error) {
 	// 根据用户名查找用户
 	user, err := s.dao.FindUserByUsername(tx, username)
 	if err != nil {
@@ -29,20 +72,15 @@ func (s *Service) Login(username, password string) (*dto.LoginData, error) {
 	case 2:
 		return nil, fmt.Errorf(stderr.ErrorUserBanned)
 	}
+	return user, nil
+}
 
-	// 一切正常，生成JWT Token
-	token, err := s.jwt.GenerateToken(user.UID, user.Username, user.IsAdmin == 1)
-	if err != nil {
-		return nil, fmt.Errorf("user: %s Login, 生成token错误：%s", user.Username, err.Error())
-	}
-
-	loginData := dto.LoginData{
-		Username:    user.Username,
-		Name:        user.Name,
-		Phone:       user.Phone,
-		Email:       *user.UserEmail,
-		AccessToken: token,
+func buildLoginData(user *model.User, tokenData *dto.TokenData) *dto.LoginData {
+	return &dto.LoginData{
+		Username:  user.Username,
+		Name:      user.Name,
+		Phone:     user.Phone,
+		Email:     util.DerefString(user.UserEmail),
+		TokenData: tokenData,
 	}
-	return &loginData, nil
-
 }
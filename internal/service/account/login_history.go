@@ -0,0 +1,144 @@
+package account
+
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"time"
+	accountDao "xinde/internal/dao/account"
+	dto "xinde/internal/dto/account"
+	model "xinde/internal/model/account"
+	"xinde/pkg/logger"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+// recordLogin 写入一条登录记录。登录记录不应影响登录本身，写入失败只记日志。
+// user 为 nil 时按用户名补查 UID，用户名不存在时 UID 为空
+func (s *Service) recordLogin(user *model.User, username string, client ClientInfo, loginErr error) {
+	tx := s.dao.DB()
+	if user == nil {
+		user, _ = s.dao.FindUserByUsername(tx, username)
+	}
+
+	history := &model.LoginHistory{
+		Username:  username,
+		Success:   loginErr == nil,
+		IP:        client.IP,
+		UserAgent: truncate(client.UserAgent, 255),
+	}
+	if user != nil {
+		history.UID = util.UintToPointer(user.UID)
+	}
+	if loginErr != nil {
+		history.Reason = loginFailureReason(loginErr)
+	}
+
+	if err := s.dao.CreateLoginHistory(tx, history); err != nil {
+		logger.Error(fmt.Sprintf("写入登录记录失败 用户: %s 错误: %s", username, err.Error()))
+	}
+}
+
+// GetLoginHistoryList 管理员分页查看登录记录，最新的在前
+func (s *Service) GetLoginHistoryList(req *dto.LoginHistoryReq) (*dto.LoginHistoryPageData, error) {
+	tx := s.dao.DB()
+	page, pageSize := req.Page, req.PageSize
+
+	filter := &accountDao.LoginHistoryFilter{
+		UID:      req.UID,
+		Username: req.Username,
+		IP:       req.IP,
+		Success:  req.Success,
+	}
+	var err error
+	if filter.From, err = parseLoginHistoryTime(req.From); err != nil {
+		return nil, err
+	}
+	if filter.To, err = parseLoginHistoryTime(req.To); err != nil {
+		return nil, err
+	}
+
+	// 计算页数
+	count, err := s.dao.CountLoginHistory(tx, filter)
+	if err != nil {
+		return nil, err
+	}
+	pages := int((count + int64(pageSize-1)) / int64(pageSize))
+	if pages == 0 {
+		pages = 1
+	}
+
+	// 对page过大、过小的情况做判断
+	currentPage := page
+	if currentPage > pages {
+		currentPage = pages
+	}
+	if currentPage < 1 {
+		currentPage = 1
+	}
+
+	histories, err := s.dao.FindLoginHistoryWithPagination(tx, currentPage, pageSize, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*dto.LoginHistoryData, 0, len(histories))
+	for _, h := range histories {
+		list = append(list, convertLoginHistoryToDTO(h))
+	}
+
+	pageData := &dto.LoginHistoryPageData{
+		List:     list,
+		Total:    int(count),
+		Page:     currentPage,
+		PageSize: pageSize,
+		Pages:    pages,
+	}
+
+	// 针对用户输入page过大、过小的情况做特殊处理，返回最后一页/第一页的数据，但依然提交err
+	if page > pages {
+		return pageData, fmt.Errorf(stderr.ErrorOverLargePage)
+	}
+	if page < 1 {
+		return pageData, fmt.Errorf(stderr.ErrorOverSmallPage)
+	}
+	return pageData, nil
+}
+
+// GetRecentLogins 用户查看自己最近的登录记录，包括失败的尝试
+func (s *Service) GetRecentLogins(uid uint) ([]*dto.LoginHistoryData, error) {
+	histories, err := s.dao.FindRecentLoginHistory(s.dao.DB(), uid, viper.GetInt("account.recentLoginLimit"))
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*dto.LoginHistoryData, 0, len(histories))
+	for _, h := range histories {
+		list = append(list, convertLoginHistoryToDTO(h))
+	}
+	return list, nil
+}
+
+func parseLoginHistoryTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{util.StandardDateTimeFormat, util.StandardDateFormat} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf(stderr.ErrorLoginHistoryTimeInvalid)
+}
+
+func convertLoginHistoryToDTO(h *model.LoginHistory) *dto.LoginHistoryData {
+	return &dto.LoginHistoryData{
+		ID:        h.ID,
+		UID:       util.DerefUint(h.UID),
+		Username:  h.Username,
+		Success:   h.Success,
+		Reason:    h.Reason,
+		IP:        h.IP,
+		UserAgent: h.UserAgent,
+		CreatedAt: util.FormatTimeToStandardString(h.CreatedAt),
+	}
+}
@@ -0,0 +1,206 @@
+package account
+
+import (
+	"fmt"
+	"strings"
+	dto "xinde/internal/dto/account"
+	model "xinde/internal/model/account"
+	"xinde/internal/model/audit"
+	"xinde/pkg/limiter"
+	"xinde/pkg/logger"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+// 计数的 key 前缀，用户名和IP共用 t_login_throttle 时以此区分
+const (
+	userLimitKeyPrefix = "user:"
+	ipLimitKeyPrefix   = "ip:"
+)
+
+func userLimitKey(username string) string {
+	return userLimitKeyPrefix + strings.TrimSpace(username)
+}
+
+func ipLimitKey(ip string) string {
+	return ipLimitKeyPrefix + ip
+}
+
+// checkLoginLimit 登录前检查用户名和IP是否处于锁定状态
+func (s *Service) checkLoginLimit(username, ip string) error {
+	remaining, err := s.ipLimiter.Check(ipLimitKey(ip))
+	if err != nil {
+		return err
+	}
+	if remaining > 0 {
+		return fmt.Errorf(stderr.ErrorLoginIPLocked)
+	}
+
+	remaining, err = s.userLimiter.Check(userLimitKey(username))
+	if err != nil {
+		return err
+	}
+	if remaining > 0 {
+		return fmt.Errorf(stderr.ErrorLoginUserLocked)
+	}
+	return nil
+}
+
+// loginFailed 用户名或密码错误时，用户名和IP各计一次失败。用户名不存在时同样计数，避免通过是否锁定判断用户名是否存在
+func (s *Service) loginFailed(username, ip string) {
+	if lockout, err := s.userLimiter.Fail(userLimitKey(username)); err != nil {
+		logger.Error(fmt.Sprintf("记录登录失败次数失败 用户: %s 错误: %s", username, err.Error()))
+	} else if lockout > 0 {
+		logger.Warn(fmt.Sprintf("用户 %s 登录失败次数过多，锁定 %s", username, lockout))
+	}
+
+	if lockout, err := s.ipLimiter.Fail(ipLimitKey(ip)); err != nil {
+		logger.Error(fmt.Sprintf("记录登录失败次数失败 IP: %s 错误: %s", ip, err.Error()))
+	} else if lockout > 0 {
+		logger.Warn(fmt.Sprintf("IP %s 登录失败次数过多，锁定 %s", ip, lockout))
+	}
+}
+
+// loginSucceeded 登录成功后清除用户名的失败计数。
+// IP的计数不清除，否则攻击者用一个自己的账号登录成功就能继续尝试其他账号
+func (s *Service) loginSucceeded(username string) {
+	if err := s.userLimiter.Reset(userLimitKey(username)); err != nil {
+		logger.Error(fmt.Sprintf("清除登录失败次数失败 用户: %s 错误: %s", username, err.Error()))
+	}
+}
+
+// checkResetIPLimit 找回密码前检查IP是否处于锁定状态，与登录共用IP的计数
+func (s *Service) checkResetIPLimit(ip string) error {
+	remaining, err := s.ipLimiter.Check(ipLimitKey(ip))
+	if err != nil {
+		return err
+	}
+	if remaining > 0 {
+		return fmt.Errorf(stderr.ErrorResetIPLocked)
+	}
+	return nil
+}
+
+// resetAttempted 获取找回密码的验证码或验证码校验失败时，IP计一次失败
+func (s *Service) resetAttempted(ip string) {
+	if lockout, err := s.ipLimiter.Fail(ipLimitKey(ip)); err != nil {
+		logger.Error(fmt.Sprintf("记录找回密码次数失败 IP: %s 错误: %s", ip, err.Error()))
+	} else if lockout > 0 {
+		logger.Warn(fmt.Sprintf("IP %s 找回密码请求过多，锁定 %s", ip, lockout))
+	}
+}
+
+// ListLoginLocks 列出当前被锁定的用户名和IP
+func (s *Service) ListLoginLocks() ([]*dto.LockData, error) {
+	users, err := s.userLimiter.ListLocked(userLimitKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := s.ipLimiter.ListLocked(ipLimitKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*dto.LockData, 0, len(users)+len(ips))
+	for _, st := range users {
+		username := strings.TrimPrefix(st.Key, userLimitKeyPrefix)
+		data := &dto.LockData{
+			Type:        dto.LockTypeUser,
+			Username:    username,
+			Lockouts:    st.Lockouts,
+			LockedUntil: util.FormatTimeToStandardString(st.LockedUntil),
+		}
+		// 用户名不存在时也可能被锁定，此时不返回 UID
+		if user, err := s.dao.FindUserByUsername(s.dao.DB(), username); err == nil {
+			data.UID = user.UID
+		}
+		list = append(list, data)
+	}
+	for _, st := range ips {
+		list = append(list, &dto.LockData{
+			Type:        dto.LockTypeIP,
+			IP:          strings.TrimPrefix(st.Key, ipLimitKeyPrefix),
+			Lockouts:    st.Lockouts,
+			LockedUntil: util.FormatTimeToStandardString(st.LockedUntil),
+		})
+	}
+	return list, nil
+}
+
+// UnlockUser 管理员解除用户的登录锁定，同时清除失败计数
+func (s *Service) UnlockUser(actor *audit.Actor, uid uint) error {
+	tx := s.dao.DB()
+	isExist, err := s.dao.IsExistUserByID(tx, uid)
+	if err != nil {
+		return err
+	}
+	if !isExist {
+		return fmt.Errorf(stderr.ErrorUserNotFound)
+	}
+	user, err := s.dao.GetUserByID(tx, uid)
+	if err != nil {
+		return err
+	}
+
+	key := userLimitKey(user.Username)
+	st, err := s.userLimiter.Get(key)
+	if err != nil {
+		return err
+	}
+	if err := s.userLimiter.Reset(key); err != nil {
+		return err
+	}
+
+	// 计数不在 MySQL 事务中，解锁后再单独记录审计日志
+	return s.auditDao.Record(s.auditDao.DB(), actor, audit.ActionUserUnlock, audit.EntityUser, uid, lockSnapshot(st), nil)
+}
+
+// UnlockIP 管理员解除IP的登录限制，同时清除失败计数
+func (s *Service) UnlockIP(actor *audit.Actor, ip string) error {
+	key := ipLimitKey(ip)
+	st, err := s.ipLimiter.Get(key)
+	if err != nil {
+		return err
+	}
+	if err := s.ipLimiter.Reset(key); err != nil {
+		return err
+	}
+	return s.auditDao.Record(s.auditDao.DB(), actor, audit.ActionIPUnlock, audit.EntityIP, ip, lockSnapshot(st), nil)
+}
+
+// lockSnapshot 解锁前的计数状态，用于审计日志
+func lockSnapshot(st *limiter.State) map[string]interface{} {
+	if st == nil {
+		return nil
+	}
+	snapshot := map[string]interface{}{
+		"failures": st.Failures,
+		"lockouts": st.Lockouts,
+	}
+	if !st.LockedUntil.IsZero() {
+		snapshot["locked_until"] = util.FormatTimeToStandardString(st.LockedUntil)
+	}
+	return snapshot
+}
+
+// loginFailureReason 将登录失败的错误转换为登录记录中的失败原因
+func loginFailureReason(err error) string {
+	switch err.Error() {
+	case stderr.ErrorUserUnauthorized:
+		return model.LoginReasonBadCredentials
+	case stderr.ErrorUserNotPass:
+		return model.LoginReasonNotApproved
+	case stderr.ErrorUserBanned:
+		return model.LoginReasonRejected
+	case stderr.ErrorUserMustChangePassword:
+		return model.LoginReasonMustChangePassword
+	case stderr.ErrorLoginUserLocked:
+		return model.LoginReasonUserLocked
+	case stderr.ErrorLoginIPLocked:
+		return model.LoginReasonIPLocked
+	case stderr.ErrorMFACodeInvalid:
+		return model.LoginReasonBadMFACode
+	default:
+		return model.LoginReasonError
+	}
+}
@@ -0,0 +1,475 @@
+package account
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+	dto "xinde/internal/dto/account"
+	model "xinde/internal/model/account"
+	"xinde/internal/model/audit"
+	"xinde/pkg/jwt"
+	"xinde/pkg/stderr"
+	"xinde/pkg/totp"
+	"xinde/pkg/util"
+)
+
+// mfaEnforced 该用户是否必须开启二次验证
+func mfaEnforced(user *model.User) bool {
+	return user.IsAdmin == 1 && viper.GetBool("mfa.enforceAdmin")
+}
+
+// getUserMFA 查找用户的二次验证记录，没有时返回 nil
+func (s *Service) getUserMFA(tx *gorm.DB, uid uint, forUpdate bool) (*model.UserMFA, error) {
+	var mfa *model.UserMFA
+	var err error
+	if forUpdate {
+		mfa, err = s.dao.GetUserMFAForUpdate(tx, uid)
+	} else {
+		mfa, err = s.dao.GetUserMFA(tx, uid)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return mfa, nil
+}
+
+// completeLogin 密码校验通过后完成登录。已开启二次验证或必须开启的用户只签发 mfa token，
+// 凭它和验证码（或先绑定验证器）换取真正的 token；其他用户直接签发 access token 和 refresh token
+func (s *Service) completeLogin(tx *gorm.DB, user *model.User, client ClientInfo) (*dto.LoginData, error) {
+	mfa, err := s.getUserMFA(tx, user.UID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if mfa.Enabled() || mfaEnforced(user) {
+		mfaToken, err := s.jwt.GenerateMFAToken(user.UID, user.Username, user.IsAdmin == 1, user.TokenVersion)
+		if err != nil {
+			return nil, fmt.Errorf("user: %s 生成mfa token错误：%s", user.Username, err.Error())
+		}
+		data := buildLoginData(user, nil)
+		data.MFARequired = true
+		data.MFAEnrollRequired = !mfa.Enabled()
+		data.MFAToken = mfaToken
+		data.MFAExpiresIn = int64(s.jwt.MFATokenDuration().Seconds())
+		return data, nil
+	}
+
+	tokenData, _, err := s.issueTokens(tx, user, client)
+	if err != nil {
+		return nil, err
+	}
+	return buildLoginData(user, tokenData), nil
+}
+
+// VerifyMFALogin 凭 mfa token 和验证码（或恢复码）完成登录。验证码错误与密码错误一样计入登录失败次数
+func (s *Service) VerifyMFALogin(mfaToken, code string, client ClientInfo) (*dto.LoginData, error) {
+	claims, err := s.jwt.ValidateMFAToken(mfaToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkLoginLimit(claims.Username, client.IP); err != nil {
+		s.recordLogin(nil, claims.Username, client, err)
+		return nil, err
+	}
+
+	var data *dto.LoginData
+	err = s.dao.Transaction(func(tx *gorm.DB) error {
+		user, err := s.loadMFAUser(tx, claims)
+		if err != nil {
+			return err
+		}
+
+		mfa, err := s.getUserMFA(tx, user.UID, true)
+		if err != nil {
+			return err
+		}
+		if !mfa.Enabled() {
+			return fmt.Errorf(stderr.ErrorMFANotEnabled)
+		}
+		ok, err := s.checkMFACode(tx, mfa, code, true)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf(stderr.ErrorMFACodeInvalid)
+		}
+
+		tokenData, err := s.finishMFALogin(tx, claims, user, client)
+		if err != nil {
+			return err
+		}
+		data = buildLoginData(user, tokenData)
+		return nil
+	})
+	if err != nil {
+		if err.Error() == stderr.ErrorMFACodeInvalid {
+			s.loginFailed(claims.Username, client.IP)
+			s.recordLogin(nil, claims.Username, client, err)
+		}
+		return nil, err
+	}
+
+	s.loginSucceeded(claims.Username)
+	s.recordLogin(nil, claims.Username, client, nil)
+	return data, nil
+}
+
+// BeginMFAEnrollWithToken 登录时必须开启二次验证但尚未绑定的用户，凭 mfa token 获取密钥
+func (s *Service) BeginMFAEnrollWithToken(mfaToken string) (*dto.MFAEnrollData, error) {
+	claims, err := s.jwt.ValidateMFAToken(mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var data *dto.MFAEnrollData
+	err = s.dao.Transaction(func(tx *gorm.DB) error {
+		user, err := s.loadMFAUser(tx, claims)
+		if err != nil {
+			return err
+		}
+		data, err = s.beginMFAEnroll(tx, user)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ConfirmMFAEnrollWithToken 凭 mfa token 和验证器中的验证码确认绑定，同时完成登录。
+// actor 只需要带上请求的IP和请求ID，用户信息在这里补全
+func (s *Service) ConfirmMFAEnrollWithToken(actor *audit.Actor, mfaToken, code string, client ClientInfo) (*dto.MFAConfirmLoginData, error) {
+	claims, err := s.jwt.ValidateMFAToken(mfaToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkLoginLimit(claims.Username, client.IP); err != nil {
+		s.recordLogin(nil, claims.Username, client, err)
+		return nil, err
+	}
+
+	var data *dto.MFAConfirmLoginData
+	err = s.dao.Transaction(func(tx *gorm.DB) error {
+		user, err := s.loadMFAUser(tx, claims)
+		if err != nil {
+			return err
+		}
+
+		actor.UID, actor.Username = user.UID, user.Username
+		codes, err := s.confirmMFAEnroll(tx, actor, code)
+		if err != nil {
+			return err
+		}
+
+		tokenData, err := s.finishMFALogin(tx, claims, user, client)
+		if err != nil {
+			return err
+		}
+		data = &dto.MFAConfirmLoginData{
+			RecoveryCodes: codes,
+			Login:         buildLoginData(user, tokenData),
+		}
+		return nil
+	})
+	if err != nil {
+		if err.Error() == stderr.ErrorMFACodeInvalid {
+			s.loginFailed(claims.Username, client.IP)
+			s.recordLogin(nil, claims.Username, client, err)
+		}
+		return nil, err
+	}
+
+	s.loginSucceeded(claims.Username)
+	s.recordLogin(nil, claims.Username, client, nil)
+	return data, nil
+}
+
+// GetMFAStatus 查看当前用户二次验证的状态
+func (s *Service) GetMFAStatus(uid uint) (*dto.MFAStatusData, error) {
+	tx := s.dao.DB()
+	user, err := s.dao.GetUserByID(tx, uid)
+	if err != nil {
+		return nil, err
+	}
+	mfa, err := s.getUserMFA(tx, uid, false)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &dto.MFAStatusData{
+		Enabled:  mfa.Enabled(),
+		Required: mfaEnforced(user),
+	}
+	if mfa.Enabled() {
+		if data.RecoveryCodesLeft, err = s.dao.CountUnusedRecoveryCodes(tx, uid); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// BeginMFAEnroll 已登录的用户开始绑定验证器，返回密钥和二维码内容。确认之前不会生效，重复调用会生成新的密钥
+func (s *Service) BeginMFAEnroll(uid uint) (*dto.MFAEnrollData, error) {
+	var data *dto.MFAEnrollData
+	err := s.dao.Transaction(func(tx *gorm.DB) error {
+		user, err := s.dao.GetUserByID(tx, uid)
+		if err != nil {
+			return err
+		}
+		data, err = s.beginMFAEnroll(tx, user)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ConfirmMFAEnroll 已登录的用户输入验证器中的验证码确认绑定，返回恢复码
+func (s *Service) ConfirmMFAEnroll(actor *audit.Actor, code string) (*dto.MFARecoveryCodesData, error) {
+	var codes []string
+	err := s.dao.Transaction(func(tx *gorm.DB) error {
+		var err error
+		codes, err = s.confirmMFAEnroll(tx, actor, code)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.MFARecoveryCodesData{RecoveryCodes: codes}, nil
+}
+
+// DisableMFA 用户校验密码和验证码（或恢复码）后关闭二次验证，必须开启二次验证的管理员不能关闭
+func (s *Service) DisableMFA(actor *audit.Actor, password, code string) error {
+	return s.dao.Transaction(func(tx *gorm.DB) error {
+		user, err := s.dao.GetUserByID(tx, actor.UID)
+		if err != nil {
+			return err
+		}
+		if mfaEnforced(user) {
+			return fmt.Errorf(stderr.ErrorMFARequiredForAdmin)
+		}
+		if !util.CheckPasswordHash(password, user.Password) {
+			return fmt.Errorf(stderr.ErrorMFAPasswordWrong)
+		}
+
+		mfa, err := s.getUserMFA(tx, user.UID, true)
+		if err != nil {
+			return err
+		}
+		if !mfa.Enabled() {
+			return fmt.Errorf(stderr.ErrorMFANotEnabled)
+		}
+		ok, err := s.checkMFACode(tx, mfa, code, true)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf(stderr.ErrorMFACodeInvalid)
+		}
+
+		if err := s.dao.DeleteUserMFA(tx, user.UID); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, audit.ActionUserDisableMFA, audit.EntityUser, user.UID, nil, nil)
+	})
+}
+
+// RegenerateRecoveryCodes 用户输入验证器中的验证码后重新生成恢复码，原有的恢复码全部作废
+func (s *Service) RegenerateRecoveryCodes(actor *audit.Actor, code string) (*dto.MFARecoveryCodesData, error) {
+	var codes []string
+	err := s.dao.Transaction(func(tx *gorm.DB) error {
+		mfa, err := s.getUserMFA(tx, actor.UID, true)
+		if err != nil {
+			return err
+		}
+		if !mfa.Enabled() {
+			return fmt.Errorf(stderr.ErrorMFANotEnabled)
+		}
+		// 只接受验证器中的验证码，不能用恢复码换取新的恢复码
+		ok, err := s.checkMFACode(tx, mfa, code, false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf(stderr.ErrorMFACodeInvalid)
+		}
+
+		if codes, err = s.generateRecoveryCodes(tx, actor.UID); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, audit.ActionUserRecoveryCodes, audit.EntityUser, actor.UID, nil, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.MFARecoveryCodesData{RecoveryCodes: codes}, nil
+}
+
+// ResetUserMFA 管理员为丢失验证器的用户清除二次验证，用户下次登录时可以（或必须）重新绑定
+func (s *Service) ResetUserMFA(actor *audit.Actor, uid uint) error {
+	return s.dao.Transaction(func(tx *gorm.DB) error {
+		isExist, err := s.dao.IsExistUserByID(tx, uid)
+		if err != nil {
+			return err
+		}
+		if !isExist {
+			return fmt.Errorf(stderr.ErrorUserNotFound)
+		}
+
+		mfa, err := s.getUserMFA(tx, uid, true)
+		if err != nil {
+			return err
+		}
+		if mfa == nil {
+			return fmt.Errorf(stderr.ErrorMFANotEnabled)
+		}
+
+		if err := s.dao.DeleteUserMFA(tx, uid); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, audit.ActionUserResetMFA, audit.EntityUser, uid, map[string]interface{}{
+			"enabled": mfa.Enabled(),
+		}, nil)
+	})
+}
+
+// loadMFAUser 确认 mfa token 仍然有效（未使用过、用户状态和 token 版本号未变）并查出对应的用户
+func (s *Service) loadMFAUser(tx *gorm.DB, claims *jwt.CustomClaims) (*model.User, error) {
+	state, err := s.dao.GetTokenState(tx, claims.UID, claims.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf(stderr.ErrorMFATokenInvalid)
+		}
+		return nil, err
+	}
+	if state.IsUser != model.UserApproved || state.TokenVersion != claims.TokenVersion || state.JTIRevoked {
+		return nil, fmt.Errorf(stderr.ErrorMFATokenInvalid)
+	}
+	return s.dao.GetUserByID(tx, claims.UID)
+}
+
+// finishMFALogin 二次验证通过后注销 mfa token，使其只能使用一次，然后签发真正的 token
+func (s *Service) finishMFALogin(tx *gorm.DB, claims *jwt.CustomClaims, user *model.User, client ClientInfo) (*dto.TokenData, error) {
+	if err := s.dao.CreateRevokedToken(tx, claims.ID, user.UID, claims.ExpiresAt.Time); err != nil {
+		return nil, err
+	}
+	tokenData, _, err := s.issueTokens(tx, user, client)
+	if err != nil {
+		return nil, err
+	}
+	return tokenData, nil
+}
+
+func (s *Service) beginMFAEnroll(tx *gorm.DB, user *model.User) (*dto.MFAEnrollData, error) {
+	mfa, err := s.getUserMFA(tx, user.UID, true)
+	if err != nil {
+		return nil, err
+	}
+	if mfa.Enabled() {
+		return nil, fmt.Errorf(stderr.ErrorMFAAlreadyEnabled)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := util.EncryptString(util.EncryptionKey("mfa.encryptionKey"), secret)
+	if err != nil {
+		return nil, fmt.Errorf("加密TOTP密钥失败: %w", err)
+	}
+	if err := s.dao.SaveUserMFA(tx, &model.UserMFA{UID: user.UID, Secret: encrypted}); err != nil {
+		return nil, err
+	}
+
+	return &dto.MFAEnrollData{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(secret, viper.GetString("mfa.issuer"), user.Username),
+	}, nil
+}
+
+func (s *Service) confirmMFAEnroll(tx *gorm.DB, actor *audit.Actor, code string) ([]string, error) {
+	mfa, err := s.getUserMFA(tx, actor.UID, true)
+	if err != nil {
+		return nil, err
+	}
+	if mfa == nil {
+		return nil, fmt.Errorf(stderr.ErrorMFANotEnrolling)
+	}
+	if mfa.Enabled() {
+		return nil, fmt.Errorf(stderr.ErrorMFAAlreadyEnabled)
+	}
+
+	ok, err := s.checkMFACode(tx, mfa, code, false)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf(stderr.ErrorMFACodeInvalid)
+	}
+	if err := s.dao.UpdateUserMFA(tx, actor.UID, map[string]interface{}{"enabled_at": time.Now()}); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.generateRecoveryCodes(tx, actor.UID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.auditDao.Record(tx, actor, audit.ActionUserEnableMFA, audit.EntityUser, actor.UID, nil, nil); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// checkMFACode 校验验证器中的验证码，allowRecovery 为 true 时也接受未使用过的恢复码。
+// 验证码通过后记录其时间步，同一个验证码不能使用两次；恢复码使用后作废
+func (s *Service) checkMFACode(tx *gorm.DB, mfa *model.UserMFA, code string, allowRecovery bool) (bool, error) {
+	secret, err := util.DecryptString(util.EncryptionKey("mfa.encryptionKey"), mfa.Secret)
+	if err != nil {
+		return false, fmt.Errorf("解密TOTP密钥失败: %w", err)
+	}
+
+	step, ok, err := totp.Validate(secret, code, time.Now(), viper.GetInt("mfa.skew"), mfa.LastUsedStep)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		return true, s.dao.UpdateUserMFA(tx, mfa.UID, map[string]interface{}{"last_used_step": step})
+	}
+
+	if !allowRecovery {
+		return false, nil
+	}
+	return s.dao.UseRecoveryCode(tx, mfa.UID, util.HashToken(normalizeRecoveryCode(code)))
+}
+
+// generateRecoveryCodes 生成一组新的恢复码，原有的恢复码全部作废
+func (s *Service) generateRecoveryCodes(tx *gorm.DB, uid uint) ([]string, error) {
+	n := viper.GetInt("mfa.recoveryCodes")
+	codes := make([]string, 0, n)
+	hashes := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		code, err := util.RandomRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, util.HashToken(normalizeRecoveryCode(code)))
+	}
+	if err := s.dao.ReplaceRecoveryCodes(tx, uid, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// normalizeRecoveryCode 忽略恢复码中的大小写、空格和连字符
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
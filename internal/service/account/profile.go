@@ -0,0 +1,151 @@
+package account
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"strings"
+	dto "xinde/internal/dto/account"
+	model "xinde/internal/model/account"
+	"xinde/internal/model/audit"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+// GetProfile 查看当前用户自己的信息，包括公司的价格等级和注册申请的审核状态
+func (s *Service) GetProfile(uid uint) (*dto.ProfileData, error) {
+	user, err := s.dao.GetUserProfileByID(s.dao.DB(), uid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf(stderr.ErrorUserNotFound)
+		}
+		return nil, err
+	}
+
+	return &dto.ProfileData{
+		UID:            user.UID,
+		Username:       user.Username,
+		Name:           user.Name,
+		Phone:          user.Phone,
+		Email:          util.DerefString(user.UserEmail),
+		CompanyName:    user.CompanyName,
+		CompanyAddress: util.DerefString(user.CompanyAddress),
+		PriceLevel:     user.PriceLevel,
+		PriceLevelName: user.PriceLevelName,
+		Status:         userStatus(user.IsUser),
+		Why:            util.DerefString(user.Why),
+		HandledAt:      util.FormatNullableTimeToStandardString(user.HandledAt),
+		CreatedAt:      util.FormatTimeToStandardString(user.CreatedAt),
+	}, nil
+}
+
+// UpdateProfile 修改当前用户自己的姓名、电话和邮箱。
+// 姓名和电话是注册时的必填项，传空字符串视为不修改；邮箱可选，传空字符串表示清空
+func (s *Service) UpdateProfile(actor *audit.Actor, req *dto.UpdateProfileReq) error {
+	return s.dao.Transaction(func(tx *gorm.DB) error {
+		user, err := s.dao.GetUserByIDForUpdate(tx, actor.UID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf(stderr.ErrorUserNotFound)
+			}
+			return err
+		}
+
+		before := make(map[string]interface{})
+		updateData := make(map[string]interface{})
+		if req.Name != nil {
+			if name := strings.TrimSpace(*req.Name); name != "" && name != user.Name {
+				before["name"] = user.Name
+				updateData["name"] = name
+			}
+		}
+		if req.Phone != nil {
+			if phone := strings.TrimSpace(*req.Phone); phone != "" && phone != user.Phone {
+				before["phone"] = user.Phone
+				updateData["phone"] = phone
+			}
+		}
+		if req.Email != nil {
+			email := strings.TrimSpace(*req.Email)
+			if email != util.DerefString(user.UserEmail) {
+				before["user_email"] = util.DerefString(user.UserEmail)
+				if email == "" {
+					updateData["user_email"] = nil
+				} else {
+					updateData["user_email"] = email
+				}
+			}
+		}
+		if len(updateData) == 0 {
+			return nil
+		}
+
+		if err := s.dao.UpdateUser(tx, actor.UID, updateData); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, audit.ActionUserUpdateProfile, audit.EntityUser, actor.UID, before, updateData)
+	})
+}
+
+// ChangePassword 用户校验原密码后修改自己的密码。
+// 修改后该用户所有的 token 都会失效，同时为当前客户端签发一对新的 token，避免当前设备也被登出
+func (s *Service) ChangePassword(actor *audit.Actor, oldPassword, newPassword string, client ClientInfo) (*dto.TokenData, error) {
+	var data *dto.TokenData
+	err := s.dao.Transaction(func(tx *gorm.DB) error {
+		user, err := s.dao.GetUserByIDForUpdate(tx, actor.UID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf(stderr.ErrorUserNotFound)
+			}
+			return err
+		}
+
+		if !util.CheckPasswordHash(oldPassword, user.Password) {
+			return fmt.Errorf(stderr.ErrorUserOldPasswordWrong)
+		}
+		if oldPassword == newPassword {
+			return fmt.Errorf(stderr.ErrorUserPasswordUnchanged)
+		}
+
+		hashPassword, err := util.HashPassword(newPassword)
+		if err != nil {
+			return fmt.Errorf("加密密码失败: %w", err)
+		}
+		updateData := map[string]interface{}{
+			"password":             hashPassword,
+			"must_change_password": false,
+		}
+		if err := s.dao.UpdateUser(tx, user.UID, updateData); err != nil {
+			return err
+		}
+
+		// 吊销全部 token 会使 token 版本号加一，新签发的 token 要使用递增后的版本号
+		if err := s.dao.RevokeUserTokens(tx, user.UID); err != nil {
+			return err
+		}
+		user.TokenVersion++
+		data, _, err = s.issueTokens(tx, user, client)
+		if err != nil {
+			return err
+		}
+
+		// 记录审计日志，不记录密码本身
+		return s.auditDao.Record(tx, actor, audit.ActionUserChangePassword, audit.EntityUser, user.UID, nil, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// userStatus 将 is_user 字段转换为注册申请的状态
+func userStatus(isUser int) string {
+	switch isUser {
+	case model.UserApproved:
+		return dto.StatusApproved
+	case model.UserRejected:
+		return dto.StatusRejected
+	default:
+		return dto.StatusPending
+	}
+}
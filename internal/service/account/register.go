@@ -5,15 +5,37 @@ import (
 	"fmt"
 	"gorm.io/gorm"
 	registerDao "xinde/internal/dao/account"
+	"xinde/internal/dao/attachment"
+	"xinde/internal/dao/audit"
+	"xinde/internal/dao/company"
+	"xinde/internal/dao/price"
+	"xinde/internal/dao/role"
 	dto "xinde/internal/dto/account"
 	"xinde/pkg/jwt"
+	"xinde/pkg/limiter"
+	"xinde/pkg/notify"
 	"xinde/pkg/stderr"
 	"xinde/pkg/util"
 )
 
 type Service struct {
-	dao *registerDao.Dao
-	jwt *jwt.JWTService
+	dao      *registerDao.Dao
+	roleDao  *role.Dao
+	auditDao *audit.Dao
+	jwt      *jwt.JWTService
+	// 审批时关联公司、合并公司和设置公司的价格等级
+	companyDao *company.Dao
+	priceDao   *price.Dao
+	// 记录批量导入用户时上传的文件
+	attachmentDao *attachment.Dao
+	// 发送验证码的通道
+	emailSender notify.Sender
+	smsSender   notify.Sender
+	// 登录失败的计数，用户名和IP分别计数和锁定
+	userLimiter limiter.Limiter
+	ipLimiter   limiter.Limiter
+	// 不为 0 时为公司管理员使用的 Service，只能管理该公司的用户，见 ForCompany
+	companyID uint
 }
 
 func NewAccountService() (*Service, error) {
@@ -22,11 +44,63 @@ func NewAccountService() (*Service, error) {
 		return nil, fmt.Errorf("创建 DAO 实例失败: %w", err)
 	}
 
+	roleDao, err := role.NewRoleDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建 DAO 实例失败: %w", err)
+	}
+
+	auditDao, err := audit.NewAuditDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建 DAO 实例失败: %w", err)
+	}
+
 	jwtService := jwt.NewJWTService()
 
+	companyDao, err := company.NewCompanyDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建 DAO 实例失败: %w", err)
+	}
+	priceDao, err := price.NewPriceDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建 DAO 实例失败: %w", err)
+	}
+
+	attachmentDao, err := attachment.NewAttachmentDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建 DAO 实例失败: %w", err)
+	}
+
+	emailSender, err := notify.NewEmailSender()
+	if err != nil {
+		return nil, fmt.Errorf("创建邮件发送器失败: %w", err)
+	}
+	smsSender, err := notify.NewSMSSender()
+	if err != nil {
+		return nil, fmt.Errorf("创建短信发送器失败: %w", err)
+	}
+
+	userLimiter, err := limiter.New("account.loginLimit.user", dao.DB())
+	if err != nil {
+		return nil, fmt.Errorf("创建登录限流器失败: %w", err)
+	}
+	ipLimiter, err := limiter.New("account.loginLimit.ip", dao.DB())
+	if err != nil {
+		return nil, fmt.Errorf("创建登录限流器失败: %w", err)
+	}
+
 	return &Service{
-		dao: dao,
-		jwt: jwtService,
+		dao:        dao,
+		roleDao:    roleDao,
+		auditDao:   auditDao,
+		jwt:        jwtService,
+		companyDao: companyDao,
+		priceDao:   priceDao,
+
+		attachmentDao: attachmentDao,
+		emailSender:   emailSender,
+		smsSender:     smsSender,
+		userLimiter:   userLimiter,
+		ipLimiter:     ipLimiter,
 	}, nil
 }
 
@@ -2,14 +2,22 @@ package account
 
 import (
 	"fmt"
-	"github.com/spf13/viper"
 	"gorm.io/gorm"
+	dto "xinde/internal/dto/account"
+	model "xinde/internal/model/account"
+	"xinde/internal/model/audit"
 	"xinde/pkg/stderr"
-	"xinde/pkg/util"
 )
 
-func (s *Service) ResetPassword(uid uint) error {
-	return s.dao.Transaction(func(tx *gorm.DB) error {
+// ResetPassword 管理员重置用户的密码为一个随机的一次性密码，用户下次登录前必须修改。
+// 一次性密码只通过返回值交给管理员一次，数据库中只保存哈希
+func (s *Service) ResetPassword(actor *audit.Actor, uid uint) (*dto.ResetPasswordData, error) {
+	password, hashPassword, err := newOneTimePassword()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.dao.Transaction(func(tx *gorm.DB) error {
 		// 检查用户是否存在
 		isExist, err := s.dao.IsExistUserByID(tx, uid)
 		if err != nil {
@@ -18,20 +26,41 @@ func (s *Service) ResetPassword(uid uint) error {
 		if !isExist {
 			return fmt.Errorf(stderr.ErrorUserNotFound)
 		}
-
-		// 调用dao将用户的密码重置为123456
-		hashPassword, err := util.HashPassword(viper.GetString("account.defaultPassword"))
-		if err != nil {
-			return fmt.Errorf("加密密码失败: %w", err)
+		if err := s.checkCompanyAdminTarget(tx, actor, uid); err != nil {
+			return err
 		}
+
 		updateData := map[string]interface{}{
-			"password": hashPassword,
+			"password":             hashPassword,
+			"must_change_password": true,
 		}
 		err = s.dao.UpdateUser(tx, uid, updateData)
 		if err != nil {
 			return err
 		}
 
+		// 密码变更后，用旧密码登录得到的token全部失效
+		err = s.dao.RevokeUserTokens(tx, uid)
+		if err != nil {
+			return err
+		}
+
+		// 同时作废尚未使用的找回密码验证码
+		err = s.dao.ConsumeVerificationCodes(tx, uid, model.CodePurposeResetPassword)
+		if err != nil {
+			return err
+		}
+
+		// 记录审计日志，不记录密码本身
+		err = s.auditDao.Record(tx, actor, audit.ActionUserResetPassword, audit.EntityUser, uid, nil, nil)
+		if err != nil {
+			return err
+		}
+
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ResetPasswordData{Password: password}, nil
 }
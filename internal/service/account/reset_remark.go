@@ -3,10 +3,11 @@ package account
 import (
 	"fmt"
 	"gorm.io/gorm"
+	"xinde/internal/model/audit"
 	"xinde/pkg/stderr"
 )
 
-func (s *Service) ResetRemark(uid uint, remark string) error {
+func (s *Service) ResetRemark(actor *audit.Actor, uid uint, remark string) error {
 	return s.dao.Transaction(func(tx *gorm.DB) error {
 		// 检查用户是否存在
 		isExist, err := s.dao.IsExistUserByID(tx, uid)
@@ -17,6 +18,11 @@ func (s *Service) ResetRemark(uid uint, remark string) error {
 			return fmt.Errorf(stderr.ErrorUserNotFound)
 		}
 
+		user, err := s.dao.GetUserByID(tx, uid)
+		if err != nil {
+			return err
+		}
+
 		// 调用dao进行update
 		updateData := map[string]interface{}{
 			"remarks": remark,
@@ -26,6 +32,13 @@ func (s *Service) ResetRemark(uid uint, remark string) error {
 			return err
 		}
 
+		// 记录审计日志
+		err = s.auditDao.Record(tx, actor, audit.ActionUserUpdateRemark, audit.EntityUser, uid,
+			map[string]interface{}{"remarks": user.Remarks}, map[string]interface{}{"remarks": remark})
+		if err != nil {
+			return err
+		}
+
 		return nil
 	})
 }
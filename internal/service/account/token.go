@@ -0,0 +1,143 @@
+package account
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"time"
+	dto "xinde/internal/dto/account"
+	model "xinde/internal/model/account"
+	"xinde/pkg/jwt"
+	"xinde/pkg/stderr"
+)
+
+// ClientInfo 签发 refresh token 时记录的客户端信息
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+// issueTokens 为用户签发一对 access token 和 refresh token，refresh token 的哈希保存在 tx 中
+func (s *Service) issueTokens(tx *gorm.DB, user *model.User, client ClientInfo) (*dto.TokenData, *model.RefreshToken, error) {
+	accessToken, err := s.jwt.GenerateToken(user.UID, user.Username, user.IsAdmin == 1, user.TokenVersion)
+	if err != nil {
+		return nil, nil, fmt.Errorf("user: %s 生成token错误：%s", user.Username, err.Error())
+	}
+
+	refreshToken, hash, err := s.jwt.GenerateRefreshToken()
+	if err != nil {
+		return nil, nil, err
+	}
+	record := &model.RefreshToken{
+		UID:       user.UID,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(s.jwt.RefreshTokenDuration()),
+		UserAgent: truncate(client.UserAgent, 255),
+		IP:        truncate(client.IP, 64),
+	}
+	if err := s.dao.CreateRefreshToken(tx, record); err != nil {
+		return nil, nil, err
+	}
+
+	return &dto.TokenData{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(s.jwt.TokenDuration().Seconds()),
+	}, record, nil
+}
+
+// Refresh 用 refresh token 换取新的 access token，同时轮换 refresh token。
+// 已被吊销的 refresh token 再次出现说明它可能已经泄露，此时吊销该用户的全部 token
+func (s *Service) Refresh(refreshToken string, client ClientInfo) (*dto.TokenData, error) {
+	var data *dto.TokenData
+	reused := false
+	err := s.dao.Transaction(func(tx *gorm.DB) error {
+		old, err := s.dao.GetRefreshTokenByHashForUpdate(tx, jwt.HashRefreshToken(refreshToken))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf(stderr.ErrorRefreshTokenInvalid)
+			}
+			return err
+		}
+
+		if old.RevokedAt != nil {
+			// 吊销操作需要提交，所以这里不返回错误
+			reused = true
+			return s.dao.RevokeUserTokens(tx, old.UID)
+		}
+		if time.Now().After(old.ExpiresAt) {
+			return fmt.Errorf(stderr.ErrorRefreshTokenInvalid)
+		}
+
+		// 用户被删除或不再是通过审核的状态时不能再刷新
+		isExist, err := s.dao.IsExistUserByID(tx, old.UID)
+		if err != nil {
+			return err
+		}
+		if !isExist {
+			return fmt.Errorf(stderr.ErrorRefreshTokenInvalid)
+		}
+		user, err := s.dao.GetUserByID(tx, old.UID)
+		if err != nil {
+			return err
+		}
+		if user.IsUser != model.UserApproved {
+			return fmt.Errorf(stderr.ErrorRefreshTokenInvalid)
+		}
+
+		var record *model.RefreshToken
+		data, record, err = s.issueTokens(tx, user, client)
+		if err != nil {
+			return err
+		}
+		return s.dao.RevokeRefreshToken(tx, old.ID, &record.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		return nil, fmt.Errorf(stderr.ErrorRefreshTokenReused)
+	}
+	return data, nil
+}
+
+// Logout 注销当前的 access token，并吊销客户端持有的 refresh token；all 为 true 时注销该用户的全部登录
+func (s *Service) Logout(claims *jwt.CustomClaims, refreshToken string, all bool) error {
+	return s.dao.Transaction(func(tx *gorm.DB) error {
+		if all {
+			return s.dao.RevokeUserTokens(tx, claims.UID)
+		}
+
+		if claims.ID != "" && claims.ExpiresAt != nil {
+			err := s.dao.CreateRevokedToken(tx, claims.ID, claims.UID, claims.ExpiresAt.Time)
+			if err != nil {
+				return err
+			}
+		}
+
+		if refreshToken == "" {
+			return nil
+		}
+		token, err := s.dao.GetRefreshTokenByHashForUpdate(tx, jwt.HashRefreshToken(refreshToken))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf(stderr.ErrorRefreshTokenInvalid)
+			}
+			return err
+		}
+		// 不能注销别人的 refresh token
+		if token.UID != claims.UID {
+			return fmt.Errorf(stderr.ErrorRefreshTokenInvalid)
+		}
+		return s.dao.RevokeRefreshToken(tx, token.ID, nil)
+	})
+}
+
+// truncate 按字符截断字符串，避免超出数据库列的长度
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
@@ -3,11 +3,12 @@ package account
 import (
 	"fmt"
 	"gorm.io/gorm"
+	"xinde/internal/model/audit"
 	"xinde/pkg/stderr"
 	"xinde/pkg/util"
 )
 
-func (s *Service) UpdatePassword(uid uint, password string) error {
+func (s *Service) UpdatePassword(actor *audit.Actor, uid uint, password string) error {
 	return s.dao.Transaction(func(tx *gorm.DB) error {
 		// 检查用户是否存在
 		isExist, err := s.dao.IsExistUserByID(tx, uid)
@@ -30,6 +31,18 @@ func (s *Service) UpdatePassword(uid uint, password string) error {
 		if err != nil {
 			return err
 		}
+
+		// 密码变更后，用旧密码登录得到的token全部失效
+		err = s.dao.RevokeUserTokens(tx, uid)
+		if err != nil {
+			return err
+		}
+
+		// 记录审计日志，不记录密码本身
+		err = s.auditDao.Record(tx, actor, audit.ActionUserSetPassword, audit.EntityUser, uid, nil, nil)
+		if err != nil {
+			return err
+		}
 		return nil
 	})
 }
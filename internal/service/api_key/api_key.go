@@ -0,0 +1,302 @@
+package api_key
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"time"
+	"xinde/internal/dao/api_key"
+	"xinde/internal/dao/audit"
+	"xinde/internal/dao/company"
+	dto "xinde/internal/dto/api_key"
+	model "xinde/internal/model/api_key"
+	auditModel "xinde/internal/model/audit"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+// keyIDPrefix API Key 公开标识的前缀，便于在日志和配置中识别
+const keyIDPrefix = "xd"
+
+type Service struct {
+	dao        *api_key.Dao
+	companyDao *company.Dao
+	auditDao   *audit.Dao
+}
+
+func NewAPIKeyService() (*Service, error) {
+	dao, err := api_key.NewAPIKeyDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建 DAO 实例失败: %w", err)
+	}
+	companyDao, err := company.NewCompanyDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建 DAO 实例失败: %w", err)
+	}
+	auditDao, err := audit.NewAuditDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建 DAO 实例失败: %w", err)
+	}
+
+	return &Service{
+		dao:        dao,
+		companyDao: companyDao,
+		auditDao:   auditDao,
+	}, nil
+}
+
+func (s *Service) GetScopeList() []*dto.ScopeData {
+	list := make([]*dto.ScopeData, 0, len(model.Scopes))
+	for _, scope := range model.Scopes {
+		list = append(list, &dto.ScopeData{
+			Code: scope.Code,
+			Name: scope.Name,
+		})
+	}
+	return list
+}
+
+func (s *Service) GetAPIKeyList(req *dto.ListReq) ([]*dto.APIKeyData, error) {
+	keys, err := s.dao.FindAPIKeys(s.dao.DB(), req.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	list := make([]*dto.APIKeyData, 0, len(keys))
+	for _, k := range keys {
+		list = append(list, convertAPIKeyToDTO(k, now))
+	}
+	return list, nil
+}
+
+// CreateAPIKey 为公司创建 API Key，返回的签名密钥只出现这一次
+func (s *Service) CreateAPIKey(actor *auditModel.Actor, req *dto.CreateReq) (*dto.SecretData, error) {
+	scopes, err := checkScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, err := parseExpiresAt(req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	keyID, err := util.RandomKeyID(keyIDPrefix)
+	if err != nil {
+		return nil, err
+	}
+	secret, encrypted, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	key := &model.APIKey{
+		KeyID:     keyID,
+		Secret:    encrypted,
+		Name:      req.Name,
+		CompanyID: req.CompanyID,
+		ExpiresAt: expiresAt,
+		CreatedBy: actor.UID,
+	}
+	for _, scope := range scopes {
+		key.Scopes = append(key.Scopes, &model.APIKeyScope{Scope: scope})
+	}
+
+	err = s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		isExist, err := s.companyDao.IsExistCompanyByID(tx, req.CompanyID)
+		if err != nil {
+			return err
+		}
+		if !isExist {
+			return fmt.Errorf(stderr.ErrorCompanyNotFound)
+		}
+
+		if err := s.dao.CreateAPIKey(tx, key); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, auditModel.ActionAPIKeyCreate, auditModel.EntityAPIKey, key.ID, nil, apiKeySnapshot(key))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.SecretData{ID: key.ID, KeyID: key.KeyID, Secret: secret}, nil
+}
+
+// UpdateAPIKey 修改 API Key 的名称、访问范围或过期时间，已吊销的 Key 不能修改
+func (s *Service) UpdateAPIKey(actor *auditModel.Actor, id uint, req *dto.UpdateReq) error {
+	var scopes []string
+	if len(req.Scopes) > 0 {
+		var err error
+		if scopes, err = checkScopes(req.Scopes); err != nil {
+			return err
+		}
+	}
+
+	updateData := make(map[string]interface{})
+	if req.Name != "" {
+		updateData["name"] = req.Name
+	}
+	if req.ExpiresAt != nil {
+		expiresAt, err := parseExpiresAt(*req.ExpiresAt)
+		if err != nil {
+			return err
+		}
+		updateData["expires_at"] = expiresAt
+	}
+
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		key, err := s.getActiveAPIKey(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if len(updateData) > 0 {
+			if err := s.dao.UpdateAPIKey(tx, id, updateData); err != nil {
+				return err
+			}
+		}
+		if scopes != nil {
+			if err := s.dao.ReplaceAPIKeyScopes(tx, id, scopes); err != nil {
+				return err
+			}
+		}
+
+		updated, err := s.dao.GetAPIKeyByID(tx, id)
+		if err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, auditModel.ActionAPIKeyUpdate, auditModel.EntityAPIKey, id, apiKeySnapshot(key), apiKeySnapshot(updated))
+	})
+}
+
+// RotateAPIKeySecret 重新生成签名密钥，原密钥立即失效，key_id 不变
+func (s *Service) RotateAPIKeySecret(actor *auditModel.Actor, id uint) (*dto.SecretData, error) {
+	secret, encrypted, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	var key *model.APIKey
+	err = s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		if key, err = s.getActiveAPIKey(tx, id); err != nil {
+			return err
+		}
+		if err := s.dao.UpdateAPIKey(tx, id, map[string]interface{}{"secret": encrypted}); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, auditModel.ActionAPIKeyRotate, auditModel.EntityAPIKey, id, nil, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.SecretData{ID: key.ID, KeyID: key.KeyID, Secret: secret}, nil
+}
+
+// RevokeAPIKey 吊销 API Key，吊销后不能恢复，记录保留用于审计
+func (s *Service) RevokeAPIKey(actor *auditModel.Actor, id uint) error {
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		key, err := s.getActiveAPIKey(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.dao.UpdateAPIKey(tx, id, map[string]interface{}{"revoked_at": time.Now()}); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, auditModel.ActionAPIKeyRevoke, auditModel.EntityAPIKey, id, apiKeySnapshot(key), nil)
+	})
+}
+
+// getActiveAPIKey 查找未被吊销的 API Key
+func (s *Service) getActiveAPIKey(tx *gorm.DB, id uint) (*model.APIKey, error) {
+	key, err := s.dao.GetAPIKeyByID(tx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf(stderr.ErrorAPIKeyNotFound)
+		}
+		return nil, err
+	}
+	if key.RevokedAt != nil {
+		return nil, fmt.Errorf(stderr.ErrorAPIKeyRevoked)
+	}
+	return key, nil
+}
+
+// newSecret 生成签名密钥，返回明文和加密后用于入库的密文
+func newSecret() (string, string, error) {
+	secret, _, err := util.RandomToken()
+	if err != nil {
+		return "", "", err
+	}
+	encrypted, err := util.EncryptString(util.EncryptionKey("apiKey.encryptionKey"), secret)
+	if err != nil {
+		return "", "", fmt.Errorf("加密API Key签名密钥失败: %w", err)
+	}
+	return secret, encrypted, nil
+}
+
+// checkScopes 校验访问范围并去重
+func checkScopes(scopes []string) ([]string, error) {
+	seen := make(map[string]bool, len(scopes))
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !model.IsValidScope(scope) {
+			return nil, fmt.Errorf(stderr.ErrorAPIKeyScopeInvalid)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, scope)
+		}
+	}
+	return result, nil
+}
+
+// parseExpiresAt 解析过期时间，空字符串表示永不过期。只有日期时表示该日零点过期
+func parseExpiresAt(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{util.StandardDateTimeFormat, util.StandardDateFormat} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			if !t.After(time.Now()) {
+				break
+			}
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf(stderr.ErrorAPIKeyExpiresAtInvalid)
+}
+
+func apiKeySnapshot(k *model.APIKey) map[string]interface{} {
+	return map[string]interface{}{
+		"key_id":     k.KeyID,
+		"name":       k.Name,
+		"company_id": k.CompanyID,
+		"scopes":     scopeCodes(k),
+		"expires_at": util.FormatNullableTimeToStandardString(k.ExpiresAt),
+	}
+}
+
+func scopeCodes(k *model.APIKey) []string {
+	codes := make([]string, 0, len(k.Scopes))
+	for _, s := range k.Scopes {
+		codes = append(codes, s.Scope)
+	}
+	return codes
+}
+
+func convertAPIKeyToDTO(k *model.APIKey, now time.Time) *dto.APIKeyData {
+	return &dto.APIKeyData{
+		ID:         k.ID,
+		KeyID:      k.KeyID,
+		Name:       k.Name,
+		CompanyID:  k.CompanyID,
+		Scopes:     scopeCodes(k),
+		Active:     k.Active(now),
+		ExpiresAt:  util.FormatNullableTimeToStandardString(k.ExpiresAt),
+		RevokedAt:  util.FormatNullableTimeToStandardString(k.RevokedAt),
+		LastUsedAt: util.FormatNullableTimeToStandardString(k.LastUsedAt),
+		LastUsedIP: k.LastUsedIP,
+		CreatedBy:  k.CreatedBy,
+		CreatedAt:  util.FormatTimeToStandardString(k.CreatedAt),
+	}
+}
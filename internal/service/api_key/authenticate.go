@@ -0,0 +1,119 @@
+package api_key
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+	model "xinde/internal/model/api_key"
+	"xinde/pkg/logger"
+	"xinde/pkg/signer"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+// touchInterval 两次记录 API Key 使用时间的最小间隔
+const touchInterval = time.Minute
+
+// nonce 只允许字母、数字、- 和 _，长度 8-64
+var nonceRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]{8,64}$`)
+
+// SignedRequest 一次签名请求中参与校验的内容
+type SignedRequest struct {
+	KeyID     string
+	Timestamp string
+	Nonce     string
+	Signature string
+	Method    string
+	// 请求路径加查询串
+	Path string
+	Body []byte
+	IP   string
+}
+
+// nonceCleaner 控制清理过期 nonce 的频率，多个请求共享
+var nonceCleaner struct {
+	sync.Mutex
+	last time.Time
+}
+
+// Authenticate 校验签名请求，通过时返回对应的 API Key：
+//   - 时间戳与服务器时间相差不超过 apiKey.maxSkew；
+//   - Key 存在、未过期且未被吊销；
+//   - 签名与按 pkg/signer 规则计算的一致；
+//   - nonce 在时间窗口内没有使用过（签名通过后才记录，伪造的请求不会占用 nonce）。
+func (s *Service) Authenticate(req *SignedRequest) (*model.APIKey, error) {
+	if req.KeyID == "" || req.Timestamp == "" || req.Nonce == "" || req.Signature == "" {
+		return nil, fmt.Errorf(stderr.ErrorAPIKeyMissing)
+	}
+	if !nonceRegexp.MatchString(req.Nonce) {
+		return nil, fmt.Errorf(stderr.ErrorAPIKeyNonceInvalid)
+	}
+
+	now := time.Now()
+	maxSkew := viper.GetDuration("apiKey.maxSkew")
+	ts, err := strconv.ParseInt(req.Timestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf(stderr.ErrorAPIKeyTimestampInvalid)
+	}
+	signedAt := time.Unix(ts, 0)
+	if signedAt.Before(now.Add(-maxSkew)) || signedAt.After(now.Add(maxSkew)) {
+		return nil, fmt.Errorf(stderr.ErrorAPIKeyTimestampInvalid)
+	}
+
+	tx := s.dao.DB()
+	key, err := s.dao.GetAPIKeyByKeyID(tx, req.KeyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf(stderr.ErrorAPIKeyInvalid)
+		}
+		return nil, err
+	}
+	if !key.Active(now) {
+		return nil, fmt.Errorf(stderr.ErrorAPIKeyInvalid)
+	}
+
+	secret, err := util.DecryptString(util.EncryptionKey("apiKey.encryptionKey"), key.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("解密API Key签名密钥失败: %w", err)
+	}
+	stringToSign := signer.StringToSign(req.Method, req.Path, req.Timestamp, req.Nonce, signer.BodyHash(req.Body))
+	if !signer.Verify(secret, stringToSign, req.Signature) {
+		return nil, fmt.Errorf(stderr.ErrorAPIKeySignatureInvalid)
+	}
+
+	// 超过 signedAt+maxSkew 后同一个时间戳已无法通过校验，nonce 记录也就可以删除了
+	ok, err := s.dao.UseNonce(tx, key.ID, req.Nonce, signedAt.Add(maxSkew))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf(stderr.ErrorAPIKeyReplayed)
+	}
+
+	// 使用记录和清理只影响展示和存储，失败不影响本次请求
+	if err := s.dao.TouchAPIKey(tx, key.ID, req.IP, now, touchInterval); err != nil {
+		logger.Warn(err.Error())
+	}
+	s.cleanupNonces(now, maxSkew)
+	return key, nil
+}
+
+// cleanupNonces 每隔 maxSkew 清理一次过期的 nonce
+func (s *Service) cleanupNonces(now time.Time, interval time.Duration) {
+	nonceCleaner.Lock()
+	if now.Sub(nonceCleaner.last) < interval {
+		nonceCleaner.Unlock()
+		return
+	}
+	nonceCleaner.last = now
+	nonceCleaner.Unlock()
+
+	if err := s.dao.DeleteExpiredNonces(s.dao.DB(), now); err != nil {
+		logger.Warn(err.Error())
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"gorm.io/gorm"
 	"os"
+	"xinde/internal/model/audit"
+	"xinde/pkg/util"
 )
 
-func (s *Service) Delete(id uint) error {
+func (s *Service) Delete(actor *audit.Actor, id uint) error {
 	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
 		tx = s.dao.DB()
 		attachment, err := s.dao.GetAttachmentByID(tx, id)
@@ -37,6 +39,10 @@ func (s *Service) Delete(id uint) error {
 		if err != nil {
 			return err
 		}
-		return nil
+		return s.auditDao.Record(tx, actor, audit.ActionAttachmentDelete, audit.EntityAttachment, id, map[string]interface{}{
+			"filename":      attachment.Filename,
+			"storage_path":  attachment.StoragePath,
+			"business_type": util.DerefString(attachment.BusinessType),
+		}, nil)
 	})
 }
@@ -6,12 +6,13 @@ import (
 	"os"
 	"path/filepath"
 	model "xinde/internal/model/attachment"
+	"xinde/internal/model/audit"
 	"xinde/pkg/logger"
 	"xinde/pkg/stderr"
 	"xinde/pkg/util"
 )
 
-func (s *Service) FixOrphan(adminID uint, filePath string, action string) error {
+func (s *Service) FixOrphan(actor *audit.Actor, filePath string, action string) error {
 	tx := s.dao.DB()
 
 	savePath := viper.GetString("attachment.save_path")
@@ -40,7 +41,7 @@ func (s *Service) FixOrphan(adminID uint, filePath string, action string) error
 			FileType:      fileType,
 			FileSize:      uint64(fileInfo.Size()),
 			StorageDriver: "local",
-			UploadedByUID: adminID,
+			UploadedByUID: actor.UID,
 			BusinessType:  businessType,
 		}
 
@@ -54,5 +55,10 @@ func (s *Service) FixOrphan(adminID uint, filePath string, action string) error
 			return fmt.Errorf("删除孤儿文件失败: " + err.Error())
 		}
 	}
-	return nil
+
+	// 孤儿文件在数据库中没有记录，以文件路径作为对象ID
+	return s.auditDao.Record(tx, actor, audit.ActionAttachmentFixOrphan, audit.EntityAttachment, filePath, nil, map[string]interface{}{
+		"action":    action,
+		"file_size": fileInfo.Size(),
+	})
 }
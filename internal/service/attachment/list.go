@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"xinde/internal/dao/attachment"
 	dao "xinde/internal/dao/attachment"
+	"xinde/internal/dao/audit"
+	"xinde/internal/dao/device"
+	"xinde/internal/dao/group"
+	"xinde/internal/dao/outbox"
 	dto "xinde/internal/dto/attachment"
 	model "xinde/internal/model/attachment"
 	"xinde/pkg/jwt"
@@ -12,8 +16,13 @@ import (
 )
 
 type Service struct {
-	jwt *jwt.JWTService
-	dao *attachment.Dao
+	jwt      *jwt.JWTService
+	dao      *attachment.Dao
+	auditDao *audit.Dao
+	// 核对附件和产品目录是否一致
+	deviceDao *device.Dao
+	groupDao  *group.Dao
+	outboxDao *outbox.Dao
 }
 
 func NewAttachmentService() (*Service, error) {
@@ -22,9 +31,29 @@ func NewAttachmentService() (*Service, error) {
 	if err != nil {
 		return nil, fmt.Errorf("创建Dao实例失败: " + err.Error())
 	}
+	auditDao, err := audit.NewAuditDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: " + err.Error())
+	}
+	deviceDao, err := device.NewDeviceDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: " + err.Error())
+	}
+	groupDao, err := group.NewGroupDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: " + err.Error())
+	}
+	outboxDao, err := outbox.NewOutboxDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: " + err.Error())
+	}
 	return &Service{
-		jwt: jwtService,
-		dao: d,
+		jwt:       jwtService,
+		dao:       d,
+		auditDao:  auditDao,
+		deviceDao: deviceDao,
+		groupDao:  groupDao,
+		outboxDao: outboxDao,
 	}, nil
 }
 
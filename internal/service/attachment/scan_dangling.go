@@ -0,0 +1,129 @@
+package attachment
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/viper"
+	"time"
+	dto "xinde/internal/dto/attachment"
+	outboxModel "xinde/internal/model/outbox"
+	"xinde/pkg/logger"
+	"xinde/pkg/util"
+)
+
+// ScanDangling 核对MySQL中的附件和PostgreSQL中的产品目录：找出业务对象已经不存在的附件，
+// 以及缺少附件的设备类型和筛选图片。两个库无法在同一个事务中读取，结果可能包含正在进行中的修改
+func (s *Service) ScanDangling() (*dto.DanglingData, error) {
+	deviceTypeIDs, err := s.deviceDao.GetAllDeviceTypeIDs(s.deviceDao.DB())
+	if err != nil {
+		return nil, err
+	}
+	filterImageIDs, err := s.deviceDao.GetAllFilterImageIDs(s.deviceDao.DB())
+	if err != nil {
+		return nil, err
+	}
+	groups, err := s.groupDao.GetAll(s.groupDao.DB())
+	if err != nil {
+		return nil, err
+	}
+	groupIDs := make([]uint, 0, len(groups))
+	for _, g := range groups {
+		groupIDs = append(groupIDs, g.ID)
+	}
+
+	// 业务类型 -> 业务对象ID，以及该类业务对象是否必须有附件（分组的图标是可选的）
+	checks := []struct {
+		businessType string
+		ids          []uint
+		required     bool
+	}{
+		{viper.GetString("business_type.device_icon"), deviceTypeIDs, true},
+		{viper.GetString("business_type.device_import"), deviceTypeIDs, true},
+		{viper.GetString("business_type.filter_image"), filterImageIDs, true},
+		{viper.GetString("business_type.group_icon"), groupIDs, false},
+	}
+
+	result := &dto.DanglingData{
+		DanglingRecords: []*dto.DanglingRecord{},
+		MissingRecords:  []*dto.MissingRecord{},
+	}
+	for _, check := range checks {
+		exists := make(map[uint]bool, len(check.ids))
+		for _, id := range check.ids {
+			exists[id] = true
+		}
+
+		attachments, err := s.dao.GetAttachmentsByBusinessType(s.dao.DB(), check.businessType)
+		if err != nil {
+			return nil, err
+		}
+		hasAttachment := make(map[uint]bool, len(attachments))
+		for _, a := range attachments {
+			hasAttachment[a.BusinessID] = true
+			if !exists[a.BusinessID] {
+				result.DanglingRecords = append(result.DanglingRecords, &dto.DanglingRecord{
+					ID:           a.ID,
+					Filename:     a.Filename,
+					StoragePath:  a.StoragePath,
+					BusinessType: check.businessType,
+					BusinessID:   a.BusinessID,
+					CreatedAt:    util.FormatTimeToStandardString(a.CreatedAt),
+				})
+			}
+		}
+
+		if !check.required {
+			continue
+		}
+		for _, id := range check.ids {
+			if !hasAttachment[id] {
+				result.MissingRecords = append(result.MissingRecords, &dto.MissingRecord{
+					BusinessType: check.businessType,
+					BusinessID:   id,
+				})
+			}
+		}
+	}
+
+	if result.PendingOperations, err = s.outboxDao.CountByStatus(s.outboxDao.DB(), outboxModel.StatusPending); err != nil {
+		return nil, err
+	}
+	if result.FailedOperations, err = s.outboxDao.CountByStatus(s.outboxDao.DB(), outboxModel.StatusFailed); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// StartReconcile 每隔 outbox.reconcileInterval 在后台核对一次，ctx 取消后退出
+func (s *Service) StartReconcile(ctx context.Context) {
+	interval := viper.GetDuration("outbox.reconcileInterval")
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.Reconcile()
+			}
+		}
+	}()
+}
+
+// Reconcile 核对附件和产品目录，把结果写入日志
+func (s *Service) Reconcile() {
+	result, err := s.ScanDangling()
+	if err != nil {
+		logger.Error("核对附件和产品目录失败: " + err.Error())
+		return
+	}
+	if len(result.DanglingRecords) == 0 && len(result.MissingRecords) == 0 && result.FailedOperations == 0 {
+		return
+	}
+	logger.Warn(fmt.Sprintf("附件和产品目录不一致: %d 条附件的业务对象不存在, %d 个业务对象缺少附件, %d 个跨库操作待执行, %d 个跨库操作失败",
+		len(result.DanglingRecords), len(result.MissingRecords), result.PendingOperations, result.FailedOperations))
+}
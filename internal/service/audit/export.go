@@ -0,0 +1,68 @@
+package audit
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	dto "xinde/internal/dto/audit"
+	model "xinde/internal/model/audit"
+	"xinde/pkg/util"
+)
+
+// csvFlushEvery 每写入多少行刷新一次缓冲，使大文件边查边下载
+const csvFlushEvery = 500
+
+var auditCSVHeader = []string{
+	"id", "created_at", "actor_uid", "actor_name", "action", "entity_type", "entity_id",
+	"diff", "before", "after", "ip", "request_id",
+}
+
+// ExportAuditLogs 按与列表相同的查询条件把审计日志以 CSV 格式写入 w（不分页）。
+// 开头写入 UTF-8 BOM，用 Excel 打开时中文不会乱码
+func (s *Service) ExportAuditLogs(req *dto.FilterReq, w io.Writer) error {
+	filter, err := buildAuditLogFilter(req)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write([]byte("\xEF\xBB\xBF")); err != nil {
+		return fmt.Errorf("写入CSV失败: %w", err)
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write(auditCSVHeader); err != nil {
+		return fmt.Errorf("写入CSV失败: %w", err)
+	}
+
+	rows := 0
+	err = s.dao.StreamAuditLogs(s.dao.DB(), filter, func(l *model.AuditLog) error {
+		record := []string{
+			fmt.Sprint(l.ID),
+			util.FormatTimeToStandardString(l.CreatedAt),
+			fmt.Sprint(l.ActorUID),
+			l.ActorName,
+			l.Action,
+			l.EntityType,
+			l.EntityID,
+			string(l.Diff),
+			string(l.Before),
+			string(l.After),
+			l.IP,
+			l.RequestID,
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("写入CSV失败: %w", err)
+		}
+		rows++
+		if rows%csvFlushEvery == 0 {
+			writer.Flush()
+			return writer.Error()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
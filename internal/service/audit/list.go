@@ -0,0 +1,137 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+	"xinde/internal/dao/audit"
+	dto "xinde/internal/dto/audit"
+	model "xinde/internal/model/audit"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+type Service struct {
+	dao *audit.Dao
+}
+
+func NewAuditService() (*Service, error) {
+	dao, err := audit.NewAuditDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: %v", err)
+	}
+	return &Service{
+		dao: dao,
+	}, nil
+}
+
+func (s *Service) GetAuditLogList(req *dto.ListReq) (*dto.ListPageData, error) {
+	tx := s.dao.DB()
+	page, pageSize := req.Page, req.PageSize
+
+	filter, err := buildAuditLogFilter(&req.FilterReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// 计算页数
+	count, err := s.dao.CountAuditLogs(tx, filter)
+	if err != nil {
+		return nil, err
+	}
+	pages := int((count + int64(pageSize-1)) / int64(pageSize))
+	if pages == 0 {
+		pages = 1
+	}
+
+	// 对page过大、过小的情况做判断
+	currentPage := page
+	if currentPage > pages {
+		currentPage = pages
+	}
+	if currentPage < 1 {
+		currentPage = 1
+	}
+
+	logs, err := s.dao.FindAuditLogsWithPagination(tx, currentPage, pageSize, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*dto.ListData, 0, len(logs))
+	for _, l := range logs {
+		list = append(list, convertAuditLogToDTOListData(l))
+	}
+
+	pageData := &dto.ListPageData{
+		List:     list,
+		Total:    int(count),
+		Page:     currentPage,
+		PageSize: pageSize,
+		Pages:    pages,
+	}
+
+	// 针对用户输入page过大、过小的情况做特殊处理，返回最后一页/第一页的数据，但依然提交err
+	if page > pages {
+		return pageData, fmt.Errorf(stderr.ErrorOverLargePage)
+	}
+	if page < 1 {
+		return pageData, fmt.Errorf(stderr.ErrorOverSmallPage)
+	}
+	return pageData, nil
+}
+
+// ValidateFilter 只校验查询条件。导出时需要在写入文件流之前确认参数正确，否则无法再返回错误响应
+func (s *Service) ValidateFilter(req *dto.FilterReq) error {
+	_, err := buildAuditLogFilter(req)
+	return err
+}
+
+// buildAuditLogFilter 校验并转换查询条件
+func buildAuditLogFilter(req *dto.FilterReq) (*audit.AuditLogFilter, error) {
+	filter := &audit.AuditLogFilter{
+		ActorUID:   req.ActorUID,
+		Action:     req.Action,
+		EntityType: req.EntityType,
+		EntityID:   req.EntityID,
+		RequestID:  req.RequestID,
+	}
+
+	var err error
+	if filter.From, err = parseFilterTime(req.From); err != nil {
+		return nil, err
+	}
+	if filter.To, err = parseFilterTime(req.To); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+func parseFilterTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{util.StandardDateTimeFormat, util.StandardDateFormat} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf(stderr.ErrorAuditTimeInvalid)
+}
+
+func convertAuditLogToDTOListData(l *model.AuditLog) *dto.ListData {
+	return &dto.ListData{
+		ID:         l.ID,
+		ActorUID:   l.ActorUID,
+		ActorName:  l.ActorName,
+		Action:     l.Action,
+		EntityType: l.EntityType,
+		EntityID:   l.EntityID,
+		Before:     json.RawMessage(l.Before),
+		After:      json.RawMessage(l.After),
+		Diff:       json.RawMessage(l.Diff),
+		IP:         l.IP,
+		RequestID:  l.RequestID,
+		CreatedAt:  util.FormatTimeToStandardString(l.CreatedAt),
+	}
+}
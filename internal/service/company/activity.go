@@ -0,0 +1,93 @@
+package company
+
+import (
+	"fmt"
+	"time"
+	"xinde/internal/dao/device_access_log"
+	dto "xinde/internal/dto/company"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+// GetCompanyActivity 按访问时间倒序分页返回公司用户的搜索记录
+func (s *Service) GetCompanyActivity(companyID uint, req *dto.ActivityReq) (*dto.ActivityPageData, error) {
+	filter := &device_access_log.AccessLogFilter{UserID: req.UserID}
+	var err error
+	if filter.From, err = parseActivityTime(req.From); err != nil {
+		return nil, err
+	}
+	if filter.To, err = parseActivityTime(req.To); err != nil {
+		return nil, err
+	}
+
+	tx := s.dao.DB()
+	if err := s.checkCompanyExist(tx, companyID); err != nil {
+		return nil, err
+	}
+
+	// 计算总页数
+	count, err := s.accessLogDao.CountCompanyAccessLogs(tx, companyID, filter)
+	if err != nil {
+		return nil, err
+	}
+	pages := int((count + int64(req.PageSize-1)) / int64(req.PageSize))
+	if pages == 0 {
+		pages = 1
+	}
+
+	// 对page过大或过小的情况做判断
+	currentPage := req.Page
+	if currentPage > pages {
+		currentPage = pages
+	}
+	if currentPage < 1 {
+		currentPage = 1
+	}
+
+	logs, err := s.accessLogDao.FindCompanyAccessLogsWithPagination(tx, companyID, filter, currentPage, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*dto.ActivityData, 0, len(logs))
+	for _, l := range logs {
+		list = append(list, &dto.ActivityData{
+			ID:             l.ID,
+			UserID:         l.UserID,
+			Username:       l.Username,
+			Name:           l.Name,
+			DeviceTypeID:   l.DeviceTypeID,
+			DeviceTypeName: l.DeviceTypeName,
+			AccessedAt:     util.FormatTimeToStandardString(l.AccessedAt),
+		})
+	}
+
+	pageData := &dto.ActivityPageData{
+		List:     list,
+		Total:    int(count),
+		Page:     currentPage,
+		PageSize: req.PageSize,
+		Pages:    pages,
+	}
+
+	// 针对用户输入page过大或过小的情况做特殊处理，返回最后一页或第一页的数据，但依然提交err
+	if req.Page > pages {
+		return pageData, fmt.Errorf(stderr.ErrorOverLargePage)
+	}
+	if req.Page < 1 {
+		return pageData, fmt.Errorf(stderr.ErrorOverSmallPage)
+	}
+	return pageData, nil
+}
+
+func parseActivityTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{util.StandardDateTimeFormat, util.StandardDateFormat} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf(stderr.ErrorCompanyActivityTimeInvalid)
+}
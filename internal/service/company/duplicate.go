@@ -0,0 +1,191 @@
+package company
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	dto "xinde/internal/dto/company"
+	model "xinde/internal/model/account"
+	"xinde/pkg/util"
+)
+
+// defaultDuplicateThreshold 未指定时判定为疑似重复的名称相似度
+const defaultDuplicateThreshold = 0.8
+
+// companySuffixes 比较名称前去掉的常见后缀，长的在前
+var companySuffixes = []string{
+	"股份有限公司", "有限责任公司", "有限公司", "集团公司", "分公司", "公司", "集团",
+	"coltd", "limited", "ltd", "inc", "corp", "company", "co",
+}
+
+// FindDuplicateCompanies 查找名称疑似重复的公司。名称先统一全角半角、大小写，去掉空白、标点和“有限公司”等后缀，
+// 再按编辑距离计算相似度，相似度不低于 threshold 的公司归为一组（相似关系可传递）。结果按相似度从高到低排列
+func (s *Service) FindDuplicateCompanies(threshold float64) ([]*dto.DuplicateGroup, error) {
+	if threshold <= 0 {
+		threshold = defaultDuplicateThreshold
+	}
+
+	tx := s.dao.DB()
+	companies, err := s.dao.FindAllCompanies(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 按规范化后的名称长度排序，长度相差过大的不可能相似，可以提前结束比较
+	type entry struct {
+		company *model.Company
+		name    []rune
+	}
+	entries := make([]*entry, 0, len(companies))
+	for _, c := range companies {
+		entries = append(entries, &entry{company: c, name: []rune(normalizeCompanyName(c.Name))})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return len(entries[i].name) < len(entries[j].name) })
+
+	parent := make([]int, len(entries))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	best := make(map[int]float64)
+	for i := 0; i < len(entries); i++ {
+		for j := i + 1; j < len(entries); j++ {
+			a, b := entries[i].name, entries[j].name
+			// b 不短于 a，长度之差决定了相似度的上限
+			if len(b) > 0 && 1-float64(len(b)-len(a))/float64(len(b)) < threshold {
+				break
+			}
+			sim := nameSimilarity(a, b)
+			if sim < threshold {
+				continue
+			}
+			ri, rj := find(i), find(j)
+			if ri != rj {
+				parent[rj] = ri
+				best[ri] = math.Max(best[ri], best[rj])
+			}
+			best[ri] = math.Max(best[ri], sim)
+		}
+	}
+
+	groups := make(map[int][]*model.Company)
+	for i, e := range entries {
+		root := find(i)
+		groups[root] = append(groups[root], e.company)
+	}
+
+	var ids []uint
+	for _, members := range groups {
+		if len(members) > 1 {
+			for _, c := range members {
+				ids = append(ids, c.ID)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return []*dto.DuplicateGroup{}, nil
+	}
+	userCounts, err := s.dao.CountUsersByCompanyIDs(tx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.DuplicateGroup, 0)
+	for root, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+		sort.Slice(members, func(i, j int) bool { return members[i].ID < members[j].ID })
+		group := &dto.DuplicateGroup{Similarity: math.Round(best[root]*100) / 100}
+		for _, c := range members {
+			group.Companies = append(group.Companies, &dto.DuplicateCompany{
+				ID:             c.ID,
+				Name:           c.Name,
+				Address:        util.DerefString(c.Address),
+				PriceLevelCode: c.PriceLevel,
+				UserCount:      userCounts[c.ID],
+				CreatedAt:      util.FormatTimeToStandardString(c.CreatedAt),
+			})
+		}
+		result = append(result, group)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Similarity != result[j].Similarity {
+			return result[i].Similarity > result[j].Similarity
+		}
+		return result[i].Companies[0].ID < result[j].Companies[0].ID
+	})
+	return result, nil
+}
+
+// normalizeCompanyName 全角转半角、转小写，去掉空白、标点、符号和常见的公司后缀
+func normalizeCompanyName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r == '　':
+			continue
+		case r >= '！' && r <= '～':
+			r -= 0xFEE0
+		}
+		if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			continue
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	normalized := b.String()
+	for {
+		trimmed := normalized
+		for _, suffix := range companySuffixes {
+			if strings.HasSuffix(trimmed, suffix) && len(trimmed) > len(suffix) {
+				trimmed = strings.TrimSuffix(trimmed, suffix)
+				break
+			}
+		}
+		if trimmed == normalized {
+			return normalized
+		}
+		normalized = trimmed
+	}
+}
+
+// nameSimilarity 基于编辑距离的相似度，1 表示完全相同
+func nameSimilarity(a, b []rune) float64 {
+	maxLen := len(a)
+	if len(b) > maxLen {
+		maxLen = len(b)
+	}
+	if maxLen == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(a, b))/float64(maxLen)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
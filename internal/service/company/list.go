@@ -2,17 +2,27 @@ package company
 
 import (
 	"fmt"
+	"xinde/internal/dao/audit"
 	"xinde/internal/dao/company"
+	"xinde/internal/dao/device_access_log"
+	"xinde/internal/dao/group"
+	"xinde/internal/dao/price"
 	dto "xinde/internal/dto/company"
 	model "xinde/internal/model/account"
+	priceModel "xinde/internal/model/price"
 	"xinde/pkg/jwt"
 	"xinde/pkg/stderr"
 	"xinde/pkg/util"
 )
 
 type Service struct {
-	dao *company.Dao
-	jwt *jwt.JWTService
+	dao      *company.Dao
+	jwt      *jwt.JWTService
+	priceDao *price.Dao
+	groupDao *group.Dao
+	auditDao *audit.Dao
+	// 查询公司用户的搜索记录
+	accessLogDao *device_access_log.Dao
 }
 
 func NewCompanyService() (*Service, error) {
@@ -23,9 +33,34 @@ func NewCompanyService() (*Service, error) {
 
 	jwtService := jwt.NewJWTService()
 
+	priceDao, err := price.NewPriceDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建 DAO 实例失败: %w", err)
+	}
+
+	groupDao, err := group.NewGroupDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建 DAO 实例失败: %w", err)
+	}
+
+	auditDao, err := audit.NewAuditDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建 DAO 实例失败: %w", err)
+	}
+
+	accessLogDao, err := device_access_log.NewDeviceAccessLogDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建 DAO 实例失败: %w", err)
+	}
+
 	return &Service{
-		dao: dao,
-		jwt: jwtService,
+		dao:      dao,
+		jwt:      jwtService,
+		priceDao: priceDao,
+		groupDao: groupDao,
+		auditDao: auditDao,
+
+		accessLogDao: accessLogDao,
 	}, nil
 }
 
@@ -58,10 +93,15 @@ func (s *Service) GetCompanyList(page, pageSize int) (*dto.ListPageData, error)
 		return nil, err
 	}
 
+	levels, err := s.priceDao.FindAllPriceLevels(tx)
+	if err != nil {
+		return nil, err
+	}
+
 	// 将model.Company转换成dto.ListData
 	var listData []*dto.ListData
 	for _, c := range companies {
-		listData = append(listData, convertCompanyToDTOListData(c))
+		listData = append(listData, convertCompanyToDTOListData(c, levels))
 	}
 
 	// 组装分页
@@ -85,19 +125,23 @@ func (s *Service) GetCompanyList(page, pageSize int) (*dto.ListPageData, error)
 	return pageData, nil
 }
 
-func convertCompanyToDTOListData(company *model.Company) *dto.ListData {
-	mp := map[string]string{
-		"price_1": "价格等级1",
-		"price_2": "价格等级2",
-		"price_3": "价格等级3",
-		"price_4": "价格等级4",
+func convertCompanyToDTOListData(company *model.Company, levels []*priceModel.PriceLevel) *dto.ListData {
+	// 等级已不存在时直接显示编码
+	levelName := company.PriceLevel
+	for _, l := range levels {
+		if l.Code == company.PriceLevel {
+			levelName = l.Name
+			break
+		}
 	}
 
 	return &dto.ListData{
-		ID:         company.ID,
-		Name:       company.Name,
-		Address:    util.DerefString(company.Address),
-		PriceLevel: mp[company.PriceLevel],
-		CreatedAt:  util.FormatTimeToStandardString(company.CreatedAt),
+		ID:             company.ID,
+		Name:           company.Name,
+		Address:        util.DerefString(company.Address),
+		PriceLevel:     levelName,
+		PriceLevelCode: company.PriceLevel,
+		Notes:          util.DerefString(company.Notes),
+		CreatedAt:      util.FormatTimeToStandardString(company.CreatedAt),
 	}
 }
@@ -0,0 +1,260 @@
+package company
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"strings"
+	accountDto "xinde/internal/dto/account"
+	dto "xinde/internal/dto/company"
+	model "xinde/internal/model/account"
+	"xinde/internal/model/audit"
+	priceModel "xinde/internal/model/price"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+// GetCompanyDetail 返回公司信息及用户数
+func (s *Service) GetCompanyDetail(id uint) (*dto.DetailData, error) {
+	tx := s.dao.DB()
+	company, err := s.getCompany(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	levels, err := s.priceDao.FindAllPriceLevels(tx)
+	if err != nil {
+		return nil, err
+	}
+	userCount, err := s.dao.CountCompanyUsers(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.DetailData{
+		ListData:  *convertCompanyToDTOListData(company, levels),
+		UserCount: userCount,
+	}, nil
+}
+
+// CreateCompany 创建公司，名称不能与已有的公司（包括已删除和已合并的）重复
+func (s *Service) CreateCompany(actor *audit.Actor, req *dto.CreateReq) (uint, error) {
+	company := &model.Company{
+		Name:       strings.TrimSpace(req.Name),
+		PriceLevel: req.PriceLevel,
+		Address:    nullableString(req.Address),
+		Notes:      nullableString(req.Notes),
+	}
+	if company.PriceLevel == "" {
+		company.PriceLevel = priceModel.DefaultPriceLevelCode
+	}
+
+	err := s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		if err := s.checkCompanyName(tx, company.Name, 0); err != nil {
+			return err
+		}
+		if err := s.checkPriceLevel(tx, company.PriceLevel); err != nil {
+			return err
+		}
+
+		if err := s.dao.CreateCompany(tx, company); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, audit.ActionCompanyCreate, audit.EntityCompany, company.ID, nil, companySnapshot(company))
+	})
+	if err != nil {
+		return 0, err
+	}
+	return company.ID, nil
+}
+
+// UpdateCompany 修改公司的名称、地址、价格等级或备注，改名时同步用户冗余保存的公司名称
+func (s *Service) UpdateCompany(actor *audit.Actor, id uint, req *dto.UpdateReq) error {
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		company, err := s.getCompany(tx, id)
+		if err != nil {
+			return err
+		}
+		before := companySnapshot(company)
+
+		updateData := make(map[string]interface{})
+		if name := strings.TrimSpace(req.Name); name != "" && name != company.Name {
+			if err := s.checkCompanyName(tx, name, id); err != nil {
+				return err
+			}
+			if err := s.dao.UpdateCompanyUsersName(tx, id, name); err != nil {
+				return err
+			}
+			updateData["name"] = name
+			company.Name = name
+		}
+		if req.PriceLevel != "" && req.PriceLevel != company.PriceLevel {
+			if err := s.checkPriceLevel(tx, req.PriceLevel); err != nil {
+				return err
+			}
+			updateData["price_level"] = req.PriceLevel
+			company.PriceLevel = req.PriceLevel
+		}
+		if req.Address != nil {
+			company.Address = nullableString(*req.Address)
+			updateData["address"] = company.Address
+		}
+		if req.Notes != nil {
+			company.Notes = nullableString(*req.Notes)
+			updateData["notes"] = company.Notes
+		}
+		if len(updateData) == 0 {
+			return nil
+		}
+
+		if err := s.dao.UpdateCompany(tx, id, updateData); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, audit.ActionCompanyUpdate, audit.EntityCompany, id, before, companySnapshot(company))
+	})
+}
+
+// DeleteCompany 删除公司（软删除）。公司下还有用户、专属价格、折扣规则或 API Key 时不能删除，重复的公司请使用合并
+func (s *Service) DeleteCompany(actor *audit.Actor, id uint) error {
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		company, err := s.getCompany(tx, id)
+		if err != nil {
+			return err
+		}
+
+		hasReferences, err := s.dao.HasCompanyReferences(tx, id)
+		if err != nil {
+			return err
+		}
+		if hasReferences {
+			return fmt.Errorf(stderr.ErrorCompanyInUse)
+		}
+
+		if err := s.dao.DeleteCompanyByID(tx, id); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, audit.ActionCompanyDelete, audit.EntityCompany, id, companySnapshot(company), nil)
+	})
+}
+
+// GetCompanyMemberList 分页返回公司的用户，包括尚未审批和已拒绝的
+func (s *Service) GetCompanyMemberList(id uint, page, pageSize int) (*dto.MemberPageData, error) {
+	tx := s.dao.DB()
+	if err := s.checkCompanyExist(tx, id); err != nil {
+		return nil, err
+	}
+
+	// 计算总页数
+	count, err := s.dao.CountCompanyUsers(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	pages := int((count + int64(pageSize-1)) / int64(pageSize))
+	if pages == 0 {
+		pages = 1
+	}
+
+	// 对page过大或过小的情况做判断
+	currentPage := page
+	if currentPage > pages {
+		currentPage = pages
+	}
+	if currentPage < 1 {
+		currentPage = 1
+	}
+
+	users, err := s.dao.FindCompanyUsersWithPagination(tx, id, currentPage, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*dto.MemberData, 0, len(users))
+	for _, u := range users {
+		list = append(list, &dto.MemberData{
+			ID:        u.UID,
+			Username:  u.Username,
+			Name:      u.Name,
+			Phone:     u.Phone,
+			Email:     util.DerefString(u.UserEmail),
+			Status:    userStatus(u.IsUser),
+			CreatedAt: util.FormatTimeToStandardString(u.CreatedAt),
+		})
+	}
+
+	pageData := &dto.MemberPageData{
+		List:     list,
+		Total:    int(count),
+		Page:     currentPage,
+		PageSize: pageSize,
+		Pages:    pages,
+	}
+
+	// 针对用户输入page过大或过小的情况做特殊处理，返回最后一页或第一页的数据，但依然提交err
+	if page > pages {
+		return pageData, fmt.Errorf(stderr.ErrorOverLargePage)
+	}
+	if page < 1 {
+		return pageData, fmt.Errorf(stderr.ErrorOverSmallPage)
+	}
+	return pageData, nil
+}
+
+// getCompany 查找公司，不存在时返回 ErrorCompanyNotFound
+func (s *Service) getCompany(tx *gorm.DB, id uint) (*model.Company, error) {
+	if err := s.checkCompanyExist(tx, id); err != nil {
+		return nil, err
+	}
+	return s.dao.GetCompanyByID(tx, id)
+}
+
+func (s *Service) checkCompanyName(tx *gorm.DB, name string, excludeID uint) error {
+	isExist, err := s.dao.IsExistCompanyByName(tx, name, excludeID)
+	if err != nil {
+		return err
+	}
+	if isExist {
+		return fmt.Errorf(stderr.ErrorCompanyNameExists)
+	}
+	return nil
+}
+
+func (s *Service) checkPriceLevel(tx *gorm.DB, code string) error {
+	isExist, err := s.priceDao.IsExistPriceLevelByCode(tx, code)
+	if err != nil {
+		return err
+	}
+	if !isExist {
+		return fmt.Errorf(stderr.ErrorPriceLevelNotFound)
+	}
+	return nil
+}
+
+// nullableString 去掉首尾空白，空字符串存为 NULL
+func nullableString(s string) *string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// userStatus 将 is_user 字段转换为注册申请的状态
+func userStatus(isUser int) string {
+	switch isUser {
+	case model.UserApproved:
+		return accountDto.StatusApproved
+	case model.UserRejected:
+		return accountDto.StatusRejected
+	default:
+		return accountDto.StatusPending
+	}
+}
+
+// companySnapshot 审计日志中记录的公司信息
+func companySnapshot(c *model.Company) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        c.Name,
+		"address":     util.DerefString(c.Address),
+		"price_level": c.PriceLevel,
+		"notes":       util.DerefString(c.Notes),
+	}
+}
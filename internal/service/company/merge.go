@@ -0,0 +1,82 @@
+package company
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	dto "xinde/internal/dto/company"
+	"xinde/internal/model/audit"
+	"xinde/pkg/stderr"
+)
+
+// MergeCompanies 把 sourceIDs 公司合并到 targetID：用户、设备访问记录和 API Key 转到 targetID，被合并的公司软删除，
+// 以后按这些公司的名称注册的用户直接归入 targetID。被合并的公司的公司管理员不再是公司管理员。全部在一个事务中完成。
+// 被合并的公司有专属价格或折扣规则时不能合并，需要管理员先确认保留哪些
+func (s *Service) MergeCompanies(actor *audit.Actor, targetID uint, sourceIDs []uint) (*dto.MergeData, error) {
+	seen := make(map[uint]bool, len(sourceIDs))
+	ids := make([]uint, 0, len(sourceIDs))
+	for _, id := range sourceIDs {
+		if id == targetID {
+			return nil, fmt.Errorf(stderr.ErrorCompanyMergeTargetSource)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	data := &dto.MergeData{}
+	err := s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		target, err := s.getCompany(tx, targetID)
+		if err != nil {
+			return err
+		}
+		sources, err := s.dao.FindCompaniesByIDs(tx, ids)
+		if err != nil {
+			return err
+		}
+		if len(sources) != len(ids) {
+			return fmt.Errorf(stderr.ErrorCompanyNotFound)
+		}
+		for _, source := range sources {
+			hasPriceData, err := s.dao.HasCompanyPriceData(tx, source.ID)
+			if err != nil {
+				return err
+			}
+			if hasPriceData {
+				return fmt.Errorf(stderr.ErrorCompanyMergeHasPriceData)
+			}
+		}
+
+		result, err := s.dao.MergeCompanies(tx, target, ids)
+		if err != nil {
+			return err
+		}
+		data.MovedUsers = result.Users
+		data.MovedAccessLogs = result.AccessLogs
+		data.MovedAPIKeys = result.APIKeys
+		data.MovedVisibilityRules = result.VisibilityRules
+		data.DemotedCompanyAdmins = make([]uint, 0, len(result.DemotedAdmins))
+		for _, admin := range result.DemotedAdmins {
+			data.DemotedCompanyAdmins = append(data.DemotedCompanyAdmins, admin.UID)
+			err := s.auditDao.Record(tx, actor, audit.ActionUserSetCompanyAdmin, audit.EntityUser, admin.UID,
+				map[string]interface{}{"is_company_admin": true, "company_id": admin.CompanyID},
+				map[string]interface{}{"is_company_admin": false, "company_id": target.ID})
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, source := range sources {
+			err := s.auditDao.Record(tx, actor, audit.ActionCompanyMerge, audit.EntityCompany, source.ID,
+				companySnapshot(source), map[string]interface{}{"merged_into": target.ID})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
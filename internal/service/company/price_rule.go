@@ -0,0 +1,207 @@
+package company
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"strings"
+	dto "xinde/internal/dto/company"
+	"xinde/internal/model/audit"
+	model "xinde/internal/model/price"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+func (s *Service) GetPriceOverrideList(companyID uint) ([]*dto.OverrideData, error) {
+	tx := s.dao.DB()
+	if err := s.checkCompanyExist(tx, companyID); err != nil {
+		return nil, err
+	}
+
+	overrides, err := s.dao.FindPriceOverridesByCompanyID(tx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*dto.OverrideData, 0, len(overrides))
+	for _, o := range overrides {
+		list = append(list, &dto.OverrideData{
+			ID:          o.ID,
+			ProductCode: o.ProductCode,
+			Price:       o.Price,
+			Remark:      util.DerefString(o.Remark),
+			UpdatedAt:   util.FormatTimeToStandardString(o.UpdatedAt),
+		})
+	}
+	return list, nil
+}
+
+// SavePriceOverride 设置公司在某个产品上的专属价格，已存在时覆盖
+func (s *Service) SavePriceOverride(actor *audit.Actor, companyID uint, productCode string, price float64, remark string) error {
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		if err := s.checkCompanyExist(tx, companyID); err != nil {
+			return err
+		}
+
+		override := &model.CompanyPriceOverride{
+			CompanyID:    companyID,
+			ProductCode:  strings.TrimSpace(productCode),
+			Price:        price,
+			CreatedByUID: actor.UID,
+		}
+		if remark != "" {
+			override.Remark = util.StringToPointer(remark)
+		}
+		if err := s.dao.UpsertPriceOverride(tx, override); err != nil {
+			return err
+		}
+
+		// 专属价格和折扣规则都记在公司名下，按公司即可查到全部价格调整
+		return s.auditDao.Record(tx, actor, audit.ActionPriceOverrideSave, audit.EntityCompany, companyID, nil, overrideSnapshot(override))
+	})
+}
+
+func (s *Service) DeletePriceOverride(actor *audit.Actor, id uint) error {
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		override, err := s.dao.GetPriceOverrideByID(tx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf(stderr.ErrorPriceOverrideNotFound)
+			}
+			return err
+		}
+		if err := s.dao.DeletePriceOverrideByID(tx, id); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, audit.ActionPriceOverrideDelete, audit.EntityCompany, override.CompanyID, overrideSnapshot(override), nil)
+	})
+}
+
+func (s *Service) GetPriceRuleList(companyID uint) ([]*dto.RuleData, error) {
+	tx := s.dao.DB()
+	if err := s.checkCompanyExist(tx, companyID); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.dao.FindPriceRulesByCompanyID(tx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*dto.RuleData, 0, len(rules))
+	for _, r := range rules {
+		list = append(list, &dto.RuleData{
+			ID:              r.ID,
+			ScopeType:       r.ScopeType,
+			ProductPrefix:   r.ProductPrefix,
+			GroupID:         r.GroupID,
+			GroupName:       r.GroupName,
+			DiscountPercent: r.DiscountPercent,
+			Remark:          util.DerefString(r.Remark),
+			CreatedAt:       util.FormatTimeToStandardString(r.CreatedAt),
+		})
+	}
+	return list, nil
+}
+
+// CreatePriceRule 为公司创建折扣规则，同一公司下相同范围（同一前缀或同一分组）只能有一条规则
+func (s *Service) CreatePriceRule(actor *audit.Actor, companyID uint, req *dto.CreateRuleReq) error {
+	rule := &model.CompanyPriceRule{
+		CompanyID:       companyID,
+		ScopeType:       req.ScopeType,
+		DiscountPercent: req.DiscountPercent,
+		CreatedByUID:    actor.UID,
+	}
+	// 只保留与范围类型对应的字段，另一个字段保持零值，以便唯一索引生效
+	switch req.ScopeType {
+	case model.RuleScopeProductPrefix:
+		rule.ProductPrefix = strings.TrimSpace(req.ProductPrefix)
+		if rule.ProductPrefix == "" {
+			return fmt.Errorf(stderr.ErrorPriceRuleScopeInvalid)
+		}
+	case model.RuleScopeGroup:
+		rule.GroupID = req.GroupID
+		if rule.GroupID == 0 {
+			return fmt.Errorf(stderr.ErrorPriceRuleScopeInvalid)
+		}
+	}
+	if req.Remark != "" {
+		rule.Remark = util.StringToPointer(req.Remark)
+	}
+
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		if err := s.checkCompanyExist(tx, companyID); err != nil {
+			return err
+		}
+
+		if rule.GroupID != 0 {
+			if _, err := s.groupDao.GetGroupByID(tx, rule.GroupID); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return fmt.Errorf(stderr.ErrorGroupNotFound)
+				}
+				return err
+			}
+		}
+
+		isExist, err := s.dao.IsExistPriceRule(tx, companyID, rule.ScopeType, rule.ProductPrefix, rule.GroupID)
+		if err != nil {
+			return err
+		}
+		if isExist {
+			return fmt.Errorf(stderr.ErrorPriceRuleConflict)
+		}
+
+		if err := s.dao.CreatePriceRule(tx, rule); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, audit.ActionPriceRuleCreate, audit.EntityCompany, companyID, nil, ruleSnapshot(rule))
+	})
+}
+
+func (s *Service) DeletePriceRule(actor *audit.Actor, id uint) error {
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		rule, err := s.dao.GetPriceRuleByID(tx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf(stderr.ErrorPriceRuleNotFound)
+			}
+			return err
+		}
+		if err := s.dao.DeletePriceRuleByID(tx, id); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, audit.ActionPriceRuleDelete, audit.EntityCompany, rule.CompanyID, ruleSnapshot(rule), nil)
+	})
+}
+
+func (s *Service) checkCompanyExist(tx *gorm.DB, companyID uint) error {
+	isExist, err := s.dao.IsExistCompanyByID(tx, companyID)
+	if err != nil {
+		return err
+	}
+	if !isExist {
+		return fmt.Errorf(stderr.ErrorCompanyNotFound)
+	}
+	return nil
+}
+
+// overrideSnapshot 审计日志中记录的专属价格
+func overrideSnapshot(o *model.CompanyPriceOverride) map[string]interface{} {
+	return map[string]interface{}{
+		"product_code": o.ProductCode,
+		"price":        o.Price,
+		"remark":       util.DerefString(o.Remark),
+	}
+}
+
+// ruleSnapshot 审计日志中记录的折扣规则
+func ruleSnapshot(r *model.CompanyPriceRule) map[string]interface{} {
+	return map[string]interface{}{
+		"rule_id":          r.ID,
+		"scope_type":       r.ScopeType,
+		"product_prefix":   r.ProductPrefix,
+		"group_id":         r.GroupID,
+		"discount_percent": r.DiscountPercent,
+		"remark":           util.DerefString(r.Remark),
+	}
+}
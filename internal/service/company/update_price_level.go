@@ -3,10 +3,11 @@ package company
 import (
 	"fmt"
 	"gorm.io/gorm"
+	"xinde/internal/model/audit"
 	"xinde/pkg/stderr"
 )
 
-func (s *Service) UpdatePriceLevel(id uint, priceLevel string) error {
+func (s *Service) UpdatePriceLevel(actor *audit.Actor, id uint, priceLevel string) error {
 	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
 		// 检查公司是否存在
 		isExist, err := s.dao.IsExistCompanyByID(tx, id)
@@ -16,6 +17,19 @@ func (s *Service) UpdatePriceLevel(id uint, priceLevel string) error {
 		if !isExist {
 			return fmt.Errorf(stderr.ErrorCompanyNotFound)
 		}
+		company, err := s.dao.GetCompanyByID(tx, id)
+		if err != nil {
+			return err
+		}
+
+		// 检查价格等级是否存在
+		isExist, err = s.priceDao.IsExistPriceLevelByCode(tx, priceLevel)
+		if err != nil {
+			return err
+		}
+		if !isExist {
+			return fmt.Errorf(stderr.ErrorPriceLevelNotFound)
+		}
 
 		// 调用dao修改公司的价格等级
 		updateData := map[string]interface{}{
@@ -26,6 +40,8 @@ func (s *Service) UpdatePriceLevel(id uint, priceLevel string) error {
 			return err
 		}
 
-		return nil
+		// 记录审计日志
+		return s.auditDao.Record(tx, actor, audit.ActionCompanySetPriceLevel, audit.EntityCompany, id,
+			map[string]interface{}{"price_level": company.PriceLevel}, updateData)
 	})
 }
@@ -4,10 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"gorm.io/gorm"
+	"xinde/internal/model/audit"
+	outboxModel "xinde/internal/model/outbox"
 	"xinde/pkg/stderr"
 )
 
-func (s *Service) ChangeFilterImageDevice(id, deviceTypeID uint) error {
+func (s *Service) ChangeFilterImageDevice(actor *audit.Actor, id, deviceTypeID uint) error {
 	// 验证要移动的记录是否存在
 	filterImage, err := s.dao.GetFilterImageByID(s.dao.DB(), id)
 	if err != nil {
@@ -37,13 +39,29 @@ func (s *Service) ChangeFilterImageDevice(id, deviceTypeID uint) error {
 		return fmt.Errorf(stderr.ErrorFilterImageValueConflict)
 	}
 
-	// 一切都没有问题，更新deviceTypeID字段
-	err = s.dao.UpdateFilterImage(s.dao.DB(), id, map[string]interface{}{
-		"device_type_id": deviceTypeID,
+	// 一切都没有问题，更新deviceTypeID字段，审计日志保存在MySQL中，随修改一起写入待执行操作
+	var op *outboxModel.Operation
+	err = s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		err := s.dao.UpdateFilterImage(tx, id, map[string]interface{}{
+			"device_type_id": deviceTypeID,
+		})
+		if err != nil {
+			return err
+		}
+
+		auditLog, err := newAuditLog(actor, audit.ActionFilterImageChangeOwner, audit.EntityFilterImage, id,
+			map[string]interface{}{"device_type_id": filterImage.DeviceTypeID}, map[string]interface{}{"device_type_id": deviceTypeID})
+		if err != nil {
+			return err
+		}
+		op, err = s.outboxService.Enqueue(tx, outboxModel.OpCatalogSync, outboxModel.DeviceTypeEntity(deviceTypeID), &outboxModel.CatalogSync{Audit: auditLog})
+		return err
 	})
 	if err != nil {
 		return err
 	}
 
+	// 立即写入审计日志，失败时由后台任务重试
+	s.outboxService.ApplyNow(op)
 	return nil
 }
@@ -5,39 +5,46 @@ import (
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
 	"mime/multipart"
+	attachmentModel "xinde/internal/model/attachment"
+	"xinde/internal/model/audit"
 	model "xinde/internal/model/device"
+	outboxModel "xinde/internal/model/outbox"
 )
 
-func (s *Service) CreateFilterImage(adminUID, deviceTypeID uint, filterValue string, imageFile *multipart.FileHeader) error {
+func (s *Service) CreateFilterImage(actor *audit.Actor, deviceTypeID uint, filterValue string, imageFile *multipart.FileHeader) error {
 
-	var newFilterImage *model.FilterImage
-	// 1. PG事务，创建filter_image记录
-	err := s.dao.DB().Transaction(func(tx *gorm.DB) error {
+	// 1. 先把上传的图片保存到磁盘，附件记录等筛选图片创建后随待执行操作写入
+	businessType := viper.GetString("business_type.filter_image")
+	newRecord, err := s.getNewAttachmentRecord(imageFile, actor.UID, 0, businessType)
+	if err != nil {
+		return err
+	}
+
+	var op *outboxModel.Operation
+	// 2. PG事务，创建filter_image记录
+	err = s.dao.DB().Transaction(func(tx *gorm.DB) error {
 		// a. 检查是否存在deviceType
 		_, err := s.dao.GetDeviceTypeByID(tx, deviceTypeID)
 		if err != nil {
 			return err
 		}
 
-		// b. 检查是否存在相同的配置，如果存在还要删掉(附件也要删掉)
+		// b. 检查是否存在相同的配置，如果存在还要删掉(附件随待执行操作删除)
+		sync := &outboxModel.CatalogSync{}
 		isExists, filterImageID, err := s.dao.CheckFilterImageExists(tx, deviceTypeID, filterValue)
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			return err
 		}
 		if isExists {
-			businessType := viper.GetString("business_type.filter_image")
-			err = s.attachmentDao.DeleteAttachmentsByBusinessTypeAndIDs(s.attachmentDao.DB(), businessType, []uint{filterImageID})
-			if err != nil {
-				return err
-			}
 			err := s.dao.DeleteFilterImageByID(tx, filterImageID)
 			if err != nil {
 				return err
 			}
+			sync.DeleteAttachments = []*outboxModel.AttachmentRef{{BusinessType: businessType, BusinessIDs: []uint{filterImageID}}}
 		}
 
 		// c. 在t_filter_image表中创建记录
-		newFilterImage = &model.FilterImage{
+		newFilterImage := &model.FilterImage{
 			DeviceTypeID: deviceTypeID,
 			FilterValue:  filterValue,
 		}
@@ -45,31 +52,26 @@ func (s *Service) CreateFilterImage(adminUID, deviceTypeID uint, filterValue str
 		if err != nil {
 			return err
 		}
-		return nil
-	})
-	if err != nil {
-		return err
-	}
 
-	// 2. MySQL事务，创建attachment记录
-	err = s.attachmentDao.DB().Transaction(func(tx *gorm.DB) error {
-
-		// 由于已经在前一个事务中删除了可能存在的附件记录，所以直接创建就行
-		businessType := viper.GetString("business_type.filter_image")
-		newRecord, err := s.getNewAttachmentRecord(imageFile, adminUID, newFilterImage.ID, businessType)
-		if err != nil {
-			return err
-		}
-		err = s.attachmentDao.Create(tx, newRecord)
+		// d. 附件和审计日志保存在MySQL中，随筛选图片一起写入待执行操作
+		newRecord.BusinessID = newFilterImage.ID
+		sync.CreateAttachments = []*attachmentModel.Attachment{newRecord}
+		sync.Audit, err = newAuditLog(actor, audit.ActionFilterImageCreate, audit.EntityFilterImage, newFilterImage.ID, nil, map[string]interface{}{
+			"device_type_id": deviceTypeID,
+			"filter_value":   filterValue,
+			"image":          imageFile.Filename,
+		})
 		if err != nil {
 			return err
 		}
-
-		return nil
+		op, err = s.outboxService.Enqueue(tx, outboxModel.OpCatalogSync, outboxModel.DeviceTypeEntity(deviceTypeID), sync)
+		return err
 	})
 	if err != nil {
 		return err
 	}
 
+	// 3. 立即在MySQL中创建附件记录，失败时由后台任务重试
+	s.outboxService.ApplyNow(op)
 	return nil
 }
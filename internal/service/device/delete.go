@@ -1,19 +1,22 @@
 package device
 
 import (
-	"errors"
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
+	"xinde/internal/model/audit"
+	outboxModel "xinde/internal/model/outbox"
 )
 
-func (s *Service) Delete(deviceTypeID uint) error {
+func (s *Service) Delete(actor *audit.Actor, deviceTypeID uint) error {
+	var op *outboxModel.Operation
 	// 删除postgresql里的deviceType和device
 	err := s.dao.DB().Transaction(func(tx *gorm.DB) error {
 		// 首先检查deviceType是否存在
-		_, err := s.dao.GetDeviceTypeByID(tx, deviceTypeID)
+		deviceType, err := s.dao.GetDeviceTypeByID(tx, deviceTypeID)
 		if err != nil {
 			return err
 		}
+		before := map[string]interface{}{"name": deviceType.Name, "group_id": deviceType.GroupID}
 
 		// 删除所有关联的方案
 		err = s.dao.DeleteByDeviceTypeID(tx, deviceTypeID)
@@ -26,32 +29,27 @@ func (s *Service) Delete(deviceTypeID uint) error {
 		if err != nil {
 			return err
 		}
-		return nil
-	})
-	if err != nil {
-		return err
-	}
-
-	// 删除t_attachment表里的excel和主图文件
-	err = s.attachmentDao.DB().Transaction(func(tx *gorm.DB) error {
-		businessTypes := []string{
-			viper.GetString("business_type.device_icon"),
-			viper.GetString("business_type.device_import"),
-		}
 
-		for _, businessType := range businessTypes {
-			err := s.attachmentDao.DeleteAttachmentsByBusinessTypeAndIDs(tx, businessType, []uint{deviceTypeID})
-			if err != nil {
-				if errors.Is(err, gorm.ErrRecordNotFound) {
-					continue
-				}
-				return err
-			}
+		// t_attachment表里的excel和主图文件、可见性规则和审计日志都在MySQL中，随删除一起写入待执行操作
+		auditLog, err := newAuditLog(actor, audit.ActionDeviceTypeDelete, audit.EntityDeviceType, deviceTypeID, before, nil)
+		if err != nil {
+			return err
 		}
-		return nil
+		op, err = s.outboxService.Enqueue(tx, outboxModel.OpCatalogSync, outboxModel.DeviceTypeEntity(deviceTypeID), &outboxModel.CatalogSync{
+			DeleteAttachments: []*outboxModel.AttachmentRef{
+				{BusinessType: viper.GetString("business_type.device_icon"), BusinessIDs: []uint{deviceTypeID}},
+				{BusinessType: viper.GetString("business_type.device_import"), BusinessIDs: []uint{deviceTypeID}},
+			},
+			DeleteVisibilityDeviceTypeID: deviceTypeID,
+			Audit:                        auditLog,
+		})
+		return err
 	})
 	if err != nil {
 		return err
 	}
+
+	// 立即在MySQL中删除，失败时由后台任务重试
+	s.outboxService.ApplyNow(op)
 	return nil
 }
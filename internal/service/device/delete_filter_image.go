@@ -3,13 +3,16 @@ package device
 import (
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
+	"xinde/internal/model/audit"
+	outboxModel "xinde/internal/model/outbox"
 )
 
-func (s *Service) DeleteFilterImage(id uint) error {
+func (s *Service) DeleteFilterImage(actor *audit.Actor, id uint) error {
+	var op *outboxModel.Operation
 	// 1. 在PG事务中删除记录
 	err := s.dao.DB().Transaction(func(tx *gorm.DB) error {
 		// a. 确认记录存在
-		_, err := s.dao.GetFilterImageByID(tx, id)
+		filterImage, err := s.dao.GetFilterImageByID(tx, id)
 		if err != nil {
 			return err
 		}
@@ -19,24 +22,27 @@ func (s *Service) DeleteFilterImage(id uint) error {
 		if err != nil {
 			return err
 		}
-		return nil
-	})
-	if err != nil {
-		return err
-	}
 
-	// 2. 删除关联的附件
-	err = s.attachmentDao.DB().Transaction(func(tx *gorm.DB) error {
+		// c. 附件和审计日志保存在MySQL中，随删除一起写入待执行操作
 		businessType := viper.GetString("business_type.filter_image")
-		err := s.attachmentDao.DeleteAttachmentsByBusinessTypeAndIDs(tx, businessType, []uint{id})
+		sync := &outboxModel.CatalogSync{
+			DeleteAttachments: []*outboxModel.AttachmentRef{{BusinessType: businessType, BusinessIDs: []uint{id}}},
+		}
+		sync.Audit, err = newAuditLog(actor, audit.ActionFilterImageDelete, audit.EntityFilterImage, id, map[string]interface{}{
+			"device_type_id": filterImage.DeviceTypeID,
+			"filter_value":   filterImage.FilterValue,
+		}, nil)
 		if err != nil {
 			return err
 		}
-		return nil
+		op, err = s.outboxService.Enqueue(tx, outboxModel.OpCatalogSync, outboxModel.DeviceTypeEntity(filterImage.DeviceTypeID), sync)
+		return err
 	})
-
 	if err != nil {
 		return err
 	}
+
+	// 2. 立即在MySQL中删除关联的附件，失败时由后台任务重试
+	s.outboxService.ApplyNow(op)
 	return nil
 }
@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
+	"time"
 	dto "xinde/internal/dto/device"
+	groupModel "xinde/internal/model/group"
 	"xinde/pkg/stderr"
 )
 
-func (s *Service) GroupDeviceList(groupID uint) ([]*dto.GroupDeviceListData, error) {
+// GroupDeviceList 返回分组下 viewer 可见的设备类型，分组对 viewer 隐藏时与分组不存在一样返回 ErrorGroupNotFound
+func (s *Service) GroupDeviceList(groupID uint, viewer *groupModel.Viewer) ([]*dto.GroupDeviceListData, error) {
 	// 1. 判断是否存在该Group
 	group, err := s.groupDao.GetGroupByID(s.groupDao.DB(), groupID)
 	if err != nil {
@@ -19,12 +22,30 @@ func (s *Service) GroupDeviceList(groupID uint) ([]*dto.GroupDeviceListData, err
 			return nil, err
 		}
 	}
+	visibility, err := s.groupDao.LoadVisibility(s.groupDao.DB(), viewer)
+	if err != nil {
+		return nil, err
+	}
+	if !visibility.GroupVisible(groupID) {
+		return nil, fmt.Errorf(stderr.ErrorGroupNotFound)
+	}
 
 	// 2. 根据GroupID获取DeviceType列表
-	deviceTypeList, err := s.dao.GetDeviceTypesByGroupID(s.dao.DB(), groupID)
+	allDeviceTypes, err := s.dao.GetDeviceTypesByGroupID(s.dao.DB(), groupID)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, err
 	}
+	// 前台只能看到已发布的设备类型，管理端（viewer.All）可以看到全部状态
+	now := time.Now()
+	deviceTypeList := allDeviceTypes[:0]
+	for _, deviceType := range allDeviceTypes {
+		if !viewer.All && !deviceType.IsPublished(now) {
+			continue
+		}
+		if visibility.DeviceTypeVisible(deviceType.ID, deviceType.GroupID) {
+			deviceTypeList = append(deviceTypeList, deviceType)
+		}
+	}
 
 	// 3. 根据DeviceTypeID往attachment表里获取图片
 	imageMap := make(map[uint]string)
@@ -53,6 +74,7 @@ func (s *Service) GroupDeviceList(groupID uint) ([]*dto.GroupDeviceListData, err
 			DeviceName: deviceType.Name,
 			ImageURL:   imageMap[deviceType.ID],
 			GroupName:  group.Name,
+			Status:     deviceType.EffectiveStatus(now),
 		})
 	}
 
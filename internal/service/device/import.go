@@ -8,12 +8,17 @@ import (
 	"gorm.io/gorm"
 	"mime/multipart"
 	"strings"
+	"time"
 	"xinde/internal/dao/attachment"
+	"xinde/internal/dao/audit"
 	"xinde/internal/dao/device"
 	"xinde/internal/dao/group"
 	dto "xinde/internal/dto/device"
 	model "xinde/internal/model/attachment"
+	auditModel "xinde/internal/model/audit"
 	deviceModel "xinde/internal/model/device"
+	outboxModel "xinde/internal/model/outbox"
+	"xinde/internal/service/outbox"
 	"xinde/pkg/jwt"
 	"xinde/pkg/util"
 )
@@ -23,6 +28,9 @@ type Service struct {
 	j             *jwt.JWTService
 	attachmentDao *attachment.Dao
 	groupDao      *group.Dao
+	auditDao      *audit.Dao
+	// 附件、可见性规则和审计日志保存在MySQL中，通过待执行操作在目录修改提交后完成
+	outboxService *outbox.Service
 }
 
 func NewDeviceService() (*Service, error) {
@@ -38,16 +46,26 @@ func NewDeviceService() (*Service, error) {
 	if err != nil {
 		return nil, fmt.Errorf("创建Dao实例失败: " + err.Error())
 	}
+	auditDao, err := audit.NewAuditDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: " + err.Error())
+	}
+	outboxService, err := outbox.NewOutboxService()
+	if err != nil {
+		return nil, fmt.Errorf("创建service实例失败: " + err.Error())
+	}
 	j := jwt.NewJWTService()
 	return &Service{
 		dao:           dao,
 		j:             j,
 		attachmentDao: attachmentDao,
 		groupDao:      groupDao,
+		auditDao:      auditDao,
+		outboxService: outboxService,
 	}, nil
 }
 
-func (s *Service) ImportFromExcel(adminID, groupID uint, deviceTypeName string, file, image *multipart.FileHeader) error {
+func (s *Service) ImportFromExcel(actor *auditModel.Actor, groupID uint, deviceTypeName, status string, file, image *multipart.FileHeader) error {
 
 	// 1. 解析Excel。这一步只做纯粹的解析，不涉及任何数据库或API调用。
 	parsedData, err := s.parseFromExcel(file)
@@ -57,16 +75,40 @@ func (s *Service) ImportFromExcel(adminID, groupID uint, deviceTypeName string,
 	if len(parsedData) == 0 {
 		return fmt.Errorf("excel没有解析到有效内容")
 	}
-	var deviceType *deviceModel.DeviceType
 
-	// --- 2. 开启 PostgresSQL 事务，执行替换操作 ---
+	// 2. 先把上传的文件保存到磁盘，附件记录等设备类型确定后随待执行操作写入。
+	// 事务失败时这些文件成为孤儿文件，可以通过扫描异常附件清理
+	fileBusinessType := viper.GetString("business_type.device_import")
+	iconBusinessType := viper.GetString("business_type.device_icon")
+	newFileRecord, err := s.getNewAttachmentRecord(file, actor.UID, 0, fileBusinessType)
+	if err != nil {
+		return err
+	}
+	newImageRecord, err := s.getNewAttachmentRecord(image, actor.UID, 0, iconBusinessType)
+	if err != nil {
+		return err
+	}
+
+	var op *outboxModel.Operation
+	// --- 3. 开启 PostgresSQL 事务，执行替换操作 ---
 	err = s.dao.DB().Transaction(func(tx *gorm.DB) error {
 
-		// a. 查找或创建 DeviceType
-		deviceType, err = s.dao.FindOrCreateDeviceType(tx, deviceTypeName, groupID)
+		// a. 查找或创建 DeviceType。新建时未指定状态则直接发布，保持和以前一样的行为
+		createStatus := status
+		if createStatus == "" {
+			createStatus = deviceModel.DeviceTypePublished
+		}
+		deviceType, err := s.dao.FindOrCreateDeviceType(tx, deviceTypeName, groupID, createStatus)
 		if err != nil {
 			return err
 		}
+		if updateData := importStatusUpdate(deviceType, status); updateData != nil {
+			err = s.dao.UpdateDeviceType(tx, deviceType.ID, updateData)
+			if err != nil {
+				return err
+			}
+			deviceType.Status, deviceType.PublishAt, deviceType.UnpublishAt = status, nil, nil
+		}
 
 		// b. 删除与此 DeviceType 关联的所有旧方案 (Device)
 		err = s.dao.DeleteByDeviceTypeID(tx, deviceType.ID)
@@ -97,57 +139,49 @@ func (s *Service) ImportFromExcel(adminID, groupID uint, deviceTypeName string,
 				return err
 			}
 		}
-		return nil
-	})
-	if err != nil {
-		return fmt.Errorf("导入设备提交事务失败: " + err.Error())
-	}
-
-	// --- 3. 【独立】处理主图附件 ---
-	err = s.attachmentDao.DB().Transaction(func(tx *gorm.DB) error {
-
-		// a. 获取business_type
-		fileBusinessType := viper.GetString("business_type.device_import")
-		iconBusinessType := viper.GetString("business_type.device_icon")
-
-		// b. 查找并删除旧的数据库记录
-		err := s.attachmentDao.DeleteAttachmentsByBusinessTypeAndIDs(tx, fileBusinessType, []uint{deviceType.ID})
-		if err != nil {
-			return err
-		}
-		err = s.attachmentDao.DeleteAttachmentsByBusinessTypeAndIDs(tx, iconBusinessType, []uint{deviceType.ID})
-		if err != nil {
-			return err
-		}
-
-		// c. 保存新上传的文件
-		newFileRecord, err := s.getNewAttachmentRecord(file, adminID, deviceType.ID, fileBusinessType)
-		if err != nil {
-			return err
-		}
-		newImageRecord, err := s.getNewAttachmentRecord(image, adminID, deviceType.ID, iconBusinessType)
-		if err != nil {
-			return err
-		}
 
-		// d. 往附件表中写入记录
-		err = s.attachmentDao.Create(tx, newFileRecord)
-		if err != nil {
-			return err
-		}
-		err = s.attachmentDao.Create(tx, newImageRecord)
+		// e. 替换附件并记录审计日志，这些数据保存在MySQL中，随方案一起写入待执行操作
+		newFileRecord.BusinessID = deviceType.ID
+		newImageRecord.BusinessID = deviceType.ID
+		auditLog, err := newAuditLog(actor, auditModel.ActionDeviceTypeImport, auditModel.EntityDeviceType, deviceType.ID, nil, map[string]interface{}{
+			"name":      deviceTypeName,
+			"group_id":  groupID,
+			"status":    deviceType.Status,
+			"filename":  file.Filename,
+			"solutions": len(parsedData),
+		})
 		if err != nil {
 			return err
 		}
-		return nil
+		op, err = s.outboxService.Enqueue(tx, outboxModel.OpCatalogSync, outboxModel.DeviceTypeEntity(deviceType.ID), &outboxModel.CatalogSync{
+			DeleteAttachments: []*outboxModel.AttachmentRef{
+				{BusinessType: fileBusinessType, BusinessIDs: []uint{deviceType.ID}},
+				{BusinessType: iconBusinessType, BusinessIDs: []uint{deviceType.ID}},
+			},
+			CreateAttachments: []*model.Attachment{newFileRecord, newImageRecord},
+			Audit:             auditLog,
+		})
+		return err
 	})
 	if err != nil {
-		return fmt.Errorf("导入设备处理附件提交事务失败: " + err.Error())
+		return fmt.Errorf("导入设备提交事务失败: " + err.Error())
 	}
 
+	// 4. 立即在MySQL中替换附件，失败时由后台任务重试
+	s.outboxService.ApplyNow(op)
 	return nil
 }
 
+// newAuditLog 生成随待执行操作写入的审计日志，时间记为修改发生的时间而不是写入MySQL的时间
+func newAuditLog(actor *auditModel.Actor, action, entityType string, entityID uint, before, after interface{}) (*auditModel.AuditLog, error) {
+	log, err := auditModel.NewAuditLog(actor, action, entityType, entityID, before, after)
+	if err != nil {
+		return nil, err
+	}
+	log.CreatedAt = time.Now()
+	return log, nil
+}
+
 // excelSchema 用于存储从 Header 行解析出的列结构信息
 type excelSchema struct {
 	// 简单筛选条件: 列索引 -> 条件名称
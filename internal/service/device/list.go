@@ -5,6 +5,7 @@ import (
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
 	"strings"
+	"time"
 	dto "xinde/internal/dto/device"
 	_ "xinde/internal/model/device"
 	"xinde/internal/model/group"
@@ -54,16 +55,21 @@ func (s *Service) List(page, pageSize int) (*dto.ListPageData, error) {
 	}
 
 	// 5. 组装数据
+	now := time.Now()
 	var listData []*dto.ListData
 	for _, item := range rawList {
 		listData = append(listData, &dto.ListData{
-			ID:            item.ID,
-			GroupName:     s.buildGroupPath(item.GroupID, groupMap, pathCache),
-			Name:          item.Name,
-			ImageURL:      imageUrlMap[item.ID],
-			SolutionCount: item.SolutionCount,
-			CreatedAt:     util.FormatTimeToStandardString(item.CreatedAt),
-			UpdatedAt:     util.FormatTimeToStandardString(item.UpdatedAt),
+			ID:              item.ID,
+			GroupName:       s.buildGroupPath(item.GroupID, groupMap, pathCache),
+			Name:            item.Name,
+			ImageURL:        imageUrlMap[item.ID],
+			SolutionCount:   item.SolutionCount,
+			Status:          item.Status,
+			EffectiveStatus: item.EffectiveStatus(now),
+			PublishAt:       util.FormatNullableTimeToStandardString(item.PublishAt),
+			UnpublishAt:     util.FormatNullableTimeToStandardString(item.UnpublishAt),
+			CreatedAt:       util.FormatTimeToStandardString(item.CreatedAt),
+			UpdatedAt:       util.FormatTimeToStandardString(item.UpdatedAt),
 		})
 	}
 
@@ -109,43 +115,17 @@ func (s *Service) getCurrentPageAndPages(tx *gorm.DB, page, pageSize int) (int,
 	return currentPage, pages, nil
 }
 
-// buildGroupPath 是一个带缓存的辅助函数，用于在内存中构建分组的完整层级路径
+// buildGroupPath 是一个带缓存的辅助函数，用于在内存中构建分组的完整层级路径，如 "一级分组-二级分组"
 func (s *Service) buildGroupPath(groupID uint, groupMap map[uint]*group.Group, pathCache map[uint]string) string {
 	// 如果缓存中已有，直接返回
 	if path, ok := pathCache[groupID]; ok {
 		return path
 	}
 
-	// 使用迭代（循环）代替递归，更安全高效
 	var pathParts []string
-	currentID := groupID
-
-	for {
-		g, ok := groupMap[currentID]
-		if !ok {
-			// 如果在 map 中找不到，说明数据有问题，中断循环
-			break
-		}
-
-		// 规则：root 分组 (ID=1) 的名称不加入路径
-		if g.ID != 1 {
-			pathParts = append(pathParts, g.Name)
-		}
-
-		// 如果到达 root (parent_id=0) 或顶级分组 (parent_id=1)，则停止回溯
-		if g.ParentID == 0 || g.ParentID == 1 {
-			break
-		}
-
-		currentID = g.ParentID
+	for _, g := range group.GroupPath(groupID, groupMap) {
+		pathParts = append(pathParts, g.Name)
 	}
-
-	// 反转路径片段
-	// pathParts 现在是 [GroupName, level2, level1]，需要反转
-	for i, j := 0, len(pathParts)-1; i < j; i, j = i+1, j-1 {
-		pathParts[i], pathParts[j] = pathParts[j], pathParts[i]
-	}
-
 	fullPath := strings.Join(pathParts, "-")
 
 	// 存入缓存
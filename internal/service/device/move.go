@@ -0,0 +1,91 @@
+package device
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"xinde/internal/model/audit"
+	outboxModel "xinde/internal/model/outbox"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+// Move 把一批设备类型按给定的顺序移动到 groupID 分组的第 position 个位置，也可以用于调整分组内的顺序。
+// 移动后重新为目标分组中的所有设备类型编号，保证顺序连续
+func (s *Service) Move(actor *audit.Actor, deviceTypeIDs []uint, groupID uint, position *int) error {
+	// 检查groupID对应的分组是否存在，分组保存在MySQL中
+	_, err := s.groupDao.GetGroupByID(s.groupDao.DB(), groupID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf(stderr.ErrorGroupNotFound)
+		}
+		return err
+	}
+
+	// 去掉重复的ID，保留第一次出现的顺序
+	seen := make(map[uint]bool, len(deviceTypeIDs))
+	ids := make([]uint, 0, len(deviceTypeIDs))
+	for _, id := range deviceTypeIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	var ops []*outboxModel.Operation
+	err = s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		newPositions := make(map[uint]int, len(ids))
+		moving, err := s.dao.GetDeviceTypesByIDs(tx, ids)
+		if err != nil {
+			return err
+		}
+		if len(moving) != len(ids) {
+			return fmt.Errorf(stderr.ErrorDeviceNotFound)
+		}
+
+		siblings, err := s.dao.GetDeviceTypesByGroupID(tx, groupID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		siblingIDs := make([]uint, 0, len(siblings))
+		for _, dt := range siblings {
+			siblingIDs = append(siblingIDs, dt.ID)
+		}
+
+		// 按新的顺序重新编号，被移动的设备类型同时更新分组
+		for i, id := range util.InsertIDsAt(siblingIDs, position, ids...) {
+			updateMap := map[string]interface{}{"sort_order": i + 1}
+			if seen[id] {
+				updateMap["group_id"] = groupID
+				newPositions[id] = i
+			}
+			if err := s.dao.UpdateDeviceType(tx, id, updateMap); err != nil {
+				return err
+			}
+		}
+
+		// 审计日志保存在MySQL中，每个被移动的设备类型随移动写入一条待执行操作
+		for _, dt := range moving {
+			auditLog, err := newAuditLog(actor, audit.ActionDeviceTypeMove, audit.EntityDeviceType, dt.ID,
+				map[string]interface{}{"group_id": dt.GroupID}, map[string]interface{}{"group_id": groupID, "position": newPositions[dt.ID]})
+			if err != nil {
+				return err
+			}
+			op, err := s.outboxService.Enqueue(tx, outboxModel.OpCatalogSync, outboxModel.DeviceTypeEntity(dt.ID), &outboxModel.CatalogSync{Audit: auditLog})
+			if err != nil {
+				return err
+			}
+			ops = append(ops, op)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// 立即写入审计日志，失败时由后台任务重试
+	for _, op := range ops {
+		s.outboxService.ApplyNow(op)
+	}
+	return nil
+}
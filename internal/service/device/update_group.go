@@ -4,13 +4,24 @@ import (
 	"errors"
 	"fmt"
 	"gorm.io/gorm"
+	"xinde/internal/model/audit"
+	outboxModel "xinde/internal/model/outbox"
 	"xinde/pkg/stderr"
 )
 
-func (s *Service) UpdateGroup(deviceTypeID, groupID uint) error {
-	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+func (s *Service) UpdateGroup(actor *audit.Actor, deviceTypeID, groupID uint) error {
+	var op *outboxModel.Operation
+	err := s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		deviceType, err := s.dao.GetDeviceTypeByID(tx, deviceTypeID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf(stderr.ErrorDeviceNotFound)
+			}
+			return err
+		}
+
 		// 检查groupID对应的分组是否存在
-		_, err := s.groupDao.GetGroupByID(s.groupDao.DB(), groupID)
+		_, err = s.groupDao.GetGroupByID(s.groupDao.DB(), groupID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return fmt.Errorf(stderr.ErrorGroupNotFound)
@@ -19,14 +30,34 @@ func (s *Service) UpdateGroup(deviceTypeID, groupID uint) error {
 			}
 		}
 
-		// 更新groupID
+		// 更新groupID，排在新分组的最后
+		maxSortOrder, err := s.dao.GetMaxDeviceTypeSortOrder(tx, groupID)
+		if err != nil {
+			return err
+		}
 		updateMap := map[string]interface{}{
-			"group_id": groupID,
+			"group_id":   groupID,
+			"sort_order": maxSortOrder + 1,
 		}
 		err = s.dao.UpdateDeviceType(tx, deviceTypeID, updateMap)
 		if err != nil {
 			return err
 		}
-		return nil
+
+		// 审计日志保存在MySQL中，随修改一起写入待执行操作
+		auditLog, err := newAuditLog(actor, audit.ActionDeviceTypeUpdateGroup, audit.EntityDeviceType, deviceTypeID,
+			map[string]interface{}{"group_id": deviceType.GroupID}, map[string]interface{}{"group_id": groupID})
+		if err != nil {
+			return err
+		}
+		op, err = s.outboxService.Enqueue(tx, outboxModel.OpCatalogSync, outboxModel.DeviceTypeEntity(deviceTypeID), &outboxModel.CatalogSync{Audit: auditLog})
+		return err
 	})
+	if err != nil {
+		return err
+	}
+
+	// 立即写入审计日志，失败时由后台任务重试
+	s.outboxService.ApplyNow(op)
+	return nil
 }
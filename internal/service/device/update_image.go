@@ -2,10 +2,12 @@ package device
 
 import (
 	"github.com/spf13/viper"
+	"gorm.io/gorm"
 	"mime/multipart"
+	"xinde/internal/model/audit"
 )
 
-func (s *Service) UpdateImage(deviceTypeID, adminUID uint, imageFile *multipart.FileHeader) error {
+func (s *Service) UpdateImage(actor *audit.Actor, deviceTypeID uint, imageFile *multipart.FileHeader) error {
 
 	// 首先确认deviceType是否存在
 	_, err := s.dao.GetDeviceTypeByID(s.dao.DB(), deviceTypeID)
@@ -13,24 +15,29 @@ func (s *Service) UpdateImage(deviceTypeID, adminUID uint, imageFile *multipart.
 		return err
 	}
 
-	// 根据business_type和business_id查找并删除的旧图片
+	// 保存新上传的文件
 	businessType := viper.GetString("business_type.device_icon")
-	err = s.attachmentDao.DeleteAttachmentsByBusinessTypeAndIDs(s.attachmentDao.DB(), businessType, []uint{deviceTypeID})
+	newRecord, err := s.getNewAttachmentRecord(imageFile, actor.UID, deviceTypeID, businessType)
 	if err != nil {
 		return err
 	}
 
-	// 保存新上传的文件
-	newRecord, err := s.getNewAttachmentRecord(imageFile, adminUID, deviceTypeID, businessType)
-	if err != nil {
-		return err
-	}
+	// 替换附件记录和审计日志都在MySQL中，放在同一个事务里，避免删除旧图片后新图片或审计日志写入失败
+	return s.attachmentDao.DB().Transaction(func(tx *gorm.DB) error {
+		// 根据business_type和business_id查找并删除的旧图片
+		err := s.attachmentDao.DeleteAttachmentsByBusinessTypeAndIDs(tx, businessType, []uint{deviceTypeID})
+		if err != nil {
+			return err
+		}
 
-	// 在t_attachment表中创建记录
-	err = s.attachmentDao.Create(s.attachmentDao.DB(), newRecord)
-	if err != nil {
-		return err
-	}
+		// 在t_attachment表中创建记录
+		err = s.attachmentDao.Create(tx, newRecord)
+		if err != nil {
+			return err
+		}
 
-	return nil
+		return s.auditDao.Record(tx, actor, audit.ActionDeviceTypeUpdateImage, audit.EntityDeviceType, deviceTypeID, nil, map[string]interface{}{
+			"image": imageFile.Filename,
+		})
+	})
 }
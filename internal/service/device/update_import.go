@@ -7,11 +7,14 @@ import (
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
 	"mime/multipart"
+	attachmentModel "xinde/internal/model/attachment"
+	"xinde/internal/model/audit"
 	deviceModel "xinde/internal/model/device"
+	outboxModel "xinde/internal/model/outbox"
 	"xinde/pkg/stderr"
 )
 
-func (s *Service) UpdateImport(deviceTypeID, adminID uint, file *multipart.FileHeader) error {
+func (s *Service) UpdateImport(actor *audit.Actor, deviceTypeID uint, status string, file *multipart.FileHeader) error {
 
 	// 1. 解析Excel。这一步只做纯粹的解析，不涉及任何数据库或API调用。
 	parsedData, err := s.parseFromExcel(file)
@@ -22,11 +25,24 @@ func (s *Service) UpdateImport(deviceTypeID, adminID uint, file *multipart.FileH
 		return fmt.Errorf("excel没有解析到有效内容")
 	}
 
-	// 2. 开启Postgres事务
+	auditData := map[string]interface{}{
+		"filename":  file.Filename,
+		"solutions": len(parsedData),
+	}
+
+	// 2. 先把上传的Excel保存到磁盘，附件记录随待执行操作写入
+	businessType := viper.GetString("business_type.device_import")
+	newFileRecord, err := s.getNewAttachmentRecord(file, actor.UID, deviceTypeID, businessType)
+	if err != nil {
+		return err
+	}
+
+	var op *outboxModel.Operation
+	// 3. 开启Postgres事务
 	err = s.dao.DB().Transaction(func(tx *gorm.DB) error {
 
 		// a. 确认要更新的DeviceType是否存在
-		_, err := s.dao.GetDeviceTypeByID(tx, deviceTypeID)
+		deviceType, err := s.dao.GetDeviceTypeByID(tx, deviceTypeID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return fmt.Errorf(stderr.ErrorDeviceNotFound)
@@ -34,6 +50,13 @@ func (s *Service) UpdateImport(deviceTypeID, adminID uint, file *multipart.FileH
 				return err
 			}
 		}
+		if updateData := importStatusUpdate(deviceType, status); updateData != nil {
+			err = s.dao.UpdateDeviceType(tx, deviceTypeID, updateData)
+			if err != nil {
+				return err
+			}
+			auditData["status"] = status
+		}
 
 		// b. 删除与此DeviceTypeID关联的所有旧方案
 		err = s.dao.DeleteByDeviceTypeID(tx, deviceTypeID)
@@ -65,7 +88,17 @@ func (s *Service) UpdateImport(deviceTypeID, adminID uint, file *multipart.FileH
 			}
 		}
 
-		return nil
+		// e. 替换Excel附件并记录审计日志，随方案一起写入待执行操作
+		auditLog, err := newAuditLog(actor, audit.ActionDeviceTypeReimport, audit.EntityDeviceType, deviceTypeID, nil, auditData)
+		if err != nil {
+			return err
+		}
+		op, err = s.outboxService.Enqueue(tx, outboxModel.OpCatalogSync, outboxModel.DeviceTypeEntity(deviceTypeID), &outboxModel.CatalogSync{
+			DeleteAttachments: []*outboxModel.AttachmentRef{{BusinessType: businessType, BusinessIDs: []uint{deviceTypeID}}},
+			CreateAttachments: []*attachmentModel.Attachment{newFileRecord},
+			Audit:             auditLog,
+		})
+		return err
 	})
 	if err != nil {
 		if err.Error() == stderr.ErrorDeviceNotFound {
@@ -74,34 +107,7 @@ func (s *Service) UpdateImport(deviceTypeID, adminID uint, file *multipart.FileH
 		return fmt.Errorf("导入设备提交事务失败: " + err.Error())
 	}
 
-	// 3. 处理上传的Excel附件
-	err = s.attachmentDao.DB().Transaction(func(tx *gorm.DB) error {
-
-		// a. 获取businessType
-		businessType := viper.GetString("business_type.device_import")
-
-		// b. 查找并删除旧的数据库记录
-		err := s.attachmentDao.DeleteAttachmentsByBusinessTypeAndIDs(tx, businessType, []uint{deviceTypeID})
-		if err != nil {
-			return err
-		}
-
-		// c. 保存新上传的文件
-		newFileRecord, err := s.getNewAttachmentRecord(file, adminID, deviceTypeID, businessType)
-		if err != nil {
-			return err
-		}
-
-		// d. 往附件表中写入记录
-		err = s.attachmentDao.Create(tx, newFileRecord)
-		if err != nil {
-			return err
-		}
-		return nil
-	})
-	if err != nil {
-		return fmt.Errorf("导入设备提交附件处理事务失败: " + err.Error())
-	}
-
+	// 4. 立即在MySQL中替换附件，失败时由后台任务重试
+	s.outboxService.ApplyNow(op)
 	return nil
 }
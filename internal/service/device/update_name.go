@@ -1,12 +1,46 @@
 package device
 
-func (s *Service) UpdateName(deviceTypeID uint, name string) error {
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"xinde/internal/model/audit"
+	outboxModel "xinde/internal/model/outbox"
+	"xinde/pkg/stderr"
+)
+
+func (s *Service) UpdateName(actor *audit.Actor, deviceTypeID uint, name string) error {
+	deviceType, err := s.dao.GetDeviceTypeByID(s.dao.DB(), deviceTypeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf(stderr.ErrorDeviceNotFound)
+		}
+		return err
+	}
+
 	updateData := map[string]interface{}{
 		"name": name,
 	}
-	err := s.dao.UpdateDeviceType(s.dao.DB(), deviceTypeID, updateData)
+	var op *outboxModel.Operation
+	err = s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		if err := s.dao.UpdateDeviceType(tx, deviceTypeID, updateData); err != nil {
+			return err
+		}
+
+		// 审计日志保存在MySQL中，随修改一起写入待执行操作
+		auditLog, err := newAuditLog(actor, audit.ActionDeviceTypeUpdateName, audit.EntityDeviceType, deviceTypeID,
+			map[string]interface{}{"name": deviceType.Name}, updateData)
+		if err != nil {
+			return err
+		}
+		op, err = s.outboxService.Enqueue(tx, outboxModel.OpCatalogSync, outboxModel.DeviceTypeEntity(deviceTypeID), &outboxModel.CatalogSync{Audit: auditLog})
+		return err
+	})
 	if err != nil {
 		return err
 	}
+
+	// 立即写入审计日志，失败时由后台任务重试
+	s.outboxService.ApplyNow(op)
 	return nil
 }
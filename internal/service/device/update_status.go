@@ -0,0 +1,105 @@
+package device
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"time"
+	dto "xinde/internal/dto/device"
+	"xinde/internal/model/audit"
+	deviceModel "xinde/internal/model/device"
+	outboxModel "xinde/internal/model/outbox"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+func (s *Service) UpdateStatus(actor *audit.Actor, deviceTypeID uint, req *dto.UpdateStatusReq) error {
+	publishAt, err := parseStatusTime(req.PublishAt)
+	if err != nil {
+		return err
+	}
+	unpublishAt, err := parseStatusTime(req.UnpublishAt)
+	if err != nil {
+		return err
+	}
+	// 直接发布时计划发布时间已没有意义
+	if req.Status == deviceModel.DeviceTypePublished {
+		publishAt = nil
+	}
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return fmt.Errorf(stderr.ErrorDeviceStatusWindowWrong)
+	}
+
+	deviceType, err := s.dao.GetDeviceTypeByID(s.dao.DB(), deviceTypeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf(stderr.ErrorDeviceNotFound)
+		}
+		return err
+	}
+
+	updateData := map[string]interface{}{
+		"status":       req.Status,
+		"publish_at":   publishAt,
+		"unpublish_at": unpublishAt,
+	}
+	var op *outboxModel.Operation
+	err = s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		if err := s.dao.UpdateDeviceType(tx, deviceTypeID, updateData); err != nil {
+			return err
+		}
+
+		// 审计日志保存在MySQL中，随修改一起写入待执行操作
+		auditLog, err := newAuditLog(actor, audit.ActionDeviceTypeUpdateStatus, audit.EntityDeviceType, deviceTypeID,
+			statusSnapshot(deviceType.Status, deviceType.PublishAt, deviceType.UnpublishAt),
+			statusSnapshot(req.Status, publishAt, unpublishAt))
+		if err != nil {
+			return err
+		}
+		op, err = s.outboxService.Enqueue(tx, outboxModel.OpCatalogSync, outboxModel.DeviceTypeEntity(deviceTypeID), &outboxModel.CatalogSync{Audit: auditLog})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// 立即写入审计日志，失败时由后台任务重试
+	s.outboxService.ApplyNow(op)
+	return nil
+}
+
+func statusSnapshot(status string, publishAt, unpublishAt *time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"status":       status,
+		"publish_at":   util.FormatNullableTimeToStandardString(publishAt),
+		"unpublish_at": util.FormatNullableTimeToStandardString(unpublishAt),
+	}
+}
+
+func parseStatusTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{util.StandardDateTimeFormat, util.StandardDateFormat} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf(stderr.ErrorDeviceStatusTimeInvalid)
+}
+
+// importStatusUpdate 导入时显式指定了状态，返回需要更新的字段，不需要更新时返回 nil。
+// 与 UpdateStatus 一样同时清除计划的发布/下架时间，否则已经过去的 publish_at 会让设为草稿的设备类型依然对前台可见
+func importStatusUpdate(deviceType *deviceModel.DeviceType, status string) map[string]interface{} {
+	if status == "" {
+		return nil
+	}
+	if deviceType.Status == status && deviceType.PublishAt == nil && deviceType.UnpublishAt == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"status":       status,
+		"publish_at":   nil,
+		"unpublish_at": nil,
+	}
+}
@@ -0,0 +1,78 @@
+package group
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+	dto "xinde/internal/dto/group"
+	model "xinde/internal/model/group"
+	"xinde/pkg/stderr"
+)
+
+// Breadcrumb 返回分组或设备类型从最上层分组开始的完整路径。
+// 节点对 viewer 隐藏（前台还包括未发布的设备类型）时与不存在一样返回 ErrorGroupNotFound 或 ErrorDeviceNotFound
+func (s *Service) Breadcrumb(req *dto.BreadcrumbReq, viewer *model.Viewer) (*dto.BreadcrumbData, error) {
+	if (req.GroupID == 0) == (req.DeviceTypeID == 0) {
+		return nil, fmt.Errorf(stderr.ErrorBreadcrumbNodeInvalid)
+	}
+
+	tx := s.dao.DB()
+	allGroups, err := s.dao.GetAll(tx)
+	if err != nil {
+		return nil, err
+	}
+	visibility, err := s.dao.LoadVisibility(tx, viewer)
+	if err != nil {
+		return nil, err
+	}
+	groupMap := make(map[uint]*model.Group, len(allGroups))
+	for _, g := range allGroups {
+		groupMap[g.ID] = g
+	}
+
+	// 1. 确定要查找的分组，查询设备类型时为设备类型所在的分组
+	var last *dto.BreadcrumbNode
+	groupID := req.GroupID
+	if req.DeviceTypeID != 0 {
+		deviceType, err := s.deviceDao.GetDeviceTypeByID(s.deviceDao.DB(), req.DeviceTypeID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf(stderr.ErrorDeviceNotFound)
+			}
+			return nil, err
+		}
+		if !viewer.All && !deviceType.IsPublished(time.Now()) {
+			return nil, fmt.Errorf(stderr.ErrorDeviceNotFound)
+		}
+		if !visibility.DeviceTypeVisible(deviceType.ID, deviceType.GroupID) {
+			return nil, fmt.Errorf(stderr.ErrorDeviceNotFound)
+		}
+		groupID = deviceType.GroupID
+		last = &dto.BreadcrumbNode{Type: dto.BreadcrumbTypeDeviceType, ID: deviceType.ID, Name: deviceType.Name}
+	} else {
+		if _, ok := groupMap[groupID]; !ok {
+			return nil, fmt.Errorf(stderr.ErrorGroupNotFound)
+		}
+		if !visibility.GroupVisible(groupID) {
+			return nil, fmt.Errorf(stderr.ErrorGroupNotFound)
+		}
+	}
+
+	// 2. 在内存中回溯分组路径，规则与设备类型列表中的分组路径相同（不包含 root 分组）
+	data := &dto.BreadcrumbData{Nodes: []*dto.BreadcrumbNode{}}
+	for _, g := range model.GroupPath(groupID, groupMap) {
+		data.Nodes = append(data.Nodes, &dto.BreadcrumbNode{Type: dto.BreadcrumbTypeGroup, ID: g.ID, Name: g.Name})
+	}
+	if last != nil {
+		data.Nodes = append(data.Nodes, last)
+	}
+
+	names := make([]string, 0, len(data.Nodes))
+	for _, node := range data.Nodes {
+		names = append(names, node.Name)
+	}
+	data.Path = strings.Join(names, "-")
+	return data, nil
+}
@@ -6,8 +6,13 @@ import (
 	"gorm.io/gorm"
 	"mime/multipart"
 	"xinde/internal/dao/attachment"
+	"xinde/internal/dao/audit"
+	"xinde/internal/dao/company"
+	"xinde/internal/dao/device"
 	"xinde/internal/dao/group"
+	"xinde/internal/dao/role"
 	model "xinde/internal/model/attachment"
+	auditModel "xinde/internal/model/audit"
 	"xinde/pkg/jwt"
 	"xinde/pkg/logger"
 	"xinde/pkg/util"
@@ -17,6 +22,11 @@ type Service struct {
 	dao           *group.Dao
 	j             *jwt.JWTService
 	attachmentDao *attachment.Dao
+	auditDao      *audit.Dao
+	// 校验和展示可见性规则的对象（公司、角色）和设备类型，删除分组时移动其下的设备类型
+	companyDao *company.Dao
+	roleDao    *role.Dao
+	deviceDao  *device.Dao
 }
 
 func NewGroupService() (*Service, error) {
@@ -29,14 +39,34 @@ func NewGroupService() (*Service, error) {
 	if err != nil {
 		return nil, fmt.Errorf("创建Dao实例失败: %v", err.Error())
 	}
+	auditDao, err := audit.NewAuditDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: %v", err.Error())
+	}
+	companyDao, err := company.NewCompanyDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: %v", err.Error())
+	}
+	roleDao, err := role.NewRoleDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: %v", err.Error())
+	}
+	deviceDao, err := device.NewDeviceDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: %v", err.Error())
+	}
 	return &Service{
 		dao:           dao,
 		j:             j,
 		attachmentDao: attachmentDao,
+		auditDao:      auditDao,
+		companyDao:    companyDao,
+		roleDao:       roleDao,
+		deviceDao:     deviceDao,
 	}, nil
 }
 
-func (s *Service) Create(name string, parentID uint, adminID uint, iconFile *multipart.FileHeader) error {
+func (s *Service) Create(actor *auditModel.Actor, name string, parentID uint, iconFile *multipart.FileHeader) error {
 	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
 		// 创建分组的数据库记录
 		id, err := s.dao.Create(tx, name, parentID)
@@ -59,7 +89,7 @@ func (s *Service) Create(name string, parentID uint, adminID uint, iconFile *mul
 				FileType:      iconFile.Header.Get("Content-Type"),
 				FileSize:      uint64(iconFile.Size),
 				StorageDriver: "local",
-				UploadedByUID: adminID,
+				UploadedByUID: actor.UID,
 				BusinessType:  util.StringToPointer(businessType),
 				BusinessID:    id,
 			}
@@ -69,6 +99,10 @@ func (s *Service) Create(name string, parentID uint, adminID uint, iconFile *mul
 				logger.Error("记录上传附件信息到数据库失败: " + err.Error())
 			}
 		}
-		return nil
+		return s.auditDao.Record(tx, actor, auditModel.ActionGroupCreate, auditModel.EntityGroup, id, nil, map[string]interface{}{
+			"name":      name,
+			"parent_id": parentID,
+			"has_icon":  iconFile != nil,
+		})
 	})
 }
@@ -5,56 +5,269 @@ import (
 	"fmt"
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
+	dto "xinde/internal/dto/group"
+	"xinde/internal/model/audit"
+	deviceModel "xinde/internal/model/device"
+	model "xinde/internal/model/group"
+	"xinde/pkg/logger"
 	"xinde/pkg/stderr"
 )
 
-const rootGroupID = 1
+// Delete 删除分组及其所有子孙分组。分组下的设备类型按 req.Mode 处理：
+// move 移动到目标分组（默认 root 分组），refuse 在还有设备类型时拒绝删除。
+// 设备类型保存在PostgreSQL中，无法和MySQL放在同一个事务里，所以先移动设备类型，
+// 删除分组失败时再把设备类型移回原来的分组。
+// 被删除的分组上的可见性规则会随分组一起删除，移动后也不再受原来祖先分组上规则的限制，
+// 移动前先转换为设备类型自己的规则，见 inheritedVisibilityRules
+func (s *Service) Delete(actor *audit.Actor, groupID uint, req *dto.DeleteReq) error {
+	// 业务场景：根分组不能被删除
+	if groupID == model.RootGroupID {
+		return fmt.Errorf(stderr.ErrorRootGroupCannotBeDeleted)
+	}
 
-func (s *Service) Delete(groupID uint) error {
-	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
-		// 业务场景：根分组不能被删除
-		if groupID == rootGroupID {
-			return fmt.Errorf(stderr.ErrorRootGroupCannotBeDeleted)
+	// 查找当前分组是否存在
+	g, err := s.dao.GetGroupByID(s.dao.DB(), groupID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf(stderr.ErrorGroupNotFound)
 		}
+		return err
+	}
 
-		// 查找当前分组是否存在
-		_, err := s.dao.GetGroupByID(tx, groupID)
-		if err != nil {
-			if !errors.Is(err, gorm.ErrRecordNotFound) {
-				return fmt.Errorf(stderr.ErrorGroupNotFound)
-			} else {
-				return err
-			}
+	// 获取当前分组及其所有子分组的ID
+	idList, err := s.dao.FindAllDescendantIDs(s.dao.DB(), groupID)
+	if err != nil {
+		return err
+	}
+	idList = append(idList, groupID)
 
+	mode := req.Mode
+	if mode == "" {
+		mode = dto.DeleteModeMove
+	}
+	targetGroupID := req.TargetGroupID
+	if targetGroupID == 0 {
+		targetGroupID = model.RootGroupID
+	}
+	if mode == dto.DeleteModeMove {
+		if err := s.checkDeleteTarget(targetGroupID, idList); err != nil {
+			return err
 		}
+	}
 
-		// 获取当前分组及其所有子分组的ID
-		idList, err := s.dao.FindAllDescendantIDs(tx, groupID)
+	// 1. 在PostgreSQL中处理这些分组下的设备类型
+	var moved []*deviceModel.DeviceType
+	var inherited []*model.VisibilityRule
+	err = s.deviceDao.DB().Transaction(func(tx *gorm.DB) error {
+		moved, err = s.deviceDao.GetDeviceTypesByGroupIDs(tx, idList)
 		if err != nil {
 			return err
 		}
-		idList = append(idList, groupID)
-
-		// 【未来任务】处理关联的设备
-		// ------------------------------------------------------------------
-		// TODO: 当设备模块实现后，在这里添加逻辑：
-		//  - 调用 device service 的方法:
-		//    deviceService.MoveDevicesToGroup(tx, idsToDelete, RootGroupID)
-		//  - 该方法会执行类似 UPDATE t_device SET group_id = 1 WHERE group_id IN (...) 的操作。
-		//  - 因为设备存储可能不是MySQL，所以必须通过service层调用，不能直接操作DAO。
-		// ------------------------------------------------------------------
+		if len(moved) == 0 {
+			return nil
+		}
+		if mode == dto.DeleteModeRefuse {
+			return fmt.Errorf(stderr.ErrorGroupNotEmpty)
+		}
+		inherited, err = s.inheritedVisibilityRules(actor, moved, targetGroupID)
+		if err != nil {
+			return err
+		}
+		return s.moveDeviceTypesToEnd(tx, moved, targetGroupID)
+	})
+	if err != nil {
+		return err
+	}
 
+	// 2. 在MySQL中删除分组
+	err = s.dao.DB().Transaction(func(tx *gorm.DB) error {
 		// 处理这些分组关联的图标
 		businessType := viper.GetString("business_type.group_icon")
 		if err := s.attachmentDao.DeleteAttachmentsByBusinessTypeAndIDs(tx, businessType, idList); err != nil {
 			return fmt.Errorf("批量删除关联的附件记录失败: %w", err)
 		}
 
+		// 设备类型移动后会失去的可见性规则，转换为设备类型自己的规则
+		for _, rule := range inherited {
+			if err := s.dao.CreateVisibilityRule(tx, rule); err != nil {
+				return err
+			}
+			if err := s.auditDao.Record(tx, actor, audit.ActionVisibilityRuleCreate, audit.EntityVisibility, rule.ID, nil, visibilitySnapshot(rule)); err != nil {
+				return err
+			}
+		}
+
+		// 删除这些分组上的可见性规则
+		if err := s.dao.DeleteVisibilityRulesByGroupIDs(tx, idList); err != nil {
+			return err
+		}
+
 		// 删除分组
-		err = s.dao.DeleteGroupsByIDs(tx, idList)
+		err := s.dao.DeleteGroupsByIDs(tx, idList)
 		if err != nil {
 			return err
 		}
+		var after map[string]interface{}
+		if len(moved) > 0 {
+			after = map[string]interface{}{
+				"device_type_ids": deviceTypeIDs(moved),
+				"target_group_id": targetGroupID,
+			}
+		}
+		return s.auditDao.Record(tx, actor, audit.ActionGroupDelete, audit.EntityGroup, groupID, map[string]interface{}{
+			"name":      g.Name,
+			"parent_id": g.ParentID,
+			"group_ids": idList,
+		}, after)
+	})
+	if err != nil {
+		s.restoreDeviceTypeGroups(moved)
+		return err
+	}
+	return nil
+}
+
+// checkDeleteTarget 接收设备类型的分组必须存在，且不能在被删除的分组中
+func (s *Service) checkDeleteTarget(targetGroupID uint, idList []uint) error {
+	for _, id := range idList {
+		if id == targetGroupID {
+			return fmt.Errorf(stderr.ErrorGroupDeleteTargetInvalid)
+		}
+	}
+	if _, err := s.dao.GetGroupByID(s.dao.DB(), targetGroupID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf(stderr.ErrorGroupDeleteTargetNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+// inheritedVisibilityRules 把设备类型移动到 targetGroupID 后会失去的可见性规则转换为设备类型自己的规则，
+// 避免设备类型移动到其他分组后失去原来的限制，对所有用户和API Key可见。
+// 会失去的规则包括被删除的分组上的规则，以及原来的祖先分组中不是目标分组祖先的分组上的规则。
+// 规则逐层生效（同一层满足任意一条，每一层都要满足），只有一层有规则时才能原样转换；
+// 设备类型自身也有规则或会失去多层规则时无法表达，返回 ErrorGroupDeleteRuleConflict
+func (s *Service) inheritedVisibilityRules(actor *audit.Actor, moved []*deviceModel.DeviceType, targetGroupID uint) ([]*model.VisibilityRule, error) {
+	tx := s.dao.DB()
+	allGroups, err := s.dao.GetAll(tx)
+	if err != nil {
+		return nil, err
+	}
+	parents := make(map[uint]uint, len(allGroups))
+	groupIDs := make([]uint, 0, len(allGroups))
+	for _, g := range allGroups {
+		parents[g.ID] = g.ParentID
+		groupIDs = append(groupIDs, g.ID)
+	}
+	groupRules, err := s.dao.FindVisibilityRulesByGroupIDs(tx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(groupRules) == 0 {
+		return nil, nil
+	}
+	rulesByGroup := make(map[uint][]*model.VisibilityRule)
+	for _, r := range groupRules {
+		rulesByGroup[r.GroupID] = append(rulesByGroup[r.GroupID], r)
+	}
+
+	deviceRules, err := s.dao.FindVisibilityRulesByDeviceTypeIDs(tx, deviceTypeIDs(moved))
+	if err != nil {
+		return nil, err
+	}
+	hasOwnRules := make(map[uint]bool)
+	for _, r := range deviceRules {
+		hasOwnRules[r.DeviceTypeID] = true
+	}
+
+	// 目标分组及其祖先分组上的规则移动后依然生效
+	shared := make(map[uint]bool)
+	for id := targetGroupID; id != 0 && !shared[id]; id = parents[id] {
+		shared[id] = true
+	}
+
+	var inherited []*model.VisibilityRule
+	for _, dt := range moved {
+		// 从设备类型所在的分组一直向上找到根，跳过移动后依然生效的分组
+		var levels [][]*model.VisibilityRule
+		seen := make(map[uint]bool)
+		for id := dt.GroupID; id != 0 && !seen[id]; id = parents[id] {
+			seen[id] = true
+			if shared[id] {
+				continue
+			}
+			if rules, ok := rulesByGroup[id]; ok {
+				levels = append(levels, rules)
+			}
+		}
+		if len(levels) == 0 {
+			continue
+		}
+		if len(levels) > 1 || hasOwnRules[dt.ID] {
+			return nil, fmt.Errorf(stderr.ErrorGroupDeleteRuleConflict)
+		}
+		for _, r := range levels[0] {
+			inherited = append(inherited, &model.VisibilityRule{
+				DeviceTypeID: dt.ID,
+				TargetType:   r.TargetType,
+				TargetID:     r.TargetID,
+				CreatedByUID: actor.UID,
+			})
+		}
+	}
+	return inherited, nil
+}
+
+// moveDeviceTypesToEnd 把设备类型按原来的分组和顺序依次排到 groupID 分组的最后，与修改单个设备类型的分组一样不打乱目标分组原有的顺序
+func (s *Service) moveDeviceTypesToEnd(tx *gorm.DB, deviceTypes []*deviceModel.DeviceType, groupID uint) error {
+	maxSortOrder, err := s.deviceDao.GetMaxDeviceTypeSortOrder(tx, groupID)
+	if err != nil {
+		return err
+	}
+	for i, dt := range deviceTypes {
+		err := s.deviceDao.UpdateDeviceType(tx, dt.ID, map[string]interface{}{
+			"group_id":   groupID,
+			"sort_order": maxSortOrder + i + 1,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreDeviceTypeGroups 删除分组失败时的补偿操作，把已经移动的设备类型移回原来的分组和位置。
+// 补偿也失败时只能记录日志，由管理员按日志手动修复
+func (s *Service) restoreDeviceTypeGroups(moved []*deviceModel.DeviceType) {
+	if len(moved) == 0 {
+		return
+	}
+	err := s.deviceDao.DB().Transaction(func(tx *gorm.DB) error {
+		for _, dt := range moved {
+			err := s.deviceDao.UpdateDeviceType(tx, dt.ID, map[string]interface{}{
+				"group_id":   dt.GroupID,
+				"sort_order": dt.SortOrder,
+			})
+			if err != nil {
+				return err
+			}
+		}
 		return nil
 	})
+	if err != nil {
+		byGroup := make(map[uint][]uint)
+		for _, dt := range moved {
+			byGroup[dt.GroupID] = append(byGroup[dt.GroupID], dt.ID)
+		}
+		logger.Error(fmt.Sprintf("删除分组失败后恢复设备类型的分组失败，需要手动修复! 原分组(分组ID: 设备类型ID): %v 错误: %s", byGroup, err.Error()))
+	}
+}
+
+func deviceTypeIDs(deviceTypes []*deviceModel.DeviceType) []uint {
+	ids := make([]uint, 0, len(deviceTypes))
+	for _, dt := range deviceTypes {
+		ids = append(ids, dt.ID)
+	}
+	return ids
 }
@@ -1,19 +1,30 @@
 package group
 
 import (
+	"fmt"
+	"github.com/spf13/viper"
+	"time"
 	dto "xinde/internal/dto/group"
+	model "xinde/internal/model/group"
 )
 
-func (s *Service) GetTree(includedIcon string) ([]*dto.GroupTreeNode, error) {
+// GetTree 返回 viewer 可见的分组树，隐藏的分组及其子孙分组不会出现在树中。
+// req.DeviceTypes 或 req.PruneEmpty 为 true 时还会统计每个分组下 viewer 可见的设备类型数量，前台只统计已发布的设备类型
+func (s *Service) GetTree(req *dto.TreeReq, viewer *model.Viewer) ([]*dto.GroupTreeNode, error) {
 	tx := s.dao.DB()
 	allGroups, err := s.dao.GetAll(tx)
 	if err != nil {
 		return nil, err
 	}
+	visibility, err := s.dao.LoadVisibility(tx, viewer)
+	if err != nil {
+		return nil, err
+	}
+	includedIcon := req.Icon == "true"
 
 	// 如果需要图标，还要获取所有分组对应的图标映射
 	iconMap := make(map[uint]string)
-	if includedIcon == "true" {
+	if includedIcon {
 		iconMap, err = s.GetIconMap()
 		if err != nil {
 			return nil, err
@@ -24,13 +35,16 @@ func (s *Service) GetTree(includedIcon string) ([]*dto.GroupTreeNode, error) {
 	// 第一次遍历先用map做预处理
 	nodeMap := make(map[uint]*dto.GroupTreeNode)
 	for _, group := range allGroups {
+		if !visibility.GroupVisible(group.ID) {
+			continue
+		}
 		node := &dto.GroupTreeNode{
 			ID:       group.ID,
 			Name:     group.Name,
 			ParentID: group.ParentID,
 			Children: []*dto.GroupTreeNode{}, //初始化为空切片，避免json序列化为null
 		}
-		if includedIcon == "true" {
+		if includedIcon {
 			if url, ok := iconMap[group.ID]; ok {
 				node.IconURL = url
 			}
@@ -38,8 +52,13 @@ func (s *Service) GetTree(includedIcon string) ([]*dto.GroupTreeNode, error) {
 		nodeMap[group.ID] = node
 	}
 
+	// 按 allGroups 的顺序（sort_order, id）挂到父节点下，保证每次返回的兄弟分组顺序一致
 	var tree []*dto.GroupTreeNode
-	for _, node := range nodeMap {
+	for _, group := range allGroups {
+		node, ok := nodeMap[group.ID]
+		if !ok {
+			continue
+		}
 		if node.ParentID == 0 {
 			// 根节点root
 			tree = append(tree, node)
@@ -49,5 +68,97 @@ func (s *Service) GetTree(includedIcon string) ([]*dto.GroupTreeNode, error) {
 			}
 		}
 	}
+
+	if req.DeviceTypes || req.PruneEmpty {
+		directCounts, err := s.attachDeviceTypes(nodeMap, visibility, viewer, req.DeviceTypes, includedIcon)
+		if err != nil {
+			return nil, err
+		}
+		for _, root := range tree {
+			countDeviceTypes(root, directCounts, req.PruneEmpty)
+		}
+	}
+	if req.MaxDepth > 0 {
+		for _, root := range tree {
+			limitDepth(root, req.MaxDepth)
+		}
+	}
 	return tree, nil
 }
+
+// attachDeviceTypes 统计每个分组直接包含的 viewer 可见的设备类型数量，attach 为 true 时把设备类型挂到分组节点下
+func (s *Service) attachDeviceTypes(nodeMap map[uint]*dto.GroupTreeNode, visibility *model.Visibility, viewer *model.Viewer, attach, includedIcon bool) (map[uint]int, error) {
+	deviceTypes, err := s.deviceDao.GetAllDeviceTypesWithSolutionCount(s.deviceDao.DB())
+	if err != nil {
+		return nil, err
+	}
+
+	imageMap := make(map[uint]string)
+	if attach && includedIcon {
+		businessType := viper.GetString("business_type.device_icon")
+		images, err := s.attachmentDao.GetAttachmentsByBusinessType(s.attachmentDao.DB(), businessType)
+		if err != nil {
+			return nil, err
+		}
+		baseURL := viper.GetString("server.base_url")
+		uploadUrlPrefix := viper.GetString("attachment.upload_url_prefix")
+		for _, image := range images {
+			imageMap[image.BusinessID] = fmt.Sprintf("%s%s/%s", baseURL, uploadUrlPrefix, image.StoragePath)
+		}
+	}
+
+	// 前台只能看到已发布的设备类型，管理端（viewer.All）可以看到全部状态
+	now := time.Now()
+	directCounts := make(map[uint]int)
+	for _, deviceType := range deviceTypes {
+		node, ok := nodeMap[deviceType.GroupID]
+		if !ok {
+			continue
+		}
+		if !viewer.All && !deviceType.IsPublished(now) {
+			continue
+		}
+		if !visibility.DeviceTypeVisible(deviceType.ID, deviceType.GroupID) {
+			continue
+		}
+		directCounts[deviceType.GroupID]++
+		if attach {
+			node.DeviceTypes = append(node.DeviceTypes, &dto.TreeDeviceTypeNode{
+				ID:            deviceType.ID,
+				Name:          deviceType.Name,
+				ImageURL:      imageMap[deviceType.ID],
+				SolutionCount: deviceType.SolutionCount,
+				Status:        deviceType.EffectiveStatus(now),
+			})
+		}
+	}
+	return directCounts, nil
+}
+
+// countDeviceTypes 递归计算分组及其子孙分组下的设备类型数量，prune 为 true 时去掉数量为 0 的子分组
+func countDeviceTypes(node *dto.GroupTreeNode, directCounts map[uint]int, prune bool) int {
+	count := directCounts[node.ID]
+	children := node.Children[:0]
+	for _, child := range node.Children {
+		childCount := countDeviceTypes(child, directCounts, prune)
+		if prune && childCount == 0 {
+			continue
+		}
+		children = append(children, child)
+		count += childCount
+	}
+	node.Children = children
+	node.DeviceTypeCount = &count
+	return count
+}
+
+// limitDepth 去掉超过 maxDepth 层的子分组，node 为第1层
+func limitDepth(node *dto.GroupTreeNode, maxDepth int) {
+	if maxDepth <= 1 {
+		node.Children = []*dto.GroupTreeNode{}
+		return
+	}
+	for _, child := range node.Children {
+		limitDepth(child, maxDepth-1)
+	}
+}
@@ -0,0 +1,77 @@
+package group
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	auditModel "xinde/internal/model/audit"
+	model "xinde/internal/model/group"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+// Move 把分组移动到 parentID 下的第 position 个位置，parentID 为0时只在原来的兄弟分组中调整顺序。
+// 移动后重新为所有兄弟分组编号，保证顺序连续
+func (s *Service) Move(actor *auditModel.Actor, groupID, parentID uint, position *int) error {
+	if groupID == model.RootGroupID {
+		return fmt.Errorf(stderr.ErrorRootGroupCannotBeMoved)
+	}
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		g, err := s.dao.GetGroupByID(tx, groupID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf(stderr.ErrorGroupNotFound)
+			}
+			return err
+		}
+		if parentID == 0 {
+			parentID = g.ParentID
+		} else if parentID != g.ParentID {
+			if err := s.checkNewParent(tx, groupID, parentID); err != nil {
+				return err
+			}
+		}
+
+		oldSiblings, err := s.dao.GetChildren(tx, g.ParentID)
+		if err != nil {
+			return err
+		}
+		siblings, err := s.dao.GetChildren(tx, parentID)
+		if err != nil {
+			return err
+		}
+		ordered := util.InsertIDsAt(groupIDs(siblings), position, groupID)
+
+		// 按新的顺序重新编号，父级改变时同时更新父级
+		for i, id := range ordered {
+			updateMap := map[string]interface{}{"sort_order": i + 1}
+			if id == groupID {
+				updateMap["parent_id"] = parentID
+			}
+			if err := s.dao.UpdateGroupByID(tx, id, updateMap); err != nil {
+				return err
+			}
+		}
+
+		return s.auditDao.Record(tx, actor, auditModel.ActionGroupMove, auditModel.EntityGroup, groupID,
+			map[string]interface{}{"parent_id": g.ParentID, "position": indexOf(groupIDs(oldSiblings), groupID)},
+			map[string]interface{}{"parent_id": parentID, "position": indexOf(ordered, groupID)})
+	})
+}
+
+func groupIDs(groups []*model.Group) []uint {
+	ids := make([]uint, 0, len(groups))
+	for _, g := range groups {
+		ids = append(ids, g.ID)
+	}
+	return ids
+}
+
+func indexOf(ids []uint, id uint) int {
+	for i, v := range ids {
+		if v == id {
+			return i
+		}
+	}
+	return -1
+}
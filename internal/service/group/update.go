@@ -7,51 +7,44 @@ import (
 	"gorm.io/gorm"
 	"mime/multipart"
 	model "xinde/internal/model/attachment"
+	auditModel "xinde/internal/model/audit"
 	"xinde/pkg/stderr"
 	"xinde/pkg/util"
 )
 
-func (s *Service) Update(adminID uint, groupID uint, parentID uint, name string, icon *multipart.FileHeader) error {
+func (s *Service) Update(actor *auditModel.Actor, groupID uint, parentID uint, name string, icon *multipart.FileHeader) error {
 	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
 		// 检查分组是否存在
 		if parentID != 0 {
-			// 1. 【新增】校验：分组不能成为自己的父级
-			if groupID == parentID {
-				return fmt.Errorf(stderr.ErrorCannotMoveGroupIntoItself)
-			}
-
-			// 2. 【新增】校验：分组不能被移动到自己的子树下
-			// a. 获取当前分组的所有子孙节点ID
-			descendantIDs, err := s.dao.FindAllDescendantIDs(tx, groupID)
-			if err != nil {
+			if err := s.checkNewParent(tx, groupID, parentID); err != nil {
 				return err
 			}
+		}
 
-			// b. 检查新的父级ID是否在子孙列表中
-			for _, descID := range descendantIDs {
-				if parentID == descID {
-					return fmt.Errorf(stderr.ErrorCannotMoveGroupIntoItself)
-				}
-			}
-			_, err = s.dao.GetGroupByID(tx, parentID)
-			if err != nil {
-				if !errors.Is(err, gorm.ErrRecordNotFound) {
-					return err
-				} else {
-					return fmt.Errorf(stderr.ErrorGroupNotFound)
-				}
+		old, err := s.dao.GetGroupByID(tx, groupID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf(stderr.ErrorGroupNotFound)
 			}
+			return err
 		}
+		before := map[string]interface{}{"name": old.Name, "parent_id": old.ParentID}
 
 		// 更新分组
 		updateMap := make(map[string]interface{})
-		if parentID != 0 {
+		if parentID != 0 && parentID != old.ParentID {
+			// 移动到新的父级时排在最后
+			maxSortOrder, err := s.dao.GetMaxSortOrder(tx, parentID)
+			if err != nil {
+				return err
+			}
 			updateMap["parent_id"] = parentID
+			updateMap["sort_order"] = maxSortOrder + 1
 		}
 		if name != "" {
 			updateMap["name"] = name
 		}
-		err := s.dao.UpdateGroupByID(tx, groupID, updateMap)
+		err = s.dao.UpdateGroupByID(tx, groupID, updateMap)
 		if err != nil {
 			return err
 		}
@@ -84,7 +77,7 @@ func (s *Service) Update(adminID uint, groupID uint, parentID uint, name string,
 				FileType:      icon.Header.Get("Content-Type"),
 				FileSize:      uint64(icon.Size),
 				StorageDriver: "local",
-				UploadedByUID: adminID,
+				UploadedByUID: actor.UID,
 				BusinessType:  util.StringToPointer(businessType),
 				BusinessID:    groupID,
 			}
@@ -93,6 +86,47 @@ func (s *Service) Update(adminID uint, groupID uint, parentID uint, name string,
 				return err
 			}
 		}
-		return nil
+
+		after := map[string]interface{}{"name": old.Name, "parent_id": old.ParentID}
+		for k, v := range updateMap {
+			if k != "sort_order" {
+				after[k] = v
+			}
+		}
+		if icon != nil {
+			after["icon"] = icon.Filename
+		}
+		return s.auditDao.Record(tx, actor, auditModel.ActionGroupUpdate, auditModel.EntityGroup, groupID, before, after)
 	})
 }
+
+// checkNewParent 校验 parentID 可以作为 groupID 的新父级：不能是分组自己或其子孙分组，且必须存在
+func (s *Service) checkNewParent(tx *gorm.DB, groupID, parentID uint) error {
+	// 1. 校验：分组不能成为自己的父级
+	if groupID == parentID {
+		return fmt.Errorf(stderr.ErrorCannotMoveGroupIntoItself)
+	}
+
+	// 2. 校验：分组不能被移动到自己的子树下
+	// a. 获取当前分组的所有子孙节点ID
+	descendantIDs, err := s.dao.FindAllDescendantIDs(tx, groupID)
+	if err != nil {
+		return err
+	}
+
+	// b. 检查新的父级ID是否在子孙列表中
+	for _, descID := range descendantIDs {
+		if parentID == descID {
+			return fmt.Errorf(stderr.ErrorCannotMoveGroupIntoItself)
+		}
+	}
+	_, err = s.dao.GetGroupByID(tx, parentID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		} else {
+			return fmt.Errorf(stderr.ErrorGroupNotFound)
+		}
+	}
+	return nil
+}
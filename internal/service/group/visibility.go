@@ -0,0 +1,214 @@
+package group
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	groupDao "xinde/internal/dao/group"
+	dto "xinde/internal/dto/group"
+	auditModel "xinde/internal/model/audit"
+	model "xinde/internal/model/group"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+// GetVisibilityList 按条件返回可见性规则，同时带上分组、设备类型和对象的名称
+func (s *Service) GetVisibilityList(req *dto.VisibilityListReq) ([]*dto.VisibilityData, error) {
+	if req.TargetID != 0 && req.TargetType == "" {
+		return nil, fmt.Errorf(stderr.ErrorVisibilityRuleTargetFilter)
+	}
+
+	tx := s.dao.DB()
+	rules, err := s.dao.FindVisibilityRules(tx, &groupDao.VisibilityRuleFilter{
+		GroupID:      req.GroupID,
+		DeviceTypeID: req.DeviceTypeID,
+		TargetType:   req.TargetType,
+		TargetID:     req.TargetID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 收集需要查询名称的ID，按类型批量查询
+	var groupIDs, deviceTypeIDs, companyIDs, roleIDs []uint
+	for _, r := range rules {
+		if r.GroupID != 0 {
+			groupIDs = append(groupIDs, r.GroupID)
+		}
+		if r.DeviceTypeID != 0 {
+			deviceTypeIDs = append(deviceTypeIDs, r.DeviceTypeID)
+		}
+		switch r.TargetType {
+		case model.VisibilityTargetCompany:
+			companyIDs = append(companyIDs, r.TargetID)
+		case model.VisibilityTargetRole:
+			roleIDs = append(roleIDs, r.TargetID)
+		}
+	}
+
+	groupNames := make(map[uint]string)
+	if len(groupIDs) > 0 {
+		groups, err := s.dao.GetGroupsByIDs(tx, groupIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range groups {
+			groupNames[g.ID] = g.Name
+		}
+	}
+	deviceTypeNames := make(map[uint]string)
+	deviceTypes, err := s.deviceDao.GetDeviceTypesByIDs(s.deviceDao.DB(), deviceTypeIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, dt := range deviceTypes {
+		deviceTypeNames[dt.ID] = dt.Name
+	}
+	companyNames := make(map[uint]string)
+	if len(companyIDs) > 0 {
+		companies, err := s.companyDao.FindCompaniesByIDs(tx, companyIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range companies {
+			companyNames[c.ID] = c.Name
+		}
+	}
+	roleNames := make(map[uint]string)
+	roles, err := s.roleDao.FindRolesByIDs(tx, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range roles {
+		roleNames[r.ID] = r.Name
+	}
+
+	list := make([]*dto.VisibilityData, 0, len(rules))
+	for _, r := range rules {
+		data := &dto.VisibilityData{
+			ID:             r.ID,
+			GroupID:        r.GroupID,
+			GroupName:      groupNames[r.GroupID],
+			DeviceTypeID:   r.DeviceTypeID,
+			DeviceTypeName: deviceTypeNames[r.DeviceTypeID],
+			TargetType:     r.TargetType,
+			TargetID:       r.TargetID,
+			CreatedAt:      util.FormatTimeToStandardString(r.CreatedAt),
+		}
+		if r.TargetType == model.VisibilityTargetCompany {
+			data.TargetName = companyNames[r.TargetID]
+		} else {
+			data.TargetName = roleNames[r.TargetID]
+		}
+		list = append(list, data)
+	}
+	return list, nil
+}
+
+// CreateVisibility 创建可见性规则。分组或设备类型有了第一条规则后，只有规则中的公司和拥有规则中角色的用户可以看到它
+func (s *Service) CreateVisibility(actor *auditModel.Actor, req *dto.CreateVisibilityReq) error {
+	// group_id 和 device_type_id 只保留一个，另一个保持零值，以便唯一索引生效
+	if (req.GroupID == 0) == (req.DeviceTypeID == 0) {
+		return fmt.Errorf(stderr.ErrorVisibilityRuleNodeInvalid)
+	}
+	rule := &model.VisibilityRule{
+		GroupID:      req.GroupID,
+		DeviceTypeID: req.DeviceTypeID,
+		TargetType:   req.TargetType,
+		TargetID:     req.TargetID,
+		CreatedByUID: actor.UID,
+	}
+
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		if err := s.checkVisibilityNode(tx, rule); err != nil {
+			return err
+		}
+		if err := s.checkVisibilityTarget(tx, rule); err != nil {
+			return err
+		}
+
+		isExist, err := s.dao.IsExistVisibilityRule(tx, rule)
+		if err != nil {
+			return err
+		}
+		if isExist {
+			return fmt.Errorf(stderr.ErrorVisibilityRuleConflict)
+		}
+
+		if err := s.dao.CreateVisibilityRule(tx, rule); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, auditModel.ActionVisibilityRuleCreate, auditModel.EntityVisibility, rule.ID, nil, visibilitySnapshot(rule))
+	})
+}
+
+// DeleteVisibility 删除可见性规则，分组或设备类型的最后一条规则删除后对所有用户可见
+func (s *Service) DeleteVisibility(actor *auditModel.Actor, id uint) error {
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		rule, err := s.dao.GetVisibilityRuleByID(tx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf(stderr.ErrorVisibilityRuleNotFound)
+			}
+			return err
+		}
+		if err := s.dao.DeleteVisibilityRuleByID(tx, id); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, auditModel.ActionVisibilityRuleDelete, auditModel.EntityVisibility, id, visibilitySnapshot(rule), nil)
+	})
+}
+
+// checkVisibilityNode 校验规则所在的分组或设备类型存在
+func (s *Service) checkVisibilityNode(tx *gorm.DB, rule *model.VisibilityRule) error {
+	if rule.GroupID != 0 {
+		if _, err := s.dao.GetGroupByID(tx, rule.GroupID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf(stderr.ErrorGroupNotFound)
+			}
+			return err
+		}
+		return nil
+	}
+
+	// 设备类型保存在 PostgreSQL 中
+	if _, err := s.deviceDao.GetDeviceTypeByID(s.deviceDao.DB(), rule.DeviceTypeID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf(stderr.ErrorDeviceNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+// checkVisibilityTarget 校验规则的对象（公司或角色）存在
+func (s *Service) checkVisibilityTarget(tx *gorm.DB, rule *model.VisibilityRule) error {
+	switch rule.TargetType {
+	case model.VisibilityTargetCompany:
+		isExist, err := s.companyDao.IsExistCompanyByID(tx, rule.TargetID)
+		if err != nil {
+			return err
+		}
+		if !isExist {
+			return fmt.Errorf(stderr.ErrorCompanyNotFound)
+		}
+	case model.VisibilityTargetRole:
+		if _, err := s.roleDao.GetRoleByID(tx, rule.TargetID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf(stderr.ErrorRoleNotFound)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// visibilitySnapshot 审计日志中记录的可见性规则
+func visibilitySnapshot(r *model.VisibilityRule) map[string]interface{} {
+	return map[string]interface{}{
+		"group_id":       r.GroupID,
+		"device_type_id": r.DeviceTypeID,
+		"target_type":    r.TargetType,
+		"target_id":      r.TargetID,
+	}
+}
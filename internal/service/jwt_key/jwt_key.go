@@ -0,0 +1,97 @@
+package jwt_key
+
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"time"
+	"xinde/internal/dao/audit"
+	dto "xinde/internal/dto/jwt_key"
+	auditModel "xinde/internal/model/audit"
+	"xinde/pkg/jwt"
+	"xinde/pkg/logger"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+type Service struct {
+	auditDao *audit.Dao
+}
+
+func NewJWTKeyService() (*Service, error) {
+	auditDao, err := audit.NewAuditDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建 DAO 实例失败: %w", err)
+	}
+
+	return &Service{
+		auditDao: auditDao,
+	}, nil
+}
+
+// GetKeyList 返回全部签名密钥的公开信息，按创建时间倒序
+func (s *Service) GetKeyList() ([]*dto.KeyData, error) {
+	keys := jwt.Keys()
+	if err := keys.Reload(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	infos := keys.List()
+	list := make([]*dto.KeyData, 0, len(infos))
+	for _, info := range infos {
+		list = append(list, convertKeyToDTO(info, now))
+	}
+	return list, nil
+}
+
+// RotateKey 生成一把新的签名密钥用于签发，原密钥退役后在已签发的 token 过期之前仍可验证，用户不会因此掉线。
+// alg 为空时使用配置的 jwt.algorithm
+func (s *Service) RotateKey(actor *auditModel.Actor, alg string) (*dto.KeyData, error) {
+	if alg == "" {
+		alg = viper.GetString("jwt.algorithm")
+	}
+	if !jwt.IsValidAlg(alg) {
+		return nil, fmt.Errorf(stderr.ErrorJWTKeyAlgInvalid)
+	}
+
+	keys := jwt.Keys()
+	var info *jwt.KeyInfo
+	err := s.auditDao.DB().Transaction(func(tx *gorm.DB) error {
+		var err error
+		if info, err = keys.Rotate(tx, alg); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, auditModel.ActionJWTKeyRotate, auditModel.EntityJWTKey, info.Kid, nil, map[string]interface{}{
+			"kid": info.Kid,
+			"alg": info.Alg,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 本实例立即使用新密钥，其他实例在 jwt.keyRefreshInterval 内生效。加载失败时下次刷新会重试
+	if err := keys.Reload(); err != nil {
+		logger.Warn("轮换后重新加载JWT签名密钥失败: " + err.Error())
+	}
+	return convertKeyToDTO(info, time.Now()), nil
+}
+
+// GetJWKS 返回仍可用于验证的非对称密钥的公钥
+func (s *Service) GetJWKS() *dto.JWKSResp {
+	return &dto.JWKSResp{Keys: jwt.Keys().JWKS()}
+}
+
+func convertKeyToDTO(info *jwt.KeyInfo, now time.Time) *dto.KeyData {
+	verifiable := info.Status == jwt.KeyStatusActive || (info.VerifyUntil != nil && now.Before(*info.VerifyUntil))
+	return &dto.KeyData{
+		Kid:         info.Kid,
+		Alg:         info.Alg,
+		Status:      info.Status,
+		Verifiable:  verifiable,
+		VerifyUntil: util.FormatNullableTimeToStandardString(info.VerifyUntil),
+		RetiredAt:   util.FormatNullableTimeToStandardString(info.RetiredAt),
+		CreatedAt:   util.FormatTimeToStandardString(info.CreatedAt),
+	}
+}
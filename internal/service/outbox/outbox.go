@@ -0,0 +1,150 @@
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/spf13/viper"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"time"
+	"xinde/internal/dao/attachment"
+	"xinde/internal/dao/group"
+	"xinde/internal/dao/outbox"
+	model "xinde/internal/model/outbox"
+	"xinde/pkg/logger"
+)
+
+// Service 执行产品目录修改后需要在MySQL中完成的操作。
+// 操作和PostgreSQL中的修改在同一个事务里写入 t_outbox，提交后由修改方立即调用 Apply 执行，
+// 执行失败或进程崩溃时由 Start 启动的后台任务按退避时间重试。
+// 同一实体的操作按ID顺序执行：较早的操作还在等待重试时，较新的操作不会先执行，
+// 否则重试较早的操作会用旧的附件覆盖较新操作写入的附件
+// errEarlierPending 同一实体还有较早的操作没有执行，当前操作需要等它执行完成
+var errEarlierPending = errors.New("同一实体还有较早的操作等待执行")
+
+type Service struct {
+	dao           *outbox.Dao
+	attachmentDao *attachment.Dao
+	groupDao      *group.Dao
+}
+
+func NewOutboxService() (*Service, error) {
+	dao, err := outbox.NewOutboxDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: " + err.Error())
+	}
+	attachmentDao, err := attachment.NewAttachmentDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: " + err.Error())
+	}
+	groupDao, err := group.NewGroupDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: " + err.Error())
+	}
+	return &Service{
+		dao:           dao,
+		attachmentDao: attachmentDao,
+		groupDao:      groupDao,
+	}, nil
+}
+
+// Enqueue 在PostgreSQL事务 tx 中写入一条待执行的操作，entityKey 是操作涉及的实体。后台任务要等 outbox.retryDelay 之后才会执行它，
+// 避免和修改方提交后的立即执行同时进行；即使同时执行，t_outbox_applied 也保证只生效一次
+func (s *Service) Enqueue(tx *gorm.DB, opType, entityKey string, payload interface{}) (*model.Operation, error) {
+	op, err := model.NewOperation(opType, entityKey, payload)
+	if err != nil {
+		return nil, err
+	}
+	op.NextAttemptAt = time.Now().Add(viper.GetDuration("outbox.retryDelay"))
+	if err := s.dao.Create(tx, op); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// ApplyNow 在PostgreSQL事务提交后立即执行操作。失败时只记录日志，操作已经持久化，由后台任务重试
+func (s *Service) ApplyNow(op *model.Operation) {
+	if op == nil {
+		return
+	}
+	if err := s.Apply(op); err != nil {
+		if errors.Is(err, errEarlierPending) {
+			logger.Info(fmt.Sprintf("同一实体还有较早的跨库操作等待重试，由后台任务按顺序执行! 操作ID: %d 实体: %s", op.ID, op.EntityKey))
+			return
+		}
+		logger.Warn(fmt.Sprintf("执行跨库操作失败，稍后重试! 操作ID: %d 错误: %s", op.ID, err.Error()))
+	}
+}
+
+// Apply 在MySQL中执行操作并标记完成。操作是否执行过记录在同一个MySQL事务里，重复调用不会重复执行。
+// 同一实体还有较早的操作没有执行时返回 errEarlierPending，不执行当前操作
+func (s *Service) Apply(op *model.Operation) error {
+	if op.EntityKey != "" {
+		earlier, err := s.dao.HasEarlierPending(s.dao.DB(), op.EntityKey, op.ID)
+		if err != nil {
+			return err
+		}
+		if earlier {
+			return errEarlierPending
+		}
+	}
+
+	err := s.attachmentDao.DB().Transaction(func(tx *gorm.DB) error {
+		applied, err := s.dao.IsApplied(tx, op.ID)
+		if err != nil {
+			return err
+		}
+		if applied {
+			return nil
+		}
+
+		switch op.OpType {
+		case model.OpCatalogSync:
+			var sync model.CatalogSync
+			if err := json.Unmarshal(op.Payload, &sync); err != nil {
+				return fmt.Errorf("解析待执行操作失败: " + err.Error())
+			}
+			if err := s.applyCatalogSync(tx, &sync); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("未知的操作类型: %s", op.OpType)
+		}
+		return s.dao.CreateApplied(tx, op.ID)
+	})
+	if err != nil {
+		return err
+	}
+	return s.dao.MarkDone(s.dao.DB(), op.ID)
+}
+
+func (s *Service) applyCatalogSync(tx *gorm.DB, sync *model.CatalogSync) error {
+	for _, ref := range sync.DeleteAttachments {
+		if err := s.attachmentDao.DeleteAttachmentsByBusinessTypeAndIDs(tx, ref.BusinessType, ref.BusinessIDs); err != nil {
+			return err
+		}
+	}
+	for _, a := range sync.CreateAttachments {
+		if err := s.attachmentDao.Create(tx, a); err != nil {
+			return err
+		}
+	}
+	if sync.DeleteVisibilityDeviceTypeID != 0 {
+		if err := s.groupDao.DeleteVisibilityRulesByDeviceTypeID(tx, sync.DeleteVisibilityDeviceTypeID); err != nil {
+			return err
+		}
+	}
+	if sync.Audit != nil {
+		// 反序列化后空的快照是 JSON null，还原为 NULL，和直接写入的审计日志保持一致
+		for _, field := range []*datatypes.JSON{&sync.Audit.Before, &sync.Audit.After, &sync.Audit.Diff} {
+			if string(*field) == "null" {
+				*field = nil
+			}
+		}
+		if err := tx.Create(sync.Audit).Error; err != nil {
+			return fmt.Errorf("写入审计日志失败: " + err.Error())
+		}
+	}
+	return nil
+}
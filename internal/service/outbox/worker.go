@@ -0,0 +1,71 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/viper"
+	"time"
+	model "xinde/internal/model/outbox"
+	"xinde/pkg/logger"
+)
+
+// Start 在后台定期重试到期的操作，ctx 取消后退出
+func (s *Service) Start(ctx context.Context) {
+	interval := viper.GetDuration("outbox.interval")
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.RunDue(); err != nil {
+					logger.Error("重试跨库操作失败: " + err.Error())
+				}
+			}
+		}
+	}()
+}
+
+// RunDue 按ID顺序执行所有到期的操作。同一实体较早的操作本轮失败时，较新的操作留到下一轮，不计入重试次数
+func (s *Service) RunDue() error {
+	batchSize := viper.GetInt("outbox.batchSize")
+	var lastID uint
+	for {
+		ops, err := s.dao.FindDue(s.dao.DB(), time.Now(), lastID, batchSize)
+		if err != nil {
+			return err
+		}
+		for _, op := range ops {
+			lastID = op.ID
+			if err := s.Apply(op); err != nil {
+				if errors.Is(err, errEarlierPending) {
+					continue
+				}
+				s.retryLater(op, err)
+			}
+		}
+		if len(ops) < batchSize {
+			return nil
+		}
+	}
+}
+
+// retryLater 记录失败并按指数退避安排下一次重试，重试次数用完后标记为失败，需要人工处理
+func (s *Service) retryLater(op *model.Operation, applyErr error) {
+	attempts := op.Attempts + 1
+	status := model.StatusPending
+	delay := viper.GetDuration("outbox.retryDelay") << uint(attempts-1)
+	if maxDelay := viper.GetDuration("outbox.maxRetryDelay"); delay <= 0 || delay > maxDelay {
+		delay = maxDelay
+	}
+	if attempts >= viper.GetInt("outbox.maxAttempts") {
+		status = model.StatusFailed
+		logger.Error(fmt.Sprintf("跨库操作重试%d次后仍然失败，需要人工处理! 操作ID: %d 错误: %s", attempts, op.ID, applyErr.Error()))
+	}
+	if err := s.dao.MarkRetry(s.dao.DB(), op.ID, status, attempts, applyErr.Error(), time.Now().Add(delay)); err != nil {
+		logger.Error(fmt.Sprintf("更新跨库操作的重试状态失败! 操作ID: %d 错误: %s", op.ID, err.Error()))
+	}
+}
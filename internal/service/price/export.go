@@ -0,0 +1,91 @@
+package price
+
+import (
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	dto "xinde/internal/dto/price"
+	model "xinde/internal/model/price"
+)
+
+const (
+	exportSheetName = "价格"
+	// exportBatchSize 每批从数据库读取并写入 Excel 的产品数
+	exportBatchSize = 500
+)
+
+// ExportPrices 按查询条件导出价格，列的布局与导入文件一致（产品编码、单位、规格型号、各价格等级），
+// 导出的文件修改后可以直接重新导入。
+// 使用 excelize 的 StreamWriter 逐行写入，数据库也是按游标分批读取，不会把整张表读入内存。
+// 调用方负责在写出后关闭返回的文件。
+func (s *Service) ExportPrices(req *dto.FilterReq) (*excelize.File, error) {
+	tx := s.dao.DB()
+	filter, err := s.buildPriceListFilter(tx, req)
+	if err != nil {
+		return nil, err
+	}
+	levels, err := s.dao.FindAllPriceLevels(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	f := excelize.NewFile()
+	if err := f.SetSheetName(f.GetSheetName(0), exportSheetName); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("设置工作表名称失败: %w", err)
+	}
+	sw, err := f.NewStreamWriter(exportSheetName)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("创建Excel写入器失败: %w", err)
+	}
+
+	// 表头使用导入时可识别的名称，价格列使用等级编码
+	header := []interface{}{productCodeAliases[0], unitAliases[0], specCodeAliases[0]}
+	for _, l := range levels {
+		header = append(header, l.Code)
+	}
+	if err := sw.SetRow("A1", header); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("写入表头失败: %w", err)
+	}
+
+	rowNum := 1
+	err = s.dao.StreamPrices(tx, filter, exportBatchSize, func(prices []*model.Price) error {
+		for _, p := range prices {
+			rowNum++
+			values := make(map[string]float64, len(p.Values))
+			for _, v := range p.Values {
+				values[v.LevelCode] = v.Price
+			}
+
+			row := []interface{}{p.ProductCode, p.Unit, p.SpecCode}
+			for _, l := range levels {
+				// 缺少的价格留空，重新导入时会被标记为错误行，提醒补全
+				if price, ok := values[l.Code]; ok {
+					row = append(row, price)
+				} else {
+					row = append(row, nil)
+				}
+			}
+
+			cell, err := excelize.CoordinatesToCellName(1, rowNum)
+			if err != nil {
+				return err
+			}
+			if err := sw.SetRow(cell, row); err != nil {
+				return fmt.Errorf("写入第 %d 行失败: %w", rowNum, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	if err := sw.Flush(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("生成Excel失败: %w", err)
+	}
+	return f, nil
+}
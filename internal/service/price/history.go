@@ -0,0 +1,54 @@
+package price
+
+import (
+	"time"
+	dto "xinde/internal/dto/price"
+	"xinde/pkg/util"
+)
+
+// GetPriceHistory 返回某个产品编码的价格时间线，按生效时间倒序，并标注每条记录的状态
+func (s *Service) GetPriceHistory(productCode string) ([]*dto.HistoryData, error) {
+	tx := s.dao.DB()
+	histories, err := s.dao.FindPriceHistoryByProductCode(tx, productCode)
+	if err != nil {
+		return nil, err
+	}
+	levels, err := s.dao.FindAllPriceLevels(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	list := make([]*dto.HistoryData, 0, len(histories))
+	// 记录按生效时间倒序排列，第一条已生效的记录即为当前价格，之后的都已被取代
+	activeFound := false
+	for _, h := range histories {
+		status := dto.HistoryStatusSuperseded
+		if h.EffectiveFrom.After(now) {
+			status = dto.HistoryStatusScheduled
+		} else if !activeFound {
+			status = dto.HistoryStatusActive
+			activeFound = true
+		}
+
+		values := make(map[string]float64, len(h.Values))
+		for _, v := range h.Values {
+			values[v.LevelCode] = v.Price
+		}
+
+		list = append(list, &dto.HistoryData{
+			ID:             h.ID,
+			ProductCode:    h.ProductCode,
+			Unit:           h.Unit,
+			SpecCode:       h.SpecCode,
+			Prices:         buildLevelPrices(values, levels),
+			EffectiveFrom:  util.FormatTimeToStandardString(h.EffectiveFrom),
+			Status:         status,
+			AttachmentID:   h.AttachmentID,
+			AttachmentName: h.AttachmentName,
+			Operator:       h.OperatorName,
+			CreatedAt:      util.FormatTimeToStandardString(h.CreatedAt),
+		})
+	}
+	return list, nil
+}
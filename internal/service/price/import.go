@@ -1,39 +1,83 @@
 package price
 
 import (
-	_ "errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/xuri/excelize/v2"
 	"gorm.io/gorm"
 	"mime/multipart"
 	"strconv"
-	_ "time"
+	"strings"
+	"time"
+	dto "xinde/internal/dto/price"
 	attachmentModel "xinde/internal/model/attachment"
+	"xinde/internal/model/audit"
 	model "xinde/internal/model/price"
 	"xinde/pkg/logger"
+	"xinde/pkg/stderr"
 	"xinde/pkg/util"
-	_ "xinde/pkg/util"
 )
 
-func (s *Service) ImportPricesFromFile(c *gin.Context, fileHeader *multipart.FileHeader, adminID uint) error {
+// priceImportSchema 记录从表头解析出的各业务字段所在的列索引
+type priceImportSchema struct {
+	ProductCode int
+	Unit        int
+	SpecCode    int
+	// 可选列，不存在时为 -1
+	EffectiveFrom int
+	// 文件中出现的价格等级列，按价格等级的排序排列
+	Prices []priceLevelColumn
+}
+
+// priceLevelColumn 某个价格等级在导入文件中所在的列
+type priceLevelColumn struct {
+	Code  string
+	Index int
+}
+
+var (
+	productCodeAliases = []string{"product_code", "itemcode", "产品编码", "商品编码"}
+	unitAliases        = []string{"unit", "单位"}
+	specCodeAliases    = []string{"spec_code", "规格型号"}
+	// 生效时间为可选列，缺省表示立即生效
+	effectiveFromAliases = []string{"effective_from", "生效时间", "生效日期"}
+)
+
+// effectiveFromLayouts 生效时间列可接受的日期格式，均按服务器本地时区解析
+var effectiveFromLayouts = []string{
+	util.StandardDateTimeFormat,
+	util.StandardDateFormat,
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+	"2006/1/2",
+}
+
+// importedPrice 一行校验通过的导入数据
+type importedPrice struct {
+	Price *model.Price
+	// 为空表示立即生效
+	EffectiveFrom *time.Time
+}
+
+func (s *Service) ImportPricesFromFile(c *gin.Context, fileHeader *multipart.FileHeader, actor *audit.Actor, mode string) (*dto.ImportResult, error) {
+	if mode == "" {
+		mode = dto.ImportModeStrict
+	}
+
 	// --- 1. 文件存储 ---
 	// a. 打开上传的文件流
 	file, err := fileHeader.Open()
 	if err != nil {
-		return fmt.Errorf("打开上传文件失败: %w", err)
+		return nil, fmt.Errorf("打开上传文件失败: %w", err)
 	}
 	defer file.Close()
 
 	// b. 将文件保存到临时位置或直接上传到云存储
 	storagePath, err := util.SaveUploadedFile(fileHeader)
 	if err != nil {
-		return fmt.Errorf("保存上传文件失败: %w", err)
+		return nil, fmt.Errorf("保存上传文件失败: %w", err)
 	}
 
-	// 重置文件读取指针，以便后续解析
-	file.Seek(0, 0)
-
 	// 在t_attachment表中记录这次上传
 	attachment := &attachmentModel.Attachment{
 		Filename:      fileHeader.Filename,
@@ -41,75 +85,301 @@ func (s *Service) ImportPricesFromFile(c *gin.Context, fileHeader *multipart.Fil
 		FileType:      fileHeader.Header.Get("Content-Type"),
 		FileSize:      uint64(fileHeader.Size),
 		StorageDriver: "local",
-		UploadedByUID: adminID,
+		UploadedByUID: actor.UID,
 		BusinessType:  util.StringToPointer("price_import"),
 	}
+	// 价格历史和审计日志都要关联这条记录，记录失败时不能继续导入，否则历史记录无法追溯到来源文件
 	if err := s.attachmentDao.Create(s.attachmentDao.DB(), attachment); err != nil {
-		// 记录日志，但通常不因为这个失败而中断主流程
-		logger.Error("记录上传附件信息到数据库失败: " + err.Error())
+		return nil, fmt.Errorf("记录上传附件信息到数据库失败: %w", err)
 	}
 
 	// --- 2. 解析Excel ---
 	xlsx, err := excelize.OpenReader(file)
 	if err != nil {
-		return fmt.Errorf("读取Excel文件失败: %w", err)
+		return nil, fmt.Errorf("读取Excel文件失败: %w", err)
 	}
+	defer xlsx.Close()
 	// 获取工作表中的所有行
 	sheetList := xlsx.GetSheetList()
 	if len(sheetList) == 0 {
-		return fmt.Errorf("excel文件中没有任何工作表")
+		return nil, fmt.Errorf("excel文件中没有任何工作表")
 	}
 	firstSheetName := sheetList[0]
-	rows, err := xlsx.GetRows(firstSheetName) // 假设数据在 "Sheet1"
+	rows, err := xlsx.GetRows(firstSheetName)
 	if err != nil {
-		return fmt.Errorf("获取 Sheet1 数据失败: %w", err)
+		return nil, fmt.Errorf("获取 %s 数据失败: %w", firstSheetName, err)
 	}
 	if len(rows) <= 1 {
-		return fmt.Errorf("excel 文件为空或只有表头")
+		return nil, fmt.Errorf(stderr.ErrorPriceImportEmpty)
+	}
+
+	// 根据表头定位各列，列的顺序不再固定；价格列的表头为价格等级的编码或名称
+	levels, err := s.dao.FindAllPriceLevels(s.dao.DB())
+	if err != nil {
+		return nil, err
+	}
+	header := rows[0]
+	schema, err := buildPriceImportSchema(header, levels)
+	if err != nil {
+		return nil, err
 	}
 
-	// 在事务中处理数据并入库
-	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
-		for i, row := range rows[1:] {
-			// 解析每一行数据，进行类型转化和校验
-			priceData, err := s.parsePriceRow(row)
-			if err != nil {
-				return fmt.Errorf("解析第 %d 行数据失败: %w", i+2, err)
+	// --- 3. 逐行校验，收集所有错误而不是遇到第一行错误就中断 ---
+	result := &dto.ImportResult{
+		Mode:   mode,
+		Errors: []*dto.ImportRowError{},
+	}
+	var validPrices []*importedPrice
+	var failedRows [][]string
+	// 同一产品编码可以在一个文件里出现多次，只要生效时间不同（例如同时导入本季度和下季度的价格）
+	seen := make(map[string]int) // product_code + 生效时间 -> 首次出现的行号
+	for i, row := range rows[1:] {
+		rowNum := i + 2
+		if isBlankRow(row) {
+			continue
+		}
+		result.Total++
+
+		priceData, reasons := parsePriceRow(row, schema)
+		effectiveFrom, err := parseEffectiveFrom(cellValue(row, schema.EffectiveFrom))
+		if err != nil {
+			reasons = append(reasons, err.Error())
+		}
+		if priceData.ProductCode != "" {
+			key := priceData.ProductCode
+			if effectiveFrom != nil {
+				key += "@" + effectiveFrom.Format(util.StandardDateTimeFormat)
 			}
-			// 调用dao执行更新或插入操作
-			err = s.dao.UpsertPrices(tx, priceData)
-			if err != nil {
-				return fmt.Errorf("导入第 %d 行数据失败: %w", i+2, err)
+			if firstRow, ok := seen[key]; ok {
+				reasons = append(reasons, fmt.Sprintf("产品编码与第 %d 行重复", firstRow))
+			} else {
+				seen[key] = rowNum
 			}
 		}
-		return nil
+
+		if len(reasons) > 0 {
+			result.Errors = append(result.Errors, &dto.ImportRowError{
+				Row:         rowNum,
+				ProductCode: priceData.ProductCode,
+				Reason:      strings.Join(reasons, "; "),
+			})
+			failedRows = append(failedRows, row)
+			continue
+		}
+		validPrices = append(validPrices, &importedPrice{Price: priceData, EffectiveFrom: effectiveFrom})
+	}
+	result.Failed = len(result.Errors)
+
+	// --- 4. 如果存在错误行，生成可下载的错误报告 ---
+	if result.Failed > 0 {
+		reportID, err := s.saveImportErrorReport(header, failedRows, result.Errors, attachment, actor.UID)
+		if err != nil {
+			// 错误报告生成失败不影响导入结果，错误明细依然会随接口返回
+			logger.Error("生成价格导入错误报告失败: " + err.Error())
+		}
+		result.ErrorReportID = reportID
+
+		// 严格模式下，任意一行出错都不写入数据库
+		if mode == dto.ImportModeStrict {
+			return result, fmt.Errorf(stderr.ErrorPriceImportHasInvalidRows)
+		}
+	}
+
+	// --- 5. 在事务中批量写入 ---
+	// 每一行都按文件中的生效时间（未指定时为导入时间）追加一条历史记录。
+	// 已到生效时间的行在同一事务中写入当前价格 (t_price)，保证二者一致；未来生效的行由后台任务在到达生效时间后写入，见 PromoteDue
+	now := time.Now()
+	var dueCodes []string
+	histories := make([]*model.PriceHistory, 0, len(validPrices))
+	for _, item := range validPrices {
+		effectiveFrom := now
+		if item.EffectiveFrom != nil {
+			effectiveFrom = *item.EffectiveFrom
+		}
+		if !effectiveFrom.After(now) {
+			dueCodes = append(dueCodes, item.Price.ProductCode)
+		}
+		historyValues := make([]*model.PriceHistoryValue, 0, len(item.Price.Values))
+		for _, v := range item.Price.Values {
+			historyValues = append(historyValues, &model.PriceHistoryValue{
+				LevelCode: v.LevelCode,
+				Price:     v.Price,
+			})
+		}
+		histories = append(histories, &model.PriceHistory{
+			ProductCode:   item.Price.ProductCode,
+			Unit:          item.Price.Unit,
+			SpecCode:      item.Price.SpecCode,
+			Values:        historyValues,
+			EffectiveFrom: effectiveFrom,
+			AttachmentID:  attachment.ID,
+			CreatedByUID:  actor.UID,
+		})
+	}
+	err = s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		if err := s.dao.CreatePriceHistories(tx, histories); err != nil {
+			return err
+		}
+		if err := s.promotePrices(tx, dueCodes, now); err != nil {
+			return err
+		}
+		// 逐行的价格变化已记录在价格历史中，审计日志只记录这次导入的概况
+		return s.auditDao.Record(tx, actor, audit.ActionPriceImport, audit.EntityAttachment, attachment.ID, nil, map[string]interface{}{
+			"filename":  fileHeader.Filename,
+			"mode":      mode,
+			"total":     result.Total,
+			"failed":    result.Failed,
+			"succeeded": len(validPrices),
+		})
 	})
+	if err != nil {
+		return nil, fmt.Errorf("导入价格数据失败: %w", err)
+	}
+	result.Succeeded = len(validPrices)
+
+	return result, nil
 }
 
-func (s *Service) parsePriceRow(row []string) (*model.Price, error) {
-	price1, err := strconv.ParseFloat(row[1], 64)
-	if err != nil {
-		return nil, fmt.Errorf("价格数字有误，不是数字类型: %w", err)
+// buildPriceImportSchema 根据表头找到每个字段所在的列，缺少任何必需的列都会返回错误。
+// 价格列按价格等级的编码或名称匹配，文件中至少要包含一个价格等级，未出现的等级保持原价格不变
+func buildPriceImportSchema(header []string, levels []*model.PriceLevel) (*priceImportSchema, error) {
+	columnIndex := make(map[string]int, len(header))
+	for idx, name := range header {
+		key := normalizeHeader(name)
+		if key == "" {
+			continue
+		}
+		// 同名列以第一次出现的为准
+		if _, exists := columnIndex[key]; !exists {
+			columnIndex[key] = idx
+		}
 	}
-	price2, err := strconv.ParseFloat(row[2], 64)
-	if err != nil {
-		return nil, fmt.Errorf("价格数字有误，不是数字类型: %w", err)
+
+	var missing []string
+	find := func(aliases []string) int {
+		for _, alias := range aliases {
+			if idx, ok := columnIndex[normalizeHeader(alias)]; ok {
+				return idx
+			}
+		}
+		missing = append(missing, aliases[0])
+		return -1
 	}
-	price3, err := strconv.ParseFloat(row[3], 64)
-	if err != nil {
-		return nil, fmt.Errorf("价格数字有误，不是数字类型: %w", err)
+
+	schema := &priceImportSchema{
+		ProductCode:   find(productCodeAliases),
+		Unit:          find(unitAliases),
+		SpecCode:      find(specCodeAliases),
+		EffectiveFrom: -1,
 	}
-	price4, err := strconv.ParseFloat(row[4], 64)
-	if err != nil {
-		return nil, fmt.Errorf("价格数字有误，不是数字类型: %w", err)
-	}
-	return &model.Price{
-		ProductCode: row[0],
-		Price1:      price1,
-		Price2:      price2,
-		Price3:      price3,
-		Price4:      price4,
-		Unit:        row[5],
-		SpecCode:    row[6],
-	}, nil
+
+	for _, alias := range effectiveFromAliases {
+		if idx, ok := columnIndex[normalizeHeader(alias)]; ok {
+			schema.EffectiveFrom = idx
+			break
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("价格文件缺少必需的列: %s", strings.Join(missing, ", "))
+	}
+
+	for _, level := range levels {
+		for _, alias := range []string{level.Code, level.Name} {
+			if idx, ok := columnIndex[normalizeHeader(alias)]; ok {
+				schema.Prices = append(schema.Prices, priceLevelColumn{Code: level.Code, Index: idx})
+				break
+			}
+		}
+	}
+	if len(schema.Prices) == 0 {
+		return nil, fmt.Errorf("价格文件中没有任何价格等级列，价格列的表头应为价格等级的编码或名称")
+	}
+	return schema, nil
+}
+
+// parsePriceRow 解析一行价格数据，返回解析出的数据以及该行所有的错误原因
+func parsePriceRow(row []string, schema *priceImportSchema) (*model.Price, []string) {
+	var reasons []string
+
+	p := &model.Price{
+		ProductCode: cellValue(row, schema.ProductCode),
+		Unit:        cellValue(row, schema.Unit),
+		SpecCode:    cellValue(row, schema.SpecCode),
+	}
+	if p.ProductCode == "" {
+		reasons = append(reasons, "产品编码为空")
+	}
+	if p.Unit == "" {
+		reasons = append(reasons, "单位为空")
+	}
+	if p.SpecCode == "" {
+		reasons = append(reasons, "规格型号为空")
+	}
+
+	for _, level := range schema.Prices {
+		raw := strings.ReplaceAll(cellValue(row, level.Index), ",", "")
+		if raw == "" {
+			reasons = append(reasons, fmt.Sprintf("%s为空", level.Code))
+			continue
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			reasons = append(reasons, fmt.Sprintf("%s不是有效的数字: %s", level.Code, raw))
+			continue
+		}
+		if value < 0 {
+			reasons = append(reasons, fmt.Sprintf("%s不能为负数", level.Code))
+			continue
+		}
+		p.Values = append(p.Values, &model.PriceValue{
+			ProductCode: p.ProductCode,
+			LevelCode:   level.Code,
+			Price:       value,
+		})
+	}
+
+	return p, reasons
+}
+
+// parseEffectiveFrom 解析生效时间列，空值返回 nil 表示立即生效。
+// 除文本日期外，也兼容 Excel 把日期单元格读成序列号（如 45658）的情况。
+func parseEffectiveFrom(raw string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	for _, layout := range effectiveFromLayouts {
+		if t, err := time.ParseInLocation(layout, raw, time.Local); err == nil {
+			return &t, nil
+		}
+	}
+	if serial, err := strconv.ParseFloat(raw, 64); err == nil {
+		if t, err := excelize.ExcelDateToTime(serial, false); err == nil {
+			// ExcelDateToTime 返回 UTC 下的墙上时间，这里按本地时区重新解释
+			local := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local)
+			return &local, nil
+		}
+	}
+	return nil, fmt.Errorf("生效时间格式不正确: %s", raw)
+}
+
+// cellValue 安全地读取一个单元格，excelize 会省略行尾的空单元格，所以短行不能直接按下标访问
+func cellValue(row []string, idx int) string {
+	if idx < 0 || idx >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[idx])
+}
+
+func isBlankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeHeader(name string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), " ", ""))
 }
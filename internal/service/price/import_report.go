@@ -0,0 +1,82 @@
+package price
+
+import (
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"path/filepath"
+	"strings"
+	dto "xinde/internal/dto/price"
+	attachmentModel "xinde/internal/model/attachment"
+	"xinde/pkg/util"
+)
+
+const (
+	importErrorBusinessType = "price_import_error"
+	importErrorSheetName    = "导入错误"
+	xlsxContentType         = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// saveImportErrorReport 将出错的行按原表头写入一个新的Excel，末尾追加行号和错误原因两列，
+// 修正后的报告可以直接重新导入（多出来的两列会被忽略）。
+// 报告作为附件保存，business_id 指向本次导入的源文件附件，返回报告的附件ID。
+func (s *Service) saveImportErrorReport(header []string, failedRows [][]string, rowErrors []*dto.ImportRowError, source *attachmentModel.Attachment, adminID uint) (uint, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+	if err := f.SetSheetName(f.GetSheetName(0), importErrorSheetName); err != nil {
+		return 0, fmt.Errorf("设置工作表名称失败: %w", err)
+	}
+
+	reportHeader := make([]interface{}, 0, len(header)+2)
+	for _, name := range header {
+		reportHeader = append(reportHeader, name)
+	}
+	reportHeader = append(reportHeader, "行号", "错误原因")
+	if err := writeReportRow(f, 1, reportHeader); err != nil {
+		return 0, err
+	}
+	for i, row := range failedRows {
+		line := make([]interface{}, 0, len(header)+2)
+		for col := range header {
+			line = append(line, cellValue(row, col))
+		}
+		line = append(line, rowErrors[i].Row, rowErrors[i].Reason)
+		if err := writeReportRow(f, i+2, line); err != nil {
+			return 0, err
+		}
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return 0, fmt.Errorf("生成错误报告失败: %w", err)
+	}
+	storagePath, size, err := util.SaveFileFromReader(".xlsx", buf)
+	if err != nil {
+		return 0, err
+	}
+
+	report := &attachmentModel.Attachment{
+		Filename:      fmt.Sprintf("导入错误报告_%s.xlsx", strings.TrimSuffix(source.Filename, filepath.Ext(source.Filename))),
+		StoragePath:   storagePath,
+		FileType:      xlsxContentType,
+		FileSize:      uint64(size),
+		StorageDriver: "local",
+		UploadedByUID: adminID,
+		BusinessType:  util.StringToPointer(importErrorBusinessType),
+		BusinessID:    source.ID,
+	}
+	if err := s.attachmentDao.Create(s.attachmentDao.DB(), report); err != nil {
+		return 0, err
+	}
+	return report.ID, nil
+}
+
+func writeReportRow(f *excelize.File, rowNum int, values []interface{}) error {
+	cell, err := excelize.CoordinatesToCellName(1, rowNum)
+	if err != nil {
+		return err
+	}
+	if err := f.SetSheetRow(importErrorSheetName, cell, &values); err != nil {
+		return fmt.Errorf("写入错误报告第 %d 行失败: %w", rowNum, err)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package price
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"sort"
+	dto "xinde/internal/dto/price"
+	"xinde/internal/model/audit"
+	model "xinde/internal/model/price"
+	"xinde/pkg/stderr"
+)
+
+func (s *Service) GetPriceLevelList() ([]*dto.LevelData, error) {
+	levels, err := s.dao.FindAllPriceLevels(s.dao.DB())
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*dto.LevelData, 0, len(levels))
+	for _, l := range levels {
+		list = append(list, &dto.LevelData{
+			ID:        l.ID,
+			Code:      l.Code,
+			Name:      l.Name,
+			SortOrder: l.SortOrder,
+		})
+	}
+	return list, nil
+}
+
+func (s *Service) CreatePriceLevel(actor *audit.Actor, code, name string, sortOrder int) error {
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		// 导入价格时按编码或名称匹配表头，所以二者都不能重复
+		isExist, err := s.dao.IsExistPriceLevelByCode(tx, code)
+		if err != nil {
+			return err
+		}
+		if isExist {
+			return fmt.Errorf(stderr.ErrorPriceLevelCodeConflict)
+		}
+		isExist, err = s.dao.IsExistPriceLevelByName(tx, name, 0)
+		if err != nil {
+			return err
+		}
+		if isExist {
+			return fmt.Errorf(stderr.ErrorPriceLevelNameConflict)
+		}
+
+		level := &model.PriceLevel{
+			Code:      code,
+			Name:      name,
+			SortOrder: sortOrder,
+		}
+		if err := s.dao.CreatePriceLevel(tx, level); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, audit.ActionPriceLevelCreate, audit.EntityPriceLevel, level.ID, nil, levelSnapshot(level))
+	})
+}
+
+// UpdatePriceLevel 修改价格等级的名称或排序，编码被公司和价格数据引用，不允许修改
+func (s *Service) UpdatePriceLevel(actor *audit.Actor, id uint, name string, sortOrder *int) error {
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		level, err := s.dao.GetPriceLevelByID(tx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf(stderr.ErrorPriceLevelNotFound)
+			}
+			return err
+		}
+		before := levelSnapshot(level)
+
+		updateData := make(map[string]interface{})
+		if name != "" {
+			isExist, err := s.dao.IsExistPriceLevelByName(tx, name, id)
+			if err != nil {
+				return err
+			}
+			if isExist {
+				return fmt.Errorf(stderr.ErrorPriceLevelNameConflict)
+			}
+			updateData["name"] = name
+			level.Name = name
+		}
+		if sortOrder != nil {
+			updateData["sort_order"] = *sortOrder
+			level.SortOrder = *sortOrder
+		}
+		if len(updateData) == 0 {
+			return nil
+		}
+
+		if err := s.dao.UpdatePriceLevel(tx, id, updateData); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, audit.ActionPriceLevelUpdate, audit.EntityPriceLevel, id, before, levelSnapshot(level))
+	})
+}
+
+// DeletePriceLevel 删除价格等级及该等级下的当前价格，仍被公司使用的等级和新建公司使用的默认等级不能删除
+func (s *Service) DeletePriceLevel(actor *audit.Actor, id uint) error {
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		level, err := s.dao.GetPriceLevelByID(tx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf(stderr.ErrorPriceLevelNotFound)
+			}
+			return err
+		}
+		if level.Code == model.DefaultPriceLevelCode {
+			return fmt.Errorf(stderr.ErrorPriceLevelDefault)
+		}
+
+		levels, err := s.dao.FindAllPriceLevels(tx)
+		if err != nil {
+			return err
+		}
+		if len(levels) <= 1 {
+			return fmt.Errorf(stderr.ErrorPriceLevelLastOne)
+		}
+
+		count, err := s.companyDao.CountCompaniesByPriceLevel(tx, level.Code)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf(stderr.ErrorPriceLevelInUse)
+		}
+
+		if err := s.dao.DeletePriceValuesByLevelCode(tx, level.Code); err != nil {
+			return err
+		}
+		if err := s.dao.DeletePriceLevelByID(tx, id); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, audit.ActionPriceLevelDelete, audit.EntityPriceLevel, id, levelSnapshot(level), nil)
+	})
+}
+
+// levelSnapshot 审计日志中记录的价格等级
+func levelSnapshot(l *model.PriceLevel) map[string]interface{} {
+	return map[string]interface{}{
+		"code":       l.Code,
+		"name":       l.Name,
+		"sort_order": l.SortOrder,
+	}
+}
+
+// buildLevelPrices 按价格等级的排序将 等级编码->价格 转换为列表，values 中没有的等级不输出。
+// 已被删除的等级（只会出现在历史记录中）排在最后，名称显示为编码
+func buildLevelPrices(values map[string]float64, levels []*model.PriceLevel) []*dto.LevelPrice {
+	prices := make([]*dto.LevelPrice, 0, len(values))
+	known := make(map[string]bool, len(levels))
+	for _, l := range levels {
+		known[l.Code] = true
+		price, ok := values[l.Code]
+		if !ok {
+			continue
+		}
+		prices = append(prices, &dto.LevelPrice{
+			LevelCode: l.Code,
+			LevelName: l.Name,
+			Price:     price,
+		})
+	}
+
+	var removed []string
+	for code := range values {
+		if !known[code] {
+			removed = append(removed, code)
+		}
+	}
+	sort.Strings(removed)
+	for _, code := range removed {
+		prices = append(prices, &dto.LevelPrice{
+			LevelCode: code,
+			LevelName: code,
+			Price:     values[code],
+		})
+	}
+	return prices
+}
@@ -2,18 +2,34 @@ package price
 
 import (
 	"fmt"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+	"xinde/internal/dao/account"
 	"xinde/internal/dao/attachment"
+	"xinde/internal/dao/audit"
+	"xinde/internal/dao/company"
+	"xinde/internal/dao/device"
+	"xinde/internal/dao/group"
 	"xinde/internal/dao/price"
+	"xinde/internal/dao/solution"
 	dto "xinde/internal/dto/price"
 	model "xinde/internal/model/price"
 	"xinde/pkg/jwt"
 	"xinde/pkg/stderr"
+	"xinde/pkg/util"
 )
 
 type Service struct {
 	dao           *price.Dao
 	jwt           *jwt.JWTService
 	attachmentDao *attachment.Dao
+	companyDao    *company.Dao
+	auditDao      *audit.Dao
+	accountDao    *account.Dao
+	solutionDao   *solution.Dao
+	deviceDao     *device.Dao
+	groupDao      *group.Dao
 }
 
 func NewPriceService() (*Service, error) {
@@ -26,18 +42,54 @@ func NewPriceService() (*Service, error) {
 	if err != nil {
 		return nil, fmt.Errorf("创建Dao实例失败: %v", err)
 	}
+	companyDao, err := company.NewCompanyDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: %v", err)
+	}
+	auditDao, err := audit.NewAuditDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: %v", err)
+	}
+	accountDao, err := account.NewRegisterDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: %v", err)
+	}
+	solutionDao, err := solution.NewSolutionDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: %v", err)
+	}
+	deviceDao, err := device.NewDeviceDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: %v", err)
+	}
+	groupDao, err := group.NewGroupDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建Dao实例失败: %v", err)
+	}
 	return &Service{
 		dao:           dao,
 		jwt:           jwtService,
 		attachmentDao: attachmentDao,
+		companyDao:    companyDao,
+		auditDao:      auditDao,
+		accountDao:    accountDao,
+		solutionDao:   solutionDao,
+		deviceDao:     deviceDao,
+		groupDao:      groupDao,
 	}, nil
 }
 
-func (s *Service) GetPriceList(page, pageSize int) (*dto.ListPageData, error) {
+func (s *Service) GetPriceList(req *dto.ListReq) (*dto.ListPageData, error) {
 	tx := s.dao.DB()
+	page, pageSize := req.Page, req.PageSize
+
+	filter, err := s.buildPriceListFilter(tx, &req.FilterReq)
+	if err != nil {
+		return nil, err
+	}
 
 	// 计算页数
-	count, err := s.dao.CountPrices(tx)
+	count, err := s.dao.CountPrices(tx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -57,7 +109,12 @@ func (s *Service) GetPriceList(page, pageSize int) (*dto.ListPageData, error) {
 	}
 
 	// 查询数据库获取当前页面的价格数据
-	priceList, err := s.dao.FindPriceListWithPagination(tx, currentPage, pageSize)
+	priceList, err := s.dao.FindPriceListWithPagination(tx, currentPage, pageSize, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	levels, err := s.dao.FindAllPriceLevels(tx)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +122,7 @@ func (s *Service) GetPriceList(page, pageSize int) (*dto.ListPageData, error) {
 	// 将model.Price转换成dto.ListData
 	var list []*dto.ListData
 	for _, p := range priceList {
-		list = append(list, convertPriceToDTOListData(p))
+		list = append(list, convertPriceToDTOListData(p, levels))
 	}
 
 	// 组装分页数据
@@ -89,15 +146,72 @@ func (s *Service) GetPriceList(page, pageSize int) (*dto.ListPageData, error) {
 	return pageData, nil
 }
 
-func convertPriceToDTOListData(price *model.Price) *dto.ListData {
+// buildPriceListFilter 校验并转换查询条件，排序字段和价格等级必须存在
+func (s *Service) buildPriceListFilter(tx *gorm.DB, req *dto.FilterReq) (*price.PriceListFilter, error) {
+	filter := &price.PriceListFilter{
+		Keyword:     strings.TrimSpace(req.Keyword),
+		PrefixMatch: req.MatchMode == dto.MatchModePrefix,
+		Missing:     req.PriceFilter == dto.PriceFilterMissing || req.PriceFilter == dto.PriceFilterMissingOrZero,
+		Zero:        req.PriceFilter == dto.PriceFilterZero || req.PriceFilter == dto.PriceFilterMissingOrZero,
+		LevelCode:   req.LevelCode,
+		SortBy:      req.SortBy,
+		SortDesc:    req.SortOrder == dto.SortOrderDesc,
+	}
+
+	if req.LevelCode != "" {
+		isExist, err := s.dao.IsExistPriceLevelByCode(tx, req.LevelCode)
+		if err != nil {
+			return nil, err
+		}
+		if !isExist {
+			return nil, fmt.Errorf(stderr.ErrorPriceLevelNotFound)
+		}
+	}
+
+	if req.SortBy != "" && !price.IsPriceSortColumn(req.SortBy) {
+		isExist, err := s.dao.IsExistPriceLevelByCode(tx, req.SortBy)
+		if err != nil {
+			return nil, err
+		}
+		if !isExist {
+			return nil, fmt.Errorf(stderr.ErrorPriceSortByInvalid)
+		}
+	}
+
+	if req.UpdatedSince != "" {
+		var parsed bool
+		for _, layout := range []string{util.StandardDateTimeFormat, util.StandardDateFormat} {
+			if t, err := time.ParseInLocation(layout, req.UpdatedSince, time.Local); err == nil {
+				filter.UpdatedSince = &t
+				parsed = true
+				break
+			}
+		}
+		if !parsed {
+			return nil, fmt.Errorf(stderr.ErrorPriceUpdatedSinceInvalid)
+		}
+	}
+
+	return filter, nil
+}
+
+func convertPriceToDTOListData(price *model.Price, levels []*model.PriceLevel) *dto.ListData {
+	// 各等级的价格单独保存，最后更新时间取产品信息和各等级价格中最晚的一个
+	updatedAt := price.UpdatedAt
+	values := make(map[string]float64, len(price.Values))
+	for _, v := range price.Values {
+		values[v.LevelCode] = v.Price
+		if v.UpdatedAt.After(updatedAt) {
+			updatedAt = v.UpdatedAt
+		}
+	}
+
 	return &dto.ListData{
 		ID:          price.ID,
 		ProductCode: price.ProductCode,
-		Price1:      price.Price1,
-		Price2:      price.Price2,
-		Price3:      price.Price3,
-		Price4:      price.Price4,
+		Prices:      buildLevelPrices(values, levels),
 		Unit:        price.Unit,
 		SpecCode:    price.SpecCode,
+		UpdatedAt:   util.FormatTimeToStandardString(updatedAt),
 	}
 }
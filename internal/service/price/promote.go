@@ -0,0 +1,110 @@
+package price
+
+import (
+	"context"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"time"
+	model "xinde/internal/model/price"
+	"xinde/pkg/logger"
+)
+
+// StartPromote 每隔 price.promoteInterval 在后台把到达生效时间的价格写入当前价格，ctx 取消后退出
+func (s *Service) StartPromote(ctx context.Context) {
+	interval := viper.GetDuration("price.promoteInterval")
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.PromoteDue(); err != nil {
+					logger.Error("写入到达生效时间的价格失败: " + err.Error())
+				}
+			}
+		}
+	}()
+}
+
+// PromoteDue 把所有已到生效时间、但还没写入当前价格的历史记录写入 t_price / t_price_value
+func (s *Service) PromoteDue() error {
+	batchSize := viper.GetInt("price.promoteBatchSize")
+	for {
+		now := time.Now()
+		codes, err := s.dao.FindDuePriceHistoryCodes(s.dao.DB(), now, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		err = s.dao.DB().Transaction(func(tx *gorm.DB) error {
+			return s.promotePrices(tx, codes, now)
+		})
+		if err != nil {
+			return err
+		}
+		if len(codes) < batchSize {
+			return nil
+		}
+	}
+}
+
+// promotePrices 按价格历史重新计算这些产品在 at 时刻的当前价格，写入 t_price / t_price_value，并标记已处理的历史记录。
+// 每个等级取生效时间不晚于 at 的最新一条历史，与按时间查询价格（FindPricesForUser）的规则相同，
+// 因此补录的过去日期的价格不会覆盖生效时间更晚的价格。已删除的价格等级不再写入
+func (s *Service) promotePrices(tx *gorm.DB, productCodes []string, at time.Time) error {
+	if len(productCodes) == 0 {
+		return nil
+	}
+	levels, err := s.dao.FindAllPriceLevels(tx)
+	if err != nil {
+		return err
+	}
+	levelExists := make(map[string]bool, len(levels))
+	for _, l := range levels {
+		levelExists[l.Code] = true
+	}
+
+	histories, err := s.dao.FindEffectivePriceHistories(tx, productCodes, at)
+	if err != nil {
+		return err
+	}
+	var prices []*model.Price
+	var values []*model.PriceValue
+	seenPrice := make(map[string]bool)
+	seenValue := make(map[string]bool)
+	for _, h := range histories {
+		// 最新的一条历史决定产品的单位和规格型号
+		if !seenPrice[h.ProductCode] {
+			seenPrice[h.ProductCode] = true
+			prices = append(prices, &model.Price{
+				ProductCode: h.ProductCode,
+				Unit:        h.Unit,
+				SpecCode:    h.SpecCode,
+			})
+		}
+		for _, v := range h.Values {
+			key := h.ProductCode + "\x00" + v.LevelCode
+			if !levelExists[v.LevelCode] || seenValue[key] {
+				continue
+			}
+			seenValue[key] = true
+			values = append(values, &model.PriceValue{
+				ProductCode: h.ProductCode,
+				LevelCode:   v.LevelCode,
+				Price:       v.Price,
+			})
+		}
+	}
+
+	if err := s.dao.UpsertPrices(tx, prices); err != nil {
+		return err
+	}
+	if err := s.dao.UpsertPriceValues(tx, values); err != nil {
+		return err
+	}
+	return s.dao.MarkPriceHistoriesPromoted(tx, productCodes, at)
+}
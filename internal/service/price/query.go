@@ -0,0 +1,118 @@
+package price
+
+import (
+	"time"
+	"xinde/internal/dao/account"
+	dto "xinde/internal/dto/price"
+	groupModel "xinde/internal/model/group"
+)
+
+// QueryPricesForUser 查询用户在一组产品上最终看到的价格。这里不知道产品所属的设备分组，
+// 按设备分组的折扣规则不会生效；没有任何价格的产品和 viewer 看不到的产品（见 visibleProductCodes）不会出现在结果中
+func (s *Service) QueryPricesForUser(viewer *groupModel.Viewer, uid uint, productCodes []string) ([]*dto.QueryData, error) {
+	codes, err := s.visibleProductCodes(viewer, uniqueCodes(productCodes))
+	if err != nil {
+		return nil, err
+	}
+	prices, err := s.accountDao.FindPricesForUser(s.accountDao.DB(), uid, codes, nil, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return convertUserPricesToDTO(prices), nil
+}
+
+// QueryPricesForCompany 与 QueryPricesForUser 相同，按公司计算价格，供 API Key 调用
+func (s *Service) QueryPricesForCompany(viewer *groupModel.Viewer, companyID uint, productCodes []string) ([]*dto.QueryData, error) {
+	codes, err := s.visibleProductCodes(viewer, uniqueCodes(productCodes))
+	if err != nil {
+		return nil, err
+	}
+	prices, err := s.accountDao.FindPricesForCompany(s.accountDao.DB(), companyID, codes, nil, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return convertUserPricesToDTO(prices), nil
+}
+
+// visibleProductCodes 去掉 viewer 看不到的产品：产品出现在方案中时，至少要有一个所属的设备类型已发布且对 viewer 可见，
+// 与查看方案时的限制一致。没有出现在任何方案中的产品不属于产品目录，不受限制
+func (s *Service) visibleProductCodes(viewer *groupModel.Viewer, productCodes []string) ([]string, error) {
+	if viewer.All || len(productCodes) == 0 {
+		return productCodes, nil
+	}
+	deviceTypeIDsByCode, err := s.solutionDao.FindDeviceTypeIDsByProductCodes(s.solutionDao.DB(), productCodes)
+	if err != nil {
+		return nil, err
+	}
+	if len(deviceTypeIDsByCode) == 0 {
+		return productCodes, nil
+	}
+
+	seen := make(map[uint]bool)
+	var ids []uint
+	for _, dtIDs := range deviceTypeIDsByCode {
+		for _, id := range dtIDs {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	deviceTypes, err := s.deviceDao.GetDeviceTypesByIDs(s.deviceDao.DB(), ids)
+	if err != nil {
+		return nil, err
+	}
+	visibility, err := s.groupDao.LoadVisibility(s.groupDao.DB(), viewer)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	visible := make(map[uint]bool, len(deviceTypes))
+	for _, dt := range deviceTypes {
+		if dt.IsPublished(now) && visibility.DeviceTypeVisible(dt.ID, dt.GroupID) {
+			visible[dt.ID] = true
+		}
+	}
+
+	result := make([]string, 0, len(productCodes))
+	for _, code := range productCodes {
+		dtIDs, inCatalog := deviceTypeIDsByCode[code]
+		if !inCatalog {
+			result = append(result, code)
+			continue
+		}
+		for _, id := range dtIDs {
+			if visible[id] {
+				result = append(result, code)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func uniqueCodes(codes []string) []string {
+	seen := make(map[string]bool, len(codes))
+	result := make([]string, 0, len(codes))
+	for _, code := range codes {
+		if !seen[code] {
+			seen[code] = true
+			result = append(result, code)
+		}
+	}
+	return result
+}
+
+func convertUserPricesToDTO(prices []*account.UserPrice) []*dto.QueryData {
+	list := make([]*dto.QueryData, 0, len(prices))
+	for _, p := range prices {
+		list = append(list, &dto.QueryData{
+			ProductCode:     p.ProductCode,
+			Price:           p.Price,
+			Source:          p.Source,
+			LevelCode:       p.LevelCode,
+			DiscountPercent: p.DiscountPercent,
+		})
+	}
+	return list
+}
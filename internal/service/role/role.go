@@ -0,0 +1,355 @@
+package role
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"xinde/internal/dao/account"
+	"xinde/internal/dao/audit"
+	"xinde/internal/dao/role"
+	dto "xinde/internal/dto/role"
+	auditModel "xinde/internal/model/audit"
+	model "xinde/internal/model/role"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+type Service struct {
+	dao        *role.Dao
+	accountDao *account.Dao
+	auditDao   *audit.Dao
+}
+
+func NewRoleService() (*Service, error) {
+	dao, err := role.NewRoleDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建 DAO 实例失败: %w", err)
+	}
+	accountDao, err := account.NewRegisterDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建 DAO 实例失败: %w", err)
+	}
+
+	auditDao, err := audit.NewAuditDao()
+	if err != nil {
+		return nil, fmt.Errorf("创建 DAO 实例失败: %w", err)
+	}
+
+	return &Service{
+		dao:        dao,
+		accountDao: accountDao,
+		auditDao:   auditDao,
+	}, nil
+}
+
+func (s *Service) GetPermissionList() []*dto.PermissionData {
+	list := make([]*dto.PermissionData, 0, len(model.Permissions))
+	for _, p := range model.Permissions {
+		list = append(list, &dto.PermissionData{
+			Code: p.Code,
+			Name: p.Name,
+		})
+	}
+	return list
+}
+
+func (s *Service) GetRoleList() ([]*dto.RoleData, error) {
+	roles, err := s.dao.FindAllRoles(s.dao.DB())
+	if err != nil {
+		return nil, err
+	}
+	return buildRoleList(roles), nil
+}
+
+func (s *Service) CreateRole(actor *auditModel.Actor, req *dto.CreateRoleReq) error {
+	permissions, err := checkPermissions(req.Permissions)
+	if err != nil {
+		return err
+	}
+
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		isExist, err := s.dao.IsExistRoleByCode(tx, req.Code)
+		if err != nil {
+			return err
+		}
+		if isExist {
+			return fmt.Errorf(stderr.ErrorRoleCodeConflict)
+		}
+
+		role := &model.Role{
+			Code:        req.Code,
+			Name:        req.Name,
+			Description: util.StringToPointer(req.Description),
+		}
+		for _, p := range permissions {
+			role.Permissions = append(role.Permissions, &model.RolePermission{Permission: p})
+		}
+		if err := s.dao.CreateRole(tx, role); err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, auditModel.ActionRoleCreate, auditModel.EntityRole, role.ID, nil, roleSnapshot(role))
+	})
+}
+
+// UpdateRole 修改角色的名称、说明或权限。权限在每次请求时实时查询，修改后立即对拥有该角色的用户生效
+func (s *Service) UpdateRole(actor *auditModel.Actor, id uint, req *dto.UpdateRoleReq) error {
+	var permissions []string
+	if req.Permissions != nil {
+		var err error
+		if permissions, err = checkPermissions(req.Permissions); err != nil {
+			return err
+		}
+	}
+
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		role, err := s.dao.GetRoleByID(tx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf(stderr.ErrorRoleNotFound)
+			}
+			return err
+		}
+		if role.IsSystem {
+			return fmt.Errorf(stderr.ErrorRoleSystemReadOnly)
+		}
+
+		updateData := make(map[string]interface{})
+		if req.Name != "" {
+			updateData["name"] = req.Name
+		}
+		if req.Description != nil {
+			updateData["description"] = util.StringToPointer(*req.Description)
+		}
+		if len(updateData) > 0 {
+			if err := s.dao.UpdateRole(tx, id, updateData); err != nil {
+				return err
+			}
+		}
+
+		if permissions != nil {
+			if err := s.dao.ReplaceRolePermissions(tx, id, permissions); err != nil {
+				return err
+			}
+			// 标签角色和管理角色互相转换时，拥有该角色的用户是否为管理员可能随之变化
+			if (len(role.Permissions) == 0) != (len(permissions) == 0) {
+				uids, err := s.dao.FindUIDsByRoleID(tx, id)
+				if err != nil {
+					return err
+				}
+				for _, uid := range uids {
+					if err := s.afterUserRolesChanged(tx, uid); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		updated, err := s.dao.GetRoleByID(tx, id)
+		if err != nil {
+			return err
+		}
+		return s.auditDao.Record(tx, actor, auditModel.ActionRoleUpdate, auditModel.EntityRole, id, roleSnapshot(role), roleSnapshot(updated))
+	})
+}
+
+// DeleteRole 删除角色，拥有该角色的用户随之失去相应权限，并需要重新登录
+func (s *Service) DeleteRole(actor *auditModel.Actor, id uint) error {
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		role, err := s.dao.GetRoleByID(tx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf(stderr.ErrorRoleNotFound)
+			}
+			return err
+		}
+		if role.IsSystem {
+			return fmt.Errorf(stderr.ErrorRoleSystemReadOnly)
+		}
+
+		uids, err := s.dao.FindUIDsByRoleID(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.dao.DeleteRoleByID(tx, id); err != nil {
+			return err
+		}
+		for _, uid := range uids {
+			if err := s.afterUserRolesChanged(tx, uid); err != nil {
+				return err
+			}
+		}
+
+		before := roleSnapshot(role)
+		before["uids"] = uids
+		return s.auditDao.Record(tx, actor, auditModel.ActionRoleDelete, auditModel.EntityRole, id, before, nil)
+	})
+}
+
+func (s *Service) GetUserRoles(uid uint) ([]*dto.RoleData, error) {
+	tx := s.dao.DB()
+	isExist, err := s.accountDao.IsExistUserByID(tx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if !isExist {
+		return nil, fmt.Errorf(stderr.ErrorUserNotFound)
+	}
+
+	roles, err := s.dao.FindRolesByUID(tx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return buildRoleList(roles), nil
+}
+
+// SetUserRoles 整体替换用户的角色。角色变化后吊销该用户已签发的token，
+// 并且不允许拿掉最后一名超级管理员的全部权限
+func (s *Service) SetUserRoles(actor *auditModel.Actor, uid uint, roleIDs []uint) error {
+	roleIDs = uniqueIDs(roleIDs)
+
+	return s.dao.DB().Transaction(func(tx *gorm.DB) error {
+		isExist, err := s.accountDao.IsExistUserByID(tx, uid)
+		if err != nil {
+			return err
+		}
+		if !isExist {
+			return fmt.Errorf(stderr.ErrorUserNotFound)
+		}
+
+		roles, err := s.dao.FindRolesByIDs(tx, roleIDs)
+		if err != nil {
+			return err
+		}
+		if len(roles) != len(roleIDs) {
+			return fmt.Errorf(stderr.ErrorRoleNotFound)
+		}
+
+		oldRoles, err := s.dao.FindRolesByUID(tx, uid)
+		if err != nil {
+			return err
+		}
+		if hasPermission(oldRoles, model.PermAll) && !hasPermission(roles, model.PermAll) {
+			count, err := s.dao.CountUsersWithPermission(tx, model.PermAll, uid)
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				return fmt.Errorf(stderr.ErrorRoleLastSuperAdmin)
+			}
+		}
+
+		if err := s.dao.ReplaceUserRoles(tx, uid, roleIDs); err != nil {
+			return err
+		}
+		if err := s.afterUserRolesChanged(tx, uid); err != nil {
+			return err
+		}
+
+		return s.auditDao.Record(tx, actor, auditModel.ActionUserSetRoles, auditModel.EntityUser, uid,
+			map[string]interface{}{"roles": roleCodes(oldRoles)}, map[string]interface{}{"roles": roleCodes(roles)})
+	})
+}
+
+// afterUserRolesChanged 用户的角色变化后，同步 is_admin（角色授予了任意权限即为管理员，只有标签角色的用户不是管理员）
+// 并吊销该用户的token，使新的权限和 token 中的 is_admin 立即生效
+func (s *Service) afterUserRolesChanged(tx *gorm.DB, uid uint) error {
+	roles, err := s.dao.FindRolesByUID(tx, uid)
+	if err != nil {
+		return err
+	}
+	isAdmin := 0
+	for _, r := range roles {
+		if len(r.Permissions) > 0 {
+			isAdmin = 1
+			break
+		}
+	}
+	if err := s.accountDao.UpdateUser(tx, uid, map[string]interface{}{"is_admin": isAdmin}); err != nil {
+		return err
+	}
+	return s.accountDao.RevokeUserTokens(tx, uid)
+}
+
+// checkPermissions 校验并去重权限编码。全部权限(*)只属于内置的超级管理员角色，不能分配给自定义角色
+func checkPermissions(permissions []string) ([]string, error) {
+	seen := make(map[string]bool, len(permissions))
+	result := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		if p == model.PermAll || !model.IsValidPermission(p) {
+			return nil, fmt.Errorf(stderr.ErrorRolePermissionInvalid)
+		}
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+func hasPermission(roles []*model.Role, permission string) bool {
+	for _, r := range roles {
+		for _, p := range r.Permissions {
+			if p.Permission == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	result := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
+
+func buildRoleList(roles []*model.Role) []*dto.RoleData {
+	list := make([]*dto.RoleData, 0, len(roles))
+	for _, r := range roles {
+		data := &dto.RoleData{
+			ID:          r.ID,
+			Code:        r.Code,
+			Name:        r.Name,
+			IsSystem:    r.IsSystem,
+			Permissions: make([]string, 0, len(r.Permissions)),
+		}
+		if r.Description != nil {
+			data.Description = *r.Description
+		}
+		for _, p := range r.Permissions {
+			data.Permissions = append(data.Permissions, p.Permission)
+		}
+		list = append(list, data)
+	}
+	return list
+}
+
+// roleSnapshot 审计日志中记录的角色信息
+func roleSnapshot(r *model.Role) map[string]interface{} {
+	permissions := make([]string, 0, len(r.Permissions))
+	for _, p := range r.Permissions {
+		permissions = append(permissions, p.Permission)
+	}
+	return map[string]interface{}{
+		"code":        r.Code,
+		"name":        r.Name,
+		"description": r.Description,
+		"permissions": permissions,
+	}
+}
+
+func roleCodes(roles []*model.Role) []string {
+	codes := make([]string, 0, len(roles))
+	for _, r := range roles {
+		codes = append(codes, r.Code)
+	}
+	return codes
+}
@@ -3,8 +3,10 @@ package solution
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/spf13/viper"
+	"gorm.io/gorm"
 	"io/ioutil"
 	"net/http"
 	"sort"
@@ -15,14 +17,17 @@ import (
 	"xinde/internal/dao/attachment"
 	"xinde/internal/dao/device"
 	"xinde/internal/dao/device_access_log"
+	"xinde/internal/dao/group"
 	"xinde/internal/dao/solution"
 	deviceDto "xinde/internal/dto/device"
 	dto "xinde/internal/dto/solution"
 	attachmentModel "xinde/internal/model/attachment"
 	deviceModel "xinde/internal/model/device"
 	model "xinde/internal/model/device_access_log"
+	groupModel "xinde/internal/model/group"
 	"xinde/pkg/jwt"
 	"xinde/pkg/logger"
+	"xinde/pkg/stderr"
 )
 
 type Service struct {
@@ -32,6 +37,7 @@ type Service struct {
 	deviceAccessLogDao *device_access_log.Dao
 	j                  *jwt.JWTService
 	accountDao         *account.Dao
+	groupDao           *group.Dao
 }
 
 func NewSolutionService() (*Service, error) {
@@ -55,6 +61,10 @@ func NewSolutionService() (*Service, error) {
 	if err != nil {
 		return nil, fmt.Errorf("NewDeviceAccessLogDao() 创建Dao实例失败: %v", err)
 	}
+	groupDao, err := group.NewGroupDao()
+	if err != nil {
+		return nil, fmt.Errorf("NewGroupDao() 创建Dao实例失败: %v", err)
+	}
 	j := jwt.NewJWTService()
 	return &Service{
 		dao:                dao,
@@ -63,6 +73,7 @@ func NewSolutionService() (*Service, error) {
 		j:                  j,
 		accountDao:         accountDao,
 		deviceAccessLogDao: deviceAcessLogDao,
+		groupDao:           groupDao,
 	}, nil
 }
 
@@ -93,9 +104,43 @@ func (s *Service) recordAccessLog(userID, deviceTypeID uint) {
 	}
 }
 
+// priceFinder 查询一组产品最终价格的方式，按用户或按公司
+type priceFinder func(tx *gorm.DB, productCodes []string, groupIDs []uint, at time.Time) ([]*account.UserPrice, error)
+
+// Query 按用户查询方案，设备类型对该用户隐藏时返回 ErrorDeviceNotFound
 func (s *Service) Query(userID uint, req *dto.QueryReq) (*dto.QueryResp, error) {
-	// 0. 记录日志
+	resp, err := s.query(req, &groupModel.Viewer{UID: userID}, func(tx *gorm.DB, productCodes []string, groupIDs []uint, at time.Time) ([]*account.UserPrice, error) {
+		return s.accountDao.FindPricesForUser(tx, userID, productCodes, groupIDs, at)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 记录日志，只记录用户可以访问的设备类型
 	go s.recordAccessLog(userID, req.DeviceTypeID)
+	return resp, nil
+}
+
+// Preview 供管理员预览方案，不受发布状态和可见性规则限制，也不记录设备访问日志。价格按管理员本人计算
+func (s *Service) Preview(userID uint, req *dto.QueryReq) (*dto.QueryResp, error) {
+	return s.query(req, &groupModel.Viewer{All: true, UID: userID}, func(tx *gorm.DB, productCodes []string, groupIDs []uint, at time.Time) ([]*account.UserPrice, error) {
+		return s.accountDao.FindPricesForUser(tx, userID, productCodes, groupIDs, at)
+	})
+}
+
+// QueryForCompany 供 API Key 调用，价格和可见性按 Key 所属的公司计算。设备访问日志按用户统计，这里不记录
+func (s *Service) QueryForCompany(companyID uint, req *dto.QueryReq) (*dto.QueryResp, error) {
+	return s.query(req, &groupModel.Viewer{CompanyID: companyID}, func(tx *gorm.DB, productCodes []string, groupIDs []uint, at time.Time) ([]*account.UserPrice, error) {
+		return s.accountDao.FindPricesForCompany(tx, companyID, productCodes, groupIDs, at)
+	})
+}
+
+func (s *Service) query(req *dto.QueryReq, viewer *groupModel.Viewer, findPrices priceFinder) (*dto.QueryResp, error) {
+	// 0. 设备类型不存在或对查询者隐藏时不返回任何数据
+	deviceType, err := s.getVisibleDeviceType(req.DeviceTypeID, viewer)
+	if err != nil {
+		return nil, err
+	}
 
 	// 1. 查询方案列表和总数
 	total, solutions, err := s.dao.QuerySolutions(s.dao.DB(), req)
@@ -110,7 +155,11 @@ func (s *Service) Query(userID uint, req *dto.QueryReq) (*dto.QueryResp, error)
 	}
 
 	// 3. 聚合外部数据 (价格 & API)
-	solutionDataList, err := s.aggregateExternalData(userID, solutions)
+	groupIDs, err := s.groupDao.FindAncestorIDs(s.groupDao.DB(), deviceType.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	solutionDataList, err := s.aggregateExternalData(findPrices, groupIDs, solutions)
 	if err != nil {
 		return nil, err
 	}
@@ -163,7 +212,7 @@ type ApiResponse struct {
 }
 
 // aggregateExternalData 是新的辅助函数，负责将 model 转换为包含外部数据的 DTO
-func (s *Service) aggregateExternalData(userID uint, solutions []*deviceModel.Device) ([]*dto.SolutionData, error) {
+func (s *Service) aggregateExternalData(findPrices priceFinder, groupIDs []uint, solutions []*deviceModel.Device) ([]*dto.SolutionData, error) {
 	var solutionDataList []*dto.SolutionData
 
 	// 1. 收集所有不重复的 product_code
@@ -192,26 +241,15 @@ func (s *Service) aggregateExternalData(userID uint, solutions []*deviceModel.De
 	}
 
 	// 3. 批量查询 MySQL 价格表
-	priceResults, err := s.accountDao.FindPricesForUser(s.accountDao.DB(), userID, productCodes)
+	priceResults, err := findPrices(s.accountDao.DB(), productCodes, groupIDs, time.Now())
 	if err != nil {
 		return nil, fmt.Errorf("查询价格失败: %w", err)
 	}
 
 	// 将价格结果转换为 product_code -> price 的 map，方便查找
-	priceMap := make(map[string]float64)
+	priceMap := make(map[string]*account.UserPrice)
 	for _, p := range priceResults {
-		switch p.PriceLevel {
-		case "price_1":
-			priceMap[p.ProductCode] = p.Price1
-		case "price_2":
-			priceMap[p.ProductCode] = p.Price2
-		case "price_3":
-			priceMap[p.ProductCode] = p.Price3
-		case "price_4":
-			priceMap[p.ProductCode] = p.Price4
-		default:
-			priceMap[p.ProductCode] = p.Price1 // 默认价格
-		}
+		priceMap[p.ProductCode] = p
 	}
 
 	// 4. 遍历并聚合数据
@@ -232,7 +270,12 @@ func (s *Service) aggregateExternalData(userID uint, solutions []*deviceModel.De
 				Name:        comp.Name,
 				ProductCode: comp.ProductCode,
 				SpecCode:    comp.SpecCode,
-				Price:       priceMap[comp.ProductCode], // 从价格 map 中获取
+			}
+			// 从价格 map 中获取
+			if p, ok := priceMap[comp.ProductCode]; ok {
+				readComp.Price = p.Price
+				readComp.PriceSource = p.Source
+				readComp.PriceRuleID = p.RuleID
 			}
 
 			// 从 API 结果中填充数据
@@ -409,3 +452,29 @@ func (s *Service) buildAvailableFilters(deviceTypeID uint, aggFilters map[string
 
 	return availableFilters, nil
 }
+
+// getVisibleDeviceType 返回 viewer 可见的设备类型。设备类型不存在或对 viewer 隐藏时都返回 ErrorDeviceNotFound，不暴露隐藏的设备类型是否存在。
+// 设备类型所属分组及其祖先分组用于匹配按设备分组的折扣规则
+func (s *Service) getVisibleDeviceType(deviceTypeID uint, viewer *groupModel.Viewer) (*deviceModel.DeviceType, error) {
+	deviceType, err := s.deviceDao.GetDeviceTypeByID(s.deviceDao.DB(), deviceTypeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf(stderr.ErrorDeviceNotFound)
+		}
+		return nil, err
+	}
+
+	// 未发布的设备类型只有预览（viewer.All）时可以查看
+	if !viewer.All && !deviceType.IsPublished(time.Now()) {
+		return nil, fmt.Errorf(stderr.ErrorDeviceNotFound)
+	}
+
+	visibility, err := s.groupDao.LoadVisibility(s.groupDao.DB(), viewer)
+	if err != nil {
+		return nil, err
+	}
+	if !visibility.DeviceTypeVisible(deviceType.ID, deviceType.GroupID) {
+		return nil, fmt.Errorf(stderr.ErrorDeviceNotFound)
+	}
+	return deviceType, nil
+}
@@ -1,8 +1,13 @@
 package jwt
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/spf13/viper"
 	"time"
 	"xinde/pkg/stderr"
@@ -12,48 +17,94 @@ import (
 
 // JWTService handles JWT token generation and validation.
 type JWTService struct {
-	secretKey     []byte        // JWT 签名密钥
-	tokenDuration time.Duration // Token 有效期
+	tokenDuration        time.Duration // access token 有效期
+	refreshTokenDuration time.Duration // refresh token 有效期
+	mfaTokenDuration     time.Duration // 等待二次验证的 token 有效期
 }
 
+// PurposeMFA 密码校验通过、等待二次验证时签发的 token 的用途，这种 token 不能当作 access token 使用
+const PurposeMFA = "mfa"
+
 // CustomClaims defines the custom claims for our JWT.
 type CustomClaims struct {
 	UID      uint   `json:"uid"`
 	Username string `json:"username"`
 	IsAdmin  bool   `json:"is_admin"`
+	// 签发时用户的 token 版本号，用户的 token 被吊销后版本号递增，旧 token 随之失效
+	TokenVersion uint `json:"ver"`
+	// token 的用途，access token 为空
+	Purpose string `json:"pur,omitempty"`
 	jwt.RegisteredClaims
 }
 
 // NewJWTService creates a new JWTService.
+// 签名和验证使用的密钥来自 Keys()，轮换密钥不需要重新创建 JWTService
 func NewJWTService() *JWTService {
-	secret := viper.GetString("jwt.secret")
 	duration := viper.GetDuration("jwt.duration")
+	refreshDuration := viper.GetDuration("jwt.refreshDuration")
+	mfaDuration := viper.GetDuration("mfa.tokenDuration")
 	return &JWTService{
-		secretKey:     []byte(secret),
-		tokenDuration: duration,
+		tokenDuration:        duration,
+		refreshTokenDuration: refreshDuration,
+		mfaTokenDuration:     mfaDuration,
 	}
 }
 
-// GenerateToken creates a new JWT token for a user.
-func (s *JWTService) GenerateToken(uid uint, username string, isAdmin bool) (string, error) {
+// TokenDuration 返回 access token 的有效期
+func (s *JWTService) TokenDuration() time.Duration {
+	return s.tokenDuration
+}
+
+// RefreshTokenDuration 返回 refresh token 的有效期
+func (s *JWTService) RefreshTokenDuration() time.Duration {
+	return s.refreshTokenDuration
+}
+
+// MFATokenDuration 返回等待二次验证的 token 的有效期
+func (s *JWTService) MFATokenDuration() time.Duration {
+	return s.mfaTokenDuration
+}
+
+// GenerateToken creates a new JWT access token for a user.
+// 每个 token 带有唯一的 jti，注销时据此把单个 token 加入黑名单
+func (s *JWTService) GenerateToken(uid uint, username string, isAdmin bool, tokenVersion uint) (string, error) {
+	return s.generate(uid, username, isAdmin, tokenVersion, "", s.tokenDuration)
+}
+
+// GenerateMFAToken 密码校验通过后签发一个短期有效的 token，只能用于完成二次验证
+func (s *JWTService) GenerateMFAToken(uid uint, username string, isAdmin bool, tokenVersion uint) (string, error) {
+	return s.generate(uid, username, isAdmin, tokenVersion, PurposeMFA, s.mfaTokenDuration)
+}
+
+func (s *JWTService) generate(uid uint, username string, isAdmin bool, tokenVersion uint, purpose string, duration time.Duration) (string, error) {
 	// 创建 claims
 	claims := CustomClaims{
-		UID:      uid,
-		Username: username,
-		IsAdmin:  isAdmin,
+		UID:          uid,
+		Username:     username,
+		IsAdmin:      isAdmin,
+		TokenVersion: tokenVersion,
+		Purpose:      purpose,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.tokenDuration)),
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(duration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Issuer:    "信德刀具选型", // 签发人
 		},
 	}
 
-	// 使用 HS256 签名方法创建一个新的 Token 对象
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	// 使用当前的签发密钥创建 Token 对象，并在头部带上 kid，验证时据此找到对应的密钥
+	key, err := Keys().signingKey()
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(key.method(), claims)
+	if key.Kid != LegacyKid {
+		token.Header["kid"] = key.Kid
+	}
 
 	// 使用密钥签名并获取完整的编码后的字符串 token
-	signedToken, err := token.SignedString(s.secretKey)
+	signedToken, err := token.SignedString(key.signKey)
 	if err != nil {
 		return "", err
 	}
@@ -63,13 +114,42 @@ func (s *JWTService) GenerateToken(uid uint, username string, isAdmin bool) (str
 
 // ValidateToken validates a JWT token string.
 // It returns the custom claims if the token is valid.
+// 带有用途的 token（如等待二次验证的 token）不是 access token，一律视为无效
 func (s *JWTService) ValidateToken(tokenString string) (*CustomClaims, error) {
+	claims, err := s.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != "" {
+		return nil, fmt.Errorf(stderr.ErrorTokenInvalid)
+	}
+	return claims, nil
+}
+
+// ValidateMFAToken 校验等待二次验证的 token，其他 token 一律视为无效
+func (s *JWTService) ValidateMFAToken(tokenString string) (*CustomClaims, error) {
+	claims, err := s.parse(tokenString)
+	if err != nil {
+		return nil, fmt.Errorf(stderr.ErrorMFATokenInvalid)
+	}
+	if claims.Purpose != PurposeMFA {
+		return nil, fmt.Errorf(stderr.ErrorMFATokenInvalid)
+	}
+	return claims, nil
+}
+
+func (s *JWTService) parse(tokenString string) (*CustomClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// 确保签名方法是我们期望的 HMAC
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		// 按 kid 找到验证密钥，并确保签名方法与密钥的算法一致，防止算法混淆攻击
+		kid, _ := token.Header["kid"].(string)
+		key, err := Keys().verificationKey(kid)
+		if err != nil {
+			return nil, err
+		}
+		if token.Method.Alg() != key.method().Alg() {
 			return nil, fmt.Errorf(stderr.ErrorTokenInvalid)
 		}
-		return s.secretKey, nil
+		return key.verifyKey, nil
 	})
 
 	if err != nil {
@@ -92,3 +172,20 @@ func (s *JWTService) ValidateToken(tokenString string) (*CustomClaims, error) {
 
 	return nil, fmt.Errorf(stderr.ErrorTokenInvalid)
 }
+
+// GenerateRefreshToken 生成一个随机的 refresh token，返回明文和用于入库的哈希值。
+// refresh token 不是 JWT，只以哈希形式保存在数据库中
+func (s *JWTService) GenerateRefreshToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("生成refresh token失败: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashRefreshToken(token), nil
+}
+
+// HashRefreshToken 计算 refresh token 的 SHA-256 哈希（十六进制）
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,532 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+	"xinde/pkg/stderr"
+	"xinde/pkg/util"
+)
+
+// 支持的签名算法
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// 密钥状态：active 用于签发新 token；retired 不再签发，在 VerifyUntil 之前仍可验证用它签发的 token
+const (
+	KeyStatusActive  = "active"
+	KeyStatusRetired = "retired"
+)
+
+// LegacyKid 配置文件中 jwt.secret 对应的密钥。引入密钥集之前签发的 token 没有 kid，按这把密钥验证
+const LegacyKid = "legacy"
+
+const (
+	rsaKeyBits = 2048
+	hmacKeyLen = 32
+	// reloadMinInterval 遇到未知 kid 时重新加载密钥的最小间隔，防止伪造的 kid 频繁查库
+	reloadMinInterval = 5 * time.Second
+	// verifyLeeway 退役密钥在 token 最长有效期之外多保留的验证时间，容忍各实例间的时钟偏差
+	verifyLeeway = time.Minute
+)
+
+// jwtKey represents the t_jwt_key table in the database.
+type jwtKey struct {
+	Kid string `gorm:"primaryKey;column:kid"`
+	Alg string `gorm:"column:alg;not null"`
+	// 私钥（PKCS#8 PEM）或 HMAC 密钥，加密保存
+	PrivateKey string `gorm:"column:private_key;not null"`
+	// 公钥（PKIX PEM），HMAC 密钥为空
+	PublicKey   string     `gorm:"column:public_key;not null;default:''"`
+	Status      string     `gorm:"column:status;not null"`
+	VerifyUntil *time.Time `gorm:"column:verify_until"`
+	RetiredAt   *time.Time `gorm:"column:retired_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at;not null;autoCreateTime"`
+}
+
+func (jwtKey) TableName() string {
+	return "t_jwt_key"
+}
+
+// KeyInfo 密钥的公开信息，不包含任何密钥材料
+type KeyInfo struct {
+	Kid         string
+	Alg         string
+	Status      string
+	VerifyUntil *time.Time
+	RetiredAt   *time.Time
+	CreatedAt   time.Time
+}
+
+// key 已解析的密钥
+type key struct {
+	KeyInfo
+	signKey   interface{} // []byte、*rsa.PrivateKey 或 ed25519.PrivateKey
+	verifyKey interface{} // []byte、*rsa.PublicKey 或 ed25519.PublicKey
+}
+
+func (k *key) method() jwt.SigningMethod {
+	switch k.Alg {
+	case AlgRS256:
+		return jwt.SigningMethodRS256
+	case AlgEdDSA:
+		return jwt.SigningMethodEdDSA
+	default:
+		return jwt.SigningMethodHS256
+	}
+}
+
+// canVerify 是否仍可用于验证 token
+func (k *key) canVerify(now time.Time) bool {
+	if k.Status == KeyStatusActive {
+		return true
+	}
+	return k.VerifyUntil != nil && now.Before(*k.VerifyUntil)
+}
+
+// KeySet 签发和验证 token 使用的一组密钥，保存在 t_jwt_key 中，多个实例共享。
+// 最新的 active 密钥用于签发；轮换后旧密钥退役，在已签发的 token 全部过期之前仍可验证，轮换不会让用户掉线。
+// 数据库中还没有任何密钥时（从未轮换过），使用配置文件中的 jwt.secret 签发，与引入密钥集之前的行为一致
+type KeySet struct {
+	db              *gorm.DB
+	refreshInterval time.Duration
+
+	mu       sync.RWMutex
+	keys     map[string]*key
+	signer   *key
+	loadedAt time.Time
+}
+
+var (
+	defaultKeySet   *KeySet
+	defaultKeySetMu sync.RWMutex
+)
+
+// InitKeySet 使用数据库中的密钥集，需要在数据库初始化之后、签发或验证 token 之前调用
+func InitKeySet(db *gorm.DB) error {
+	ks := &KeySet{
+		db:              db,
+		refreshInterval: viper.GetDuration("jwt.keyRefreshInterval"),
+	}
+	if err := ks.Reload(); err != nil {
+		return err
+	}
+
+	defaultKeySetMu.Lock()
+	defaultKeySet = ks
+	defaultKeySetMu.Unlock()
+	return nil
+}
+
+// Keys 返回当前使用的密钥集。未调用 InitKeySet 时只包含 jwt.secret，每次按当前配置构造
+func Keys() *KeySet {
+	defaultKeySetMu.RLock()
+	defer defaultKeySetMu.RUnlock()
+	if defaultKeySet == nil {
+		return newStaticKeySet()
+	}
+	return defaultKeySet
+}
+
+// newStaticKeySet 只包含 jwt.secret 的密钥集
+func newStaticKeySet() *KeySet {
+	ks := &KeySet{}
+	ks.setKeys(nil)
+	return ks
+}
+
+// legacyKey 配置文件中 jwt.secret 对应的 HS256 密钥
+func legacyKey() *key {
+	secret := []byte(viper.GetString("jwt.secret"))
+	return &key{
+		KeyInfo: KeyInfo{
+			Kid:    LegacyKid,
+			Alg:    AlgHS256,
+			Status: KeyStatusActive,
+		},
+		signKey:   secret,
+		verifyKey: secret,
+	}
+}
+
+// Reload 从数据库重新加载密钥
+func (ks *KeySet) Reload() error {
+	if ks.db == nil {
+		return nil
+	}
+
+	var rows []*jwtKey
+	if err := ks.db.Order("created_at asc").Find(&rows).Error; err != nil {
+		return fmt.Errorf("加载JWT签名密钥失败: %w", err)
+	}
+	keys := make([]*key, 0, len(rows))
+	for _, row := range rows {
+		k, err := parseKey(row)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, k)
+	}
+	ks.setKeys(keys)
+	return nil
+}
+
+func (ks *KeySet) setKeys(keys []*key) {
+	if len(keys) == 0 {
+		keys = []*key{legacyKey()}
+	}
+
+	m := make(map[string]*key, len(keys))
+	var signer *key
+	for _, k := range keys {
+		m[k.Kid] = k
+		// 按创建时间升序，最后一把 active 密钥用于签发
+		if k.Status == KeyStatusActive {
+			signer = k
+		}
+	}
+
+	ks.mu.Lock()
+	ks.keys = m
+	ks.signer = signer
+	ks.loadedAt = time.Now()
+	ks.mu.Unlock()
+}
+
+// refreshIfStale 距上次加载超过 interval 时重新加载，其他实例轮换的密钥据此生效。加载失败时继续使用已有的密钥
+func (ks *KeySet) refreshIfStale(interval time.Duration) {
+	if ks.db == nil || interval <= 0 {
+		return
+	}
+	ks.mu.RLock()
+	stale := time.Since(ks.loadedAt) >= interval
+	ks.mu.RUnlock()
+	if !stale {
+		return
+	}
+	_ = ks.Reload()
+}
+
+// signingKey 返回用于签发 token 的密钥
+func (ks *KeySet) signingKey() (*key, error) {
+	ks.refreshIfStale(ks.refreshInterval)
+
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	if ks.signer == nil {
+		return nil, fmt.Errorf("没有可用于签发token的密钥")
+	}
+	return ks.signer, nil
+}
+
+// verificationKey 根据 kid 返回用于验证 token 的密钥，没有 kid 的 token 使用 jwt.secret 验证
+func (ks *KeySet) verificationKey(kid string) (*key, error) {
+	if kid == "" {
+		kid = LegacyKid
+	}
+	ks.refreshIfStale(ks.refreshInterval)
+
+	ks.mu.RLock()
+	k, ok := ks.keys[kid]
+	ks.mu.RUnlock()
+	if !ok {
+		// 可能是其他实例刚轮换出的新密钥
+		ks.refreshIfStale(reloadMinInterval)
+		ks.mu.RLock()
+		k, ok = ks.keys[kid]
+		ks.mu.RUnlock()
+	}
+	if !ok || !k.canVerify(time.Now()) {
+		return nil, fmt.Errorf(stderr.ErrorTokenInvalid)
+	}
+	return k, nil
+}
+
+// List 返回全部密钥的公开信息，按创建时间倒序
+func (ks *KeySet) List() []*KeyInfo {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	list := make([]*KeyInfo, 0, len(ks.keys))
+	for _, k := range ks.keys {
+		info := k.KeyInfo
+		list = append(list, &info)
+	}
+	sortKeyInfos(list)
+	return list
+}
+
+// Rotate 在 tx 中生成一把新的 alg 密钥用于签发，原来的 active 密钥退役，在 token 最长有效期内仍可验证。
+// 第一次轮换时把 jwt.secret 登记为 legacy 密钥，之前签发的 token 同样在有效期内仍可验证。
+// 事务提交后需要调用 Reload 使本实例立即生效，其他实例在 jwt.keyRefreshInterval 内生效
+func (ks *KeySet) Rotate(tx *gorm.DB, alg string) (*KeyInfo, error) {
+	if tx == nil {
+		return nil, fmt.Errorf(stderr.ErrorDbNil)
+	}
+
+	row, err := generateKey(alg)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	verifyUntil := now.Add(maxTokenLifetime())
+
+	var active []*jwtKey
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("status = ?", KeyStatusActive).Find(&active).Error
+	if err != nil {
+		return nil, fmt.Errorf("查询JWT签名密钥失败: %w", err)
+	}
+	if len(active) > 0 {
+		err = tx.Model(&jwtKey{}).Where("status = ?", KeyStatusActive).Updates(map[string]interface{}{
+			"status":       KeyStatusRetired,
+			"retired_at":   now,
+			"verify_until": verifyUntil,
+		}).Error
+		if err != nil {
+			return nil, fmt.Errorf("退役JWT签名密钥失败: %w", err)
+		}
+	} else {
+		var count int64
+		if err := tx.Model(&jwtKey{}).Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("查询JWT签名密钥失败: %w", err)
+		}
+		if count == 0 {
+			legacy, err := encodeHMACKey(LegacyKid, []byte(viper.GetString("jwt.secret")))
+			if err != nil {
+				return nil, err
+			}
+			legacy.Status = KeyStatusRetired
+			legacy.RetiredAt = &now
+			legacy.VerifyUntil = &verifyUntil
+			if err := tx.Create(legacy).Error; err != nil {
+				return nil, fmt.Errorf("登记legacy密钥失败: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Create(row).Error; err != nil {
+		return nil, fmt.Errorf("保存JWT签名密钥失败: %w", err)
+	}
+	return &KeyInfo{
+		Kid:       row.Kid,
+		Alg:       row.Alg,
+		Status:    row.Status,
+		CreatedAt: row.CreatedAt,
+	}, nil
+}
+
+// JWK JSON Web Key（RFC 7517）中的公钥
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS 返回仍可用于验证的非对称密钥的公钥，供其他服务离线验证 token。HS256 密钥不能公开，不会出现在这里
+func (ks *KeySet) JWKS() []*JWK {
+	ks.refreshIfStale(ks.refreshInterval)
+
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	now := time.Now()
+	infos := make([]*KeyInfo, 0, len(ks.keys))
+	for _, k := range ks.keys {
+		if k.canVerify(now) {
+			info := k.KeyInfo
+			infos = append(infos, &info)
+		}
+	}
+	sortKeyInfos(infos)
+
+	list := make([]*JWK, 0, len(infos))
+	for _, info := range infos {
+		k := ks.keys[info.Kid]
+		switch pub := k.verifyKey.(type) {
+		case *rsa.PublicKey:
+			list = append(list, &JWK{
+				Kty: "RSA",
+				Kid: k.Kid,
+				Use: "sig",
+				Alg: k.Alg,
+				N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			list = append(list, &JWK{
+				Kty: "OKP",
+				Kid: k.Kid,
+				Use: "sig",
+				Alg: k.Alg,
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(pub),
+			})
+		}
+	}
+	return list
+}
+
+// IsValidAlg 判断签名算法是否支持
+func IsValidAlg(alg string) bool {
+	return alg == AlgHS256 || alg == AlgRS256 || alg == AlgEdDSA
+}
+
+// maxTokenLifetime 用 JWT 签发的 token 的最长有效期，退役的密钥至少要保留这么久。refresh token 不是 JWT，不受影响
+func maxTokenLifetime() time.Duration {
+	lifetime := viper.GetDuration("jwt.duration")
+	if d := viper.GetDuration("mfa.tokenDuration"); d > lifetime {
+		lifetime = d
+	}
+	return lifetime + verifyLeeway
+}
+
+func generateKey(alg string) (*jwtKey, error) {
+	if !IsValidAlg(alg) {
+		return nil, fmt.Errorf(stderr.ErrorJWTKeyAlgInvalid)
+	}
+	kid, err := newKid()
+	if err != nil {
+		return nil, err
+	}
+
+	var private crypto.PrivateKey
+	var public crypto.PublicKey
+	switch alg {
+	case AlgHS256:
+		secret := make([]byte, hmacKeyLen)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("生成HMAC密钥失败: %w", err)
+		}
+		return encodeHMACKey(kid, secret)
+	case AlgRS256:
+		rsaKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, fmt.Errorf("生成RSA密钥失败: %w", err)
+		}
+		private, public = rsaKey, &rsaKey.PublicKey
+	case AlgEdDSA:
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("生成Ed25519密钥失败: %w", err)
+		}
+		private, public = priv, pub
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, fmt.Errorf("编码私钥失败: %w", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return nil, fmt.Errorf("编码公钥失败: %w", err)
+	}
+	encrypted, err := util.EncryptString(util.EncryptionKey("jwt.keyEncryptionKey"), string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})))
+	if err != nil {
+		return nil, fmt.Errorf("加密私钥失败: %w", err)
+	}
+	return &jwtKey{
+		Kid:        kid,
+		Alg:        alg,
+		PrivateKey: encrypted,
+		PublicKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})),
+		Status:     KeyStatusActive,
+	}, nil
+}
+
+func encodeHMACKey(kid string, secret []byte) (*jwtKey, error) {
+	encrypted, err := util.EncryptString(util.EncryptionKey("jwt.keyEncryptionKey"), base64.StdEncoding.EncodeToString(secret))
+	if err != nil {
+		return nil, fmt.Errorf("加密HMAC密钥失败: %w", err)
+	}
+	return &jwtKey{
+		Kid:        kid,
+		Alg:        AlgHS256,
+		PrivateKey: encrypted,
+		Status:     KeyStatusActive,
+	}, nil
+}
+
+func parseKey(row *jwtKey) (*key, error) {
+	k := &key{KeyInfo: KeyInfo{
+		Kid:         row.Kid,
+		Alg:         row.Alg,
+		Status:      row.Status,
+		VerifyUntil: row.VerifyUntil,
+		RetiredAt:   row.RetiredAt,
+		CreatedAt:   row.CreatedAt,
+	}}
+
+	plain, err := util.DecryptString(util.EncryptionKey("jwt.keyEncryptionKey"), row.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("解密JWT签名密钥 %s 失败: %w", row.Kid, err)
+	}
+
+	if row.Alg == AlgHS256 {
+		secret, err := base64.StdEncoding.DecodeString(plain)
+		if err != nil {
+			return nil, fmt.Errorf("解析JWT签名密钥 %s 失败: %w", row.Kid, err)
+		}
+		k.signKey, k.verifyKey = secret, secret
+		return k, nil
+	}
+
+	block, _ := pem.Decode([]byte(plain))
+	if block == nil {
+		return nil, fmt.Errorf("解析JWT签名密钥 %s 失败: 不是PEM格式", row.Kid)
+	}
+	private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("解析JWT签名密钥 %s 失败: %w", row.Kid, err)
+	}
+	switch priv := private.(type) {
+	case *rsa.PrivateKey:
+		k.signKey, k.verifyKey = priv, &priv.PublicKey
+	case ed25519.PrivateKey:
+		k.signKey, k.verifyKey = priv, priv.Public().(ed25519.PublicKey)
+	default:
+		return nil, fmt.Errorf("解析JWT签名密钥 %s 失败: %w", row.Kid, errors.New("不支持的密钥类型"))
+	}
+	return k, nil
+}
+
+// newKid 生成形如 20250101-1a2b3c4d 的 kid，便于看出密钥的创建日期
+func newKid() (string, error) {
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("生成kid失败: %w", err)
+	}
+	return time.Now().Format("20060102") + "-" + hex.EncodeToString(buf), nil
+}
+
+// sortKeyInfos 按创建时间倒序排列
+func sortKeyInfos(list []*KeyInfo) {
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.After(list[j].CreatedAt)
+	})
+}
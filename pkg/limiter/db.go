@@ -0,0 +1,148 @@
+package limiter
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+	"xinde/pkg/logger"
+)
+
+// throttle represents the t_login_throttle table in the database.
+type throttle struct {
+	Key         string     `gorm:"primaryKey;column:key"`
+	Failures    int        `gorm:"column:failures;not null;default:0"`
+	Lockouts    int        `gorm:"column:lockouts;not null;default:0"`
+	LockedUntil *time.Time `gorm:"column:locked_until"`
+	LastFailure *time.Time `gorm:"column:last_failure"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;not null;autoUpdateTime"`
+}
+
+func (throttle) TableName() string {
+	return "t_login_throttle"
+}
+
+func (t *throttle) state() *State {
+	st := &State{
+		Key:      t.Key,
+		Failures: t.Failures,
+		Lockouts: t.Lockouts,
+	}
+	if t.LockedUntil != nil {
+		st.LockedUntil = *t.LockedUntil
+	}
+	if t.LastFailure != nil {
+		st.LastFailure = *t.LastFailure
+	}
+	return st
+}
+
+// DBLimiter 计数保存在数据库中，多个实例共享同一份计数
+type DBLimiter struct {
+	db     *gorm.DB
+	policy Policy
+}
+
+func NewDBLimiter(db *gorm.DB, policy Policy) *DBLimiter {
+	return &DBLimiter{
+		db:     db,
+		policy: policy,
+	}
+}
+
+func (l *DBLimiter) Check(key string) (time.Duration, error) {
+	st, err := l.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	return st.Remaining(time.Now()), nil
+}
+
+func (l *DBLimiter) Fail(key string) (time.Duration, error) {
+	var lockout time.Duration
+	err := l.db.Transaction(func(tx *gorm.DB) error {
+		// 先插入空记录再加锁读取，并发的第一次失败不会因为主键冲突而丢失
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&throttle{Key: key}).Error
+		if err != nil {
+			return fmt.Errorf("保存登录失败计数失败: %w", err)
+		}
+
+		var t throttle
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("`key` = ?", key).First(&t).Error; err != nil {
+			return fmt.Errorf("查询登录失败计数失败: %w", err)
+		}
+
+		st := t.state()
+		lockout = l.policy.fail(st, time.Now())
+
+		updateData := map[string]interface{}{
+			"failures":     st.Failures,
+			"lockouts":     st.Lockouts,
+			"last_failure": st.LastFailure,
+		}
+		if !st.LockedUntil.IsZero() {
+			updateData["locked_until"] = st.LockedUntil
+		}
+		err = tx.Model(&throttle{}).Where("`key` = ?", key).Updates(updateData).Error
+		if err != nil {
+			return fmt.Errorf("更新登录失败计数失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// 顺便清理过期的记录，清理失败不影响本次计数
+	if err := l.cleanup(); err != nil {
+		logger.Warn(err.Error())
+	}
+	return lockout, nil
+}
+
+func (l *DBLimiter) Reset(key string) error {
+	if err := l.db.Where("`key` = ?", key).Delete(&throttle{}).Error; err != nil {
+		return fmt.Errorf("清除登录失败计数失败: %w", err)
+	}
+	return nil
+}
+
+func (l *DBLimiter) Get(key string) (*State, error) {
+	var t throttle
+	err := l.db.Where("`key` = ?", key).First(&t).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("查询登录失败计数失败: %w", err)
+	}
+	return t.state(), nil
+}
+
+func (l *DBLimiter) ListLocked(prefix string) ([]*State, error) {
+	var rows []*throttle
+	err := l.db.Where("`key` LIKE ? AND locked_until > ?", prefix+"%", time.Now()).
+		Order("locked_until desc").
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("查询被锁定的记录失败: %w", err)
+	}
+
+	list := make([]*State, 0, len(rows))
+	for _, t := range rows {
+		list = append(list, t.state())
+	}
+	return list, nil
+}
+
+// cleanup 删除未锁定且最后一次失败已超过窗口期的记录
+func (l *DBLimiter) cleanup() error {
+	now := time.Now()
+	err := l.db.Where("(locked_until IS NULL OR locked_until <= ?) AND last_failure < ?", now, now.Add(-l.policy.Window)).
+		Delete(&throttle{}).Error
+	if err != nil {
+		return fmt.Errorf("清理过期的登录失败计数失败: %w", err)
+	}
+	return nil
+}
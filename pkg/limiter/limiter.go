@@ -0,0 +1,113 @@
+package limiter
+
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"time"
+)
+
+// 计数状态的保存方式，通过 <配置前缀>.driver 配置
+const (
+	DriverMemory = "memory"
+	DriverDB     = "db"
+)
+
+// Policy 渐进式锁定策略：窗口期内累计失败 MaxFailures 次后锁定 BaseLockout，
+// 解锁后再次达到上限时锁定时长翻倍，最长 MaxLockout。最后一次失败超过 Window 后计数和锁定次数都清零
+type Policy struct {
+	MaxFailures int
+	Window      time.Duration
+	BaseLockout time.Duration
+	MaxLockout  time.Duration
+}
+
+// State 某个 key 当前的计数状态
+type State struct {
+	Key         string
+	Failures    int // 本轮锁定前已累计的失败次数
+	Lockouts    int // 已经被锁定的次数，决定下一次锁定的时长
+	LockedUntil time.Time
+	LastFailure time.Time
+}
+
+// Remaining 返回剩余的锁定时长，未锁定时返回 0
+func (st *State) Remaining(now time.Time) time.Duration {
+	if st == nil || !now.Before(st.LockedUntil) {
+		return 0
+	}
+	return st.LockedUntil.Sub(now)
+}
+
+// Limiter 按 key 统计失败次数并在超过上限后锁定。
+// 业务代码只依赖这个接口：单实例部署使用 MemoryLimiter，多实例部署使用 DBLimiter 共享计数
+type Limiter interface {
+	// Check 返回 key 剩余的锁定时长，0 表示未锁定
+	Check(key string) (time.Duration, error)
+	// Fail 记录一次失败，返回因本次失败触发的锁定时长，0 表示未锁定
+	Fail(key string) (time.Duration, error)
+	// Reset 清除 key 的全部记录，成功或管理员解锁时调用
+	Reset(key string) error
+	// Get 查看 key 当前的状态，没有记录时返回 nil
+	Get(key string) (*State, error)
+	// ListLocked 列出 prefix 开头、当前仍处于锁定状态的 key
+	ListLocked(prefix string) ([]*State, error)
+}
+
+// New 根据配置创建 Limiter，配置项为 <prefix>.driver、<prefix>.maxFailures、<prefix>.window、
+// <prefix>.baseLockout 和 <prefix>.maxLockout。driver 为 db 时计数保存在 db 中，未配置时保存在内存中
+func New(prefix string, db *gorm.DB) (Limiter, error) {
+	policy := Policy{
+		MaxFailures: viper.GetInt(prefix + ".maxFailures"),
+		Window:      viper.GetDuration(prefix + ".window"),
+		BaseLockout: viper.GetDuration(prefix + ".baseLockout"),
+		MaxLockout:  viper.GetDuration(prefix + ".maxLockout"),
+	}
+	if policy.MaxFailures < 1 || policy.BaseLockout <= 0 {
+		return nil, fmt.Errorf("%s 的锁定策略配置错误", prefix)
+	}
+	if policy.MaxLockout < policy.BaseLockout {
+		policy.MaxLockout = policy.BaseLockout
+	}
+
+	switch driver := viper.GetString(prefix + ".driver"); driver {
+	case DriverDB:
+		if db == nil {
+			return nil, fmt.Errorf("数据库连接未初始化")
+		}
+		return NewDBLimiter(db, policy), nil
+	case DriverMemory, "":
+		return NewMemoryLimiter(policy), nil
+	default:
+		return nil, fmt.Errorf("不支持的计数保存方式: %s", driver)
+	}
+}
+
+// fail 按策略把一次失败计入 st，返回因本次失败触发的锁定时长
+func (p Policy) fail(st *State, now time.Time) time.Duration {
+	if !st.LastFailure.IsZero() && now.Sub(st.LastFailure) > p.Window && !now.Before(st.LockedUntil) {
+		st.Failures, st.Lockouts = 0, 0
+	}
+	st.Failures++
+	st.LastFailure = now
+	if st.Failures < p.MaxFailures {
+		return 0
+	}
+
+	lockout := p.BaseLockout
+	for i := 0; i < st.Lockouts && lockout < p.MaxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > p.MaxLockout {
+		lockout = p.MaxLockout
+	}
+	st.Failures = 0
+	st.Lockouts++
+	st.LockedUntil = now.Add(lockout)
+	return lockout
+}
+
+// expired 判断 st 是否已经没有保留的必要：未锁定且最后一次失败已超过窗口期
+func (p Policy) expired(st *State, now time.Time) bool {
+	return !now.Before(st.LockedUntil) && now.Sub(st.LastFailure) > p.Window
+}
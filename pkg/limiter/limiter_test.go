@@ -0,0 +1,163 @@
+package limiter
+
+import (
+	"testing"
+	"time"
+)
+
+var testPolicy = Policy{
+	MaxFailures: 3,
+	Window:      10 * time.Minute,
+	BaseLockout: time.Minute,
+	MaxLockout:  4 * time.Minute,
+}
+
+func TestPolicyFail(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		// failures 每次失败相对 start 的时间，want 为每次失败返回的锁定时长
+		failures []time.Duration
+		want     []time.Duration
+	}{
+		{
+			name:     "未达到上限",
+			failures: []time.Duration{0, time.Second},
+			want:     []time.Duration{0, 0},
+		},
+		{
+			name:     "达到上限后锁定",
+			failures: []time.Duration{0, time.Second, 2 * time.Second},
+			want:     []time.Duration{0, 0, time.Minute},
+		},
+		{
+			name: "解锁后再次达到上限时锁定时长翻倍",
+			failures: []time.Duration{
+				0, time.Second, 2 * time.Second, // 锁定 1 分钟
+				2 * time.Minute, 3 * time.Minute, 4 * time.Minute, // 锁定 2 分钟
+				7 * time.Minute, 8 * time.Minute, 9 * time.Minute, // 锁定 4 分钟
+			},
+			want: []time.Duration{0, 0, time.Minute, 0, 0, 2 * time.Minute, 0, 0, 4 * time.Minute},
+		},
+		{
+			name: "锁定时长不超过 MaxLockout",
+			failures: []time.Duration{
+				0, time.Second, 2 * time.Second,
+				2 * time.Minute, 3 * time.Minute, 4 * time.Minute,
+				7 * time.Minute, 8 * time.Minute, 9 * time.Minute,
+				14 * time.Minute, 15 * time.Minute, 16 * time.Minute,
+			},
+			want: []time.Duration{0, 0, time.Minute, 0, 0, 2 * time.Minute, 0, 0, 4 * time.Minute, 0, 0, 4 * time.Minute},
+		},
+		{
+			name:     "超过窗口期后重新计数",
+			failures: []time.Duration{0, time.Second, 11 * time.Minute, 12 * time.Minute},
+			want:     []time.Duration{0, 0, 0, 0},
+		},
+		{
+			name: "超过窗口期后锁定时长也重置",
+			failures: []time.Duration{
+				0, time.Second, 2 * time.Second, // 锁定 1 分钟
+				20 * time.Minute, 21 * time.Minute, 22 * time.Minute,
+			},
+			want: []time.Duration{0, 0, time.Minute, 0, 0, time.Minute},
+		},
+	}
+	for _, tt := range tests {
+		st := &State{Key: "k"}
+		for i, offset := range tt.failures {
+			if got := testPolicy.fail(st, start.Add(offset)); got != tt.want[i] {
+				t.Errorf("%s: 第 %d 次失败锁定 %v, 期望 %v", tt.name, i+1, got, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestStateRemaining(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		st   *State
+		want time.Duration
+	}{
+		{"没有记录", nil, 0},
+		{"未锁定", &State{}, 0},
+		{"锁定中", &State{LockedUntil: now.Add(time.Minute)}, time.Minute},
+		{"锁定刚好结束", &State{LockedUntil: now}, 0},
+		{"锁定已结束", &State{LockedUntil: now.Add(-time.Minute)}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.st.Remaining(now); got != tt.want {
+			t.Errorf("%s: Remaining = %v, 期望 %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPolicyExpired(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		st   *State
+		want bool
+	}{
+		{"窗口期内", &State{LastFailure: now.Add(-time.Minute)}, false},
+		{"超过窗口期", &State{LastFailure: now.Add(-11 * time.Minute)}, true},
+		{"超过窗口期但仍在锁定", &State{LastFailure: now.Add(-11 * time.Minute), LockedUntil: now.Add(time.Minute)}, false},
+	}
+	for _, tt := range tests {
+		if got := testPolicy.expired(tt.st, now); got != tt.want {
+			t.Errorf("%s: expired = %v, 期望 %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	l := NewMemoryLimiter(testPolicy)
+
+	for i := 1; i < testPolicy.MaxFailures; i++ {
+		if lockout, _ := l.Fail("ip:1"); lockout != 0 {
+			t.Fatalf("第 %d 次失败不应锁定", i)
+		}
+	}
+	if remaining, _ := l.Check("ip:1"); remaining != 0 {
+		t.Fatalf("未达到上限时不应锁定, 剩余 %v", remaining)
+	}
+	if lockout, _ := l.Fail("ip:1"); lockout != testPolicy.BaseLockout {
+		t.Fatalf("达到上限时锁定 %v, 期望 %v", lockout, testPolicy.BaseLockout)
+	}
+	if remaining, _ := l.Check("ip:1"); remaining <= 0 {
+		t.Fatal("达到上限后应处于锁定状态")
+	}
+
+	// 其他 key 不受影响
+	l.Fail("user:1")
+	if remaining, _ := l.Check("user:1"); remaining != 0 {
+		t.Fatal("其他 key 不应被锁定")
+	}
+
+	locked, _ := l.ListLocked("ip:")
+	if len(locked) != 1 || locked[0].Key != "ip:1" {
+		t.Fatalf("ListLocked(ip:) = %v, 期望只有 ip:1", locked)
+	}
+	if locked, _ := l.ListLocked("user:"); len(locked) != 0 {
+		t.Fatalf("ListLocked(user:) = %v, 期望为空", locked)
+	}
+
+	// Get 返回的是副本
+	st, _ := l.Get("ip:1")
+	st.LockedUntil = time.Time{}
+	if remaining, _ := l.Check("ip:1"); remaining <= 0 {
+		t.Fatal("修改 Get 返回的状态不应影响计数")
+	}
+
+	if err := l.Reset("ip:1"); err != nil {
+		t.Fatal(err)
+	}
+	if st, _ := l.Get("ip:1"); st != nil {
+		t.Fatalf("Reset 后应没有记录, 实际 %+v", st)
+	}
+	if remaining, _ := l.Check("ip:1"); remaining != 0 {
+		t.Fatal("Reset 后不应锁定")
+	}
+}
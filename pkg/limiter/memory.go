@@ -0,0 +1,89 @@
+package limiter
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryLimiter 计数保存在进程内存中，重启后清零，只适用于单实例部署和本地开发
+type MemoryLimiter struct {
+	policy    Policy
+	mu        sync.Mutex
+	states    map[string]*State
+	lastSweep time.Time
+}
+
+func NewMemoryLimiter(policy Policy) *MemoryLimiter {
+	return &MemoryLimiter{
+		policy:    policy,
+		states:    make(map[string]*State),
+		lastSweep: time.Now(),
+	}
+}
+
+func (l *MemoryLimiter) Check(key string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.states[key].Remaining(time.Now()), nil
+}
+
+func (l *MemoryLimiter) Fail(key string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+	st, ok := l.states[key]
+	if !ok {
+		st = &State{Key: key}
+		l.states[key] = st
+	}
+	return l.policy.fail(st, now), nil
+}
+
+func (l *MemoryLimiter) Reset(key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.states, key)
+	return nil
+}
+
+func (l *MemoryLimiter) Get(key string) (*State, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	st, ok := l.states[key]
+	if !ok {
+		return nil, nil
+	}
+	copied := *st
+	return &copied, nil
+}
+
+func (l *MemoryLimiter) ListLocked(prefix string) ([]*State, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	var list []*State
+	for key, st := range l.states {
+		if strings.HasPrefix(key, prefix) && st.Remaining(now) > 0 {
+			copied := *st
+			list = append(list, &copied)
+		}
+	}
+	return list, nil
+}
+
+// sweep 定期清理过期的记录，避免大量不同的 key 占满内存。调用方需持有锁
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.policy.Window {
+		return
+	}
+	for key, st := range l.states {
+		if l.policy.expired(st, now) {
+			delete(l.states, key)
+		}
+	}
+	l.lastSweep = now
+}
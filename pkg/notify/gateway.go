@@ -0,0 +1,66 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/spf13/viper"
+	"io"
+	"net/http"
+	"time"
+)
+
+// GatewaySender 通过短信网关的 HTTP 接口发送短信。
+// 请求体为 {"phone": "...", "content": "..."}，apiKey 放在 Authorization 头中，返回 2xx 视为发送成功
+type GatewaySender struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+func NewGatewaySender() (*GatewaySender, error) {
+	url := viper.GetString("notify.sms.gateway.url")
+	if url == "" {
+		return nil, fmt.Errorf("短信网关配置不完整，需要url")
+	}
+	timeout := viper.GetDuration("notify.sms.gateway.timeout")
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &GatewaySender{
+		url:    url,
+		apiKey: viper.GetString("notify.sms.gateway.apiKey"),
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (s *GatewaySender) Send(msg *Message) error {
+	payload, err := json.Marshal(map[string]string{
+		"phone":   msg.To,
+		"content": msg.Content,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("创建短信请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("请求短信网关失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("短信网关返回错误 %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package notify
+
+import (
+	"fmt"
+	"xinde/pkg/logger"
+)
+
+// LogSender 只把通知内容写入日志，不真正发送，用于测试和本地开发
+type LogSender struct {
+	channel string
+}
+
+func NewLogSender(channel string) *LogSender {
+	return &LogSender{channel: channel}
+}
+
+func (s *LogSender) Send(msg *Message) error {
+	logger.Info(fmt.Sprintf("[notify:%s] 发送给 %s 标题: %s 内容: %s", s.channel, msg.To, msg.Subject, msg.Content))
+	return nil
+}
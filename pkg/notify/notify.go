@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"fmt"
+	"github.com/spf13/viper"
+)
+
+// 发送方式，通过 notify.email.driver / notify.sms.driver 配置
+const (
+	DriverSMTP    = "smtp"
+	DriverGateway = "gateway"
+	DriverLog     = "log"
+)
+
+// Message 一条待发送的通知
+type Message struct {
+	To      string // 邮箱地址或手机号
+	Subject string // 邮件标题，短信会忽略
+	Content string
+}
+
+// Sender 发送通知的通道。业务代码只依赖这个接口，测试和本地开发时使用 LogSender 即可，不需要真实的邮件或短信服务
+type Sender interface {
+	Send(msg *Message) error
+}
+
+// NewEmailSender 根据配置创建邮件发送器，未配置时只输出日志
+func NewEmailSender() (Sender, error) {
+	switch driver := viper.GetString("notify.email.driver"); driver {
+	case DriverSMTP:
+		return NewSMTPSender()
+	case DriverLog, "":
+		return NewLogSender("email"), nil
+	default:
+		return nil, fmt.Errorf("不支持的邮件发送方式: %s", driver)
+	}
+}
+
+// NewSMSSender 根据配置创建短信发送器，未配置时只输出日志
+func NewSMSSender() (Sender, error) {
+	switch driver := viper.GetString("notify.sms.driver"); driver {
+	case DriverGateway:
+		return NewGatewaySender()
+	case DriverLog, "":
+		return NewLogSender("sms"), nil
+	default:
+		return nil, fmt.Errorf("不支持的短信发送方式: %s", driver)
+	}
+}
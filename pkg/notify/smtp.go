@@ -0,0 +1,103 @@
+package notify
+
+import (
+	"crypto/tls"
+	"fmt"
+	"github.com/spf13/viper"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPSender 通过 SMTP 服务器发送邮件
+type SMTPSender struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+	// 为 true 时直接建立 TLS 连接（一般是465端口），否则在服务器支持时使用 STARTTLS
+	implicitTLS bool
+}
+
+func NewSMTPSender() (*SMTPSender, error) {
+	s := &SMTPSender{
+		host:        viper.GetString("notify.email.smtp.host"),
+		port:        viper.GetInt("notify.email.smtp.port"),
+		username:    viper.GetString("notify.email.smtp.username"),
+		password:    viper.GetString("notify.email.smtp.password"),
+		from:        viper.GetString("notify.email.smtp.from"),
+		implicitTLS: viper.GetBool("notify.email.smtp.implicitTLS"),
+	}
+	if s.host == "" || s.port == 0 || s.from == "" {
+		return nil, fmt.Errorf("SMTP配置不完整，需要host、port和from")
+	}
+	return s, nil
+}
+
+func (s *SMTPSender) Send(msg *Message) error {
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+	body := s.buildMessage(msg)
+
+	if !s.implicitTLS {
+		if err := smtp.SendMail(addr, auth, s.from, []string{msg.To}, body); err != nil {
+			return fmt.Errorf("发送邮件失败: %w", err)
+		}
+		return nil
+	}
+
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 10 * time.Second}, "tcp", addr, &tls.Config{ServerName: s.host})
+	if err != nil {
+		return fmt.Errorf("连接SMTP服务器失败: %w", err)
+	}
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("连接SMTP服务器失败: %w", err)
+	}
+	defer client.Close()
+
+	if auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("SMTP认证失败: %w", err)
+		}
+	}
+	if err := client.Mail(s.from); err != nil {
+		return fmt.Errorf("发送邮件失败: %w", err)
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return fmt.Errorf("发送邮件失败: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("发送邮件失败: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("发送邮件失败: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("发送邮件失败: %w", err)
+	}
+	return client.Quit()
+}
+
+// buildMessage 组装邮件内容，标题按 RFC 2047 编码以支持中文
+func (s *SMTPSender) buildMessage(msg *Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + s.from + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", msg.Subject) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(msg.Content)
+	return []byte(b.String())
+}
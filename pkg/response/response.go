@@ -21,6 +21,7 @@ const (
 	CodeForbidden          = 403 // 禁止访问
 	CodeNotFound           = 404 // 资源不存在
 	CodeConflict           = 409 // 资源冲突（如用户已存在）
+	CodeTooManyRequests    = 429 // 请求过于频繁
 	CodeInternalError      = 500 // 服务器内部错误
 	CodeServiceUnavailable = 503 // 服务不可用
 )
@@ -65,6 +66,16 @@ func Error(c *gin.Context, httpCode int, businessCode int, message string) {
 	})
 }
 
+// ErrorWithData 错误响应（附带数据，例如导入失败时的逐行错误明细）
+func ErrorWithData(c *gin.Context, httpCode int, businessCode int, message string, data interface{}) {
+	c.JSON(httpCode, Response{
+		Code:    businessCode,
+		Message: message,
+		Data:    data,
+		Success: false,
+	})
+}
+
 // BadRequest 便捷的错误响应方法
 func BadRequest(c *gin.Context, message string) {
 	if message == "" {
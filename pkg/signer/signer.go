@@ -0,0 +1,44 @@
+package signer
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// 签名请求使用的请求头
+const (
+	HeaderKeyID     = "X-Api-Key"
+	HeaderTimestamp = "X-Timestamp" // Unix 时间戳（秒）
+	HeaderNonce     = "X-Nonce"     // 调用方生成的随机串，同一个 Key 在时间窗口内不能重复
+	HeaderSignature = "X-Signature"
+)
+
+// BodyHash 请求体的 SHA-256，十六进制小写。没有请求体时为空串的哈希
+func BodyHash(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// StringToSign 拼接待签名的字符串，各部分以换行分隔：
+//
+//	METHOD\nPATH\nTIMESTAMP\nNONCE\nBODY_HASH
+//
+// METHOD 为大写的请求方法，PATH 为请求路径加查询串（如 /api/v1/solutions/query?a=1），原样参与签名
+func StringToSign(method, path, timestamp, nonce, bodyHash string) string {
+	return strings.Join([]string{strings.ToUpper(method), path, timestamp, nonce, bodyHash}, "\n")
+}
+
+// Sign 用 secret 计算 HMAC-SHA256 签名，十六进制小写
+func Sign(secret, stringToSign string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(stringToSign))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 校验签名，大小写不敏感，使用常量时间比较
+func Verify(secret, stringToSign, signature string) bool {
+	expected := Sign(secret, stringToSign)
+	return hmac.Equal([]byte(expected), []byte(strings.ToLower(signature)))
+}
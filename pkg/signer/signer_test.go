@@ -0,0 +1,76 @@
+package signer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBodyHash(t *testing.T) {
+	tests := []struct {
+		body []byte
+		want string
+	}{
+		{nil, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{[]byte{}, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{[]byte("abc"), "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+	}
+	for _, tt := range tests {
+		if got := BodyHash(tt.body); got != tt.want {
+			t.Errorf("BodyHash(%q) = %s, 期望 %s", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestStringToSign(t *testing.T) {
+	tests := []struct {
+		method, path, timestamp, nonce, bodyHash string
+		want                                     string
+	}{
+		{"POST", "/api/v1/prices/query", "1700000000", "n1", "h", "POST\n/api/v1/prices/query\n1700000000\nn1\nh"},
+		{"get", "/api/v1/solutions/query?a=1&b=2", "1700000000", "n2", "h", "GET\n/api/v1/solutions/query?a=1&b=2\n1700000000\nn2\nh"},
+		{"GET", "/", "", "", "", "GET\n/\n\n\n"},
+	}
+	for _, tt := range tests {
+		if got := StringToSign(tt.method, tt.path, tt.timestamp, tt.nonce, tt.bodyHash); got != tt.want {
+			t.Errorf("StringToSign(%q, %q, ...) = %q, 期望 %q", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestSign(t *testing.T) {
+	// RFC 4231 的 HMAC-SHA256 测试向量
+	tests := []struct {
+		secret, data, want string
+	}{
+		{"Jefe", "what do ya want for nothing?", "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"},
+	}
+	for _, tt := range tests {
+		if got := Sign(tt.secret, tt.data); got != tt.want {
+			t.Errorf("Sign(%q, %q) = %s, 期望 %s", tt.secret, tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	const secret = "secret"
+	sts := StringToSign("POST", "/api/v1/prices/query", "1700000000", "nonce", BodyHash([]byte(`{"product_codes":["A"]}`)))
+	signature := Sign(secret, sts)
+
+	tests := []struct {
+		name              string
+		secret, sts, sign string
+		want              bool
+	}{
+		{"正确的签名", secret, sts, signature, true},
+		{"大写的签名", secret, sts, strings.ToUpper(signature), true},
+		{"密钥错误", "other", sts, signature, false},
+		{"请求被修改", secret, StringToSign("POST", "/api/v1/prices/query", "1700000001", "nonce", BodyHash(nil)), signature, false},
+		{"签名被截断", secret, sts, signature[:len(signature)-1], false},
+		{"空签名", secret, sts, "", false},
+	}
+	for _, tt := range tests {
+		if got := Verify(tt.secret, tt.sts, tt.sign); got != tt.want {
+			t.Errorf("%s: Verify = %v, 期望 %v", tt.name, got, tt.want)
+		}
+	}
+}
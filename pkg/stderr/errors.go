@@ -30,12 +30,59 @@ const (
 	ErrorUserBanned       = "用户已经被管理员拒绝注册申请"
 	ErrorUserPassed       = "用户已经被管理员批准注册申请"
 	ErrorUserIDInvalid    = "无效的用户ID格式"
+	ErrorUserNotHandled   = "用户的注册申请尚未处理，无需重新打开"
+
+	ErrorUserOldPasswordWrong   = "原密码错误"
+	ErrorUserPasswordUnchanged  = "新密码不能与原密码相同"
+	ErrorUserPasswordNotConfirm = "两次输入的密码不一致"
+	ErrorUserMustChangePassword = "密码已被管理员重置，请先修改密码"
+	ErrorUserNoInitialPassword  = "密码未被管理员重置，请登录后修改密码"
+
+	ErrorVerificationTargetMissing = "该账号没有绑定对应的邮箱或手机号，请选择其他方式"
+	ErrorVerificationTooFrequent   = "验证码发送过于频繁，请稍后再试"
+	ErrorVerificationLimitExceeded = "获取验证码的次数已达上限，请稍后再试"
+	ErrorVerificationSendFailed    = "验证码发送失败，请稍后再试"
+	ErrorVerificationCodeInvalid   = "验证码错误或已过期"
+	ErrorVerificationTooManyTries  = "验证码错误次数过多，请重新获取"
+	ErrorResetTokenInvalid         = "重置令牌无效或已过期，请重新获取验证码"
+
+	ErrorLoginUserLocked = "登录失败次数过多，账号已被临时锁定，请稍后再试"
+	ErrorLoginIPLocked   = "当前IP登录失败次数过多，已被临时限制登录，请稍后再试"
+	ErrorResetIPLocked   = "当前IP请求过于频繁，已被临时限制找回密码，请稍后再试"
+
+	ErrorLoginHistoryTimeInvalid = "from/to格式错误，应为YYYY-MM-DD或YYYY-MM-DD HH:MM:SS"
+
+	ErrorUserFilterTimeInvalid = "created_from/created_to/active_from/active_to格式错误，应为YYYY-MM-DD或YYYY-MM-DD HH:MM:SS"
+	ErrorUserSortByInvalid     = "排序字段无效，应为id、username、name、company_name、price_level、created_at、handled_at或recent_search_at"
+
+	ErrorUserCompanyMissing       = "company_id和company_name至少需要填写一个"
+	ErrorUserImportEmpty          = "excel 文件为空或只有表头"
+	ErrorUserImportTooManyRows    = "导入的用户过多，请分成多个文件导入"
+	ErrorUserImportMissingColumns = "用户文件缺少必需的列，表头需要包含用户名、姓名、电话和公司"
+
+	ErrorMFATokenInvalid     = "二次验证已过期，请重新登录"
+	ErrorMFACodeInvalid      = "验证码或恢复码错误"
+	ErrorMFAAlreadyEnabled   = "已经开启了二次验证"
+	ErrorMFANotEnabled       = "尚未开启二次验证"
+	ErrorMFANotEnrolling     = "请先获取二次验证的密钥"
+	ErrorMFARequiredForAdmin = "管理员账号必须开启二次验证，不能关闭"
+	ErrorMFAPasswordWrong    = "密码错误"
 )
 
 // company
 const (
 	ErrorCompanyNotFound  = "公司不存在"
 	ErrorCompanyIDInvalid = "无效的公司ID格式"
+
+	ErrorCompanyNameExists        = "公司名称已存在（包括已删除或已合并的公司）"
+	ErrorCompanyInUse             = "公司下还有用户、专属价格、折扣规则或API Key，不能删除"
+	ErrorCompanyMergeHasPriceData = "被合并的公司有专属价格或折扣规则，请先处理后再合并"
+	ErrorCompanyMergeTargetSource = "被合并的公司不能包含保留的公司"
+
+	ErrorNotCompanyAdmin            = "需要公司管理员权限"
+	ErrorCompanyAdminNoCompany      = "用户尚未通过注册申请或没有所属公司，不能设为公司管理员"
+	ErrorCompanyAdminOptionDenied   = "公司管理员不能修改用户所在的公司或价格等级"
+	ErrorCompanyActivityTimeInvalid = "from/to格式错误，应为YYYY-MM-DD或YYYY-MM-DD HH:MM:SS"
 )
 
 // attachment
@@ -51,12 +98,28 @@ const (
 	ErrorGroupIDInvalid            = "无效的分组ID格式"
 	ErrorCannotMoveGroupIntoItself = "所更改的父级分组不能是其子孙分组"
 	ErrorRootGroupCannotBeDeleted  = "root分组不能被删除"
+	ErrorRootGroupCannotBeMoved    = "root分组不能被移动"
+	ErrorGroupNotEmpty             = "分组或其子孙分组下还有设备类型，不能删除"
+	ErrorGroupDeleteTargetNotFound = "接收设备类型的目标分组不存在"
+	ErrorGroupDeleteTargetInvalid  = "接收设备类型的目标分组不能是被删除的分组或其子孙分组"
+	ErrorGroupDeleteRuleConflict   = "被删除的分组下有设备类型移动后会失去多层可见性规则，无法转换为设备类型上的规则，请先调整可见性规则"
+	ErrorBreadcrumbNodeInvalid     = "查询路径时group_id和device_type_id必须且只能填写一个"
+
+	ErrorVisibilityRuleNotFound     = "可见性规则不存在"
+	ErrorVisibilityRuleIDInvalid    = "无效的可见性规则ID格式"
+	ErrorVisibilityRuleNodeInvalid  = "group_id和device_type_id必须且只能填写一个"
+	ErrorVisibilityRuleConflict     = "该分组或设备类型已存在相同对象的可见性规则"
+	ErrorVisibilityRuleTargetFilter = "按target_id过滤时需要同时指定target_type"
 )
 
 // device
 const (
 	ErrorDeviceNotFound  = "设备类型不存在"
 	ErrorDeviceIDInvalid = "无效的设备类型ID格式"
+
+	ErrorDeviceStatusTimeInvalid = "publish_at/unpublish_at格式错误，应为YYYY-MM-DD或YYYY-MM-DD HH:MM:SS"
+	ErrorDeviceStatusWindowWrong = "unpublish_at必须晚于publish_at"
+	ErrorDevicePreviewNotAllowed = "只有拥有目录查看权限的管理员才能预览未发布的设备类型"
 )
 
 // filterImage
@@ -66,6 +129,69 @@ const (
 	ErrorFilterImageValueConflict = "已存在该筛选下拉列表图片的配置，发生冲突"
 )
 
+// price
+const (
+	ErrorPriceImportEmpty          = "excel 文件为空或只有表头"
+	ErrorPriceImportHasInvalidRows = "价格文件存在错误数据，已全部回滚，请下载错误报告修正后重新导入"
+	ErrorPriceSortByInvalid        = "排序字段无效，应为id、product_code、spec_code、updated_at或价格等级编码"
+	ErrorPriceUpdatedSinceInvalid  = "updated_since格式错误，应为YYYY-MM-DD或YYYY-MM-DD HH:MM:SS"
+)
+
+// price level
+const (
+	ErrorPriceLevelNotFound     = "价格等级不存在"
+	ErrorPriceLevelIDInvalid    = "无效的价格等级ID格式"
+	ErrorPriceLevelCodeConflict = "价格等级编码已存在"
+	ErrorPriceLevelNameConflict = "价格等级名称已存在"
+	ErrorPriceLevelInUse        = "仍有公司使用该价格等级，无法删除"
+	ErrorPriceLevelLastOne      = "至少需要保留一个价格等级"
+	ErrorPriceLevelDefault      = "默认价格等级用于新建的公司，无法删除"
+)
+
+// company price
+const (
+	ErrorPriceOverrideNotFound  = "公司专属价格不存在"
+	ErrorPriceOverrideIDInvalid = "无效的公司专属价格ID格式"
+	ErrorPriceRuleNotFound      = "折扣规则不存在"
+	ErrorPriceRuleIDInvalid     = "无效的折扣规则ID格式"
+	ErrorPriceRuleConflict      = "该公司已存在相同范围的折扣规则"
+	ErrorPriceRuleScopeInvalid  = "按产品编码前缀的规则必须填写product_prefix，按设备分组的规则必须填写group_id"
+)
+
+// role
+const (
+	ErrorRoleNotFound          = "角色不存在"
+	ErrorRoleIDInvalid         = "无效的角色ID格式"
+	ErrorRoleCodeConflict      = "角色编码已存在"
+	ErrorRolePermissionInvalid = "存在无效的权限编码"
+	ErrorRoleSystemReadOnly    = "内置角色不能修改或删除"
+	ErrorRoleLastSuperAdmin    = "至少需要保留一名拥有全部权限的管理员"
+	ErrorPermissionDenied      = "权限不足"
+)
+
+// api key
+const (
+	ErrorAPIKeyNotFound         = "API Key不存在"
+	ErrorAPIKeyIDInvalid        = "无效的API Key ID格式"
+	ErrorAPIKeyScopeInvalid     = "存在无效的访问范围"
+	ErrorAPIKeyExpiresAtInvalid = "expires_at格式错误或早于当前时间，应为YYYY-MM-DD或YYYY-MM-DD HH:MM:SS"
+	ErrorAPIKeyRevoked          = "API Key已被吊销"
+
+	ErrorAPIKeyMissing          = "缺少API Key签名所需的请求头"
+	ErrorAPIKeyInvalid          = "API Key无效、已过期或已被吊销"
+	ErrorAPIKeyTimestampInvalid = "请求时间戳无效或与服务器时间相差过大"
+	ErrorAPIKeyNonceInvalid     = "nonce格式错误，应为8-64位的字母、数字、-或_"
+	ErrorAPIKeySignatureInvalid = "签名错误"
+	ErrorAPIKeyReplayed         = "nonce已被使用过，请求可能被重放"
+	ErrorAPIKeyBodyTooLarge     = "请求体过大"
+	ErrorAPIKeyScopeDenied      = "API Key没有访问该接口的权限"
+)
+
+// audit
+const (
+	ErrorAuditTimeInvalid = "from/to格式错误，应为YYYY-MM-DD或YYYY-MM-DD HH:MM:SS"
+)
+
 // JWT token
 const (
 	ErrorTokenExpired     = "token已过期"
@@ -73,6 +199,12 @@ const (
 	ErrorTokenMalFormed   = "token格式错误"
 	ErrorTokenInvalid     = "token解析失败"
 	ErrorTokenNotAdmin    = "非管理员,权限不足"
+	ErrorTokenRevoked     = "token已失效，请重新登录"
+
+	ErrorRefreshTokenInvalid = "refresh token无效或已过期，请重新登录"
+	ErrorRefreshTokenReused  = "refresh token已被使用过，为了安全已注销该用户的全部登录，请重新登录"
+
+	ErrorJWTKeyAlgInvalid = "不支持的签名算法，应为HS256、RS256或EdDSA"
 )
 
 // MsgFlags 预定义的业务错误msg
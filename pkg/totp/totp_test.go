@@ -0,0 +1,142 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret RFC 6238 附录 B 中 SHA1 使用的密钥 "12345678901234567890" 的 base32 编码
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// rfcVectors RFC 6238 附录 B 的 SHA1 测试向量，取 8 位验证码的后 6 位
+var rfcVectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestCodeAt(t *testing.T) {
+	for _, v := range rfcVectors {
+		got, err := CodeAt(rfcSecret, Step(time.Unix(v.unix, 0)))
+		if err != nil {
+			t.Fatalf("CodeAt(%d) 返回错误: %v", v.unix, err)
+		}
+		if got != v.code {
+			t.Errorf("CodeAt(%d) = %s, 期望 %s", v.unix, got, v.code)
+		}
+	}
+}
+
+func TestCodeAtInvalidSecret(t *testing.T) {
+	if _, err := CodeAt("not base32!", 1); err == nil {
+		t.Error("密钥格式错误时应返回错误")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, v := range rfcVectors {
+		at := time.Unix(v.unix, 0)
+		step, ok, err := Validate(rfcSecret, v.code, at, 0, 0)
+		if err != nil {
+			t.Fatalf("Validate(%d) 返回错误: %v", v.unix, err)
+		}
+		if !ok || step != Step(at) {
+			t.Errorf("Validate(%d) = (%d, %v), 期望 (%d, true)", v.unix, step, ok, Step(at))
+		}
+	}
+}
+
+func TestValidateSkew(t *testing.T) {
+	at := time.Unix(1234567890, 0)
+	current := Step(at)
+
+	tests := []struct {
+		name   string
+		offset int64 // 验证码所在时间步相对当前时间步的偏移
+		skew   int
+		want   bool
+	}{
+		{"当前时间步", 0, 0, true},
+		{"上一个时间步，不允许偏差", -1, 0, false},
+		{"下一个时间步，不允许偏差", 1, 0, false},
+		{"上一个时间步", -1, 1, true},
+		{"下一个时间步", 1, 1, true},
+		{"超出偏差", -2, 1, false},
+		{"超出偏差", 2, 1, false},
+		{"偏差为 2", -2, 2, true},
+	}
+	for _, tt := range tests {
+		code, err := CodeAt(rfcSecret, current+tt.offset)
+		if err != nil {
+			t.Fatalf("CodeAt 返回错误: %v", err)
+		}
+		step, ok, err := Validate(rfcSecret, code, at, tt.skew, 0)
+		if err != nil {
+			t.Fatalf("%s: Validate 返回错误: %v", tt.name, err)
+		}
+		if ok != tt.want {
+			t.Errorf("%s (偏移 %d, skew %d): 结果 %v, 期望 %v", tt.name, tt.offset, tt.skew, ok, tt.want)
+		}
+		if ok && step != current+tt.offset {
+			t.Errorf("%s: 返回的时间步 %d, 期望 %d", tt.name, step, current+tt.offset)
+		}
+	}
+}
+
+func TestValidateReplay(t *testing.T) {
+	at := time.Unix(1234567890, 0)
+	current := Step(at)
+	code, err := CodeAt(rfcSecret, current)
+	if err != nil {
+		t.Fatalf("CodeAt 返回错误: %v", err)
+	}
+
+	step, ok, err := Validate(rfcSecret, code, at, 1, 0)
+	if err != nil || !ok {
+		t.Fatalf("第一次使用应通过: ok=%v err=%v", ok, err)
+	}
+
+	// 同一个时间步的验证码再次使用，包括在允许的偏差内稍后提交
+	for _, later := range []time.Time{at, at.Add(Period * time.Second)} {
+		if _, ok, err := Validate(rfcSecret, code, later, 1, step); err != nil || ok {
+			t.Errorf("重复使用的验证码不应通过: ok=%v err=%v", ok, err)
+		}
+	}
+
+	// 比已使用的时间步更早的验证码也不能使用
+	earlier, err := CodeAt(rfcSecret, current-1)
+	if err != nil {
+		t.Fatalf("CodeAt 返回错误: %v", err)
+	}
+	if _, ok, _ := Validate(rfcSecret, earlier, at, 1, step); ok {
+		t.Error("早于已使用时间步的验证码不应通过")
+	}
+
+	// 下一个时间步的验证码可以正常使用
+	next, err := CodeAt(rfcSecret, current+1)
+	if err != nil {
+		t.Fatalf("CodeAt 返回错误: %v", err)
+	}
+	if got, ok, _ := Validate(rfcSecret, next, at.Add(Period*time.Second), 1, step); !ok || got != current+1 {
+		t.Errorf("下一个时间步的验证码应通过: step=%d ok=%v", got, ok)
+	}
+}
+
+func TestValidateMalformed(t *testing.T) {
+	at := time.Unix(59, 0)
+	for _, code := range []string{"", "28708", "2870820", "abcdef"} {
+		if _, ok, err := Validate(rfcSecret, code, at, 1, 0); err != nil || ok {
+			t.Errorf("Validate(%q) 不应通过: ok=%v err=%v", code, ok, err)
+		}
+	}
+	// 前后的空白会被忽略
+	if _, ok, _ := Validate(rfcSecret, " 287082 ", at, 0, 0); !ok {
+		t.Error("带空白的正确验证码应通过")
+	}
+}
@@ -13,15 +13,28 @@ import (
 )
 
 func SaveUploadedFile(fileHeader *multipart.FileHeader) (string, error) {
+	// 打开原文件
+	src, err := fileHeader.Open()
+	if err != nil {
+		return "", fmt.Errorf("打开上传文件流失败: %w", err)
+	}
+	defer src.Close()
+
+	relativePath, _, err := SaveFileFromReader(filepath.Ext(fileHeader.Filename), src)
+	return relativePath, err
+}
+
+// SaveFileFromReader 将服务端生成的文件内容（如导出的Excel）保存到附件目录，
+// 返回存入数据库的相对路径和写入的字节数
+func SaveFileFromReader(ext string, src io.Reader) (string, int64, error) {
 	// 从配置中获取存储根目录
 	savePath := viper.GetString("attachment.save_path")
 	if savePath == "" {
-		return "", fmt.Errorf("save_path 未配置")
+		return "", 0, fmt.Errorf("save_path 未配置")
 	}
 
 	// 生成一个唯一的文件名防止冲突
 	today := time.Now().Format("20060102")
-	ext := filepath.Ext(fileHeader.Filename)
 	uniqueFileName := uuid.New().String() + ext
 
 	// 构建完整的目录路径和文件路径
@@ -30,30 +43,24 @@ func SaveUploadedFile(fileHeader *multipart.FileHeader) (string, error) {
 
 	// 创建目标目录
 	if err := os.MkdirAll(filepath.Dir(absolutePath), os.ModePerm); err != nil {
-		return "", fmt.Errorf("创建上传目录失败: %w", err)
+		return "", 0, fmt.Errorf("创建上传目录失败: %w", err)
 	}
 
-	// 打开原文件
-	src, err := fileHeader.Open()
-	if err != nil {
-		return "", fmt.Errorf("打开上传文件流失败: %w", err)
-	}
-	defer src.Close()
-
-	// 6. 创建目标文件
+	// 创建目标文件
 	dst, err := os.Create(absolutePath)
 	if err != nil {
-		return "", fmt.Errorf("创建目标文件失败: %w", err)
+		return "", 0, fmt.Errorf("创建目标文件失败: %w", err)
 	}
 	defer dst.Close()
 
-	// 7. 将源文件内容拷贝到目标文件
-	if _, err = io.Copy(dst, src); err != nil {
-		return "", fmt.Errorf("保存文件失败: %w", err)
+	// 将源文件内容拷贝到目标文件
+	size, err := io.Copy(dst, src)
+	if err != nil {
+		return "", 0, fmt.Errorf("保存文件失败: %w", err)
 	}
 
-	// 8. 返回存入数据库的相对路径
-	return relativePath, nil
+	// 返回存入数据库的相对路径
+	return relativePath, size, nil
 }
 
 // 格式化文件大小为 KB, MB, GB
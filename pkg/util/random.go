@@ -0,0 +1,73 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+// 随机密码使用的字符，去掉了容易混淆的 0/O、1/l/I
+const passwordAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz23456789"
+
+// 恢复码使用的字符，只有小写字母和数字，同样去掉了容易混淆的字符
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// RandomDigits 生成 n 位的随机数字串，用作验证码
+func RandomDigits(n int) (string, error) {
+	return randomString(n, "0123456789")
+}
+
+// RandomPassword 生成长度为 n 的随机密码
+func RandomPassword(n int) (string, error) {
+	return randomString(n, passwordAlphabet)
+}
+
+// RandomRecoveryCode 生成一个形如 xxxxx-xxxxx 的恢复码
+func RandomRecoveryCode() (string, error) {
+	code, err := randomString(10, recoveryCodeAlphabet)
+	if err != nil {
+		return "", err
+	}
+	return code[:5] + "-" + code[5:], nil
+}
+
+// RandomKeyID 生成一个形如 prefix_xxxxxxxxxxxxxxxxxxxx 的公开标识，如 API Key 的 key_id
+func RandomKeyID(prefix string) (string, error) {
+	id, err := randomString(20, recoveryCodeAlphabet)
+	if err != nil {
+		return "", err
+	}
+	return prefix + "_" + id, nil
+}
+
+// RandomToken 生成一个随机的一次性令牌，返回明文和用于入库的 SHA-256 哈希
+func RandomToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("生成随机令牌失败: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashToken(token), nil
+}
+
+// HashToken 计算随机令牌的 SHA-256 哈希（十六进制）
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString(n int, alphabet string) (string, error) {
+	buf := make([]byte, n)
+	max := big.NewInt(int64(len(alphabet)))
+	for i := range buf {
+		idx, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("生成随机数失败: %w", err)
+		}
+		buf[i] = alphabet[idx.Int64()]
+	}
+	return string(buf), nil
+}
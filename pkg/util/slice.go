@@ -0,0 +1,28 @@
+package util
+
+// InsertIDsAt 返回把 ids 依次插入到 list 第 position 个位置后的新列表，list 中已有的 ids 会先被移除。
+// position 为 nil 或超出范围时插入到最后，小于0时插入到最前
+func InsertIDsAt(list []uint, position *int, ids ...uint) []uint {
+	moving := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		moving[id] = true
+	}
+	rest := make([]uint, 0, len(list))
+	for _, id := range list {
+		if !moving[id] {
+			rest = append(rest, id)
+		}
+	}
+
+	at := len(rest)
+	if position != nil && *position < at {
+		at = *position
+		if at < 0 {
+			at = 0
+		}
+	}
+	result := make([]uint, 0, len(rest)+len(ids))
+	result = append(result, rest[:at]...)
+	result = append(result, ids...)
+	return append(result, rest[at:]...)
+}
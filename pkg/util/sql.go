@@ -0,0 +1,8 @@
+package util
+
+import "strings"
+
+// EscapeLike 转义 LIKE 中的通配符，使关键字按字面匹配
+func EscapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,45 @@
+-- 将 t_price / t_price_history 上固定的 price_1 ~ price_4 列迁移到可配置的价格等级
+-- 执行前请先执行 t_price_level.sql、t_price_value.sql、t_price_history_value.sql 建表（含默认等级数据）
+
+START TRANSACTION;
+
+INSERT INTO `t_price_value` (`product_code`, `level_code`, `price`)
+SELECT `product_code`, 'price_1', `price_1` FROM `t_price` WHERE `deleted_at` IS NULL
+UNION ALL
+SELECT `product_code`, 'price_2', `price_2` FROM `t_price` WHERE `deleted_at` IS NULL
+UNION ALL
+SELECT `product_code`, 'price_3', `price_3` FROM `t_price` WHERE `deleted_at` IS NULL
+UNION ALL
+SELECT `product_code`, 'price_4', `price_4` FROM `t_price` WHERE `deleted_at` IS NULL;
+
+-- 价格历史表上线前已存在的价格，先补一条历史记录以便按时间回溯
+INSERT INTO `t_price_history` (`product_code`, `unit`, `spec_code`, `price_1`, `price_2`, `price_3`, `price_4`,
+                               `effective_from`, `attachment_id`, `created_by_uid`)
+SELECT p.`product_code`, p.`unit`, p.`spec_code`, p.`price_1`, p.`price_2`, p.`price_3`, p.`price_4`, p.`updated_at`, 0, 0
+FROM `t_price` p
+WHERE p.`deleted_at` IS NULL
+  AND NOT EXISTS (SELECT 1 FROM `t_price_history` h WHERE h.`product_code` = p.`product_code`);
+
+INSERT INTO `t_price_history_value` (`history_id`, `level_code`, `price`)
+SELECT `id`, 'price_1', `price_1` FROM `t_price_history`
+UNION ALL
+SELECT `id`, 'price_2', `price_2` FROM `t_price_history`
+UNION ALL
+SELECT `id`, 'price_3', `price_3` FROM `t_price_history`
+UNION ALL
+SELECT `id`, 'price_4', `price_4` FROM `t_price_history`;
+
+COMMIT;
+
+-- DDL 会隐式提交，放在数据迁移确认无误之后执行
+ALTER TABLE `t_price`
+    DROP COLUMN `price_1`,
+    DROP COLUMN `price_2`,
+    DROP COLUMN `price_3`,
+    DROP COLUMN `price_4`;
+
+ALTER TABLE `t_price_history`
+    DROP COLUMN `price_1`,
+    DROP COLUMN `price_2`,
+    DROP COLUMN `price_3`,
+    DROP COLUMN `price_4`;
@@ -0,0 +1,5 @@
+-- 支持吊销 token：t_user 增加 token 版本号
+-- 执行前请先执行 t_refresh_token.sql、t_revoked_token.sql 建表
+
+ALTER TABLE `t_user`
+    ADD COLUMN `token_version` int unsigned NOT NULL DEFAULT '0' COMMENT 'token版本号，吊销用户全部token时递增' AFTER `is_admin`;
@@ -0,0 +1,9 @@
+-- 引入角色权限后，原有的管理员(is_admin = 1)全部授予超级管理员角色
+-- 执行前请先执行 t_role.sql、t_role_permission.sql、t_user_role.sql 建表（含初始角色数据）
+
+INSERT IGNORE INTO `t_user_role` (`uid`, `role_id`)
+SELECT u.`uid`, r.`id`
+FROM `t_user` u
+         JOIN `t_role` r ON r.`code` = 'super_admin'
+WHERE u.`is_admin` = 1
+  AND u.`deleted_at` IS NULL;
@@ -0,0 +1,7 @@
+-- 审计日志：只读审计员角色增加查看审计日志的权限
+-- 执行前请先执行 t_audit_log.sql 建表
+
+INSERT IGNORE INTO `t_role_permission` (`role_id`, `permission`)
+SELECT `id`, 'audit:read'
+FROM `t_role`
+WHERE `code` = 'auditor';
@@ -0,0 +1,5 @@
+-- 找回密码与一次性密码：t_user 增加是否必须修改密码的标记
+-- 执行前请先执行 t_verification_code.sql 建表
+
+ALTER TABLE `t_user`
+    ADD COLUMN `must_change_password` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否必须修改密码，管理员重置密码后为1' AFTER `token_version`;
@@ -0,0 +1,5 @@
+-- 公司管理：t_company 增加备注，以及合并后被合并到的公司ID
+
+ALTER TABLE `t_company`
+    ADD COLUMN `notes`       varchar(500) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT '备注' AFTER `price_level`,
+    ADD COLUMN `merged_into` int unsigned                                                  DEFAULT NULL COMMENT '被合并到的公司ID，合并后该公司被软删除' AFTER `notes`;
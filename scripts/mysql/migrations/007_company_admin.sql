@@ -0,0 +1,4 @@
+-- 公司管理员：t_user 增加是否为公司管理员，公司管理员只能管理本公司的用户
+
+ALTER TABLE `t_user`
+    ADD COLUMN `is_company_admin` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否为公司管理员，只能审批和管理本公司的用户' AFTER `is_admin`;
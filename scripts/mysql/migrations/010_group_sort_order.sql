@@ -0,0 +1,9 @@
+-- 分组在兄弟分组中的顺序。已有分组按ID排序，保持和以前一样的顺序
+
+ALTER TABLE `t_group`
+    ADD COLUMN `sort_order` int NOT NULL DEFAULT '0' COMMENT '在兄弟分组中的顺序，从小到大' AFTER `parent_id`,
+    DROP INDEX `idx_parent_id`,
+    ADD INDEX `idx_parent_id` (`parent_id`, `sort_order`);
+
+UPDATE `t_group`
+SET `sort_order` = `id`;
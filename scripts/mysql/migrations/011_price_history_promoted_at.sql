@@ -0,0 +1,10 @@
+-- 价格历史写入当前价格的时间。未来生效的价格到达生效时间后由后台任务写入 t_price / t_price_value。
+-- 以前导入时已到生效时间的记录在导入时就写入了当前价格；未来生效的记录保持为空，由后台任务补写
+
+ALTER TABLE `t_price_history`
+    ADD COLUMN `promoted_at` timestamp NULL DEFAULT NULL COMMENT '写入当前价格的时间，未来生效的记录到达生效时间后才写入' AFTER `effective_from`,
+    ADD INDEX `idx_promoted_effective` (`promoted_at`, `effective_from`);
+
+UPDATE `t_price_history`
+SET `promoted_at` = `created_at`
+WHERE `effective_from` <= `created_at`;
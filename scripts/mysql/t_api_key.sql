@@ -0,0 +1,23 @@
+CREATE TABLE `t_api_key`
+(
+    `id`           int unsigned                                                  NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    `key_id`       varchar(63) CHARACTER SET ascii COLLATE ascii_bin             NOT NULL COMMENT '公开的标识，请求时放在X-Api-Key头中',
+    `secret`       varchar(255) CHARACTER SET ascii COLLATE ascii_bin            NOT NULL COMMENT '加密后的签名密钥',
+    `name`         varchar(63) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci  NOT NULL COMMENT '名称，如调用方系统的名字',
+    `company_id`   int unsigned                                                  NOT NULL COMMENT '所属公司ID，价格按该公司计算',
+    `expires_at`   timestamp                                                     NULL     DEFAULT NULL COMMENT '过期时间，NULL表示永不过期',
+    `revoked_at`   timestamp                                                     NULL     DEFAULT NULL COMMENT '吊销时间',
+    `last_used_at` timestamp                                                     NULL     DEFAULT NULL COMMENT '最近一次使用的时间',
+    `last_used_ip` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci  NOT NULL DEFAULT '' COMMENT '最近一次使用的IP',
+    `created_by`   int unsigned                                                  NOT NULL COMMENT '创建该Key的管理员ID',
+
+    `created_at`   timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
+    `updated_at`   timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '记录最后更新时间',
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_key_id` (`key_id`),
+    KEY `idx_company_id` (`company_id`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='供外部系统调用的API Key';
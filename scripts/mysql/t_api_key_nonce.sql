@@ -0,0 +1,11 @@
+CREATE TABLE `t_api_key_nonce`
+(
+    `api_key_id` int unsigned                                      NOT NULL COMMENT 'API Key ID',
+    `nonce`      varchar(64) CHARACTER SET ascii COLLATE ascii_bin NOT NULL COMMENT '请求中的X-Nonce',
+    `expires_at` timestamp                                         NOT NULL COMMENT '超过该时间后请求的时间戳已不可能通过校验，可以删除',
+
+    PRIMARY KEY (`api_key_id`, `nonce`),
+    KEY `idx_expires_at` (`expires_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='API Key已使用的nonce，防止请求重放';
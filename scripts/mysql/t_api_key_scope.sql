@@ -0,0 +1,9 @@
+CREATE TABLE `t_api_key_scope`
+(
+    `api_key_id` int unsigned                                                 NOT NULL COMMENT 'API Key ID',
+    `scope`      varchar(63) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '访问范围，如solution:query',
+
+    PRIMARY KEY (`api_key_id`, `scope`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='API Key的访问范围';
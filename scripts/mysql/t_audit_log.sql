@@ -0,0 +1,26 @@
+CREATE TABLE `t_audit_log`
+(
+    `id`          bigint unsigned                                               NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    `actor_uid`   int unsigned                                                  NOT NULL COMMENT '操作人用户ID',
+    `actor_name`  varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '操作人用户名（操作时的快照）',
+    `action`      varchar(63) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci  NOT NULL COMMENT '操作类型，如user.approve',
+    `entity_type` varchar(31) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci  NOT NULL COMMENT '被操作的对象类型',
+    `entity_id`   varchar(63) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci  NOT NULL COMMENT '被操作的对象ID',
+    `before_data` json                                                                   DEFAULT NULL COMMENT '操作前的快照',
+    `after_data`  json                                                                   DEFAULT NULL COMMENT '操作后的快照',
+    `diff`        json                                                                   DEFAULT NULL COMMENT '发生变化的字段',
+    `ip`          varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci  NOT NULL DEFAULT '' COMMENT '客户端IP',
+    `request_id`  varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci  NOT NULL DEFAULT '' COMMENT '请求ID，与响应头X-Request-ID一致',
+
+    `created_at`  timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
+
+    PRIMARY KEY (`id`),
+    KEY `idx_actor_uid` (`actor_uid`),
+    KEY `idx_action` (`action`),
+    KEY `idx_entity` (`entity_type`, `entity_id`),
+    KEY `idx_request_id` (`request_id`),
+    KEY `idx_created_at` (`created_at`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='管理操作审计日志，只追加不修改';
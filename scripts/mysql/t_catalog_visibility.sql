@@ -0,0 +1,18 @@
+CREATE TABLE `t_catalog_visibility`
+(
+    `id`             int unsigned                                                 NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    `group_id`       int unsigned                                                 NOT NULL DEFAULT '0' COMMENT '分组ID，规则同样作用于子孙分组和分组下的设备类型，与 device_type_id 只填一个',
+    `device_type_id` int unsigned                                                 NOT NULL DEFAULT '0' COMMENT '设备类型ID，与 group_id 只填一个',
+    `target_type`    varchar(31) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '可见对象类型: company 公司, role 拥有该角色的用户',
+    `target_id`      int unsigned                                                 NOT NULL COMMENT '公司ID或角色ID',
+    `created_by_uid` int unsigned                                                 NOT NULL COMMENT '操作人用户ID',
+
+    `created_at`     timestamp                                                    NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_node_target` (`group_id`, `device_type_id`, `target_type`, `target_id`),
+    KEY `idx_target` (`target_type`, `target_id`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='产品目录可见性规则表，分组或设备类型有规则时只对规则中的公司或角色可见';
@@ -5,7 +5,9 @@ CREATE TABLE `t_company`
     `id`          int unsigned                                                  NOT NULL AUTO_INCREMENT COMMENT '主键默认id',
     `name`        varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '公司名称',
     `address`     varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci          DEFAULT NULL COMMENT '公司地址',
-    `price_level` varchar(31) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci  NOT NULL DEFAULT 'price_1' COMMENT '该公司查看产品的价格等级，默认为1，一共4级',
+    `price_level` varchar(31) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci  NOT NULL DEFAULT 'price_1' COMMENT '该公司查看产品的价格等级 (t_price_level.code)，默认为price_1',
+    `notes`       varchar(500) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci          DEFAULT NULL COMMENT '备注',
+    `merged_into` int unsigned                                                           DEFAULT NULL COMMENT '被合并到的公司ID，合并后该公司被软删除',
 
     -- 新增的字段
     `created_at`  timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
@@ -0,0 +1,18 @@
+CREATE TABLE `t_company_price_override`
+(
+    `id`             int unsigned                                                  NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    `company_id`     int unsigned                                                  NOT NULL COMMENT '公司ID',
+    `product_code`   varchar(31) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci  NOT NULL COMMENT '产品编码',
+    `price`          decimal(10, 2)                                                NOT NULL COMMENT '专属价格',
+    `remark`         varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci          DEFAULT NULL COMMENT '备注，如合同编号',
+    `created_by_uid` int unsigned                                                  NOT NULL COMMENT '操作人用户ID',
+
+    `created_at`     timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
+    `updated_at`     timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '记录最后更新时间',
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_company_product` (`company_id`, `product_code`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='公司专属价格表，优先级高于折扣规则和价格等级';
@@ -0,0 +1,20 @@
+CREATE TABLE `t_company_price_rule`
+(
+    `id`               int unsigned                                                  NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    `company_id`       int unsigned                                                  NOT NULL COMMENT '公司ID',
+    `scope_type`       varchar(31) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci  NOT NULL COMMENT '适用范围: product_prefix 产品编码前缀, group 设备分组（含子孙分组）',
+    `product_prefix`   varchar(31) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci  NOT NULL DEFAULT '' COMMENT '产品编码前缀，scope_type 为 product_prefix 时有效',
+    `group_id`         int unsigned                                                  NOT NULL DEFAULT '0' COMMENT '设备分组ID，scope_type 为 group 时有效',
+    `discount_percent` decimal(5, 2)                                                 NOT NULL COMMENT '折扣百分比，10 表示在等级价格基础上减 10%',
+    `remark`           varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci          DEFAULT NULL COMMENT '备注',
+    `created_by_uid`   int unsigned                                                  NOT NULL COMMENT '操作人用户ID',
+
+    `created_at`       timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
+    `updated_at`       timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '记录最后更新时间',
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_company_scope` (`company_id`, `scope_type`, `product_prefix`, `group_id`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='公司折扣规则表';
@@ -2,10 +2,11 @@ CREATE TABLE `t_group` (
   `id` int unsigned NOT NULL AUTO_INCREMENT,
   `name` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '分组名称',
   `parent_id` int unsigned NOT NULL COMMENT '父级分组ID',
+  `sort_order` int NOT NULL DEFAULT '0' COMMENT '在兄弟分组中的顺序，从小到大',
   `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
   `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
   `deleted_at` timestamp NULL DEFAULT NULL,
   PRIMARY KEY (`id`),
-  KEY `idx_parent_id` (`parent_id`),
+  KEY `idx_parent_id` (`parent_id`, `sort_order`),
   KEY `idx_deleted_at` (`deleted_at`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='分组信息表';
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE `t_jwt_key`
+(
+    `kid`          varchar(32) CHARACTER SET ascii COLLATE ascii_bin NOT NULL COMMENT '密钥ID，签发的token头部带有该值',
+    `alg`          varchar(16)                                       NOT NULL COMMENT '签名算法：HS256、RS256或EdDSA',
+    `private_key`  text                                              NOT NULL COMMENT '加密后的私钥（PKCS#8 PEM）或HMAC密钥',
+    `public_key`   text                                              NOT NULL COMMENT '公钥（PKIX PEM），HMAC密钥为空',
+    `status`       varchar(16)                                       NOT NULL COMMENT 'active：用于签发；retired：已退役，verify_until之前仍可验证',
+    `verify_until` timestamp                                         NULL     DEFAULT NULL COMMENT '退役密钥可用于验证的截止时间',
+    `retired_at`   timestamp                                         NULL     DEFAULT NULL COMMENT '退役时间',
+    `created_at`   timestamp                                         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+
+    PRIMARY KEY (`kid`),
+    KEY `idx_status` (`status`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='JWT签名密钥';
@@ -0,0 +1,21 @@
+CREATE TABLE `t_login_history`
+(
+    `id`         bigint unsigned                                               NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    `uid`        int unsigned                                                           DEFAULT NULL COMMENT '用户ID，用户名不存在时为NULL',
+    `username`   varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '登录时填写的用户名',
+    `success`    tinyint(1)                                                    NOT NULL COMMENT '是否登录成功',
+    `reason`     varchar(32) CHARACTER SET ascii COLLATE ascii_bin             NOT NULL DEFAULT '' COMMENT '失败原因，成功时为空',
+    `ip`         varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci  NOT NULL DEFAULT '' COMMENT '客户端IP',
+    `user_agent` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '客户端User-Agent',
+
+    `created_at` timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '登录时间',
+
+    PRIMARY KEY (`id`),
+    KEY `idx_uid` (`uid`),
+    KEY `idx_username` (`username`),
+    KEY `idx_ip` (`ip`),
+    KEY `idx_created_at` (`created_at`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='登录记录，只追加不修改';
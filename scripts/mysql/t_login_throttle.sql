@@ -0,0 +1,16 @@
+CREATE TABLE `t_login_throttle`
+(
+    `key`          varchar(300) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL COMMENT '计数的对象，如user:用户名、ip:客户端IP',
+    `failures`     int unsigned                                           NOT NULL DEFAULT '0' COMMENT '本轮锁定前已累计的失败次数',
+    `lockouts`     int unsigned                                           NOT NULL DEFAULT '0' COMMENT '已经被锁定的次数，锁定时长随之翻倍',
+    `locked_until` timestamp                                              NULL     DEFAULT NULL COMMENT '锁定到期时间',
+    `last_failure` timestamp                                              NULL     DEFAULT NULL COMMENT '最后一次失败的时间',
+
+    `updated_at`   timestamp                                              NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '记录更新时间',
+
+    PRIMARY KEY (`key`),
+    KEY `idx_locked_until` (`locked_until`),
+    KEY `idx_last_failure` (`last_failure`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='登录失败计数，account.loginLimit.*.driver为db时多个实例共享';
@@ -0,0 +1,15 @@
+CREATE TABLE `t_mfa_recovery_code`
+(
+    `id`         int unsigned                                   NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    `uid`        int unsigned                                   NOT NULL COMMENT '用户ID',
+    `code_hash`  char(64) CHARACTER SET ascii COLLATE ascii_bin NOT NULL COMMENT '恢复码的SHA-256哈希',
+    `used_at`    timestamp                                      NULL     DEFAULT NULL COMMENT '使用时间，NULL表示仍可使用',
+
+    `created_at` timestamp                                      NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
+
+    PRIMARY KEY (`id`),
+    KEY `idx_uid` (`uid`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='二次验证的恢复码，只保存哈希值';
@@ -0,0 +1,9 @@
+CREATE TABLE `t_outbox_applied`
+(
+    `operation_id` bigint unsigned NOT NULL COMMENT 'PostgreSQL中t_outbox的ID',
+    `applied_at`   timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '执行时间',
+
+    PRIMARY KEY (`operation_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='已经执行过的跨库操作，保证每个操作只执行一次';
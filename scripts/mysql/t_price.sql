@@ -5,11 +5,7 @@ CREATE TABLE `t_price`
     `unit`         varchar(10) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '产品单位',
     `spec_code`    varchar(31) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '规格型号',
 
-    -- 价格字段: decimal 类型非常适合存储精确的货币值
-    `price_1`      decimal(10, 2)                                               NOT NULL DEFAULT '0.00' COMMENT '价格等级1',
-    `price_2`      decimal(10, 2)                                               NOT NULL DEFAULT '0.00' COMMENT '价格等级2',
-    `price_3`      decimal(10, 2)                                               NOT NULL DEFAULT '0.00' COMMENT '价格等级3',
-    `price_4`      decimal(10, 2)                                               NOT NULL DEFAULT '0.00' COMMENT '价格等级4',
+    -- 各价格等级的价格保存在 t_price_value 中
 
     -- 时间戳与软删除 (与 t_user 和 t_company 保持一致)
     `created_at`   timestamp                                                    NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
@@ -0,0 +1,24 @@
+CREATE TABLE `t_price_history`
+(
+    `id`             int unsigned                                                 NOT NULL AUTO_INCREMENT COMMENT '价格历史记录主键ID',
+    `product_code`   varchar(31) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '产品编码',
+    `unit`           varchar(10) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '产品单位',
+    `spec_code`      varchar(31) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '规格型号',
+
+    -- 各价格等级的价格保存在 t_price_history_value 中
+
+    `effective_from` timestamp                                                    NOT NULL COMMENT '生效时间',
+    `promoted_at`    timestamp                                                    NULL     DEFAULT NULL COMMENT '写入当前价格的时间，未来生效的记录到达生效时间后才写入',
+    `attachment_id`  int unsigned                                                          DEFAULT NULL COMMENT '导致本次变更的导入文件附件ID',
+    `created_by_uid` int unsigned                                                 NOT NULL COMMENT '操作人用户ID',
+    `created_at`     timestamp                                                    NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
+
+    PRIMARY KEY (`id`),
+    KEY `idx_product_effective` (`product_code`, `effective_from`), -- 按产品编码查询某一时刻生效的价格
+    KEY `idx_promoted_effective` (`promoted_at`, `effective_from`),             -- 查找到达生效时间但还没写入当前价格的记录
+    KEY `idx_attachment_id` (`attachment_id`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='产品价格历史表（只增不改）';
+
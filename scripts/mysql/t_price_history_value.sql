@@ -0,0 +1,13 @@
+CREATE TABLE `t_price_history_value`
+(
+    `id`         int unsigned                                                 NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    `history_id` int unsigned                                                 NOT NULL COMMENT '价格历史记录ID (t_price_history.id)',
+    `level_code` varchar(31) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '价格等级编码 (t_price_level.code)',
+    `price`      decimal(10, 2)                                               NOT NULL DEFAULT '0.00' COMMENT '该等级下的价格',
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_history_level` (`history_id`, `level_code`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='产品价格历史中各价格等级的价格';
@@ -0,0 +1,23 @@
+CREATE TABLE `t_price_level`
+(
+    `id`         int unsigned                                                 NOT NULL AUTO_INCREMENT COMMENT '价格等级主键ID',
+    `code`       varchar(31) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '等级编码，t_company.price_level 引用该字段，创建后不可修改',
+    `name`       varchar(63) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '展示名称，导入价格时也可以作为表头',
+    `sort_order` int                                                          NOT NULL DEFAULT '0' COMMENT '排序，数值越小越靠前，第一位为默认等级',
+
+    `created_at` timestamp                                                    NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
+    `updated_at` timestamp                                                    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '记录最后更新时间',
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_code` (`code`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='价格等级表';
+
+-- 初始的四个等级，与原 t_price.price_1 ~ price_4 一一对应
+INSERT INTO `t_price_level` (`code`, `name`, `sort_order`)
+VALUES ('price_1', '价格等级1', 1),
+       ('price_2', '价格等级2', 2),
+       ('price_3', '价格等级3', 3),
+       ('price_4', '价格等级4', 4);
@@ -0,0 +1,17 @@
+CREATE TABLE `t_price_value`
+(
+    `id`           int unsigned                                                 NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    `product_code` varchar(31) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '产品编码',
+    `level_code`   varchar(31) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '价格等级编码 (t_price_level.code)',
+    `price`        decimal(10, 2)                                               NOT NULL DEFAULT '0.00' COMMENT '该等级下的价格',
+
+    `created_at`   timestamp                                                    NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
+    `updated_at`   timestamp                                                    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '记录最后更新时间',
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_product_level` (`product_code`, `level_code`),
+    KEY `idx_level_code` (`level_code`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='产品各价格等级的当前价格';
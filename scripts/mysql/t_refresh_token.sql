@@ -0,0 +1,20 @@
+CREATE TABLE `t_refresh_token`
+(
+    `id`          int unsigned                                                  NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    `uid`         int unsigned                                                  NOT NULL COMMENT '用户ID',
+    `token_hash`  char(64) CHARACTER SET ascii COLLATE ascii_bin                NOT NULL COMMENT 'refresh token的SHA-256哈希',
+    `expires_at`  timestamp                                                     NOT NULL COMMENT '过期时间',
+    `revoked_at`  timestamp                                                     NULL     DEFAULT NULL COMMENT '吊销时间，NULL表示仍有效',
+    `replaced_by` int unsigned                                                           DEFAULT NULL COMMENT '轮换后新token的ID',
+    `user_agent`  varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '签发时的User-Agent',
+    `ip`          varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci  NOT NULL DEFAULT '' COMMENT '签发时的客户端IP',
+
+    `created_at`  timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_token_hash` (`token_hash`),
+    KEY `idx_uid` (`uid`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='refresh token表，只保存哈希值';
@@ -0,0 +1,13 @@
+CREATE TABLE `t_revoked_token`
+(
+    `jti`        varchar(64) CHARACTER SET ascii COLLATE ascii_bin NOT NULL COMMENT 'access token的jti',
+    `uid`        int unsigned                                      NOT NULL COMMENT '用户ID',
+    `expires_at` timestamp                                         NOT NULL COMMENT 'access token的过期时间，之后该记录可以清理',
+
+    `created_at` timestamp                                         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
+
+    PRIMARY KEY (`jti`),
+    KEY `idx_expires_at` (`expires_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='已注销的access token黑名单';
@@ -0,0 +1,25 @@
+CREATE TABLE `t_role`
+(
+    `id`          int unsigned                                                  NOT NULL AUTO_INCREMENT COMMENT '角色主键ID',
+    `code`        varchar(31) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci  NOT NULL COMMENT '角色编码，创建后不可修改',
+    `name`        varchar(63) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci  NOT NULL COMMENT '展示名称',
+    `description` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci          DEFAULT NULL COMMENT '角色说明',
+    `is_system`   tinyint(1)                                                    NOT NULL DEFAULT '0' COMMENT '是否为内置角色，内置角色不能修改或删除',
+
+    `created_at`  timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
+    `updated_at`  timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '记录最后更新时间',
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_code` (`code`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='角色表';
+
+-- 初始角色，权限见 t_role_permission.sql
+INSERT INTO `t_role` (`id`, `code`, `name`, `description`, `is_system`)
+VALUES (1, 'super_admin', '超级管理员', '拥有全部权限', 1),
+       (2, 'catalog_editor', '目录编辑', '维护分组、设备类型和筛选图片', 0),
+       (3, 'price_manager', '价格管理员', '导入价格，维护价格等级、公司专属价格和折扣规则', 0),
+       (4, 'account_approver', '账号审批员', '审批用户的注册申请', 0),
+       (5, 'auditor', '只读审计员', '只能查看，不能做任何修改', 0);
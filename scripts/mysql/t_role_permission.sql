@@ -0,0 +1,28 @@
+CREATE TABLE `t_role_permission`
+(
+    `role_id`    int unsigned                                                 NOT NULL COMMENT '角色ID',
+    `permission` varchar(63) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '权限编码，如price:write，*表示全部权限',
+
+    PRIMARY KEY (`role_id`, `permission`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='角色权限表';
+
+INSERT INTO `t_role_permission` (`role_id`, `permission`)
+VALUES (1, '*'),
+       (2, 'catalog:read'),
+       (2, 'catalog:write'),
+       (2, 'attachment:read'),
+       (3, 'price:read'),
+       (3, 'price:write'),
+       (3, 'company:read'),
+       (3, 'attachment:read'),
+       (4, 'account:read'),
+       (4, 'account:approve'),
+       (4, 'company:read'),
+       (5, 'account:read'),
+       (5, 'company:read'),
+       (5, 'price:read'),
+       (5, 'catalog:read'),
+       (5, 'attachment:read'),
+       (5, 'audit:read');
@@ -7,6 +7,9 @@ CREATE TABLE `t_user`
     `password`      varchar(255) CHARACTER SET utf8 COLLATE utf8_general_ci NOT NULL COMMENT '用户密码',
     `phone`    varchar(255) CHARACTER SET utf8 COLLATE utf8_general_ci NOT NULL COMMENT '用户电话号码',
     `is_admin`      tinyint                                                 NOT NULL DEFAULT '0' COMMENT '是否为管理员',
+    `is_company_admin` tinyint(1)                                           NOT NULL DEFAULT '0' COMMENT '是否为公司管理员，只能审批和管理本公司的用户',
+    `token_version` int unsigned                                            NOT NULL DEFAULT '0' COMMENT 'token版本号，吊销用户全部token时递增',
+    `must_change_password` tinyint(1)                                       NOT NULL DEFAULT '0' COMMENT '是否必须修改密码，管理员重置密码后为1',
     `remarks`       varchar(64)                                                      DEFAULT NULL COMMENT '备注',
     `recent_search_at`      timestamp                                                        DEFAULT NULL COMMENT '上次访问时间',
     `search_device` varchar(100)                                                     DEFAULT NULL COMMENT '上次访问的设备',
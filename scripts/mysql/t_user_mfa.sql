@@ -0,0 +1,14 @@
+CREATE TABLE `t_user_mfa`
+(
+    `uid`            int unsigned                                       NOT NULL COMMENT '用户ID',
+    `secret`         varchar(255) CHARACTER SET ascii COLLATE ascii_bin NOT NULL COMMENT '加密后的TOTP密钥',
+    `enabled_at`     timestamp                                          NULL     DEFAULT NULL COMMENT '确认绑定的时间，NULL表示尚未确认',
+    `last_used_step` bigint                                             NOT NULL DEFAULT '0' COMMENT '最近一次使用的验证码所在的时间步，防止验证码被重复使用',
+
+    `created_at`     timestamp                                          NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
+    `updated_at`     timestamp                                          NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '记录更新时间',
+
+    PRIMARY KEY (`uid`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='用户的TOTP二次验证';
@@ -0,0 +1,12 @@
+CREATE TABLE `t_user_role`
+(
+    `uid`        int unsigned NOT NULL COMMENT '用户ID',
+    `role_id`    int unsigned NOT NULL COMMENT '角色ID',
+
+    `created_at` timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
+
+    PRIMARY KEY (`uid`, `role_id`),
+    KEY `idx_role_id` (`role_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='用户角色表';
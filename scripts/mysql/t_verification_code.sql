@@ -0,0 +1,23 @@
+CREATE TABLE `t_verification_code`
+(
+    `id`               int unsigned                                                  NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    `uid`              int unsigned                                                  NOT NULL COMMENT '用户ID',
+    `purpose`          varchar(32) CHARACTER SET ascii COLLATE ascii_bin             NOT NULL COMMENT '用途，如reset_password',
+    `channel`          varchar(16) CHARACTER SET ascii COLLATE ascii_bin             NOT NULL COMMENT '发送通道，email或phone',
+    `target`           varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '接收验证码的邮箱或手机号',
+    `code_hash`        varchar(255) CHARACTER SET ascii COLLATE ascii_bin            NOT NULL COMMENT '验证码的bcrypt哈希',
+    `attempts`         int unsigned                                                  NOT NULL DEFAULT '0' COMMENT '已校验失败的次数',
+    `expires_at`       timestamp                                                     NOT NULL COMMENT '过期时间',
+    `verified_at`      timestamp                                                     NULL     DEFAULT NULL COMMENT '校验通过的时间',
+    `reset_token_hash` char(64) CHARACTER SET ascii COLLATE ascii_bin                         DEFAULT NULL COMMENT '校验通过后签发的重置令牌的SHA-256哈希',
+    `consumed_at`      timestamp                                                     NULL     DEFAULT NULL COMMENT '使用或作废的时间，NULL表示仍可使用',
+
+    `created_at`       timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_reset_token_hash` (`reset_token_hash`),
+    KEY `idx_uid_purpose` (`uid`, `purpose`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='验证码表，只保存哈希值';
@@ -0,0 +1,10 @@
+-- 设备类型的草稿/发布/下架状态，以及计划发布和下架时间。已有的设备类型保持已发布
+
+ALTER TABLE "t_device_type"
+    ADD COLUMN "status" varchar(16) NOT NULL DEFAULT 'published',
+    ADD COLUMN "publish_at" timestamptz DEFAULT NULL,
+    ADD COLUMN "unpublish_at" timestamptz DEFAULT NULL;
+
+COMMENT ON COLUMN "t_device_type"."status" IS '状态: draft 草稿, published 已发布, archived 已下架。只有已发布的设备类型对前台用户可见';
+COMMENT ON COLUMN "t_device_type"."publish_at" IS '计划发布时间，未发布的设备类型到该时间后视为已发布';
+COMMENT ON COLUMN "t_device_type"."unpublish_at" IS '计划下架时间，已发布的设备类型到该时间后视为已下架';
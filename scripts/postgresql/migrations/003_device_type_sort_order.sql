@@ -0,0 +1,10 @@
+-- 设备类型在同一分组中的顺序。已有设备类型按ID排序，保持和以前一样的顺序
+
+ALTER TABLE "t_device_type"
+    ADD COLUMN "sort_order" int NOT NULL DEFAULT 0;
+
+UPDATE "t_device_type"
+SET "sort_order" = "id";
+
+COMMENT ON COLUMN "t_device_type"."sort_order" IS '在同一分组中的顺序，从小到大';
+CREATE INDEX "idx_t_device_type_group_id_sort_order" ON "t_device_type" ("group_id", "sort_order");
@@ -0,0 +1,7 @@
+-- 待执行操作涉及的实体。同一实体的操作按ID顺序执行，较早的操作还在等待重试时，较新的操作不会先执行
+
+ALTER TABLE "t_outbox"
+    ADD COLUMN "entity_key" varchar(64) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN "t_outbox"."entity_key" IS '操作涉及的实体 (e.g., device_type:12)，同一实体的操作按ID顺序执行';
+CREATE INDEX "idx_t_outbox_entity_key_status" ON "t_outbox" ("entity_key", "status");
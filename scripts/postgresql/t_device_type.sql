@@ -2,6 +2,10 @@ CREATE TABLE "t_device_type" (
   "id" bigserial NOT NULL,
   "name" varchar(255) NOT NULL, -- 用户在导入时输入的“设备名称”
   "group_id" bigint NOT NULL,   -- 它属于哪个分组
+  "sort_order" int NOT NULL DEFAULT 0, -- 在同一分组中的顺序
+  "status" varchar(16) NOT NULL DEFAULT 'published', -- draft 草稿, published 已发布, archived 已下架
+  "publish_at" timestamptz DEFAULT NULL,   -- 计划发布时间
+  "unpublish_at" timestamptz DEFAULT NULL, -- 计划下架时间
   -- 这个表的 attachment business_type 可以是 'device_type_main_image'
   "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
   "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -11,4 +15,10 @@ CREATE TABLE "t_device_type" (
 -- 添加注释
 COMMENT ON COLUMN "t_device_type"."name" IS '设备类型名称 (e.g., U钻)';
 COMMENT ON COLUMN "t_device_type"."group_id" IS '设备类型所属的分组ID';
-COMMENT ON TABLE "t_device_type" IS '设备类型信息表';
\ No newline at end of file
+COMMENT ON COLUMN "t_device_type"."sort_order" IS '在同一分组中的顺序，从小到大';
+COMMENT ON COLUMN "t_device_type"."status" IS '状态: draft 草稿, published 已发布, archived 已下架。只有已发布的设备类型对前台用户可见';
+COMMENT ON COLUMN "t_device_type"."publish_at" IS '计划发布时间，未发布的设备类型到该时间后视为已发布';
+COMMENT ON COLUMN "t_device_type"."unpublish_at" IS '计划下架时间，已发布的设备类型到该时间后视为已下架';
+COMMENT ON TABLE "t_device_type" IS '设备类型信息表';
+-- 创建普通索引
+CREATE INDEX "idx_t_device_type_group_id_sort_order" ON "t_device_type" ("group_id", "sort_order");
\ No newline at end of file
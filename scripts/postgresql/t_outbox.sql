@@ -0,0 +1,26 @@
+-- 在 PostgreSQL 数据库中执行
+CREATE TABLE "t_outbox" (
+"id" bigserial NOT NULL,
+"op_type" varchar(64) NOT NULL,
+"entity_key" varchar(64) NOT NULL DEFAULT '',
+"payload" jsonb NOT NULL,
+"status" varchar(16) NOT NULL DEFAULT 'pending',
+"attempts" int NOT NULL DEFAULT 0,
+"last_error" text NOT NULL DEFAULT '',
+"next_attempt_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+"created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+"updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+PRIMARY KEY ("id")
+);
+-- 添加注释
+COMMENT ON COLUMN "t_outbox"."op_type" IS '操作类型 (e.g., catalog.sync)';
+COMMENT ON COLUMN "t_outbox"."entity_key" IS '操作涉及的实体 (e.g., device_type:12)，同一实体的操作按ID顺序执行';
+COMMENT ON COLUMN "t_outbox"."payload" IS '操作内容: 需要在MySQL中删除和创建的附件、删除的可见性规则、写入的审计日志';
+COMMENT ON COLUMN "t_outbox"."status" IS '状态: pending 待执行, done 已完成, failed 重试次数用完仍然失败，需要人工处理';
+COMMENT ON COLUMN "t_outbox"."attempts" IS '后台任务已经重试的次数';
+COMMENT ON COLUMN "t_outbox"."last_error" IS '最近一次执行失败的原因';
+COMMENT ON COLUMN "t_outbox"."next_attempt_at" IS '下一次重试的时间';
+COMMENT ON TABLE "t_outbox" IS '产品目录修改后需要在MySQL中完成的操作，和目录修改在同一个事务中写入';
+-- 创建普通索引
+CREATE INDEX "idx_t_outbox_status_next_attempt_at" ON "t_outbox" ("status", "next_attempt_at");
+CREATE INDEX "idx_t_outbox_entity_key_status" ON "t_outbox" ("entity_key", "status");